@@ -1,9 +1,112 @@
 CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 
+CREATE TYPE account_kind AS ENUM ('customer', 'fx_liquidity', 'fx_revenue', 'fee_revenue');
+CREATE TYPE account_status AS ENUM ('active', 'frozen', 'closed');
+
 CREATE TABLE accounts (
     id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    kind account_kind NOT NULL DEFAULT 'customer',
+    tier VARCHAR(32) NOT NULL DEFAULT 'standard',
+    currency CHAR(3) NOT NULL DEFAULT 'RUB',
     balance BIGINT NOT NULL DEFAULT 0,
-    CONSTRAINT balance_non_negative CHECK (balance >= 0)
+    held BIGINT NOT NULL DEFAULT 0,
+    opening_balance BIGINT NOT NULL DEFAULT 0,
+    status account_status NOT NULL DEFAULT 'active',
+    status_reason TEXT NOT NULL DEFAULT '',
+    status_changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    -- An FX liquidity account is the house's position in its currency and may be short.
+    CONSTRAINT balance_non_negative CHECK (balance >= 0 OR kind = 'fx_liquidity'),
+    CONSTRAINT held_within_balance CHECK (held >= 0 AND held <= GREATEST(balance, 0)),
+    CONSTRAINT closed_is_empty CHECK (status != 'closed' OR (balance = 0 AND held = 0))
+);
+
+CREATE FUNCTION set_opening_balance() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.opening_balance := NEW.balance;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER accounts_opening_balance
+    BEFORE INSERT ON accounts
+    FOR EACH ROW EXECUTE FUNCTION set_opening_balance();
+
+CREATE TABLE fx_rates (
+    base_currency CHAR(3) NOT NULL,
+    quote_currency CHAR(3) NOT NULL,
+    mid_rate NUMERIC(30, 10) NOT NULL,
+    spread_bps INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (base_currency, quote_currency),
+    CONSTRAINT rate_positive CHECK (mid_rate > 0),
+    CONSTRAINT spread_below_full CHECK (spread_bps >= 0 AND spread_bps < 10000)
+);
+
+CREATE TABLE fx_quotes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    source_amount BIGINT NOT NULL,
+    source_currency CHAR(3) NOT NULL,
+    target_amount BIGINT NOT NULL,
+    target_currency CHAR(3) NOT NULL,
+    spread_amount BIGINT NOT NULL,
+    rate NUMERIC(30, 10) NOT NULL,
+    transaction_id UUID,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT quote_amounts_positive CHECK (source_amount > 0 AND target_amount > 0 AND spread_amount >= 0),
+    CONSTRAINT quote_different_currencies CHECK (source_currency != target_currency)
+);
+
+CREATE TYPE transfer_type AS ENUM ('p2p', 'qr_merchant');
+CREATE TYPE fee_bearer AS ENUM ('payer', 'payee');
+
+-- An empty tier is the fallback for tiers without a schedule of their own.
+CREATE TABLE fee_schedules (
+    transfer_type transfer_type NOT NULL,
+    tier VARCHAR(32) NOT NULL DEFAULT '',
+    currency CHAR(3) NOT NULL,
+    fixed_amount BIGINT NOT NULL DEFAULT 0,
+    rate_bps INT NOT NULL DEFAULT 0,
+    min_amount BIGINT NOT NULL DEFAULT 0,
+    -- Zero leaves the fee uncapped.
+    max_amount BIGINT NOT NULL DEFAULT 0,
+    bearer fee_bearer NOT NULL DEFAULT 'payer',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (transfer_type, tier, currency),
+    CONSTRAINT fee_amounts_non_negative CHECK (fixed_amount >= 0 AND min_amount >= 0 AND max_amount >= 0),
+    CONSTRAINT fee_rate_below_full CHECK (rate_bps >= 0 AND rate_bps < 10000),
+    CONSTRAINT fee_min_within_max CHECK (max_amount = 0 OR min_amount <= max_amount)
+);
+
+-- Limits are set either for one account or for a tier in a currency; zero
+-- leaves a limit unenforced.
+CREATE TABLE transfer_limits (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    account_id UUID REFERENCES accounts(id),
+    tier VARCHAR(32),
+    currency CHAR(3) NOT NULL,
+    max_single_amount BIGINT NOT NULL DEFAULT 0,
+    daily_amount BIGINT NOT NULL DEFAULT 0,
+    monthly_amount BIGINT NOT NULL DEFAULT 0,
+    hourly_count INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT limits_one_scope CHECK ((account_id IS NULL) != (tier IS NULL)),
+    CONSTRAINT limits_non_negative CHECK (
+        max_single_amount >= 0 AND daily_amount >= 0 AND monthly_amount >= 0 AND hourly_count >= 0
+    )
+);
+
+CREATE TABLE account_requisites (
+    account_id UUID PRIMARY KEY REFERENCES accounts(id),
+    name VARCHAR(160) NOT NULL,
+    personal_acc CHAR(20) NOT NULL,
+    bank_name VARCHAR(45) NOT NULL,
+    bic CHAR(9) NOT NULL,
+    corresp_acc VARCHAR(20) NOT NULL DEFAULT '',
+    payee_inn VARCHAR(12) NOT NULL DEFAULT '',
+    kpp VARCHAR(9) NOT NULL DEFAULT '',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
 
 CREATE TYPE transaction_status AS ENUM ('pending', 'success', 'failed');
@@ -13,20 +116,288 @@ CREATE TABLE transactions (
     from_account UUID NOT NULL REFERENCES accounts(id),
     to_account UUID NOT NULL REFERENCES accounts(id),
     amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    credit_amount BIGINT NOT NULL,
+    credit_currency CHAR(3) NOT NULL,
+    fee_amount BIGINT NOT NULL DEFAULT 0,
     status transaction_status NOT NULL DEFAULT 'pending',
+    failure_reason VARCHAR(64),
+    original_transaction_id UUID REFERENCES transactions(id),
+    quote_id UUID REFERENCES fx_quotes(id),
+    -- The split payment the transaction is a leg of.
+    split_id UUID,
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     CONSTRAINT amount_positive CHECK (amount > 0),
-    CONSTRAINT different_accounts CHECK (from_account != to_account)
+    CONSTRAINT fee_non_negative CHECK (fee_amount >= 0),
+    CONSTRAINT different_accounts CHECK (from_account != to_account),
+    CONSTRAINT failure_reason_iff_failed CHECK ((status = 'failed') = (failure_reason IS NOT NULL)),
+    CONSTRAINT conversion_iff_quoted CHECK ((currency != credit_currency) = (quote_id IS NOT NULL))
+);
+
+ALTER TABLE fx_quotes ADD CONSTRAINT fx_quotes_transaction_fk FOREIGN KEY (transaction_id) REFERENCES transactions(id);
+
+CREATE TABLE ledger_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    transaction_id UUID NOT NULL REFERENCES transactions(id),
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT posting_non_zero CHECK (amount != 0)
+);
+
+CREATE FUNCTION check_postings_balanced() RETURNS TRIGGER AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM ledger_entries
+        WHERE transaction_id = NEW.transaction_id
+        GROUP BY currency
+        HAVING SUM(amount) != 0
+    ) THEN
+        RAISE EXCEPTION 'postings of transaction % do not sum to zero', NEW.transaction_id
+            USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER ledger_entries_balanced
+    AFTER INSERT OR UPDATE ON ledger_entries
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION check_postings_balanced();
+
+CREATE VIEW ledger_reconciliation AS
+SELECT
+    a.id AS account_id,
+    a.currency,
+    a.balance,
+    a.opening_balance + COALESCE(SUM(l.amount), 0) AS ledger_balance
+FROM accounts a
+LEFT JOIN ledger_entries l ON l.account_id = a.id
+GROUP BY a.id;
+
+CREATE TYPE authorization_status AS ENUM ('active', 'captured', 'voided', 'expired');
+
+CREATE TABLE authorizations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    from_account UUID NOT NULL REFERENCES accounts(id),
+    to_account UUID NOT NULL REFERENCES accounts(id),
+    amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    captured_amount BIGINT NOT NULL DEFAULT 0,
+    status authorization_status NOT NULL DEFAULT 'active',
+    capture_transaction_id UUID REFERENCES transactions(id),
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT authorization_amount_positive CHECK (amount > 0),
+    CONSTRAINT authorization_different_accounts CHECK (from_account != to_account),
+    CONSTRAINT captured_within_amount CHECK (captured_amount >= 0 AND captured_amount <= amount),
+    CONSTRAINT capture_transaction_iff_captured CHECK ((status = 'captured') = (capture_transaction_id IS NOT NULL))
 );
 
 CREATE TABLE idempotency_keys (
     key VARCHAR(255) PRIMARY KEY,
+    request_fingerprint CHAR(64) NOT NULL,
     response_code INT NOT NULL,
     response_body JSONB,
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
 
-CREATE INDEX idx_transactions_from_account ON transactions(from_account);
-CREATE INDEX idx_transactions_to_account ON transactions(to_account);
+-- Events are written in the unit of work that commits what they describe and
+-- published by the relay after the commit. seq numbers them in commit order
+-- and is NULL until then.
+CREATE SEQUENCE outbox_seq;
+
+CREATE TABLE outbox (
+    id UUID PRIMARY KEY,
+    seq BIGINT UNIQUE,
+    event_type VARCHAR(64) NOT NULL,
+    aggregate_id UUID NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- The sinks relays publish to. An event is queued for every sink registered
+-- when it is written, so a new sink starts with the events that follow.
+CREATE TABLE outbox_sinks (
+    name VARCHAR(64) PRIMARY KEY
+);
+
+-- The events each sink has yet to take. The relay of a sink leases entries
+-- under FOR UPDATE SKIP LOCKED and deletes them once the sink accepted the
+-- event, so relays of one sink share its queue and a sink that is down holds
+-- back only its own entries.
+CREATE TABLE outbox_pending (
+    sink VARCHAR(64) NOT NULL REFERENCES outbox_sinks(name),
+    event_id UUID NOT NULL REFERENCES outbox(id),
+    leased_until TIMESTAMPTZ NOT NULL DEFAULT '-infinity',
+    PRIMARY KEY (sink, event_id)
+);
+
+-- Numbers every event as its transaction commits and announces it on the
+-- payment_events channel. The trigger is deferred to the commit and takes a
+-- lock held until the commit completes, so events are numbered in the order
+-- they become visible: a reader that has seen an event has seen every event
+-- with a lower seq. Postgres delivers notifications only when the inserting
+-- transaction commits, so listeners on any pay-core instance see committed
+-- transfers only.
+CREATE FUNCTION notify_payment_event() RETURNS TRIGGER AS $$
+DECLARE
+    event_seq BIGINT;
+BEGIN
+    PERFORM pg_advisory_xact_lock('outbox'::regclass::oid::int, 0);
+    UPDATE outbox SET seq = nextval('outbox_seq') WHERE id = NEW.id RETURNING seq INTO event_seq;
+    PERFORM pg_notify('payment_events', json_build_object(
+        'id', NEW.id,
+        'seq', event_seq,
+        'type', NEW.event_type,
+        'aggregate_id', NEW.aggregate_id,
+        'payload', NEW.payload,
+        'created_at', NEW.created_at
+    )::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER outbox_notify
+    AFTER INSERT ON outbox
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION notify_payment_event();
+
+-- One endpoint per account; re-registering replaces its URL and secret.
+CREATE TABLE webhook_endpoints (
+    id UUID PRIMARY KEY,
+    account_id UUID NOT NULL UNIQUE REFERENCES accounts(id),
+    url TEXT NOT NULL,
+    secret VARCHAR(128) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TYPE webhook_delivery_status AS ENUM ('pending', 'delivered', 'dead');
+
+-- The body is stored as posted: it is signed byte for byte on every attempt.
+CREATE TABLE webhook_deliveries (
+    id UUID PRIMARY KEY,
+    endpoint_id UUID NOT NULL REFERENCES webhook_endpoints(id),
+    account_id UUID NOT NULL REFERENCES accounts(id),
+    event_id UUID NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    body BYTEA NOT NULL,
+    status webhook_delivery_status NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    last_status_code INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    delivered_at TIMESTAMPTZ,
+    -- The relay may publish an event more than once.
+    UNIQUE (endpoint_id, event_id)
+);
+
+CREATE TYPE batch_mode AS ENUM ('atomic', 'best_effort');
+CREATE TYPE batch_status AS ENUM ('processing', 'completed', 'partially_completed', 'failed');
+CREATE TYPE batch_item_status AS ENUM ('pending', 'success', 'failed', 'skipped');
+
+CREATE TABLE payout_batches (
+    id UUID PRIMARY KEY,
+    mode batch_mode NOT NULL,
+    status batch_status NOT NULL DEFAULT 'processing',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMPTZ,
+    CONSTRAINT completed_iff_settled CHECK ((status = 'processing') = (completed_at IS NULL))
+);
+
+-- Items are kept as requested: a failed item may name an account that does
+-- not exist, so the accounts are not foreign keys.
+CREATE TABLE payout_batch_items (
+    batch_id UUID NOT NULL REFERENCES payout_batches(id),
+    position INT NOT NULL,
+    from_account UUID NOT NULL,
+    to_account UUID NOT NULL,
+    amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    transfer_type transfer_type NOT NULL,
+    status batch_item_status NOT NULL DEFAULT 'pending',
+    transaction_id UUID REFERENCES transactions(id),
+    failure_reason VARCHAR(64) NOT NULL DEFAULT '',
+    error_message TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (batch_id, position),
+    CONSTRAINT amount_positive CHECK (amount > 0)
+);
+
+-- A split payment is the parent of the transactions paying its legs; a
+-- declined one has legs without transactions.
+CREATE TABLE split_payments (
+    id UUID PRIMARY KEY,
+    from_account UUID NOT NULL REFERENCES accounts(id),
+    amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    status transaction_status NOT NULL,
+    failure_reason VARCHAR(64),
+    error_message TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT amount_positive CHECK (amount > 0),
+    CONSTRAINT failure_reason_iff_failed CHECK ((status = 'failed') = (failure_reason IS NOT NULL))
+);
+
+CREATE TABLE split_legs (
+    split_id UUID NOT NULL REFERENCES split_payments(id),
+    position INT NOT NULL,
+    to_account UUID NOT NULL REFERENCES accounts(id),
+    amount BIGINT NOT NULL,
+    transaction_id UUID UNIQUE REFERENCES transactions(id),
+    PRIMARY KEY (split_id, position),
+    CONSTRAINT amount_positive CHECK (amount > 0)
+);
+
+-- A split payment is written after its legs' transactions, which reference it
+-- and are referenced by its legs.
+ALTER TABLE transactions ADD CONSTRAINT transactions_split_fk
+    FOREIGN KEY (split_id) REFERENCES split_payments(id) DEFERRABLE INITIALLY DEFERRED;
+
+CREATE TYPE intent_status AS ENUM ('created', 'paid', 'expired', 'cancelled');
+
+-- An intent is paid at most once; one that is past expires_at but still
+-- 'created' has expired and is marked so when it is next read for update.
+CREATE TABLE payment_intents (
+    id UUID PRIMARY KEY,
+    to_account UUID NOT NULL REFERENCES accounts(id),
+    amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    status intent_status NOT NULL DEFAULT 'created',
+    from_account UUID REFERENCES accounts(id),
+    transaction_id UUID UNIQUE REFERENCES transactions(id),
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    paid_at TIMESTAMPTZ,
+    CONSTRAINT amount_positive CHECK (amount > 0),
+    CONSTRAINT payment_iff_paid CHECK (
+        (status = 'paid') = (transaction_id IS NOT NULL AND from_account IS NOT NULL AND paid_at IS NOT NULL)
+    )
+);
+
+CREATE INDEX idx_accounts_created_at ON accounts(created_at, id);
+CREATE INDEX idx_transactions_from_account ON transactions(from_account, created_at DESC, id DESC);
+CREATE INDEX idx_transactions_to_account ON transactions(to_account, created_at DESC, id DESC);
 CREATE INDEX idx_transactions_status ON transactions(status);
+CREATE INDEX idx_transactions_created_at ON transactions(created_at DESC, id DESC);
+CREATE INDEX idx_transactions_split ON transactions(split_id)
+    WHERE split_id IS NOT NULL;
+CREATE INDEX idx_transactions_original ON transactions(original_transaction_id)
+    WHERE original_transaction_id IS NOT NULL;
+CREATE INDEX idx_ledger_entries_transaction ON ledger_entries(transaction_id);
+CREATE INDEX idx_ledger_entries_account ON ledger_entries(account_id);
 CREATE INDEX idx_idempotency_keys_created_at ON idempotency_keys(created_at);
+CREATE INDEX idx_authorizations_active_expires_at ON authorizations(expires_at) WHERE status = 'active';
+CREATE INDEX idx_authorizations_from_account ON authorizations(from_account);
+CREATE INDEX idx_outbox_created_at ON outbox(created_at, id);
+CREATE INDEX idx_outbox_from_account ON outbox((payload->>'from_account_id'), seq);
+CREATE INDEX idx_outbox_to_account ON outbox((payload->>'to_account_id'), seq);
+CREATE INDEX idx_outbox_pending_event ON outbox_pending(event_id);
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_webhook_deliveries_account ON webhook_deliveries(account_id, created_at DESC, id DESC);
+CREATE UNIQUE INDEX idx_transfer_limits_account ON transfer_limits(account_id) WHERE account_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_transfer_limits_tier ON transfer_limits(tier, currency) WHERE tier IS NOT NULL;
+CREATE UNIQUE INDEX idx_accounts_system ON accounts(kind, currency) WHERE kind != 'customer';
+-- A quote settles at most one successful payment.
+CREATE UNIQUE INDEX idx_transactions_quote ON transactions(quote_id) WHERE status = 'success';
@@ -16,8 +16,19 @@ import (
 	pb "github.com/Xausdorf/qr-pay-hub/gen/pb"
 	grpchandler "github.com/Xausdorf/qr-pay-hub/internal/delivery/grpc"
 	"github.com/Xausdorf/qr-pay-hub/internal/infrastructure/config"
+	"github.com/Xausdorf/qr-pay-hub/internal/infrastructure/eventsink"
 	"github.com/Xausdorf/qr-pay-hub/internal/infrastructure/postgres"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/account"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/expire"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/fee"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/fx"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/history"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/limit"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/outbox"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/purge"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/stream"
 	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/webhook"
 )
 
 const (
@@ -44,11 +55,95 @@ func main() {
 	defer pool.Close()
 
 	uow := postgres.NewUnitOfWork(pool)
-	transferUC := transfer.NewUseCase(uow)
-	handler := grpchandler.NewHandler(transferUC)
+	transferUC := transfer.NewUseCase(uow,
+		transfer.WithRetryPolicy(transfer.RetryPolicy{
+			MaxAttempts: cfg.TransferMaxAttempts,
+			BaseDelay:   cfg.TransferRetryBaseDelay,
+			MaxDelay:    cfg.TransferRetryMaxDelay,
+		}),
+		transfer.WithHoldTTL(cfg.HoldTTL),
+	)
+	accountUC := account.NewUseCase(uow)
+	historyUC := history.NewUseCase(uow)
+	fxUC := fx.NewUseCase(uow, fx.WithQuoteTTL(cfg.FXQuoteTTL))
+	webhookUC := webhook.NewUseCase(uow)
+	broker := stream.NewBroker(cfg.EventStreamBuffer)
+	handler := grpchandler.NewHandler(transferUC, accountUC, historyUC, fxUC, webhookUC, broker)
+	feeUC := fee.NewUseCase(uow)
+	limitUC := limit.NewUseCase(uow)
+	adminHandler := grpchandler.NewAdminHandler(fxUC, feeUC, limitUC, accountUC)
+
+	if cfg.IdempotencyPurgeInterval > 0 {
+		purgeWorker := purge.NewWorker(uow, purge.Config{
+			Retention: cfg.IdempotencyRetention,
+			Interval:  cfg.IdempotencyPurgeInterval,
+			BatchSize: cfg.IdempotencyPurgeBatchSize,
+		}, logger)
+		go purgeWorker.Run(ctx)
+	}
+
+	if cfg.HoldExpiryInterval > 0 {
+		expireWorker := expire.NewWorker(uow, expire.Config{
+			Interval:  cfg.HoldExpiryInterval,
+			BatchSize: cfg.HoldExpiryBatchSize,
+		}, logger)
+		go expireWorker.Run(ctx)
+	}
+
+	if cfg.FXRatesFile != "" && cfg.FXRatesRefreshInterval > 0 {
+		feed := fx.NewFeed(fxUC, fx.FeedConfig{
+			Path:     cfg.FXRatesFile,
+			Interval: cfg.FXRatesRefreshInterval,
+		}, logger)
+		go feed.Run(ctx)
+	}
+
+	// Webhook deliveries are queued from the outbox, so they need a relay
+	// even when no other sink is configured. Each sink has its own relay, so
+	// an event sink that is down does not hold back webhooks.
+	if cfg.OutboxRelayInterval > 0 {
+		relayCfg := outbox.Config{
+			Interval:  cfg.OutboxRelayInterval,
+			BatchSize: cfg.OutboxBatchSize,
+			Timeout:   cfg.EventSinkTimeout,
+		}
+		go outbox.NewRelay(uow, "webhooks", webhookUC, relayCfg, logger).Run(ctx)
+		if sink := newEventSink(cfg, logger); sink != nil {
+			go outbox.NewRelay(uow, "events", sink, relayCfg, logger).Run(ctx)
+		}
+	}
+
+	if cfg.OutboxPruneInterval > 0 {
+		pruner := outbox.NewPruner(uow, outbox.PruneConfig{
+			Retention: cfg.OutboxRetention,
+			Interval:  cfg.OutboxPruneInterval,
+			BatchSize: cfg.OutboxPruneBatchSize,
+		}, logger)
+		go pruner.Run(ctx)
+	}
+
+	if cfg.WebhookDispatchInterval > 0 {
+		dispatcher := webhook.NewDispatcher(uow, webhook.Config{
+			Interval:          cfg.WebhookDispatchInterval,
+			BatchSize:         cfg.WebhookBatchSize,
+			MaxAttempts:       cfg.WebhookMaxAttempts,
+			BaseDelay:         cfg.WebhookRetryBaseDelay,
+			MaxDelay:          cfg.WebhookRetryMaxDelay,
+			Timeout:           cfg.WebhookTimeout,
+			AllowPrivateHosts: cfg.WebhookAllowPrivateHosts,
+		}, logger)
+		go dispatcher.Run(ctx)
+	}
+
+	if cfg.TransferStatsInterval > 0 {
+		go logTransferStats(ctx, transferUC, cfg.TransferStatsInterval, logger)
+	}
+
+	go postgres.NewEventListener(pool, logger).Run(ctx, broker)
 
 	srv := grpc.NewServer()
 	pb.RegisterPaymentProcessorServer(srv, handler)
+	pb.RegisterPaymentAdminServer(srv, adminHandler)
 	reflection.Register(srv)
 
 	var lc net.ListenConfig
@@ -67,7 +162,46 @@ func main() {
 
 	<-ctx.Done()
 	logger.Info("shutting down...")
+	// Event streams only end when their subscriptions do.
+	broker.Close()
 	srv.GracefulStop()
+
+	stats := transferUC.Stats()
+	logger.Info("transfer retry stats", "retries", stats.Retries, "exhausted", stats.Exhausted)
+}
+
+// logTransferStats logs the transfer retry counters every interval until ctx
+// is done. The counters are totals since the start of the process.
+func logTransferStats(ctx context.Context, uc *transfer.UseCase, interval time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			stats := uc.Stats()
+			logger.InfoContext(ctx, "transfer retry stats", "retries", stats.Retries, "exhausted", stats.Exhausted)
+		}
+	}
+}
+
+// newEventSink returns the sink the "events" relay publishes to besides
+// webhooks, or nil if there is none.
+func newEventSink(cfg *config.Config, logger *slog.Logger) outbox.EventSink {
+	switch cfg.EventSink {
+	case "http":
+		if cfg.EventSinkURL == "" {
+			logger.Error("EVENT_SINK_URL is required for the http event sink, event sink disabled")
+			return nil
+		}
+		return eventsink.NewHTTPSink(cfg.EventSinkURL, cfg.EventSinkTimeout)
+	case "none":
+		return nil
+	default:
+		return eventsink.NewLogSink(logger)
+	}
 }
 
 func initDB(ctx context.Context, url string) (*pgxpool.Pool, error) {
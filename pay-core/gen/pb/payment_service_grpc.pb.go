@@ -19,7 +19,31 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PaymentProcessor_ProcessPayment_FullMethodName = "/qrpay.v1.PaymentProcessor/ProcessPayment"
+	PaymentProcessor_ProcessPayment_FullMethodName         = "/qrpay.v1.PaymentProcessor/ProcessPayment"
+	PaymentProcessor_RefundPayment_FullMethodName          = "/qrpay.v1.PaymentProcessor/RefundPayment"
+	PaymentProcessor_AuthorizePayment_FullMethodName       = "/qrpay.v1.PaymentProcessor/AuthorizePayment"
+	PaymentProcessor_CapturePayment_FullMethodName         = "/qrpay.v1.PaymentProcessor/CapturePayment"
+	PaymentProcessor_VoidAuthorization_FullMethodName      = "/qrpay.v1.PaymentProcessor/VoidAuthorization"
+	PaymentProcessor_ProcessBatch_FullMethodName           = "/qrpay.v1.PaymentProcessor/ProcessBatch"
+	PaymentProcessor_GetBatch_FullMethodName               = "/qrpay.v1.PaymentProcessor/GetBatch"
+	PaymentProcessor_ProcessSplitPayment_FullMethodName    = "/qrpay.v1.PaymentProcessor/ProcessSplitPayment"
+	PaymentProcessor_GetSplitPayment_FullMethodName        = "/qrpay.v1.PaymentProcessor/GetSplitPayment"
+	PaymentProcessor_CreatePaymentIntent_FullMethodName    = "/qrpay.v1.PaymentProcessor/CreatePaymentIntent"
+	PaymentProcessor_GetPaymentIntent_FullMethodName       = "/qrpay.v1.PaymentProcessor/GetPaymentIntent"
+	PaymentProcessor_CancelPaymentIntent_FullMethodName    = "/qrpay.v1.PaymentProcessor/CancelPaymentIntent"
+	PaymentProcessor_PayPaymentIntent_FullMethodName       = "/qrpay.v1.PaymentProcessor/PayPaymentIntent"
+	PaymentProcessor_CreateAccount_FullMethodName          = "/qrpay.v1.PaymentProcessor/CreateAccount"
+	PaymentProcessor_GetAccount_FullMethodName             = "/qrpay.v1.PaymentProcessor/GetAccount"
+	PaymentProcessor_ListAccounts_FullMethodName           = "/qrpay.v1.PaymentProcessor/ListAccounts"
+	PaymentProcessor_SetAccountRequisites_FullMethodName   = "/qrpay.v1.PaymentProcessor/SetAccountRequisites"
+	PaymentProcessor_GetAccountRequisites_FullMethodName   = "/qrpay.v1.PaymentProcessor/GetAccountRequisites"
+	PaymentProcessor_GetTransaction_FullMethodName         = "/qrpay.v1.PaymentProcessor/GetTransaction"
+	PaymentProcessor_ListTransactions_FullMethodName       = "/qrpay.v1.PaymentProcessor/ListTransactions"
+	PaymentProcessor_SubscribeAccountEvents_FullMethodName = "/qrpay.v1.PaymentProcessor/SubscribeAccountEvents"
+	PaymentProcessor_GetQuote_FullMethodName               = "/qrpay.v1.PaymentProcessor/GetQuote"
+	PaymentProcessor_RegisterWebhook_FullMethodName        = "/qrpay.v1.PaymentProcessor/RegisterWebhook"
+	PaymentProcessor_ListWebhookDeliveries_FullMethodName  = "/qrpay.v1.PaymentProcessor/ListWebhookDeliveries"
+	PaymentProcessor_ResendWebhookDelivery_FullMethodName  = "/qrpay.v1.PaymentProcessor/ResendWebhookDelivery"
 )
 
 // PaymentProcessorClient is the client API for PaymentProcessor service.
@@ -27,6 +51,54 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type PaymentProcessorClient interface {
 	ProcessPayment(ctx context.Context, in *PaymentRequest, opts ...grpc.CallOption) (*PaymentResponse, error)
+	RefundPayment(ctx context.Context, in *RefundRequest, opts ...grpc.CallOption) (*PaymentResponse, error)
+	AuthorizePayment(ctx context.Context, in *AuthorizeRequest, opts ...grpc.CallOption) (*Authorization, error)
+	CapturePayment(ctx context.Context, in *CaptureRequest, opts ...grpc.CallOption) (*PaymentResponse, error)
+	VoidAuthorization(ctx context.Context, in *VoidAuthorizationRequest, opts ...grpc.CallOption) (*Authorization, error)
+	// Pays up to 1000 transfers submitted under one idempotency key, either
+	// all or nothing (ATOMIC) or each on its own (BEST_EFFORT). Retrying an
+	// interrupted best-effort batch with the same key pays its remaining items.
+	ProcessBatch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*Batch, error)
+	GetBatch(ctx context.Context, in *GetBatchRequest, opts ...grpc.CallOption) (*Batch, error)
+	// Debits one payer and credits up to 100 payees at once, each leg as a
+	// transaction of its own under the split payment. The legs must sum to the
+	// amount; if the payment is declined, no leg is paid.
+	ProcessSplitPayment(ctx context.Context, in *SplitPaymentRequest, opts ...grpc.CallOption) (*SplitPayment, error)
+	GetSplitPayment(ctx context.Context, in *GetSplitPaymentRequest, opts ...grpc.CallOption) (*SplitPayment, error)
+	// A payment intent asks for a fixed amount to be paid to a payee before it
+	// expires, and is paid at most once: after one payment succeeds, paying it
+	// again fails with INTENT_NOT_OPEN. A payment the payer's account declines
+	// leaves the intent open.
+	CreatePaymentIntent(ctx context.Context, in *CreatePaymentIntentRequest, opts ...grpc.CallOption) (*PaymentIntent, error)
+	GetPaymentIntent(ctx context.Context, in *GetPaymentIntentRequest, opts ...grpc.CallOption) (*PaymentIntent, error)
+	CancelPaymentIntent(ctx context.Context, in *CancelPaymentIntentRequest, opts ...grpc.CallOption) (*PaymentIntent, error)
+	PayPaymentIntent(ctx context.Context, in *PayPaymentIntentRequest, opts ...grpc.CallOption) (*PaymentResponse, error)
+	CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*Account, error)
+	GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*Account, error)
+	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
+	// Replaces the bank details of a customer account, which GOST R 56042-2014
+	// payment codes read by Russian banking apps are filled in from.
+	SetAccountRequisites(ctx context.Context, in *AccountRequisites, opts ...grpc.CallOption) (*AccountRequisites, error)
+	GetAccountRequisites(ctx context.Context, in *GetAccountRequisitesRequest, opts ...grpc.CallOption) (*AccountRequisites, error)
+	GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*Transaction, error)
+	ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error)
+	// Streams transfers into and out of the account as they commit, from the
+	// time of the call on, or first replays those after after_sequence. The
+	// stream ends with RESOURCE_EXHAUSTED if the client reads too slowly and
+	// with UNAVAILABLE (reason EVENT_STREAM_RESYNC) if the server may have
+	// missed events; resubscribe with the last sequence received to catch up.
+	SubscribeAccountEvents(ctx context.Context, in *SubscribeAccountEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AccountEvent], error)
+	// Prices a conversion for a payment between accounts in different
+	// currencies; pass the quote_id to ProcessPayment before it expires.
+	GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*Quote, error)
+	// Sets the URL that payments settled to the account are posted to,
+	// replacing any earlier one and its secret. The response is the only place
+	// the new signing secret is returned.
+	RegisterWebhook(ctx context.Context, in *RegisterWebhookRequest, opts ...grpc.CallOption) (*WebhookEndpoint, error)
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
+	// Queues a delivery again with a fresh set of attempts, e.g. to take it out
+	// of the dead-letter queue.
+	ResendWebhookDelivery(ctx context.Context, in *ResendWebhookDeliveryRequest, opts ...grpc.CallOption) (*WebhookDelivery, error)
 }
 
 type paymentProcessorClient struct {
@@ -47,11 +119,308 @@ func (c *paymentProcessorClient) ProcessPayment(ctx context.Context, in *Payment
 	return out, nil
 }
 
+func (c *paymentProcessorClient) RefundPayment(ctx context.Context, in *RefundRequest, opts ...grpc.CallOption) (*PaymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PaymentResponse)
+	err := c.cc.Invoke(ctx, PaymentProcessor_RefundPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentProcessorClient) AuthorizePayment(ctx context.Context, in *AuthorizeRequest, opts ...grpc.CallOption) (*Authorization, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Authorization)
+	err := c.cc.Invoke(ctx, PaymentProcessor_AuthorizePayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentProcessorClient) CapturePayment(ctx context.Context, in *CaptureRequest, opts ...grpc.CallOption) (*PaymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PaymentResponse)
+	err := c.cc.Invoke(ctx, PaymentProcessor_CapturePayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentProcessorClient) VoidAuthorization(ctx context.Context, in *VoidAuthorizationRequest, opts ...grpc.CallOption) (*Authorization, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Authorization)
+	err := c.cc.Invoke(ctx, PaymentProcessor_VoidAuthorization_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentProcessorClient) ProcessBatch(ctx context.Context, in *BatchRequest, opts ...grpc.CallOption) (*Batch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Batch)
+	err := c.cc.Invoke(ctx, PaymentProcessor_ProcessBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentProcessorClient) GetBatch(ctx context.Context, in *GetBatchRequest, opts ...grpc.CallOption) (*Batch, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Batch)
+	err := c.cc.Invoke(ctx, PaymentProcessor_GetBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentProcessorClient) ProcessSplitPayment(ctx context.Context, in *SplitPaymentRequest, opts ...grpc.CallOption) (*SplitPayment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SplitPayment)
+	err := c.cc.Invoke(ctx, PaymentProcessor_ProcessSplitPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentProcessorClient) GetSplitPayment(ctx context.Context, in *GetSplitPaymentRequest, opts ...grpc.CallOption) (*SplitPayment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SplitPayment)
+	err := c.cc.Invoke(ctx, PaymentProcessor_GetSplitPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentProcessorClient) CreatePaymentIntent(ctx context.Context, in *CreatePaymentIntentRequest, opts ...grpc.CallOption) (*PaymentIntent, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PaymentIntent)
+	err := c.cc.Invoke(ctx, PaymentProcessor_CreatePaymentIntent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentProcessorClient) GetPaymentIntent(ctx context.Context, in *GetPaymentIntentRequest, opts ...grpc.CallOption) (*PaymentIntent, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PaymentIntent)
+	err := c.cc.Invoke(ctx, PaymentProcessor_GetPaymentIntent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentProcessorClient) CancelPaymentIntent(ctx context.Context, in *CancelPaymentIntentRequest, opts ...grpc.CallOption) (*PaymentIntent, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PaymentIntent)
+	err := c.cc.Invoke(ctx, PaymentProcessor_CancelPaymentIntent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentProcessorClient) PayPaymentIntent(ctx context.Context, in *PayPaymentIntentRequest, opts ...grpc.CallOption) (*PaymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PaymentResponse)
+	err := c.cc.Invoke(ctx, PaymentProcessor_PayPaymentIntent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentProcessorClient) CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Account)
+	err := c.cc.Invoke(ctx, PaymentProcessor_CreateAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentProcessorClient) GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Account)
+	err := c.cc.Invoke(ctx, PaymentProcessor_GetAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentProcessorClient) ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccountsResponse)
+	err := c.cc.Invoke(ctx, PaymentProcessor_ListAccounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentProcessorClient) SetAccountRequisites(ctx context.Context, in *AccountRequisites, opts ...grpc.CallOption) (*AccountRequisites, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountRequisites)
+	err := c.cc.Invoke(ctx, PaymentProcessor_SetAccountRequisites_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentProcessorClient) GetAccountRequisites(ctx context.Context, in *GetAccountRequisitesRequest, opts ...grpc.CallOption) (*AccountRequisites, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountRequisites)
+	err := c.cc.Invoke(ctx, PaymentProcessor_GetAccountRequisites_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentProcessorClient) GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*Transaction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Transaction)
+	err := c.cc.Invoke(ctx, PaymentProcessor_GetTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentProcessorClient) ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTransactionsResponse)
+	err := c.cc.Invoke(ctx, PaymentProcessor_ListTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentProcessorClient) SubscribeAccountEvents(ctx context.Context, in *SubscribeAccountEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[AccountEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PaymentProcessor_ServiceDesc.Streams[0], PaymentProcessor_SubscribeAccountEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeAccountEventsRequest, AccountEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PaymentProcessor_SubscribeAccountEventsClient = grpc.ServerStreamingClient[AccountEvent]
+
+func (c *paymentProcessorClient) GetQuote(ctx context.Context, in *GetQuoteRequest, opts ...grpc.CallOption) (*Quote, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Quote)
+	err := c.cc.Invoke(ctx, PaymentProcessor_GetQuote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentProcessorClient) RegisterWebhook(ctx context.Context, in *RegisterWebhookRequest, opts ...grpc.CallOption) (*WebhookEndpoint, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookEndpoint)
+	err := c.cc.Invoke(ctx, PaymentProcessor_RegisterWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentProcessorClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, PaymentProcessor_ListWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentProcessorClient) ResendWebhookDelivery(ctx context.Context, in *ResendWebhookDeliveryRequest, opts ...grpc.CallOption) (*WebhookDelivery, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookDelivery)
+	err := c.cc.Invoke(ctx, PaymentProcessor_ResendWebhookDelivery_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PaymentProcessorServer is the server API for PaymentProcessor service.
 // All implementations must embed UnimplementedPaymentProcessorServer
 // for forward compatibility.
 type PaymentProcessorServer interface {
 	ProcessPayment(context.Context, *PaymentRequest) (*PaymentResponse, error)
+	RefundPayment(context.Context, *RefundRequest) (*PaymentResponse, error)
+	AuthorizePayment(context.Context, *AuthorizeRequest) (*Authorization, error)
+	CapturePayment(context.Context, *CaptureRequest) (*PaymentResponse, error)
+	VoidAuthorization(context.Context, *VoidAuthorizationRequest) (*Authorization, error)
+	// Pays up to 1000 transfers submitted under one idempotency key, either
+	// all or nothing (ATOMIC) or each on its own (BEST_EFFORT). Retrying an
+	// interrupted best-effort batch with the same key pays its remaining items.
+	ProcessBatch(context.Context, *BatchRequest) (*Batch, error)
+	GetBatch(context.Context, *GetBatchRequest) (*Batch, error)
+	// Debits one payer and credits up to 100 payees at once, each leg as a
+	// transaction of its own under the split payment. The legs must sum to the
+	// amount; if the payment is declined, no leg is paid.
+	ProcessSplitPayment(context.Context, *SplitPaymentRequest) (*SplitPayment, error)
+	GetSplitPayment(context.Context, *GetSplitPaymentRequest) (*SplitPayment, error)
+	// A payment intent asks for a fixed amount to be paid to a payee before it
+	// expires, and is paid at most once: after one payment succeeds, paying it
+	// again fails with INTENT_NOT_OPEN. A payment the payer's account declines
+	// leaves the intent open.
+	CreatePaymentIntent(context.Context, *CreatePaymentIntentRequest) (*PaymentIntent, error)
+	GetPaymentIntent(context.Context, *GetPaymentIntentRequest) (*PaymentIntent, error)
+	CancelPaymentIntent(context.Context, *CancelPaymentIntentRequest) (*PaymentIntent, error)
+	PayPaymentIntent(context.Context, *PayPaymentIntentRequest) (*PaymentResponse, error)
+	CreateAccount(context.Context, *CreateAccountRequest) (*Account, error)
+	GetAccount(context.Context, *GetAccountRequest) (*Account, error)
+	ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error)
+	// Replaces the bank details of a customer account, which GOST R 56042-2014
+	// payment codes read by Russian banking apps are filled in from.
+	SetAccountRequisites(context.Context, *AccountRequisites) (*AccountRequisites, error)
+	GetAccountRequisites(context.Context, *GetAccountRequisitesRequest) (*AccountRequisites, error)
+	GetTransaction(context.Context, *GetTransactionRequest) (*Transaction, error)
+	ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error)
+	// Streams transfers into and out of the account as they commit, from the
+	// time of the call on, or first replays those after after_sequence. The
+	// stream ends with RESOURCE_EXHAUSTED if the client reads too slowly and
+	// with UNAVAILABLE (reason EVENT_STREAM_RESYNC) if the server may have
+	// missed events; resubscribe with the last sequence received to catch up.
+	SubscribeAccountEvents(*SubscribeAccountEventsRequest, grpc.ServerStreamingServer[AccountEvent]) error
+	// Prices a conversion for a payment between accounts in different
+	// currencies; pass the quote_id to ProcessPayment before it expires.
+	GetQuote(context.Context, *GetQuoteRequest) (*Quote, error)
+	// Sets the URL that payments settled to the account are posted to,
+	// replacing any earlier one and its secret. The response is the only place
+	// the new signing secret is returned.
+	RegisterWebhook(context.Context, *RegisterWebhookRequest) (*WebhookEndpoint, error)
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
+	// Queues a delivery again with a fresh set of attempts, e.g. to take it out
+	// of the dead-letter queue.
+	ResendWebhookDelivery(context.Context, *ResendWebhookDeliveryRequest) (*WebhookDelivery, error)
 	mustEmbedUnimplementedPaymentProcessorServer()
 }
 
@@ -65,6 +434,78 @@ type UnimplementedPaymentProcessorServer struct{}
 func (UnimplementedPaymentProcessorServer) ProcessPayment(context.Context, *PaymentRequest) (*PaymentResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ProcessPayment not implemented")
 }
+func (UnimplementedPaymentProcessorServer) RefundPayment(context.Context, *RefundRequest) (*PaymentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RefundPayment not implemented")
+}
+func (UnimplementedPaymentProcessorServer) AuthorizePayment(context.Context, *AuthorizeRequest) (*Authorization, error) {
+	return nil, status.Error(codes.Unimplemented, "method AuthorizePayment not implemented")
+}
+func (UnimplementedPaymentProcessorServer) CapturePayment(context.Context, *CaptureRequest) (*PaymentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CapturePayment not implemented")
+}
+func (UnimplementedPaymentProcessorServer) VoidAuthorization(context.Context, *VoidAuthorizationRequest) (*Authorization, error) {
+	return nil, status.Error(codes.Unimplemented, "method VoidAuthorization not implemented")
+}
+func (UnimplementedPaymentProcessorServer) ProcessBatch(context.Context, *BatchRequest) (*Batch, error) {
+	return nil, status.Error(codes.Unimplemented, "method ProcessBatch not implemented")
+}
+func (UnimplementedPaymentProcessorServer) GetBatch(context.Context, *GetBatchRequest) (*Batch, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBatch not implemented")
+}
+func (UnimplementedPaymentProcessorServer) ProcessSplitPayment(context.Context, *SplitPaymentRequest) (*SplitPayment, error) {
+	return nil, status.Error(codes.Unimplemented, "method ProcessSplitPayment not implemented")
+}
+func (UnimplementedPaymentProcessorServer) GetSplitPayment(context.Context, *GetSplitPaymentRequest) (*SplitPayment, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSplitPayment not implemented")
+}
+func (UnimplementedPaymentProcessorServer) CreatePaymentIntent(context.Context, *CreatePaymentIntentRequest) (*PaymentIntent, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreatePaymentIntent not implemented")
+}
+func (UnimplementedPaymentProcessorServer) GetPaymentIntent(context.Context, *GetPaymentIntentRequest) (*PaymentIntent, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPaymentIntent not implemented")
+}
+func (UnimplementedPaymentProcessorServer) CancelPaymentIntent(context.Context, *CancelPaymentIntentRequest) (*PaymentIntent, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelPaymentIntent not implemented")
+}
+func (UnimplementedPaymentProcessorServer) PayPaymentIntent(context.Context, *PayPaymentIntentRequest) (*PaymentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PayPaymentIntent not implemented")
+}
+func (UnimplementedPaymentProcessorServer) CreateAccount(context.Context, *CreateAccountRequest) (*Account, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateAccount not implemented")
+}
+func (UnimplementedPaymentProcessorServer) GetAccount(context.Context, *GetAccountRequest) (*Account, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAccount not implemented")
+}
+func (UnimplementedPaymentProcessorServer) ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAccounts not implemented")
+}
+func (UnimplementedPaymentProcessorServer) SetAccountRequisites(context.Context, *AccountRequisites) (*AccountRequisites, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetAccountRequisites not implemented")
+}
+func (UnimplementedPaymentProcessorServer) GetAccountRequisites(context.Context, *GetAccountRequisitesRequest) (*AccountRequisites, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAccountRequisites not implemented")
+}
+func (UnimplementedPaymentProcessorServer) GetTransaction(context.Context, *GetTransactionRequest) (*Transaction, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (UnimplementedPaymentProcessorServer) ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTransactions not implemented")
+}
+func (UnimplementedPaymentProcessorServer) SubscribeAccountEvents(*SubscribeAccountEventsRequest, grpc.ServerStreamingServer[AccountEvent]) error {
+	return status.Error(codes.Unimplemented, "method SubscribeAccountEvents not implemented")
+}
+func (UnimplementedPaymentProcessorServer) GetQuote(context.Context, *GetQuoteRequest) (*Quote, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetQuote not implemented")
+}
+func (UnimplementedPaymentProcessorServer) RegisterWebhook(context.Context, *RegisterWebhookRequest) (*WebhookEndpoint, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegisterWebhook not implemented")
+}
+func (UnimplementedPaymentProcessorServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedPaymentProcessorServer) ResendWebhookDelivery(context.Context, *ResendWebhookDeliveryRequest) (*WebhookDelivery, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResendWebhookDelivery not implemented")
+}
 func (UnimplementedPaymentProcessorServer) mustEmbedUnimplementedPaymentProcessorServer() {}
 func (UnimplementedPaymentProcessorServer) testEmbeddedByValue()                          {}
 
@@ -104,16 +545,921 @@ func _PaymentProcessor_ProcessPayment_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
-// PaymentProcessor_ServiceDesc is the grpc.ServiceDesc for PaymentProcessor service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var PaymentProcessor_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "qrpay.v1.PaymentProcessor",
-	HandlerType: (*PaymentProcessorServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "ProcessPayment",
-			Handler:    _PaymentProcessor_ProcessPayment_Handler,
+func _PaymentProcessor_RefundPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentProcessorServer).RefundPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentProcessor_RefundPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentProcessorServer).RefundPayment(ctx, req.(*RefundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentProcessor_AuthorizePayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentProcessorServer).AuthorizePayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentProcessor_AuthorizePayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentProcessorServer).AuthorizePayment(ctx, req.(*AuthorizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentProcessor_CapturePayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaptureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentProcessorServer).CapturePayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentProcessor_CapturePayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentProcessorServer).CapturePayment(ctx, req.(*CaptureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentProcessor_VoidAuthorization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoidAuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentProcessorServer).VoidAuthorization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentProcessor_VoidAuthorization_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentProcessorServer).VoidAuthorization(ctx, req.(*VoidAuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentProcessor_ProcessBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentProcessorServer).ProcessBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentProcessor_ProcessBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentProcessorServer).ProcessBatch(ctx, req.(*BatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentProcessor_GetBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentProcessorServer).GetBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentProcessor_GetBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentProcessorServer).GetBatch(ctx, req.(*GetBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentProcessor_ProcessSplitPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SplitPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentProcessorServer).ProcessSplitPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentProcessor_ProcessSplitPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentProcessorServer).ProcessSplitPayment(ctx, req.(*SplitPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentProcessor_GetSplitPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSplitPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentProcessorServer).GetSplitPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentProcessor_GetSplitPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentProcessorServer).GetSplitPayment(ctx, req.(*GetSplitPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentProcessor_CreatePaymentIntent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePaymentIntentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentProcessorServer).CreatePaymentIntent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentProcessor_CreatePaymentIntent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentProcessorServer).CreatePaymentIntent(ctx, req.(*CreatePaymentIntentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentProcessor_GetPaymentIntent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPaymentIntentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentProcessorServer).GetPaymentIntent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentProcessor_GetPaymentIntent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentProcessorServer).GetPaymentIntent(ctx, req.(*GetPaymentIntentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentProcessor_CancelPaymentIntent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelPaymentIntentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentProcessorServer).CancelPaymentIntent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentProcessor_CancelPaymentIntent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentProcessorServer).CancelPaymentIntent(ctx, req.(*CancelPaymentIntentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentProcessor_PayPaymentIntent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PayPaymentIntentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentProcessorServer).PayPaymentIntent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentProcessor_PayPaymentIntent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentProcessorServer).PayPaymentIntent(ctx, req.(*PayPaymentIntentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentProcessor_CreateAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentProcessorServer).CreateAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentProcessor_CreateAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentProcessorServer).CreateAccount(ctx, req.(*CreateAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentProcessor_GetAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentProcessorServer).GetAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentProcessor_GetAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentProcessorServer).GetAccount(ctx, req.(*GetAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentProcessor_ListAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentProcessorServer).ListAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentProcessor_ListAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentProcessorServer).ListAccounts(ctx, req.(*ListAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentProcessor_SetAccountRequisites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountRequisites)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentProcessorServer).SetAccountRequisites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentProcessor_SetAccountRequisites_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentProcessorServer).SetAccountRequisites(ctx, req.(*AccountRequisites))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentProcessor_GetAccountRequisites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountRequisitesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentProcessorServer).GetAccountRequisites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentProcessor_GetAccountRequisites_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentProcessorServer).GetAccountRequisites(ctx, req.(*GetAccountRequisitesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentProcessor_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentProcessorServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentProcessor_GetTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentProcessorServer).GetTransaction(ctx, req.(*GetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentProcessor_ListTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentProcessorServer).ListTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentProcessor_ListTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentProcessorServer).ListTransactions(ctx, req.(*ListTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentProcessor_SubscribeAccountEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeAccountEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PaymentProcessorServer).SubscribeAccountEvents(m, &grpc.GenericServerStream[SubscribeAccountEventsRequest, AccountEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PaymentProcessor_SubscribeAccountEventsServer = grpc.ServerStreamingServer[AccountEvent]
+
+func _PaymentProcessor_GetQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentProcessorServer).GetQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentProcessor_GetQuote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentProcessorServer).GetQuote(ctx, req.(*GetQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentProcessor_RegisterWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentProcessorServer).RegisterWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentProcessor_RegisterWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentProcessorServer).RegisterWebhook(ctx, req.(*RegisterWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentProcessor_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentProcessorServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentProcessor_ListWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentProcessorServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentProcessor_ResendWebhookDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResendWebhookDeliveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentProcessorServer).ResendWebhookDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentProcessor_ResendWebhookDelivery_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentProcessorServer).ResendWebhookDelivery(ctx, req.(*ResendWebhookDeliveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PaymentProcessor_ServiceDesc is the grpc.ServiceDesc for PaymentProcessor service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PaymentProcessor_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "qrpay.v1.PaymentProcessor",
+	HandlerType: (*PaymentProcessorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ProcessPayment",
+			Handler:    _PaymentProcessor_ProcessPayment_Handler,
+		},
+		{
+			MethodName: "RefundPayment",
+			Handler:    _PaymentProcessor_RefundPayment_Handler,
+		},
+		{
+			MethodName: "AuthorizePayment",
+			Handler:    _PaymentProcessor_AuthorizePayment_Handler,
+		},
+		{
+			MethodName: "CapturePayment",
+			Handler:    _PaymentProcessor_CapturePayment_Handler,
+		},
+		{
+			MethodName: "VoidAuthorization",
+			Handler:    _PaymentProcessor_VoidAuthorization_Handler,
+		},
+		{
+			MethodName: "ProcessBatch",
+			Handler:    _PaymentProcessor_ProcessBatch_Handler,
+		},
+		{
+			MethodName: "GetBatch",
+			Handler:    _PaymentProcessor_GetBatch_Handler,
+		},
+		{
+			MethodName: "ProcessSplitPayment",
+			Handler:    _PaymentProcessor_ProcessSplitPayment_Handler,
+		},
+		{
+			MethodName: "GetSplitPayment",
+			Handler:    _PaymentProcessor_GetSplitPayment_Handler,
+		},
+		{
+			MethodName: "CreatePaymentIntent",
+			Handler:    _PaymentProcessor_CreatePaymentIntent_Handler,
+		},
+		{
+			MethodName: "GetPaymentIntent",
+			Handler:    _PaymentProcessor_GetPaymentIntent_Handler,
+		},
+		{
+			MethodName: "CancelPaymentIntent",
+			Handler:    _PaymentProcessor_CancelPaymentIntent_Handler,
+		},
+		{
+			MethodName: "PayPaymentIntent",
+			Handler:    _PaymentProcessor_PayPaymentIntent_Handler,
+		},
+		{
+			MethodName: "CreateAccount",
+			Handler:    _PaymentProcessor_CreateAccount_Handler,
+		},
+		{
+			MethodName: "GetAccount",
+			Handler:    _PaymentProcessor_GetAccount_Handler,
+		},
+		{
+			MethodName: "ListAccounts",
+			Handler:    _PaymentProcessor_ListAccounts_Handler,
+		},
+		{
+			MethodName: "SetAccountRequisites",
+			Handler:    _PaymentProcessor_SetAccountRequisites_Handler,
+		},
+		{
+			MethodName: "GetAccountRequisites",
+			Handler:    _PaymentProcessor_GetAccountRequisites_Handler,
+		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _PaymentProcessor_GetTransaction_Handler,
+		},
+		{
+			MethodName: "ListTransactions",
+			Handler:    _PaymentProcessor_ListTransactions_Handler,
+		},
+		{
+			MethodName: "GetQuote",
+			Handler:    _PaymentProcessor_GetQuote_Handler,
+		},
+		{
+			MethodName: "RegisterWebhook",
+			Handler:    _PaymentProcessor_RegisterWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _PaymentProcessor_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "ResendWebhookDelivery",
+			Handler:    _PaymentProcessor_ResendWebhookDelivery_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeAccountEvents",
+			Handler:       _PaymentProcessor_SubscribeAccountEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/payment_service.proto",
+}
+
+const (
+	PaymentAdmin_SetRates_FullMethodName           = "/qrpay.v1.PaymentAdmin/SetRates"
+	PaymentAdmin_SetFeeSchedules_FullMethodName    = "/qrpay.v1.PaymentAdmin/SetFeeSchedules"
+	PaymentAdmin_ListFeeSchedules_FullMethodName   = "/qrpay.v1.PaymentAdmin/ListFeeSchedules"
+	PaymentAdmin_SetTransferLimits_FullMethodName  = "/qrpay.v1.PaymentAdmin/SetTransferLimits"
+	PaymentAdmin_ListTransferLimits_FullMethodName = "/qrpay.v1.PaymentAdmin/ListTransferLimits"
+	PaymentAdmin_FreezeAccount_FullMethodName      = "/qrpay.v1.PaymentAdmin/FreezeAccount"
+	PaymentAdmin_UnfreezeAccount_FullMethodName    = "/qrpay.v1.PaymentAdmin/UnfreezeAccount"
+	PaymentAdmin_CloseAccount_FullMethodName       = "/qrpay.v1.PaymentAdmin/CloseAccount"
+)
+
+// PaymentAdminClient is the client API for PaymentAdmin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Operator-only RPCs; not exposed through the gateway.
+type PaymentAdminClient interface {
+	// Replaces the given FX rates; pairs not mentioned keep their rates.
+	SetRates(ctx context.Context, in *SetRatesRequest, opts ...grpc.CallOption) (*SetRatesResponse, error)
+	// Replaces the given fee schedules; schedules not mentioned are kept.
+	SetFeeSchedules(ctx context.Context, in *SetFeeSchedulesRequest, opts ...grpc.CallOption) (*SetFeeSchedulesResponse, error)
+	ListFeeSchedules(ctx context.Context, in *ListFeeSchedulesRequest, opts ...grpc.CallOption) (*ListFeeSchedulesResponse, error)
+	// Replaces the transfer limits of one account or tier.
+	SetTransferLimits(ctx context.Context, in *TransferLimits, opts ...grpc.CallOption) (*TransferLimits, error)
+	ListTransferLimits(ctx context.Context, in *ListTransferLimitsRequest, opts ...grpc.CallOption) (*ListTransferLimitsResponse, error)
+	// Stops a customer account from sending money; it can still receive.
+	FreezeAccount(ctx context.Context, in *FreezeAccountRequest, opts ...grpc.CallOption) (*Account, error)
+	UnfreezeAccount(ctx context.Context, in *UnfreezeAccountRequest, opts ...grpc.CallOption) (*Account, error)
+	// Closes a customer account with zero balance for good.
+	CloseAccount(ctx context.Context, in *CloseAccountRequest, opts ...grpc.CallOption) (*Account, error)
+}
+
+type paymentAdminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPaymentAdminClient(cc grpc.ClientConnInterface) PaymentAdminClient {
+	return &paymentAdminClient{cc}
+}
+
+func (c *paymentAdminClient) SetRates(ctx context.Context, in *SetRatesRequest, opts ...grpc.CallOption) (*SetRatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetRatesResponse)
+	err := c.cc.Invoke(ctx, PaymentAdmin_SetRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentAdminClient) SetFeeSchedules(ctx context.Context, in *SetFeeSchedulesRequest, opts ...grpc.CallOption) (*SetFeeSchedulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetFeeSchedulesResponse)
+	err := c.cc.Invoke(ctx, PaymentAdmin_SetFeeSchedules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentAdminClient) ListFeeSchedules(ctx context.Context, in *ListFeeSchedulesRequest, opts ...grpc.CallOption) (*ListFeeSchedulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFeeSchedulesResponse)
+	err := c.cc.Invoke(ctx, PaymentAdmin_ListFeeSchedules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentAdminClient) SetTransferLimits(ctx context.Context, in *TransferLimits, opts ...grpc.CallOption) (*TransferLimits, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TransferLimits)
+	err := c.cc.Invoke(ctx, PaymentAdmin_SetTransferLimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentAdminClient) ListTransferLimits(ctx context.Context, in *ListTransferLimitsRequest, opts ...grpc.CallOption) (*ListTransferLimitsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTransferLimitsResponse)
+	err := c.cc.Invoke(ctx, PaymentAdmin_ListTransferLimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentAdminClient) FreezeAccount(ctx context.Context, in *FreezeAccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Account)
+	err := c.cc.Invoke(ctx, PaymentAdmin_FreezeAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentAdminClient) UnfreezeAccount(ctx context.Context, in *UnfreezeAccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Account)
+	err := c.cc.Invoke(ctx, PaymentAdmin_UnfreezeAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentAdminClient) CloseAccount(ctx context.Context, in *CloseAccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Account)
+	err := c.cc.Invoke(ctx, PaymentAdmin_CloseAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PaymentAdminServer is the server API for PaymentAdmin service.
+// All implementations must embed UnimplementedPaymentAdminServer
+// for forward compatibility.
+//
+// Operator-only RPCs; not exposed through the gateway.
+type PaymentAdminServer interface {
+	// Replaces the given FX rates; pairs not mentioned keep their rates.
+	SetRates(context.Context, *SetRatesRequest) (*SetRatesResponse, error)
+	// Replaces the given fee schedules; schedules not mentioned are kept.
+	SetFeeSchedules(context.Context, *SetFeeSchedulesRequest) (*SetFeeSchedulesResponse, error)
+	ListFeeSchedules(context.Context, *ListFeeSchedulesRequest) (*ListFeeSchedulesResponse, error)
+	// Replaces the transfer limits of one account or tier.
+	SetTransferLimits(context.Context, *TransferLimits) (*TransferLimits, error)
+	ListTransferLimits(context.Context, *ListTransferLimitsRequest) (*ListTransferLimitsResponse, error)
+	// Stops a customer account from sending money; it can still receive.
+	FreezeAccount(context.Context, *FreezeAccountRequest) (*Account, error)
+	UnfreezeAccount(context.Context, *UnfreezeAccountRequest) (*Account, error)
+	// Closes a customer account with zero balance for good.
+	CloseAccount(context.Context, *CloseAccountRequest) (*Account, error)
+	mustEmbedUnimplementedPaymentAdminServer()
+}
+
+// UnimplementedPaymentAdminServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPaymentAdminServer struct{}
+
+func (UnimplementedPaymentAdminServer) SetRates(context.Context, *SetRatesRequest) (*SetRatesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetRates not implemented")
+}
+func (UnimplementedPaymentAdminServer) SetFeeSchedules(context.Context, *SetFeeSchedulesRequest) (*SetFeeSchedulesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetFeeSchedules not implemented")
+}
+func (UnimplementedPaymentAdminServer) ListFeeSchedules(context.Context, *ListFeeSchedulesRequest) (*ListFeeSchedulesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListFeeSchedules not implemented")
+}
+func (UnimplementedPaymentAdminServer) SetTransferLimits(context.Context, *TransferLimits) (*TransferLimits, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetTransferLimits not implemented")
+}
+func (UnimplementedPaymentAdminServer) ListTransferLimits(context.Context, *ListTransferLimitsRequest) (*ListTransferLimitsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTransferLimits not implemented")
+}
+func (UnimplementedPaymentAdminServer) FreezeAccount(context.Context, *FreezeAccountRequest) (*Account, error) {
+	return nil, status.Error(codes.Unimplemented, "method FreezeAccount not implemented")
+}
+func (UnimplementedPaymentAdminServer) UnfreezeAccount(context.Context, *UnfreezeAccountRequest) (*Account, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnfreezeAccount not implemented")
+}
+func (UnimplementedPaymentAdminServer) CloseAccount(context.Context, *CloseAccountRequest) (*Account, error) {
+	return nil, status.Error(codes.Unimplemented, "method CloseAccount not implemented")
+}
+func (UnimplementedPaymentAdminServer) mustEmbedUnimplementedPaymentAdminServer() {}
+func (UnimplementedPaymentAdminServer) testEmbeddedByValue()                      {}
+
+// UnsafePaymentAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PaymentAdminServer will
+// result in compilation errors.
+type UnsafePaymentAdminServer interface {
+	mustEmbedUnimplementedPaymentAdminServer()
+}
+
+func RegisterPaymentAdminServer(s grpc.ServiceRegistrar, srv PaymentAdminServer) {
+	// If the following call panics, it indicates UnimplementedPaymentAdminServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PaymentAdmin_ServiceDesc, srv)
+}
+
+func _PaymentAdmin_SetRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentAdminServer).SetRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentAdmin_SetRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentAdminServer).SetRates(ctx, req.(*SetRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentAdmin_SetFeeSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFeeSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentAdminServer).SetFeeSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentAdmin_SetFeeSchedules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentAdminServer).SetFeeSchedules(ctx, req.(*SetFeeSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentAdmin_ListFeeSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFeeSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentAdminServer).ListFeeSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentAdmin_ListFeeSchedules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentAdminServer).ListFeeSchedules(ctx, req.(*ListFeeSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentAdmin_SetTransferLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferLimits)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentAdminServer).SetTransferLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentAdmin_SetTransferLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentAdminServer).SetTransferLimits(ctx, req.(*TransferLimits))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentAdmin_ListTransferLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransferLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentAdminServer).ListTransferLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentAdmin_ListTransferLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentAdminServer).ListTransferLimits(ctx, req.(*ListTransferLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentAdmin_FreezeAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreezeAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentAdminServer).FreezeAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentAdmin_FreezeAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentAdminServer).FreezeAccount(ctx, req.(*FreezeAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentAdmin_UnfreezeAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnfreezeAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentAdminServer).UnfreezeAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentAdmin_UnfreezeAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentAdminServer).UnfreezeAccount(ctx, req.(*UnfreezeAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentAdmin_CloseAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloseAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentAdminServer).CloseAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentAdmin_CloseAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentAdminServer).CloseAccount(ctx, req.(*CloseAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PaymentAdmin_ServiceDesc is the grpc.ServiceDesc for PaymentAdmin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PaymentAdmin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "qrpay.v1.PaymentAdmin",
+	HandlerType: (*PaymentAdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SetRates",
+			Handler:    _PaymentAdmin_SetRates_Handler,
+		},
+		{
+			MethodName: "SetFeeSchedules",
+			Handler:    _PaymentAdmin_SetFeeSchedules_Handler,
+		},
+		{
+			MethodName: "ListFeeSchedules",
+			Handler:    _PaymentAdmin_ListFeeSchedules_Handler,
+		},
+		{
+			MethodName: "SetTransferLimits",
+			Handler:    _PaymentAdmin_SetTransferLimits_Handler,
+		},
+		{
+			MethodName: "ListTransferLimits",
+			Handler:    _PaymentAdmin_ListTransferLimits_Handler,
+		},
+		{
+			MethodName: "FreezeAccount",
+			Handler:    _PaymentAdmin_FreezeAccount_Handler,
+		},
+		{
+			MethodName: "UnfreezeAccount",
+			Handler:    _PaymentAdmin_UnfreezeAccount_Handler,
+		},
+		{
+			MethodName: "CloseAccount",
+			Handler:    _PaymentAdmin_CloseAccount_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
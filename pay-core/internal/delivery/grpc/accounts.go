@@ -0,0 +1,147 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/Xausdorf/qr-pay-hub/gen/pb"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/account"
+)
+
+func (h *Handler) CreateAccount(ctx context.Context, req *pb.CreateAccountRequest) (*pb.Account, error) {
+	currency, err := parseCurrency(req.GetCurrency())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	tier, err := entity.ParseTier(req.GetTier())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	acc, err := h.accountUC.Create(ctx, currency, tier)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPBAccount(acc), nil
+}
+
+func (h *Handler) GetAccount(ctx context.Context, req *pb.GetAccountRequest) (*pb.Account, error) {
+	id, err := uuid.Parse(req.GetAccountId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid account_id")
+	}
+
+	acc, err := h.accountUC.Get(ctx, id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPBAccount(acc), nil
+}
+
+func (h *Handler) ListAccounts(ctx context.Context, req *pb.ListAccountsRequest) (*pb.ListAccountsResponse, error) {
+	resp, err := h.accountUC.List(ctx, account.ListRequest{
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	accounts := make([]*pb.Account, 0, len(resp.Accounts))
+	for _, acc := range resp.Accounts {
+		accounts = append(accounts, toPBAccount(acc))
+	}
+	return &pb.ListAccountsResponse{
+		Accounts:      accounts,
+		NextPageToken: resp.NextPageToken,
+	}, nil
+}
+
+func (h *AdminHandler) FreezeAccount(ctx context.Context, req *pb.FreezeAccountRequest) (*pb.Account, error) {
+	id, err := uuid.Parse(req.GetAccountId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid account_id")
+	}
+
+	acc, err := h.accountUC.Freeze(ctx, id, req.GetReason())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPBAccount(acc), nil
+}
+
+func (h *AdminHandler) UnfreezeAccount(ctx context.Context, req *pb.UnfreezeAccountRequest) (*pb.Account, error) {
+	id, err := uuid.Parse(req.GetAccountId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid account_id")
+	}
+
+	acc, err := h.accountUC.Unfreeze(ctx, id, req.GetReason())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPBAccount(acc), nil
+}
+
+func (h *AdminHandler) CloseAccount(ctx context.Context, req *pb.CloseAccountRequest) (*pb.Account, error) {
+	id, err := uuid.Parse(req.GetAccountId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid account_id")
+	}
+
+	acc, err := h.accountUC.Close(ctx, id, req.GetReason())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPBAccount(acc), nil
+}
+
+func toPBAccount(a *entity.Account) *pb.Account {
+	return &pb.Account{
+		Id:              a.ID().String(),
+		Balance:         a.Balance(),
+		CreatedAt:       timestamppb.New(a.CreatedAt()),
+		Held:            a.Held(),
+		Available:       a.Available(),
+		Currency:        string(a.Currency()),
+		Kind:            toPBKind(a.Kind()),
+		Tier:            string(a.Tier()),
+		Status:          toPBAccountStatus(a.Status()),
+		StatusReason:    a.State().Reason,
+		StatusChangedAt: timestamppb.New(a.State().ChangedAt),
+	}
+}
+
+func toPBAccountStatus(s entity.AccountStatus) pb.AccountStatus {
+	switch s {
+	case entity.AccountActive:
+		return pb.AccountStatus_ACCOUNT_STATUS_ACTIVE
+	case entity.AccountFrozen:
+		return pb.AccountStatus_ACCOUNT_STATUS_FROZEN
+	case entity.AccountClosed:
+		return pb.AccountStatus_ACCOUNT_STATUS_CLOSED
+	default:
+		return pb.AccountStatus_ACCOUNT_STATUS_UNSPECIFIED
+	}
+}
+
+func toPBKind(k entity.AccountKind) pb.AccountKind {
+	switch k {
+	case entity.AccountCustomer:
+		return pb.AccountKind_ACCOUNT_KIND_CUSTOMER
+	case entity.AccountFXLiquidity:
+		return pb.AccountKind_ACCOUNT_KIND_FX_LIQUIDITY
+	case entity.AccountFXRevenue:
+		return pb.AccountKind_ACCOUNT_KIND_FX_REVENUE
+	case entity.AccountFeeRevenue:
+		return pb.AccountKind_ACCOUNT_KIND_FEE_REVENUE
+	default:
+		return pb.AccountKind_ACCOUNT_KIND_UNSPECIFIED
+	}
+}
@@ -0,0 +1,61 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/Xausdorf/qr-pay-hub/gen/pb"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/account"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/fee"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/fx"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/limit"
+)
+
+// AdminHandler serves operator RPCs. It is registered on the same server as
+// Handler, but the gateway never calls it.
+type AdminHandler struct {
+	pb.UnimplementedPaymentAdminServer
+
+	fxUC      *fx.UseCase
+	feeUC     *fee.UseCase
+	limitUC   *limit.UseCase
+	accountUC *account.UseCase
+}
+
+func NewAdminHandler(
+	fxUC *fx.UseCase,
+	feeUC *fee.UseCase,
+	limitUC *limit.UseCase,
+	accountUC *account.UseCase,
+) *AdminHandler {
+	return &AdminHandler{fxUC: fxUC, feeUC: feeUC, limitUC: limitUC, accountUC: accountUC}
+}
+
+func (h *AdminHandler) SetRates(ctx context.Context, req *pb.SetRatesRequest) (*pb.SetRatesResponse, error) {
+	rates := make([]*entity.Rate, 0, len(req.GetRates()))
+	for i, r := range req.GetRates() {
+		rate, err := parseRate(r)
+		if err != nil {
+			return nil, toStatus(fmt.Errorf("rates[%d]: %w", i, err))
+		}
+		rates = append(rates, rate)
+	}
+
+	if err := h.fxUC.SetRates(ctx, rates); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.SetRatesResponse{Updated: int32(len(rates))}, nil //nolint:gosec // G115: bounded by the message size limit
+}
+
+func parseRate(r *pb.Rate) (*entity.Rate, error) {
+	base, err := entity.ParseCurrency(r.GetBaseCurrency())
+	if err != nil {
+		return nil, err
+	}
+	quote, err := entity.ParseCurrency(r.GetQuoteCurrency())
+	if err != nil {
+		return nil, err
+	}
+	return entity.NewRate(base, quote, r.GetMidRate(), r.GetSpreadBps())
+}
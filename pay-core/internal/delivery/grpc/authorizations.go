@@ -0,0 +1,124 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/Xausdorf/qr-pay-hub/gen/pb"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer"
+)
+
+func (h *Handler) AuthorizePayment(ctx context.Context, req *pb.AuthorizeRequest) (*pb.Authorization, error) {
+	if req.GetIdempotencyKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "idempotency_key is required")
+	}
+
+	fromID, err := uuid.Parse(req.GetFromAccountId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid from_account_id")
+	}
+
+	toID, err := uuid.Parse(req.GetToAccountId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid to_account_id")
+	}
+
+	amount, err := parseMoney(req.GetAmount(), req.GetCurrency())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	auth, err := h.transferUC.Authorize(ctx, transfer.AuthorizeRequest{
+		IdempotencyKey: req.GetIdempotencyKey(),
+		FromAccountID:  fromID,
+		ToAccountID:    toID,
+		Amount:         amount,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPBAuthorization(auth), nil
+}
+
+func (h *Handler) CapturePayment(ctx context.Context, req *pb.CaptureRequest) (*pb.PaymentResponse, error) {
+	if req.GetIdempotencyKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "idempotency_key is required")
+	}
+
+	authID, err := uuid.Parse(req.GetAuthorizationId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid authorization_id")
+	}
+
+	resp, err := h.transferUC.Capture(ctx, transfer.CaptureRequest{
+		IdempotencyKey:  req.GetIdempotencyKey(),
+		AuthorizationID: authID,
+		Amount:          req.GetAmount(),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.PaymentResponse{
+		TransactionId: resp.TransactionID,
+		Status:        mapStatus(resp.Status),
+		ErrorMessage:  resp.ErrorMessage,
+		FailureReason: string(resp.FailureReason),
+		FeeAmount:     resp.Fee,
+		FeeBearer:     string(resp.FeeBearer),
+	}, nil
+}
+
+func (h *Handler) VoidAuthorization(
+	ctx context.Context,
+	req *pb.VoidAuthorizationRequest,
+) (*pb.Authorization, error) {
+	authID, err := uuid.Parse(req.GetAuthorizationId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid authorization_id")
+	}
+
+	auth, err := h.transferUC.Void(ctx, authID)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPBAuthorization(auth), nil
+}
+
+func toPBAuthorization(a *entity.Authorization) *pb.Authorization {
+	auth := &pb.Authorization{
+		Id:             a.ID().String(),
+		FromAccountId:  a.FromAccount().String(),
+		ToAccountId:    a.ToAccount().String(),
+		Amount:         a.Amount(),
+		Currency:       string(a.Currency()),
+		CapturedAmount: a.CapturedAmount(),
+		Status:         mapAuthorizationStatus(a.Status()),
+		ExpiresAt:      timestamppb.New(a.ExpiresAt()),
+		CreatedAt:      timestamppb.New(a.CreatedAt()),
+	}
+	if a.CaptureTransactionID() != uuid.Nil {
+		auth.CaptureTransactionId = a.CaptureTransactionID().String()
+	}
+	return auth
+}
+
+func mapAuthorizationStatus(s entity.AuthorizationStatus) pb.AuthorizationStatus {
+	switch s {
+	case entity.AuthorizationActive:
+		return pb.AuthorizationStatus_AUTHORIZATION_STATUS_ACTIVE
+	case entity.AuthorizationCaptured:
+		return pb.AuthorizationStatus_AUTHORIZATION_STATUS_CAPTURED
+	case entity.AuthorizationVoided:
+		return pb.AuthorizationStatus_AUTHORIZATION_STATUS_VOIDED
+	case entity.AuthorizationExpired:
+		return pb.AuthorizationStatus_AUTHORIZATION_STATUS_EXPIRED
+	default:
+		return pb.AuthorizationStatus_AUTHORIZATION_STATUS_UNSPECIFIED
+	}
+}
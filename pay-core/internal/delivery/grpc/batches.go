@@ -0,0 +1,157 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/Xausdorf/qr-pay-hub/gen/pb"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer"
+)
+
+func (h *Handler) ProcessBatch(ctx context.Context, req *pb.BatchRequest) (*pb.Batch, error) {
+	if req.GetIdempotencyKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "idempotency_key is required")
+	}
+
+	items := make([]transfer.BatchItem, 0, len(req.GetTransfers()))
+	for pos, t := range req.GetTransfers() {
+		fromID, err := uuid.Parse(t.GetFromAccountId())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "transfers[%d]: invalid from_account_id", pos)
+		}
+		toID, err := uuid.Parse(t.GetToAccountId())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "transfers[%d]: invalid to_account_id", pos)
+		}
+		amount, err := parseMoney(t.GetAmount(), t.GetCurrency())
+		if err != nil {
+			return nil, toStatus(fmt.Errorf("transfers[%d]: %w", pos, err))
+		}
+		items = append(items, transfer.BatchItem{
+			FromAccountID: fromID,
+			ToAccountID:   toID,
+			Amount:        amount,
+			Type:          fromPBTransferType(t.GetTransferType()),
+		})
+	}
+
+	batch, err := h.transferUC.ProcessBatch(ctx, transfer.BatchRequest{
+		IdempotencyKey: req.GetIdempotencyKey(),
+		Mode:           fromPBBatchMode(req.GetMode()),
+		Items:          items,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPBBatch(batch), nil
+}
+
+func (h *Handler) GetBatch(ctx context.Context, req *pb.GetBatchRequest) (*pb.Batch, error) {
+	id, err := uuid.Parse(req.GetBatchId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid batch_id")
+	}
+
+	batch, err := h.transferUC.GetBatch(ctx, id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPBBatch(batch), nil
+}
+
+func toPBBatch(b *entity.Batch) *pb.Batch {
+	resp := &pb.Batch{
+		Id:        b.ID().String(),
+		Mode:      toPBBatchMode(b.Mode()),
+		Status:    toPBBatchStatus(b.Status()),
+		Items:     make([]*pb.BatchItem, 0, len(b.Items())),
+		CreatedAt: timestamppb.New(b.CreatedAt()),
+	}
+	for _, item := range b.Items() {
+		pbItem := &pb.BatchItem{
+			Position: int32(item.Position()), //nolint:gosec // G115: bounded by entity.MaxBatchItems
+			Transfer: &pb.BatchTransfer{
+				FromAccountId: item.FromAccountID().String(),
+				ToAccountId:   item.ToAccountID().String(),
+				Amount:        item.Amount().Amount(),
+				Currency:      string(item.Amount().Currency()),
+				TransferType:  toPBTransferType(item.TransferType()),
+			},
+			Status:        toPBBatchItemStatus(item.Status()),
+			FailureReason: string(item.FailureReason()),
+			ErrorMessage:  item.ErrorMessage(),
+		}
+		if item.TransactionID() != uuid.Nil {
+			pbItem.TransactionId = item.TransactionID().String()
+		}
+		switch item.Status() { //nolint:exhaustive // pending and skipped items are not counted
+		case entity.BatchItemSuccess:
+			resp.SucceededCount++
+		case entity.BatchItemFailed:
+			resp.FailedCount++
+		}
+		resp.Items = append(resp.Items, pbItem)
+	}
+	if !b.CompletedAt().IsZero() {
+		resp.CompletedAt = timestamppb.New(b.CompletedAt())
+	}
+	return resp
+}
+
+func fromPBBatchMode(m pb.BatchMode) entity.BatchMode {
+	switch m {
+	case pb.BatchMode_BATCH_MODE_ATOMIC:
+		return entity.BatchAtomic
+	case pb.BatchMode_BATCH_MODE_BEST_EFFORT:
+		return entity.BatchBestEffort
+	default:
+		return ""
+	}
+}
+
+func toPBBatchMode(m entity.BatchMode) pb.BatchMode {
+	switch m {
+	case entity.BatchAtomic:
+		return pb.BatchMode_BATCH_MODE_ATOMIC
+	case entity.BatchBestEffort:
+		return pb.BatchMode_BATCH_MODE_BEST_EFFORT
+	default:
+		return pb.BatchMode_BATCH_MODE_UNSPECIFIED
+	}
+}
+
+func toPBBatchStatus(s entity.BatchStatus) pb.BatchStatus {
+	switch s {
+	case entity.BatchProcessing:
+		return pb.BatchStatus_BATCH_STATUS_PROCESSING
+	case entity.BatchCompleted:
+		return pb.BatchStatus_BATCH_STATUS_COMPLETED
+	case entity.BatchPartiallyCompleted:
+		return pb.BatchStatus_BATCH_STATUS_PARTIALLY_COMPLETED
+	case entity.BatchFailed:
+		return pb.BatchStatus_BATCH_STATUS_FAILED
+	default:
+		return pb.BatchStatus_BATCH_STATUS_UNSPECIFIED
+	}
+}
+
+func toPBBatchItemStatus(s entity.BatchItemStatus) pb.BatchItemStatus {
+	switch s {
+	case entity.BatchItemPending:
+		return pb.BatchItemStatus_BATCH_ITEM_STATUS_PENDING
+	case entity.BatchItemSuccess:
+		return pb.BatchItemStatus_BATCH_ITEM_STATUS_SUCCESS
+	case entity.BatchItemFailed:
+		return pb.BatchItemStatus_BATCH_ITEM_STATUS_FAILED
+	case entity.BatchItemSkipped:
+		return pb.BatchItemStatus_BATCH_ITEM_STATUS_SKIPPED
+	default:
+		return pb.BatchItemStatus_BATCH_ITEM_STATUS_UNSPECIFIED
+	}
+}
@@ -0,0 +1,197 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/history"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/pagetoken"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/stream"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer"
+)
+
+const errorDomain = "pay-core.qrpay.v1"
+
+// Reasons are stable machine-readable identifiers sent in errdetails.ErrorInfo;
+// clients must branch on them rather than on the status message.
+const (
+	reasonAccountNotFound      = "ACCOUNT_NOT_FOUND"
+	reasonTransactionNotFound  = "TRANSACTION_NOT_FOUND"
+	reasonAuthNotFound         = "AUTHORIZATION_NOT_FOUND"
+	reasonRateNotFound         = "RATE_NOT_FOUND"
+	reasonQuoteNotFound        = "QUOTE_NOT_FOUND"
+	reasonFeeScheduleNotFound  = "FEE_SCHEDULE_NOT_FOUND"
+	reasonWebhookNotFound      = "WEBHOOK_NOT_FOUND"
+	reasonDeliveryNotFound     = "WEBHOOK_DELIVERY_NOT_FOUND"
+	reasonBatchNotFound        = "BATCH_NOT_FOUND"
+	reasonSplitNotFound        = "SPLIT_PAYMENT_NOT_FOUND"
+	reasonRequisitesNotFound   = "REQUISITES_NOT_FOUND"
+	reasonIntentNotFound       = "INTENT_NOT_FOUND"
+	reasonEventNotFound        = "EVENT_NOT_FOUND"
+	reasonInsufficientFunds    = "INSUFFICIENT_FUNDS"
+	reasonInvalidAmount        = "INVALID_AMOUNT"
+	reasonSameAccount          = "SAME_ACCOUNT"
+	reasonUnknownCurrency      = "UNKNOWN_CURRENCY"
+	reasonCurrencyMismatch     = "CURRENCY_MISMATCH"
+	reasonInvalidRate          = "INVALID_RATE"
+	reasonQuoteExpired         = "QUOTE_EXPIRED"
+	reasonQuoteUsed            = "QUOTE_USED"
+	reasonQuoteMismatch        = "QUOTE_MISMATCH"
+	reasonInvalidTier          = "INVALID_TIER"
+	reasonInvalidFeeSchedule   = "INVALID_FEE_SCHEDULE"
+	reasonFeeExceedsAmount     = "FEE_EXCEEDS_AMOUNT"
+	reasonInvalidLimits        = "INVALID_LIMITS"
+	reasonInvalidWebhookURL    = "INVALID_WEBHOOK_URL"
+	reasonInvalidBatch         = "INVALID_BATCH"
+	reasonInvalidSplit         = "INVALID_SPLIT"
+	reasonInvalidRequisites    = "INVALID_REQUISITES"
+	reasonInvalidIntent        = "INVALID_INTENT"
+	reasonAccountFrozen        = "ACCOUNT_FROZEN"
+	reasonAccountClosed        = "ACCOUNT_CLOSED"
+	reasonAccountNotEmpty      = "ACCOUNT_NOT_EMPTY"
+	reasonLimitExceeded        = "LIMIT_EXCEEDED"
+	reasonNotRefundable        = "NOT_REFUNDABLE"
+	reasonRefundExceeds        = "REFUND_EXCEEDS_ORIGINAL"
+	reasonAuthNotActive        = "AUTHORIZATION_NOT_ACTIVE"
+	reasonAuthExpired          = "AUTHORIZATION_EXPIRED"
+	reasonCaptureExceeds       = "CAPTURE_EXCEEDS_AUTHORIZED"
+	reasonIntentNotOpen        = "INTENT_NOT_OPEN"
+	reasonIntentExpired        = "INTENT_EXPIRED"
+	reasonIdempotencyKeyReused = "IDEMPOTENCY_KEY_REUSED"
+	reasonConcurrentUpdate     = "CONCURRENT_UPDATE"
+	reasonInvalidPageToken     = "INVALID_PAGE_TOKEN"
+	reasonInvalidFilter        = "INVALID_FILTER"
+	reasonSubscriptionLagged   = "SUBSCRIPTION_LAGGED"
+	reasonShuttingDown         = "SHUTTING_DOWN"
+	reasonEventStreamResync    = "EVENT_STREAM_RESYNC"
+	reasonInternal             = "INTERNAL"
+)
+
+func toStatus(err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	code, reason := classify(err)
+	msg := err.Error()
+
+	st, detailErr := status.New(code, msg).WithDetails(&errdetails.ErrorInfo{
+		Reason: reason,
+		Domain: errorDomain,
+	})
+	if detailErr != nil {
+		return status.Error(code, msg)
+	}
+	return st.Err()
+}
+
+func classify(err error) (codes.Code, string) {
+	switch {
+	case errors.Is(err, repository.ErrAccountNotFound):
+		return codes.NotFound, reasonAccountNotFound
+	case errors.Is(err, repository.ErrTransactionNotFound):
+		return codes.NotFound, reasonTransactionNotFound
+	case errors.Is(err, repository.ErrAuthorizationNotFound):
+		return codes.NotFound, reasonAuthNotFound
+	case errors.Is(err, repository.ErrRateNotFound):
+		return codes.NotFound, reasonRateNotFound
+	case errors.Is(err, repository.ErrQuoteNotFound):
+		return codes.NotFound, reasonQuoteNotFound
+	case errors.Is(err, repository.ErrFeeScheduleNotFound):
+		return codes.NotFound, reasonFeeScheduleNotFound
+	case errors.Is(err, repository.ErrWebhookNotFound):
+		return codes.NotFound, reasonWebhookNotFound
+	case errors.Is(err, repository.ErrDeliveryNotFound):
+		return codes.NotFound, reasonDeliveryNotFound
+	case errors.Is(err, repository.ErrBatchNotFound):
+		return codes.NotFound, reasonBatchNotFound
+	case errors.Is(err, repository.ErrSplitNotFound):
+		return codes.NotFound, reasonSplitNotFound
+	case errors.Is(err, repository.ErrRequisitesNotFound):
+		return codes.NotFound, reasonRequisitesNotFound
+	case errors.Is(err, repository.ErrIntentNotFound):
+		return codes.NotFound, reasonIntentNotFound
+	case errors.Is(err, repository.ErrEventNotFound):
+		return codes.NotFound, reasonEventNotFound
+	case errors.Is(err, entity.ErrNegativeAmount):
+		return codes.InvalidArgument, reasonInvalidAmount
+	case errors.Is(err, transfer.ErrSameAccount):
+		return codes.InvalidArgument, reasonSameAccount
+	case errors.Is(err, entity.ErrUnknownCurrency):
+		return codes.InvalidArgument, reasonUnknownCurrency
+	case errors.Is(err, entity.ErrCurrencyMismatch):
+		return codes.FailedPrecondition, reasonCurrencyMismatch
+	case errors.Is(err, entity.ErrInvalidRate):
+		return codes.InvalidArgument, reasonInvalidRate
+	case errors.Is(err, entity.ErrQuoteExpired):
+		return codes.FailedPrecondition, reasonQuoteExpired
+	case errors.Is(err, entity.ErrQuoteUsed):
+		return codes.FailedPrecondition, reasonQuoteUsed
+	case errors.Is(err, entity.ErrQuoteMismatch):
+		return codes.InvalidArgument, reasonQuoteMismatch
+	case errors.Is(err, entity.ErrInvalidTier):
+		return codes.InvalidArgument, reasonInvalidTier
+	case errors.Is(err, entity.ErrInvalidFeeSchedule):
+		return codes.InvalidArgument, reasonInvalidFeeSchedule
+	case errors.Is(err, entity.ErrInvalidLimits):
+		return codes.InvalidArgument, reasonInvalidLimits
+	case errors.Is(err, entity.ErrInvalidWebhookURL):
+		return codes.InvalidArgument, reasonInvalidWebhookURL
+	case errors.Is(err, entity.ErrInvalidBatch):
+		return codes.InvalidArgument, reasonInvalidBatch
+	case errors.Is(err, entity.ErrInvalidSplit):
+		return codes.InvalidArgument, reasonInvalidSplit
+	case errors.Is(err, entity.ErrInvalidRequisites):
+		return codes.InvalidArgument, reasonInvalidRequisites
+	case errors.Is(err, entity.ErrInvalidIntent):
+		return codes.InvalidArgument, reasonInvalidIntent
+	case errors.Is(err, entity.ErrFeeExceedsAmount):
+		return codes.FailedPrecondition, reasonFeeExceedsAmount
+	case errors.Is(err, entity.ErrInsufficientFunds):
+		return codes.FailedPrecondition, reasonInsufficientFunds
+	case errors.Is(err, entity.ErrAccountFrozen):
+		return codes.FailedPrecondition, reasonAccountFrozen
+	case errors.Is(err, entity.ErrAccountClosed):
+		return codes.FailedPrecondition, reasonAccountClosed
+	case errors.Is(err, entity.ErrAccountNotEmpty):
+		return codes.FailedPrecondition, reasonAccountNotEmpty
+	case errors.Is(err, entity.ErrLimitExceeded):
+		return codes.FailedPrecondition, reasonLimitExceeded
+	case errors.Is(err, entity.ErrNotRefundable):
+		return codes.FailedPrecondition, reasonNotRefundable
+	case errors.Is(err, entity.ErrRefundExceedsOriginal):
+		return codes.FailedPrecondition, reasonRefundExceeds
+	case errors.Is(err, entity.ErrAuthorizationNotActive):
+		return codes.FailedPrecondition, reasonAuthNotActive
+	case errors.Is(err, entity.ErrAuthorizationExpired):
+		return codes.FailedPrecondition, reasonAuthExpired
+	case errors.Is(err, entity.ErrCaptureExceedsAuthorized):
+		return codes.FailedPrecondition, reasonCaptureExceeds
+	case errors.Is(err, entity.ErrIntentNotOpen):
+		return codes.FailedPrecondition, reasonIntentNotOpen
+	case errors.Is(err, entity.ErrIntentExpired):
+		return codes.FailedPrecondition, reasonIntentExpired
+	case errors.Is(err, entity.ErrIdempotencyKeyReused):
+		return codes.AlreadyExists, reasonIdempotencyKeyReused
+	case errors.Is(err, pagetoken.ErrInvalid):
+		return codes.InvalidArgument, reasonInvalidPageToken
+	case errors.Is(err, history.ErrInvalidFilter):
+		return codes.InvalidArgument, reasonInvalidFilter
+	case errors.Is(err, stream.ErrLagged):
+		return codes.ResourceExhausted, reasonSubscriptionLagged
+	case errors.Is(err, stream.ErrClosed):
+		return codes.Unavailable, reasonShuttingDown
+	case errors.Is(err, stream.ErrResync):
+		return codes.Unavailable, reasonEventStreamResync
+	case errors.Is(err, repository.ErrConflict):
+		return codes.Aborted, reasonConcurrentUpdate
+	default:
+		return codes.Internal, reasonInternal
+	}
+}
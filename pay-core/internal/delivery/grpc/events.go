@@ -0,0 +1,140 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/Xausdorf/qr-pay-hub/gen/pb"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/stream"
+)
+
+// replayPageSize is how many missed events SubscribeAccountEvents reads from
+// the outbox at a time.
+const replayPageSize = 100
+
+// SubscribeAccountEvents subscribes before it replays the events after
+// after_sequence, so that none can commit unseen in between, and then skips
+// the live updates committed before the last one it replayed. It sends headers as soon as the
+// subscription is live, so that clients can tell an unknown account or event
+// from a quiet account without waiting for the first event.
+func (h *Handler) SubscribeAccountEvents(
+	req *pb.SubscribeAccountEventsRequest,
+	srv grpc.ServerStreamingServer[pb.AccountEvent],
+) error {
+	ctx := srv.Context()
+	id, err := uuid.Parse(req.GetAccountId())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid account_id")
+	}
+	afterSeq := req.GetAfterSequence()
+	if afterSeq < 0 {
+		return status.Error(codes.InvalidArgument, "invalid after_sequence")
+	}
+	if _, err = h.accountUC.Get(ctx, id); err != nil {
+		return toStatus(err)
+	}
+
+	sub := h.broker.Subscribe(id)
+	defer sub.Close()
+	var missed []*entity.Event
+	if afterSeq > 0 {
+		if missed, err = h.historyUC.EventsAfter(ctx, id, afterSeq, replayPageSize); err != nil {
+			return toStatus(err)
+		}
+	}
+	if err = srv.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+	lastSeq, err := h.replay(ctx, srv, id, afterSeq, missed)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return toStatus(ctx.Err())
+		case u, ok := <-sub.Updates():
+			if !ok {
+				return toStatus(sub.Err())
+			}
+			if u.Event.Sequence() <= lastSeq {
+				continue
+			}
+			if err = srv.Send(toPBAccountEvent(u)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// replay sends the missed events of the account, starting with the first
+// page of them, and returns the sequence of the last one it sent, or afterSeq
+// if there were none.
+func (h *Handler) replay(
+	ctx context.Context,
+	srv grpc.ServerStreamingServer[pb.AccountEvent],
+	accountID uuid.UUID,
+	afterSeq int64,
+	page []*entity.Event,
+) (int64, error) {
+	for len(page) > 0 {
+		for _, e := range page {
+			u, err := stream.UpdateFor(e, accountID)
+			if err != nil {
+				return 0, toStatus(err)
+			}
+			if err = srv.Send(toPBAccountEvent(u)); err != nil {
+				return 0, err
+			}
+			afterSeq = e.Sequence()
+		}
+		if len(page) < replayPageSize {
+			break
+		}
+		var err error
+		if page, err = h.historyUC.EventsAfter(ctx, accountID, afterSeq, replayPageSize); err != nil {
+			return 0, toStatus(err)
+		}
+	}
+	return afterSeq, nil
+}
+
+func toPBAccountEvent(u stream.Update) *pb.AccountEvent {
+	direction := pb.TransactionDirection_TRANSACTION_DIRECTION_INCOMING
+	if u.Direction == repository.DirectionOutgoing {
+		direction = pb.TransactionDirection_TRANSACTION_DIRECTION_OUTGOING
+	}
+
+	p := u.Payment
+	return &pb.AccountEvent{
+		EventId:   u.Event.ID().String(),
+		Sequence:  u.Event.Sequence(),
+		Type:      string(u.Event.Type()),
+		Direction: direction,
+		Transaction: &pb.Transaction{
+			Id:                    p.TransactionID,
+			FromAccountId:         p.FromAccountID,
+			ToAccountId:           p.ToAccountID,
+			Amount:                p.Amount,
+			Currency:              p.Currency,
+			Status:                mapStatus(entity.TransactionStatus(p.Status)),
+			FailureReason:         p.FailureReason,
+			CreatedAt:             timestamppb.New(p.CreatedAt),
+			OriginalTransactionId: p.OriginalTransactionID,
+			QuoteId:               p.QuoteID,
+			SplitId:               p.SplitID,
+			CreditedAmount:        p.CreditedAmount,
+			CreditedCurrency:      p.CreditedCurrency,
+			FeeAmount:             p.Fee,
+		},
+	}
+}
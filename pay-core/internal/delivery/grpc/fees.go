@@ -0,0 +1,103 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/Xausdorf/qr-pay-hub/gen/pb"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+)
+
+func (h *AdminHandler) SetFeeSchedules(
+	ctx context.Context,
+	req *pb.SetFeeSchedulesRequest,
+) (*pb.SetFeeSchedulesResponse, error) {
+	schedules := make([]*entity.FeeSchedule, 0, len(req.GetSchedules()))
+	for i, s := range req.GetSchedules() {
+		schedule, err := parseFeeSchedule(s)
+		if err != nil {
+			return nil, toStatus(fmt.Errorf("schedules[%d]: %w", i, err))
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	if err := h.feeUC.SetSchedules(ctx, schedules); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.SetFeeSchedulesResponse{
+		Updated: int32(len(schedules)), //nolint:gosec // G115: bounded by the message size limit
+	}, nil
+}
+
+func (h *AdminHandler) ListFeeSchedules(
+	ctx context.Context,
+	_ *pb.ListFeeSchedulesRequest,
+) (*pb.ListFeeSchedulesResponse, error) {
+	schedules, err := h.feeUC.Schedules(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.ListFeeSchedulesResponse{Schedules: make([]*pb.FeeSchedule, 0, len(schedules))}
+	for _, s := range schedules {
+		resp.Schedules = append(resp.Schedules, toPBFeeSchedule(s))
+	}
+	return resp, nil
+}
+
+// parseFeeSchedule reads a schedule; an empty tier keys the catch-all
+// schedule rather than the default tier.
+func parseFeeSchedule(s *pb.FeeSchedule) (*entity.FeeSchedule, error) {
+	currency, err := parseCurrency(s.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+	tier := entity.AnyTier
+	if s.GetTier() != "" {
+		if tier, err = entity.ParseTier(s.GetTier()); err != nil {
+			return nil, err
+		}
+	}
+	bearer := entity.FeeBearer(s.GetBearer())
+	if bearer == "" {
+		bearer = entity.FeePayer
+	}
+	return entity.NewFeeSchedule(
+		fromPBTransferType(s.GetTransferType()), tier, currency,
+		s.GetFixedAmount(), s.GetRateBps(), s.GetMinAmount(), s.GetMaxAmount(), bearer,
+	)
+}
+
+func toPBFeeSchedule(s *entity.FeeSchedule) *pb.FeeSchedule {
+	return &pb.FeeSchedule{
+		TransferType: toPBTransferType(s.TransferType()),
+		Tier:         string(s.Tier()),
+		Currency:     string(s.Currency()),
+		FixedAmount:  s.Fixed(),
+		RateBps:      s.RateBps(),
+		MinAmount:    s.Min(),
+		MaxAmount:    s.Max(),
+		Bearer:       string(s.Bearer()),
+		UpdatedAt:    timestamppb.New(s.UpdatedAt()),
+	}
+}
+
+func fromPBTransferType(t pb.TransferType) entity.TransferType {
+	if t == pb.TransferType_TRANSFER_TYPE_QR_MERCHANT {
+		return entity.TransferQRMerchant
+	}
+	return entity.TransferP2P
+}
+
+func toPBTransferType(t entity.TransferType) pb.TransferType {
+	switch t {
+	case entity.TransferP2P:
+		return pb.TransferType_TRANSFER_TYPE_P2P
+	case entity.TransferQRMerchant:
+		return pb.TransferType_TRANSFER_TYPE_QR_MERCHANT
+	default:
+		return pb.TransferType_TRANSFER_TYPE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/Xausdorf/qr-pay-hub/gen/pb"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+)
+
+func (h *Handler) GetQuote(ctx context.Context, req *pb.GetQuoteRequest) (*pb.Quote, error) {
+	amount, err := parseMoney(req.GetAmount(), req.GetFromCurrency())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	to, err := entity.ParseCurrency(req.GetToCurrency())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	quote, err := h.fxUC.Quote(ctx, amount, to)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPBQuote(quote), nil
+}
+
+func toPBQuote(q *entity.Quote) *pb.Quote {
+	validFor := time.Until(q.ExpiresAt()).Seconds()
+	return &pb.Quote{
+		QuoteId:         q.ID().String(),
+		SourceAmount:    q.Source().Amount(),
+		SourceCurrency:  string(q.Source().Currency()),
+		TargetAmount:    q.Target().Amount(),
+		TargetCurrency:  string(q.Target().Currency()),
+		Rate:            q.Rate(),
+		ExpiresAt:       timestamppb.New(q.ExpiresAt()),
+		ValidForSeconds: int32(max(0, math.Floor(validFor))),
+	}
+}
@@ -9,26 +9,47 @@ import (
 
 	pb "github.com/Xausdorf/qr-pay-hub/gen/pb"
 	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/account"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/fx"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/history"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/stream"
 	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/webhook"
 )
 
 type Handler struct {
 	pb.UnimplementedPaymentProcessorServer
 
 	transferUC *transfer.UseCase
+	accountUC  *account.UseCase
+	historyUC  *history.UseCase
+	fxUC       *fx.UseCase
+	webhookUC  *webhook.UseCase
+	broker     *stream.Broker
 }
 
-func NewHandler(transferUC *transfer.UseCase) *Handler {
-	return &Handler{transferUC: transferUC}
+func NewHandler(
+	transferUC *transfer.UseCase,
+	accountUC *account.UseCase,
+	historyUC *history.UseCase,
+	fxUC *fx.UseCase,
+	webhookUC *webhook.UseCase,
+	broker *stream.Broker,
+) *Handler {
+	return &Handler{
+		transferUC: transferUC,
+		accountUC:  accountUC,
+		historyUC:  historyUC,
+		fxUC:       fxUC,
+		webhookUC:  webhookUC,
+		broker:     broker,
+	}
 }
 
 func (h *Handler) ProcessPayment(ctx context.Context, req *pb.PaymentRequest) (*pb.PaymentResponse, error) {
 	if req.GetIdempotencyKey() == "" {
 		return nil, status.Error(codes.InvalidArgument, "idempotency_key is required")
 	}
-	if req.GetAmount() <= 0 {
-		return nil, status.Error(codes.InvalidArgument, "amount must be positive")
-	}
 
 	fromID, err := uuid.Parse(req.GetFromAccountId())
 	if err != nil {
@@ -40,27 +61,86 @@ func (h *Handler) ProcessPayment(ctx context.Context, req *pb.PaymentRequest) (*
 		return nil, status.Error(codes.InvalidArgument, "invalid to_account_id")
 	}
 
-	if fromID == toID {
-		return nil, status.Error(codes.InvalidArgument, "from and to accounts must differ")
+	amount, err := parseMoney(req.GetAmount(), req.GetCurrency())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	var quoteID uuid.UUID
+	if req.GetQuoteId() != "" {
+		quoteID, err = uuid.Parse(req.GetQuoteId())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid quote_id")
+		}
 	}
 
 	resp, err := h.transferUC.Execute(ctx, transfer.Request{
 		IdempotencyKey: req.GetIdempotencyKey(),
 		FromAccountID:  fromID,
 		ToAccountID:    toID,
+		Amount:         amount,
+		QuoteID:        quoteID,
+		Type:           fromPBTransferType(req.GetTransferType()),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.PaymentResponse{
+		TransactionId: resp.TransactionID,
+		Status:        mapStatus(resp.Status),
+		ErrorMessage:  resp.ErrorMessage,
+		FailureReason: string(resp.FailureReason),
+		FeeAmount:     resp.Fee,
+		FeeBearer:     string(resp.FeeBearer),
+		ExceededLimit: string(resp.ExceededLimit),
+	}, nil
+}
+
+func (h *Handler) RefundPayment(ctx context.Context, req *pb.RefundRequest) (*pb.PaymentResponse, error) {
+	if req.GetIdempotencyKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "idempotency_key is required")
+	}
+
+	txID, err := uuid.Parse(req.GetTransactionId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid transaction_id")
+	}
+
+	resp, err := h.transferUC.Refund(ctx, transfer.RefundRequest{
+		IdempotencyKey: req.GetIdempotencyKey(),
+		TransactionID:  txID,
 		Amount:         req.GetAmount(),
 	})
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "transfer failed: %v", err)
+		return nil, toStatus(err)
 	}
 
 	return &pb.PaymentResponse{
 		TransactionId: resp.TransactionID,
 		Status:        mapStatus(resp.Status),
 		ErrorMessage:  resp.ErrorMessage,
+		FailureReason: string(resp.FailureReason),
 	}, nil
 }
 
+// parseCurrency reads an optional ISO 4217 code. Clients that predate
+// multi-currency accounts leave it empty and mean entity.DefaultCurrency.
+func parseCurrency(code string) (entity.Currency, error) {
+	if code == "" {
+		return entity.DefaultCurrency, nil
+	}
+	return entity.ParseCurrency(code)
+}
+
+func parseMoney(amount int64, currency string) (entity.Money, error) {
+	c, err := parseCurrency(currency)
+	if err != nil {
+		return entity.Money{}, err
+	}
+	return entity.NewMoney(amount, c)
+}
+
 func mapStatus(s entity.TransactionStatus) pb.TransactionStatus {
 	switch s {
 	case entity.StatusPending:
@@ -0,0 +1,213 @@
+package grpc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Xausdorf/qr-pay-hub/gen/pb"
+	grpchandler "github.com/Xausdorf/qr-pay-hub/internal/delivery/grpc"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/account"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/fx"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/history"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/stream"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer/mocks"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/webhook"
+)
+
+func errorReason(t *testing.T, err error) string {
+	t.Helper()
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	for _, d := range st.Details() {
+		if info, isInfo := d.(*errdetails.ErrorInfo); isInfo {
+			return info.GetReason()
+		}
+	}
+	return ""
+}
+
+func TestHandler_ProcessPayment_UnknownAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+
+	handler := grpchandler.NewHandler(
+		transfer.NewUseCase(uow), account.NewUseCase(uow), history.NewUseCase(uow), fx.NewUseCase(uow),
+		webhook.NewUseCase(uow), stream.NewBroker(1),
+	)
+
+	uow.EXPECT().Idempotency().Return(idempotencyRepo)
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	txUow.EXPECT().Idempotency().Return(idempotencyRepo).Times(2)
+	txUow.EXPECT().Accounts().Return(accountRepo).AnyTimes()
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "unknown-key").Return(nil, repository.ErrNotFound).Times(2)
+	idempotencyRepo.EXPECT().Lock(gomock.Any(), "unknown-key").Return(nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), gomock.Any()).Return(nil, repository.ErrAccountNotFound)
+
+	_, err := handler.ProcessPayment(context.Background(), &pb.PaymentRequest{
+		IdempotencyKey: "unknown-key",
+		FromAccountId:  uuid.NewString(),
+		ToAccountId:    uuid.NewString(),
+		Amount:         100,
+	})
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "ACCOUNT_NOT_FOUND", errorReason(t, err))
+}
+
+func TestHandler_ProcessPayment_InvalidRequest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := grpchandler.NewHandler(transfer.NewUseCase(mocks.NewMockUnitOfWork(ctrl)), nil, nil, nil, nil, nil)
+	accountID := uuid.NewString()
+
+	tests := []struct {
+		name   string
+		req    *pb.PaymentRequest
+		reason string
+	}{
+		{
+			name: "non-positive amount",
+			req: &pb.PaymentRequest{
+				IdempotencyKey: "k", FromAccountId: accountID, ToAccountId: uuid.NewString(), Amount: 0,
+			},
+			reason: "INVALID_AMOUNT",
+		},
+		{
+			name: "same account",
+			req: &pb.PaymentRequest{
+				IdempotencyKey: "k", FromAccountId: accountID, ToAccountId: accountID, Amount: 10,
+			},
+			reason: "SAME_ACCOUNT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := handler.ProcessPayment(context.Background(), tt.req)
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Equal(t, tt.reason, errorReason(t, err))
+		})
+	}
+}
+
+// eventStream is the server side of a SubscribeAccountEvents call that hands
+// what the handler sends to the test.
+type eventStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *pb.AccountEvent
+}
+
+func (s *eventStream) Context() context.Context     { return s.ctx }
+func (s *eventStream) SendHeader(metadata.MD) error { return nil }
+func (s *eventStream) Send(e *pb.AccountEvent) error {
+	s.sent <- e
+	return nil
+}
+
+// paymentEvent returns a committed payment event with the given sequence.
+func paymentEvent(t *testing.T, seq int64, from, to uuid.UUID) *entity.Event {
+	t.Helper()
+	txn := entity.NewTransaction(from, to, entity.ReconstructMoney(1000, "RUB"), entity.StatusSuccess)
+	e, err := entity.NewPaymentEvent(txn)
+	require.NoError(t, err)
+	return entity.ReconstructEvent(e.ID(), seq, e.Type(), e.AggregateID(), e.Payload(), e.CreatedAt())
+}
+
+func TestHandler_SubscribeAccountEvents_ReplaysMissedEventsOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+	broker := stream.NewBroker(2)
+	handler := grpchandler.NewHandler(nil, account.NewUseCase(uow), history.NewUseCase(uow), nil, nil, broker)
+
+	accountID, peer := uuid.New(), uuid.New()
+	missed := []*entity.Event{paymentEvent(t, 11, accountID, peer), paymentEvent(t, 13, peer, accountID)}
+	live := paymentEvent(t, 14, peer, accountID)
+
+	uow.EXPECT().Accounts().Return(accountRepo)
+	accountRepo.EXPECT().FindByID(gomock.Any(), accountID).
+		Return(entity.NewAccount(accountID, entity.ReconstructMoney(0, "RUB")), nil)
+	uow.EXPECT().Outbox().Return(outboxRepo).Times(2)
+	outboxRepo.EXPECT().FindBySequence(gomock.Any(), int64(10)).Return(paymentEvent(t, 10, peer, accountID), nil)
+	outboxRepo.EXPECT().ListByAccount(gomock.Any(), accountID, int64(10), 100).Return(missed, nil)
+
+	srv := &eventStream{ctx: context.Background(), sent: make(chan *pb.AccountEvent, 1)}
+	done := make(chan error, 1)
+	go func() {
+		done <- handler.SubscribeAccountEvents(&pb.SubscribeAccountEventsRequest{
+			AccountId:     accountID.String(),
+			AfterSequence: 10,
+		}, srv)
+	}()
+
+	first := <-srv.sent
+	assert.Equal(t, missed[0].ID().String(), first.GetEventId())
+	assert.Equal(t, int64(11), first.GetSequence())
+	assert.Equal(t, pb.TransactionDirection_TRANSACTION_DIRECTION_OUTGOING, first.GetDirection())
+	second := <-srv.sent
+	assert.Equal(t, missed[1].ID().String(), second.GetEventId())
+	assert.Equal(t, pb.TransactionDirection_TRANSACTION_DIRECTION_INCOMING, second.GetDirection())
+
+	// The replayed event also reaches the live subscription, which skips it.
+	require.NoError(t, broker.Publish(context.Background(), missed[1]))
+	require.NoError(t, broker.Publish(context.Background(), live))
+	next := <-srv.sent
+	assert.Equal(t, live.ID().String(), next.GetEventId())
+	assert.Equal(t, int64(14), next.GetSequence())
+
+	broker.Resync()
+	err := <-done
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, "EVENT_STREAM_RESYNC", errorReason(t, err))
+}
+
+func TestHandler_SubscribeAccountEvents_UnknownLastEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+	handler := grpchandler.NewHandler(nil, account.NewUseCase(uow), history.NewUseCase(uow), nil, nil, stream.NewBroker(1))
+
+	accountID := uuid.New()
+	uow.EXPECT().Accounts().Return(accountRepo)
+	accountRepo.EXPECT().FindByID(gomock.Any(), accountID).
+		Return(entity.NewAccount(accountID, entity.ReconstructMoney(0, "RUB")), nil)
+	uow.EXPECT().Outbox().Return(outboxRepo)
+	outboxRepo.EXPECT().FindBySequence(gomock.Any(), int64(42)).Return(nil, repository.ErrEventNotFound)
+
+	srv := &eventStream{ctx: context.Background(), sent: make(chan *pb.AccountEvent)}
+	err := handler.SubscribeAccountEvents(&pb.SubscribeAccountEventsRequest{
+		AccountId:     accountID.String(),
+		AfterSequence: 42,
+	}, srv)
+
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "EVENT_NOT_FOUND", errorReason(t, err))
+}
@@ -0,0 +1,141 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/Xausdorf/qr-pay-hub/gen/pb"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer"
+)
+
+func (h *Handler) CreatePaymentIntent(
+	ctx context.Context,
+	req *pb.CreatePaymentIntentRequest,
+) (*pb.PaymentIntent, error) {
+	toID, err := uuid.Parse(req.GetToAccountId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid to_account_id")
+	}
+
+	amount, err := parseMoney(req.GetAmount(), req.GetCurrency())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	if req.GetTtlSeconds() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "ttl_seconds must not be negative")
+	}
+
+	intent, err := h.transferUC.CreateIntent(ctx, transfer.CreateIntentRequest{
+		ToAccountID: toID,
+		Amount:      amount,
+		TTL:         time.Duration(req.GetTtlSeconds()) * time.Second,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPBPaymentIntent(intent), nil
+}
+
+func (h *Handler) GetPaymentIntent(ctx context.Context, req *pb.GetPaymentIntentRequest) (*pb.PaymentIntent, error) {
+	id, err := uuid.Parse(req.GetIntentId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid intent_id")
+	}
+
+	intent, err := h.transferUC.GetIntent(ctx, id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPBPaymentIntent(intent), nil
+}
+
+func (h *Handler) CancelPaymentIntent(
+	ctx context.Context,
+	req *pb.CancelPaymentIntentRequest,
+) (*pb.PaymentIntent, error) {
+	id, err := uuid.Parse(req.GetIntentId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid intent_id")
+	}
+
+	intent, err := h.transferUC.CancelIntent(ctx, id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPBPaymentIntent(intent), nil
+}
+
+func (h *Handler) PayPaymentIntent(ctx context.Context, req *pb.PayPaymentIntentRequest) (*pb.PaymentResponse, error) {
+	if req.GetIdempotencyKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "idempotency_key is required")
+	}
+
+	intentID, err := uuid.Parse(req.GetIntentId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid intent_id")
+	}
+
+	fromID, err := uuid.Parse(req.GetFromAccountId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid from_account_id")
+	}
+
+	resp, err := h.transferUC.PayIntent(ctx, transfer.PayIntentRequest{
+		IdempotencyKey: req.GetIdempotencyKey(),
+		FromAccountID:  fromID,
+		IntentID:       intentID,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.PaymentResponse{
+		TransactionId: resp.TransactionID,
+		Status:        mapStatus(resp.Status),
+		ErrorMessage:  resp.ErrorMessage,
+		FailureReason: string(resp.FailureReason),
+		FeeAmount:     resp.Fee,
+		FeeBearer:     string(resp.FeeBearer),
+		ExceededLimit: string(resp.ExceededLimit),
+	}, nil
+}
+
+func toPBPaymentIntent(i *entity.PaymentIntent) *pb.PaymentIntent {
+	intent := &pb.PaymentIntent{
+		Id:          i.ID().String(),
+		ToAccountId: i.ToAccount().String(),
+		Amount:      i.Amount(),
+		Currency:    string(i.Currency()),
+		Status:      mapIntentStatus(i.Status()),
+		ExpiresAt:   timestamppb.New(i.ExpiresAt()),
+		CreatedAt:   timestamppb.New(i.CreatedAt()),
+	}
+	if i.TransactionID() != uuid.Nil {
+		intent.FromAccountId = i.FromAccount().String()
+		intent.TransactionId = i.TransactionID().String()
+		intent.PaidAt = timestamppb.New(i.PaidAt())
+	}
+	return intent
+}
+
+func mapIntentStatus(s entity.IntentStatus) pb.PaymentIntentStatus {
+	switch s {
+	case entity.IntentCreated:
+		return pb.PaymentIntentStatus_PAYMENT_INTENT_STATUS_CREATED
+	case entity.IntentPaid:
+		return pb.PaymentIntentStatus_PAYMENT_INTENT_STATUS_PAID
+	case entity.IntentExpired:
+		return pb.PaymentIntentStatus_PAYMENT_INTENT_STATUS_EXPIRED
+	case entity.IntentCancelled:
+		return pb.PaymentIntentStatus_PAYMENT_INTENT_STATUS_CANCELLED
+	default:
+		return pb.PaymentIntentStatus_PAYMENT_INTENT_STATUS_UNSPECIFIED
+	}
+}
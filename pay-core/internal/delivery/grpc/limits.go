@@ -0,0 +1,86 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/Xausdorf/qr-pay-hub/gen/pb"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+)
+
+func (h *AdminHandler) SetTransferLimits(ctx context.Context, req *pb.TransferLimits) (*pb.TransferLimits, error) {
+	var accountID uuid.UUID
+	if req.GetAccountId() != "" {
+		id, err := uuid.Parse(req.GetAccountId())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid account_id")
+		}
+		accountID = id
+	}
+
+	limits, err := parseTransferLimits(accountID, req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	if setErr := h.limitUC.Set(ctx, limits); setErr != nil {
+		return nil, toStatus(setErr)
+	}
+	return toPBTransferLimits(limits), nil
+}
+
+func (h *AdminHandler) ListTransferLimits(
+	ctx context.Context,
+	_ *pb.ListTransferLimitsRequest,
+) (*pb.ListTransferLimitsResponse, error) {
+	limits, err := h.limitUC.List(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.ListTransferLimitsResponse{Limits: make([]*pb.TransferLimits, 0, len(limits))}
+	for _, l := range limits {
+		resp.Limits = append(resp.Limits, toPBTransferLimits(l))
+	}
+	return resp, nil
+}
+
+// parseTransferLimits reads limits of accountID or, if it is uuid.Nil, of the
+// request's tier; an empty tier is left empty rather than read as the default
+// one, so that limits name exactly one scope.
+func parseTransferLimits(accountID uuid.UUID, l *pb.TransferLimits) (*entity.TransferLimits, error) {
+	currency, err := parseCurrency(l.GetCurrency())
+	if err != nil {
+		return nil, err
+	}
+	var tier entity.Tier
+	if l.GetTier() != "" {
+		if tier, err = entity.ParseTier(l.GetTier()); err != nil {
+			return nil, err
+		}
+	}
+	return entity.NewTransferLimits(
+		accountID, tier, currency,
+		l.GetMaxSingleAmount(), l.GetDailyAmount(), l.GetMonthlyAmount(), l.GetHourlyCount(),
+	)
+}
+
+func toPBTransferLimits(l *entity.TransferLimits) *pb.TransferLimits {
+	resp := &pb.TransferLimits{
+		Tier:            string(l.Tier()),
+		Currency:        string(l.Currency()),
+		MaxSingleAmount: l.MaxSingle(),
+		DailyAmount:     l.Daily(),
+		MonthlyAmount:   l.Monthly(),
+		HourlyCount:     l.HourlyCount(),
+		UpdatedAt:       timestamppb.New(l.UpdatedAt()),
+	}
+	if l.AccountID() != uuid.Nil {
+		resp.AccountId = l.AccountID().String()
+	}
+	return resp
+}
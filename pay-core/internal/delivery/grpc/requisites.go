@@ -0,0 +1,66 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/Xausdorf/qr-pay-hub/gen/pb"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+)
+
+func (h *Handler) SetAccountRequisites(
+	ctx context.Context,
+	req *pb.AccountRequisites,
+) (*pb.AccountRequisites, error) {
+	id, err := uuid.Parse(req.GetAccountId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid account_id")
+	}
+
+	requisites, err := entity.NewAccountRequisites(
+		id, req.GetName(), req.GetPersonalAcc(), req.GetBankName(), req.GetBic(),
+		req.GetCorrespAcc(), req.GetPayeeInn(), req.GetKpp(),
+	)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	if setErr := h.accountUC.SetRequisites(ctx, requisites); setErr != nil {
+		return nil, toStatus(setErr)
+	}
+	return toPBAccountRequisites(requisites), nil
+}
+
+func (h *Handler) GetAccountRequisites(
+	ctx context.Context,
+	req *pb.GetAccountRequisitesRequest,
+) (*pb.AccountRequisites, error) {
+	id, err := uuid.Parse(req.GetAccountId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid account_id")
+	}
+
+	requisites, err := h.accountUC.GetRequisites(ctx, id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPBAccountRequisites(requisites), nil
+}
+
+func toPBAccountRequisites(r *entity.AccountRequisites) *pb.AccountRequisites {
+	return &pb.AccountRequisites{
+		AccountId:   r.AccountID().String(),
+		Name:        r.Name(),
+		PersonalAcc: r.PersonalAcc(),
+		BankName:    r.BankName(),
+		Bic:         r.BIC(),
+		CorrespAcc:  r.CorrespAcc(),
+		PayeeInn:    r.PayeeINN(),
+		Kpp:         r.KPP(),
+		UpdatedAt:   timestamppb.New(r.UpdatedAt()),
+	}
+}
@@ -0,0 +1,94 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/Xausdorf/qr-pay-hub/gen/pb"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer"
+)
+
+func (h *Handler) ProcessSplitPayment(ctx context.Context, req *pb.SplitPaymentRequest) (*pb.SplitPayment, error) {
+	if req.GetIdempotencyKey() == "" {
+		return nil, status.Error(codes.InvalidArgument, "idempotency_key is required")
+	}
+
+	fromID, err := uuid.Parse(req.GetFromAccountId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid from_account_id")
+	}
+
+	amount, err := parseMoney(req.GetAmount(), req.GetCurrency())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	legs := make([]transfer.SplitLeg, 0, len(req.GetLegs()))
+	for pos, leg := range req.GetLegs() {
+		toID, parseErr := uuid.Parse(leg.GetToAccountId())
+		if parseErr != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "legs[%d]: invalid to_account_id", pos)
+		}
+		legAmount, parseErr := parseMoney(leg.GetAmount(), req.GetCurrency())
+		if parseErr != nil {
+			return nil, toStatus(fmt.Errorf("legs[%d]: %w", pos, parseErr))
+		}
+		legs = append(legs, transfer.SplitLeg{ToAccountID: toID, Amount: legAmount})
+	}
+
+	split, err := h.transferUC.ProcessSplit(ctx, transfer.SplitRequest{
+		IdempotencyKey: req.GetIdempotencyKey(),
+		FromAccountID:  fromID,
+		Amount:         amount,
+		Legs:           legs,
+		Type:           fromPBTransferType(req.GetTransferType()),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPBSplitPayment(split), nil
+}
+
+func (h *Handler) GetSplitPayment(ctx context.Context, req *pb.GetSplitPaymentRequest) (*pb.SplitPayment, error) {
+	id, err := uuid.Parse(req.GetSplitPaymentId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid split_payment_id")
+	}
+
+	split, err := h.transferUC.GetSplit(ctx, id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPBSplitPayment(split), nil
+}
+
+func toPBSplitPayment(s *entity.SplitPayment) *pb.SplitPayment {
+	resp := &pb.SplitPayment{
+		Id:            s.ID().String(),
+		FromAccountId: s.FromAccountID().String(),
+		Amount:        s.Amount().Amount(),
+		Currency:      string(s.Amount().Currency()),
+		Legs:          make([]*pb.SplitLeg, 0, len(s.Legs())),
+		Status:        mapStatus(s.Status()),
+		FailureReason: string(s.FailureReason()),
+		ErrorMessage:  s.ErrorMessage(),
+		CreatedAt:     timestamppb.New(s.CreatedAt()),
+	}
+	for _, leg := range s.Legs() {
+		pbLeg := &pb.SplitLeg{
+			ToAccountId: leg.ToAccountID().String(),
+			Amount:      leg.Amount().Amount(),
+		}
+		if leg.TransactionID() != uuid.Nil {
+			pbLeg.TransactionId = leg.TransactionID().String()
+		}
+		resp.Legs = append(resp.Legs, pbLeg)
+	}
+	return resp
+}
@@ -0,0 +1,117 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/Xausdorf/qr-pay-hub/gen/pb"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/history"
+)
+
+func (h *Handler) GetTransaction(ctx context.Context, req *pb.GetTransactionRequest) (*pb.Transaction, error) {
+	id, err := uuid.Parse(req.GetTransactionId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid transaction_id")
+	}
+
+	txn, err := h.historyUC.Get(ctx, id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPBTransaction(txn), nil
+}
+
+func (h *Handler) ListTransactions(
+	ctx context.Context,
+	req *pb.ListTransactionsRequest,
+) (*pb.ListTransactionsResponse, error) {
+	listReq := history.ListRequest{
+		Direction: fromPBDirection(req.GetDirection()),
+		Status:    fromPBStatus(req.GetStatus()),
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	}
+	if req.GetAccountId() != "" {
+		id, err := uuid.Parse(req.GetAccountId())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid account_id")
+		}
+		listReq.AccountID = id
+	}
+	if req.GetCreatedAfter() != nil {
+		listReq.CreatedAfter = req.GetCreatedAfter().AsTime()
+	}
+	if req.GetCreatedBefore() != nil {
+		listReq.CreatedBefore = req.GetCreatedBefore().AsTime()
+	}
+
+	resp, err := h.historyUC.List(ctx, listReq)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	txns := make([]*pb.Transaction, 0, len(resp.Transactions))
+	for _, txn := range resp.Transactions {
+		txns = append(txns, toPBTransaction(txn))
+	}
+	return &pb.ListTransactionsResponse{
+		Transactions:  txns,
+		NextPageToken: resp.NextPageToken,
+	}, nil
+}
+
+func toPBTransaction(t *entity.Transaction) *pb.Transaction {
+	txn := &pb.Transaction{
+		Id:               t.ID().String(),
+		FromAccountId:    t.FromAccount().String(),
+		ToAccountId:      t.ToAccount().String(),
+		Amount:           t.Amount(),
+		Currency:         string(t.Currency()),
+		Status:           mapStatus(t.Status()),
+		FailureReason:    string(t.FailureReason()),
+		CreatedAt:        timestamppb.New(t.CreatedAt()),
+		CreditedAmount:   t.Credited().Amount(),
+		CreditedCurrency: string(t.Credited().Currency()),
+		FeeAmount:        t.Fee().Amount(),
+	}
+	if t.IsRefund() {
+		txn.OriginalTransactionId = t.OriginalID().String()
+	}
+	if t.IsConversion() {
+		txn.QuoteId = t.QuoteID().String()
+	}
+	if t.SplitID() != uuid.Nil {
+		txn.SplitId = t.SplitID().String()
+	}
+	return txn
+}
+
+func fromPBDirection(d pb.TransactionDirection) repository.Direction {
+	switch d {
+	case pb.TransactionDirection_TRANSACTION_DIRECTION_INCOMING:
+		return repository.DirectionIncoming
+	case pb.TransactionDirection_TRANSACTION_DIRECTION_OUTGOING:
+		return repository.DirectionOutgoing
+	default:
+		return repository.DirectionAny
+	}
+}
+
+func fromPBStatus(s pb.TransactionStatus) entity.TransactionStatus {
+	switch s {
+	case pb.TransactionStatus_TRANSACTION_STATUS_PENDING:
+		return entity.StatusPending
+	case pb.TransactionStatus_TRANSACTION_STATUS_SUCCESS:
+		return entity.StatusSuccess
+	case pb.TransactionStatus_TRANSACTION_STATUS_FAILED:
+		return entity.StatusFailed
+	default:
+		return ""
+	}
+}
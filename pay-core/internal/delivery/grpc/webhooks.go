@@ -0,0 +1,126 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/Xausdorf/qr-pay-hub/gen/pb"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/webhook"
+)
+
+func (h *Handler) RegisterWebhook(ctx context.Context, req *pb.RegisterWebhookRequest) (*pb.WebhookEndpoint, error) {
+	accountID, err := uuid.Parse(req.GetAccountId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid account_id")
+	}
+
+	endpoint, err := h.webhookUC.Register(ctx, accountID, req.GetUrl())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.WebhookEndpoint{
+		Id:        endpoint.ID().String(),
+		AccountId: endpoint.AccountID().String(),
+		Url:       endpoint.URL(),
+		Secret:    endpoint.Secret(),
+		CreatedAt: timestamppb.New(endpoint.CreatedAt()),
+	}, nil
+}
+
+func (h *Handler) ListWebhookDeliveries(
+	ctx context.Context,
+	req *pb.ListWebhookDeliveriesRequest,
+) (*pb.ListWebhookDeliveriesResponse, error) {
+	listReq := webhook.ListRequest{
+		Status:    fromPBDeliveryStatus(req.GetStatus()),
+		PageSize:  int(req.GetPageSize()),
+		PageToken: req.GetPageToken(),
+	}
+	if req.GetAccountId() != "" {
+		id, err := uuid.Parse(req.GetAccountId())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid account_id")
+		}
+		listReq.AccountID = id
+	}
+
+	resp, err := h.webhookUC.Deliveries(ctx, listReq)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	deliveries := make([]*pb.WebhookDelivery, 0, len(resp.Deliveries))
+	for _, d := range resp.Deliveries {
+		deliveries = append(deliveries, toPBDelivery(d))
+	}
+	return &pb.ListWebhookDeliveriesResponse{
+		Deliveries:    deliveries,
+		NextPageToken: resp.NextPageToken,
+	}, nil
+}
+
+func (h *Handler) ResendWebhookDelivery(
+	ctx context.Context,
+	req *pb.ResendWebhookDeliveryRequest,
+) (*pb.WebhookDelivery, error) {
+	id, err := uuid.Parse(req.GetDeliveryId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid delivery_id")
+	}
+
+	delivery, err := h.webhookUC.Resend(ctx, id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPBDelivery(delivery), nil
+}
+
+func toPBDelivery(d *entity.WebhookDelivery) *pb.WebhookDelivery {
+	delivery := &pb.WebhookDelivery{
+		Id:             d.ID().String(),
+		AccountId:      d.AccountID().String(),
+		EventId:        d.EventID().String(),
+		EventType:      string(d.EventType()),
+		Status:         toPBDeliveryStatus(d.Status()),
+		Attempts:       int32(d.Attempts()),       //nolint:gosec // G115: bounded by the dispatcher's MaxAttempts
+		LastStatusCode: int32(d.LastStatusCode()), //nolint:gosec // G115: an HTTP status code
+		LastError:      d.LastError(),
+		NextAttemptAt:  timestamppb.New(d.NextAttemptAt()),
+		CreatedAt:      timestamppb.New(d.CreatedAt()),
+	}
+	if !d.DeliveredAt().IsZero() {
+		delivery.DeliveredAt = timestamppb.New(d.DeliveredAt())
+	}
+	return delivery
+}
+
+func toPBDeliveryStatus(s entity.WebhookDeliveryStatus) pb.WebhookDeliveryStatus {
+	switch s {
+	case entity.WebhookPending:
+		return pb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING
+	case entity.WebhookDelivered:
+		return pb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DELIVERED
+	case entity.WebhookDead:
+		return pb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DEAD
+	default:
+		return pb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED
+	}
+}
+
+func fromPBDeliveryStatus(s pb.WebhookDeliveryStatus) entity.WebhookDeliveryStatus {
+	switch s {
+	case pb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING:
+		return entity.WebhookPending
+	case pb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DELIVERED:
+		return entity.WebhookDelivered
+	case pb.WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DEAD:
+		return entity.WebhookDead
+	default:
+		return ""
+	}
+}
@@ -2,6 +2,8 @@ package entity
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -9,17 +11,102 @@ import (
 var (
 	ErrInsufficientFunds = errors.New("insufficient funds")
 	ErrNegativeAmount    = errors.New("amount must be positive")
+	ErrAccountFrozen     = errors.New("account is frozen")
+	ErrAccountClosed     = errors.New("account is closed")
+	ErrLimitExceeded     = errors.New("limit exceeded")
+	ErrAccountNotEmpty   = errors.New("account balance is not zero")
 )
 
+// AccountKind separates customer accounts from the house accounts that
+// conversions book against.
+type AccountKind string
+
+const (
+	AccountCustomer AccountKind = "customer"
+	// AccountFXLiquidity is the house's position in a currency: it receives
+	// what payers convert from and pays out what payees convert to.
+	AccountFXLiquidity AccountKind = "fx_liquidity"
+	// AccountFXRevenue collects the spread earned on conversions.
+	AccountFXRevenue AccountKind = "fx_revenue"
+	// AccountFeeRevenue collects transfer fees.
+	AccountFeeRevenue AccountKind = "fee_revenue"
+)
+
+// AccountStatus is set by compliance. A frozen account can receive money but
+// not send it; a closed one can do neither and is closed only when empty.
+type AccountStatus string
+
+const (
+	AccountActive AccountStatus = "active"
+	AccountFrozen AccountStatus = "frozen"
+	AccountClosed AccountStatus = "closed"
+)
+
+// AccountState is an account's status together with why and when it was last
+// changed.
+type AccountState struct {
+	Status    AccountStatus
+	Reason    string
+	ChangedAt time.Time
+}
+
+// Account tracks the booked balance together with the part of it reserved by
+// active authorizations. Only the available remainder can be spent or held.
+// Every account holds a single currency and only accepts amounts in it.
 type Account struct {
-	id      uuid.UUID
-	balance int64
+	id        uuid.UUID
+	kind      AccountKind
+	tier      Tier
+	currency  Currency
+	balance   int64
+	held      int64
+	state     AccountState
+	createdAt time.Time
+}
+
+func NewAccount(id uuid.UUID, balance Money) *Account {
+	now := time.Now()
+	return &Account{
+		id:        id,
+		kind:      AccountCustomer,
+		tier:      DefaultTier,
+		currency:  balance.Currency(),
+		balance:   balance.Amount(),
+		state:     AccountState{Status: AccountActive, ChangedAt: now},
+		createdAt: now,
+	}
+}
+
+// NewSystemAccount opens an empty house account of the given kind.
+func NewSystemAccount(kind AccountKind, currency Currency) *Account {
+	now := time.Now()
+	return &Account{
+		id:        uuid.New(),
+		kind:      kind,
+		currency:  currency,
+		state:     AccountState{Status: AccountActive, ChangedAt: now},
+		createdAt: now,
+	}
 }
 
-func NewAccount(id uuid.UUID, balance int64) *Account {
+func ReconstructAccount(
+	id uuid.UUID,
+	kind AccountKind,
+	tier Tier,
+	balance Money,
+	held int64,
+	state AccountState,
+	createdAt time.Time,
+) *Account {
 	return &Account{
-		id:      id,
-		balance: balance,
+		id:        id,
+		kind:      kind,
+		tier:      tier,
+		currency:  balance.Currency(),
+		balance:   balance.Amount(),
+		held:      held,
+		state:     state,
+		createdAt: createdAt,
 	}
 }
 
@@ -27,25 +114,165 @@ func (a *Account) ID() uuid.UUID {
 	return a.id
 }
 
+func (a *Account) Kind() AccountKind {
+	return a.kind
+}
+
+func (a *Account) Tier() Tier {
+	return a.tier
+}
+
+// SetTier moves the account to another pricing plan.
+func (a *Account) SetTier(tier Tier) {
+	a.tier = tier
+}
+
+func (a *Account) Status() AccountStatus {
+	return a.state.Status
+}
+
+func (a *Account) State() AccountState {
+	return a.state
+}
+
+// Freeze stops the account from sending money. Freezing a frozen account only
+// updates the reason.
+func (a *Account) Freeze(reason string) error {
+	if a.state.Status == AccountClosed {
+		return ErrAccountClosed
+	}
+	a.setStatus(AccountFrozen, reason)
+	return nil
+}
+
+func (a *Account) Unfreeze(reason string) error {
+	if a.state.Status == AccountClosed {
+		return ErrAccountClosed
+	}
+	a.setStatus(AccountActive, reason)
+	return nil
+}
+
+// Close closes an account for good. Its balance must be zero and nothing may
+// be held on it: money is never left on an account no one can move it from.
+func (a *Account) Close(reason string) error {
+	if a.state.Status == AccountClosed {
+		return ErrAccountClosed
+	}
+	if a.balance != 0 || a.held != 0 {
+		return fmt.Errorf("%w: balance %d, held %d", ErrAccountNotEmpty, a.balance, a.held)
+	}
+	a.setStatus(AccountClosed, reason)
+	return nil
+}
+
+func (a *Account) setStatus(status AccountStatus, reason string) {
+	a.state = AccountState{Status: status, Reason: reason, ChangedAt: time.Now()}
+}
+
+// CheckCanSend reports whether the account's status lets it be debited.
+func (a *Account) CheckCanSend() error {
+	switch a.state.Status {
+	case AccountFrozen:
+		return ErrAccountFrozen
+	case AccountClosed:
+		return ErrAccountClosed
+	default:
+		return nil
+	}
+}
+
+// CheckCanReceive reports whether the account's status lets it be credited.
+func (a *Account) CheckCanReceive() error {
+	if a.state.Status == AccountClosed {
+		return ErrAccountClosed
+	}
+	return nil
+}
+
+// CanOverdraw reports whether the balance may go below zero. Only an FX
+// liquidity account may: a short position in a currency is settled with
+// counterparties outside the ledger.
+func (a *Account) CanOverdraw() bool {
+	return a.kind == AccountFXLiquidity
+}
+
+func (a *Account) Currency() Currency {
+	return a.currency
+}
+
 func (a *Account) Balance() int64 {
 	return a.balance
 }
 
-func (a *Account) Debit(amount int64) error {
-	if amount <= 0 {
+func (a *Account) Held() int64 {
+	return a.held
+}
+
+func (a *Account) Available() int64 {
+	return a.balance - a.held
+}
+
+func (a *Account) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+func (a *Account) Debit(amount Money) error {
+	if err := a.checkSpendable(amount); err != nil {
+		return err
+	}
+	a.balance -= amount.Amount()
+	return nil
+}
+
+// Hold reserves amount of the available balance without moving money.
+func (a *Account) Hold(amount Money) error {
+	if err := a.checkSpendable(amount); err != nil {
+		return err
+	}
+	a.held += amount.Amount()
+	return nil
+}
+
+// Release returns a previously held amount to the available balance. The
+// currency was checked when the amount was held.
+func (a *Account) Release(amount int64) {
+	a.held -= amount
+}
+
+func (a *Account) Credit(amount Money) error {
+	if err := a.CheckCanReceive(); err != nil {
+		return err
+	}
+	if err := a.checkCurrency(amount); err != nil {
+		return err
+	}
+	if !amount.IsPositive() {
 		return ErrNegativeAmount
 	}
-	if a.balance < amount {
+	a.balance += amount.Amount()
+	return nil
+}
+
+func (a *Account) checkSpendable(amount Money) error {
+	if err := a.CheckCanSend(); err != nil {
+		return err
+	}
+	if err := a.checkCurrency(amount); err != nil {
+		return err
+	}
+	if !amount.IsPositive() {
+		return ErrNegativeAmount
+	}
+	if a.Available() < amount.Amount() && !a.CanOverdraw() {
 		return ErrInsufficientFunds
 	}
-	a.balance -= amount
 	return nil
 }
 
-func (a *Account) Credit(amount int64) error {
-	if amount <= 0 {
-		return ErrNegativeAmount
+func (a *Account) checkCurrency(amount Money) error {
+	if amount.Currency() != a.currency {
+		return fmt.Errorf("%w: account holds %s, got %s", ErrCurrencyMismatch, a.currency, amount.Currency())
 	}
-	a.balance += amount
 	return nil
 }
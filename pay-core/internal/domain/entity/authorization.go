@@ -0,0 +1,157 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuthorizationStatus string
+
+const (
+	AuthorizationActive   AuthorizationStatus = "active"
+	AuthorizationCaptured AuthorizationStatus = "captured"
+	AuthorizationVoided   AuthorizationStatus = "voided"
+	AuthorizationExpired  AuthorizationStatus = "expired"
+)
+
+var (
+	ErrAuthorizationNotActive   = errors.New("authorization is no longer active")
+	ErrAuthorizationExpired     = errors.New("authorization has expired")
+	ErrCaptureExceedsAuthorized = errors.New("capture exceeds the authorized amount")
+)
+
+// Authorization is a hold on the payer's funds in favour of a payee. It is
+// settled at most once: a capture of up to the authorized amount moves money
+// and releases the rest, a void or expiry releases everything.
+type Authorization struct {
+	id          uuid.UUID
+	fromAccount uuid.UUID
+	toAccount   uuid.UUID
+	amount      int64
+	currency    Currency
+	captured    int64
+	status      AuthorizationStatus
+	captureTxID uuid.UUID
+	expiresAt   time.Time
+	createdAt   time.Time
+}
+
+func NewAuthorization(from, to uuid.UUID, amount Money, ttl time.Duration) *Authorization {
+	now := time.Now()
+	return &Authorization{
+		id:          uuid.New(),
+		fromAccount: from,
+		toAccount:   to,
+		amount:      amount.Amount(),
+		currency:    amount.Currency(),
+		status:      AuthorizationActive,
+		expiresAt:   now.Add(ttl),
+		createdAt:   now,
+	}
+}
+
+func ReconstructAuthorization(
+	id, from, to uuid.UUID,
+	amount Money,
+	captured int64,
+	status AuthorizationStatus,
+	captureTxID uuid.UUID,
+	expiresAt, createdAt time.Time,
+) *Authorization {
+	return &Authorization{
+		id:          id,
+		fromAccount: from,
+		toAccount:   to,
+		amount:      amount.Amount(),
+		currency:    amount.Currency(),
+		captured:    captured,
+		status:      status,
+		captureTxID: captureTxID,
+		expiresAt:   expiresAt,
+		createdAt:   createdAt,
+	}
+}
+
+func (a *Authorization) ID() uuid.UUID {
+	return a.id
+}
+
+func (a *Authorization) FromAccount() uuid.UUID {
+	return a.fromAccount
+}
+
+func (a *Authorization) ToAccount() uuid.UUID {
+	return a.toAccount
+}
+
+func (a *Authorization) Amount() int64 {
+	return a.amount
+}
+
+func (a *Authorization) Currency() Currency {
+	return a.currency
+}
+
+func (a *Authorization) Money() Money {
+	return ReconstructMoney(a.amount, a.currency)
+}
+
+func (a *Authorization) CapturedAmount() int64 {
+	return a.captured
+}
+
+func (a *Authorization) Status() AuthorizationStatus {
+	return a.status
+}
+
+// CaptureTransactionID is the transaction that settled the capture, or
+// uuid.Nil if the authorization was not captured.
+func (a *Authorization) CaptureTransactionID() uuid.UUID {
+	return a.captureTxID
+}
+
+func (a *Authorization) ExpiresAt() time.Time {
+	return a.expiresAt
+}
+
+func (a *Authorization) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+// Capture settles amount of the hold through the transaction txID.
+func (a *Authorization) Capture(amount int64, txID uuid.UUID, now time.Time) error {
+	if a.status != AuthorizationActive {
+		return ErrAuthorizationNotActive
+	}
+	if !now.Before(a.expiresAt) {
+		return ErrAuthorizationExpired
+	}
+	if amount <= 0 {
+		return ErrNegativeAmount
+	}
+	if amount > a.amount {
+		return ErrCaptureExceedsAuthorized
+	}
+	a.status = AuthorizationCaptured
+	a.captured = amount
+	a.captureTxID = txID
+	return nil
+}
+
+func (a *Authorization) Void() error {
+	if a.status != AuthorizationActive {
+		return ErrAuthorizationNotActive
+	}
+	a.status = AuthorizationVoided
+	return nil
+}
+
+func (a *Authorization) Expire() error {
+	if a.status != AuthorizationActive {
+		return ErrAuthorizationNotActive
+	}
+	a.status = AuthorizationExpired
+	return nil
+}
@@ -0,0 +1,259 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidBatch = errors.New("invalid batch")
+
+// MaxBatchItems bounds the transfers of one batch, all of which an atomic
+// batch keeps locked until it commits.
+const MaxBatchItems = 1000
+
+// BatchMode says how a batch treats an item that cannot be paid.
+type BatchMode string
+
+const (
+	// BatchAtomic pays every item in one unit of work or none of them.
+	BatchAtomic BatchMode = "atomic"
+	// BatchBestEffort pays each item on its own; a declined item does not
+	// affect the others.
+	BatchBestEffort BatchMode = "best_effort"
+)
+
+type BatchStatus string
+
+const (
+	// BatchProcessing is a best-effort batch whose items are still being
+	// paid.
+	BatchProcessing         BatchStatus = "processing"
+	BatchCompleted          BatchStatus = "completed"
+	BatchPartiallyCompleted BatchStatus = "partially_completed"
+	BatchFailed             BatchStatus = "failed"
+)
+
+type BatchItemStatus string
+
+const (
+	BatchItemPending BatchItemStatus = "pending"
+	BatchItemSuccess BatchItemStatus = "success"
+	BatchItemFailed  BatchItemStatus = "failed"
+	// BatchItemSkipped is an item of an atomic batch that was rolled back
+	// because another item failed.
+	BatchItemSkipped BatchItemStatus = "skipped"
+)
+
+// BatchItem is one transfer of a batch together with its outcome.
+type BatchItem struct {
+	position      int
+	fromAccount   uuid.UUID
+	toAccount     uuid.UUID
+	amount        Money
+	transferType  TransferType
+	status        BatchItemStatus
+	transactionID uuid.UUID
+	failureReason FailureReason
+	errorMessage  string
+}
+
+func NewBatchItem(from, to uuid.UUID, amount Money, transferType TransferType) *BatchItem {
+	return &BatchItem{
+		fromAccount:  from,
+		toAccount:    to,
+		amount:       amount,
+		transferType: transferType,
+		status:       BatchItemPending,
+	}
+}
+
+func ReconstructBatchItem(
+	position int,
+	from, to uuid.UUID,
+	amount Money,
+	transferType TransferType,
+	status BatchItemStatus,
+	transactionID uuid.UUID,
+	failureReason FailureReason,
+	errorMessage string,
+) *BatchItem {
+	return &BatchItem{
+		position:      position,
+		fromAccount:   from,
+		toAccount:     to,
+		amount:        amount,
+		transferType:  transferType,
+		status:        status,
+		transactionID: transactionID,
+		failureReason: failureReason,
+		errorMessage:  errorMessage,
+	}
+}
+
+// Position is the item's zero-based index in the request.
+func (i *BatchItem) Position() int {
+	return i.position
+}
+
+func (i *BatchItem) FromAccountID() uuid.UUID {
+	return i.fromAccount
+}
+
+func (i *BatchItem) ToAccountID() uuid.UUID {
+	return i.toAccount
+}
+
+func (i *BatchItem) Amount() Money {
+	return i.amount
+}
+
+func (i *BatchItem) TransferType() TransferType {
+	return i.transferType
+}
+
+func (i *BatchItem) Status() BatchItemStatus {
+	return i.status
+}
+
+// TransactionID is the transaction that paid or declined the item; it is
+// uuid.Nil if the item was rejected before a transaction was recorded.
+func (i *BatchItem) TransactionID() uuid.UUID {
+	return i.transactionID
+}
+
+func (i *BatchItem) FailureReason() FailureReason {
+	return i.failureReason
+}
+
+func (i *BatchItem) ErrorMessage() string {
+	return i.errorMessage
+}
+
+func (i *BatchItem) Succeed(transactionID uuid.UUID) {
+	i.status = BatchItemSuccess
+	i.transactionID = transactionID
+}
+
+// Fail records why the item was not paid. transactionID is uuid.Nil unless a
+// declined transaction was recorded for it.
+func (i *BatchItem) Fail(transactionID uuid.UUID, reason FailureReason, message string) {
+	i.status = BatchItemFailed
+	i.transactionID = transactionID
+	i.failureReason = reason
+	i.errorMessage = message
+}
+
+// Batch is a set of transfers submitted together under one idempotency key.
+type Batch struct {
+	id          uuid.UUID
+	mode        BatchMode
+	status      BatchStatus
+	items       []*BatchItem
+	createdAt   time.Time
+	completedAt time.Time
+}
+
+// NewBatch starts a batch of 1 to MaxBatchItems items, numbering them in
+// order.
+func NewBatch(mode BatchMode, items []*BatchItem) (*Batch, error) {
+	if mode != BatchAtomic && mode != BatchBestEffort {
+		return nil, fmt.Errorf("%w: unknown mode %q", ErrInvalidBatch, mode)
+	}
+	if len(items) == 0 || len(items) > MaxBatchItems {
+		return nil, fmt.Errorf("%w: %d items, expected 1 to %d", ErrInvalidBatch, len(items), MaxBatchItems)
+	}
+	for pos, item := range items {
+		item.position = pos
+	}
+	return &Batch{
+		id:        uuid.New(),
+		mode:      mode,
+		status:    BatchProcessing,
+		items:     items,
+		createdAt: time.Now(),
+	}, nil
+}
+
+func ReconstructBatch(
+	id uuid.UUID,
+	mode BatchMode,
+	status BatchStatus,
+	items []*BatchItem,
+	createdAt, completedAt time.Time,
+) *Batch {
+	return &Batch{
+		id:          id,
+		mode:        mode,
+		status:      status,
+		items:       items,
+		createdAt:   createdAt,
+		completedAt: completedAt,
+	}
+}
+
+func (b *Batch) ID() uuid.UUID {
+	return b.id
+}
+
+func (b *Batch) Mode() BatchMode {
+	return b.mode
+}
+
+func (b *Batch) Status() BatchStatus {
+	return b.status
+}
+
+func (b *Batch) Items() []*BatchItem {
+	return b.items
+}
+
+func (b *Batch) CreatedAt() time.Time {
+	return b.createdAt
+}
+
+// CompletedAt is zero while the batch is processing.
+func (b *Batch) CompletedAt() time.Time {
+	return b.completedAt
+}
+
+// Succeeded counts the items that were paid.
+func (b *Batch) Succeeded() int {
+	n := 0
+	for _, item := range b.items {
+		if item.status == BatchItemSuccess {
+			n++
+		}
+	}
+	return n
+}
+
+// Complete settles the batch's status from its items once none is pending.
+func (b *Batch) Complete(at time.Time) {
+	switch b.Succeeded() {
+	case len(b.items):
+		b.status = BatchCompleted
+	case 0:
+		b.status = BatchFailed
+	default:
+		b.status = BatchPartiallyCompleted
+	}
+	b.completedAt = at
+}
+
+// Abort fails an atomic batch on the item at position: that item records
+// why, and every other item is skipped.
+func (b *Batch) Abort(position int, reason FailureReason, message string, at time.Time) {
+	for _, item := range b.items {
+		if item.position == position {
+			item.Fail(uuid.Nil, reason, message)
+			continue
+		}
+		item.status = BatchItemSkipped
+		item.transactionID = uuid.Nil
+	}
+	b.status = BatchFailed
+	b.completedAt = at
+}
@@ -0,0 +1,144 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType names a payment lifecycle event published to downstream services.
+type EventType string
+
+const (
+	EventPaymentCompleted EventType = "payment.completed"
+	EventPaymentFailed    EventType = "payment.failed"
+	EventPaymentRefunded  EventType = "payment.refunded"
+)
+
+// PaymentEvent is the payload of payment events: the transaction as it was
+// committed.
+type PaymentEvent struct {
+	TransactionID         string    `json:"transaction_id"`
+	FromAccountID         string    `json:"from_account_id"`
+	ToAccountID           string    `json:"to_account_id"`
+	Amount                int64     `json:"amount"`
+	Currency              string    `json:"currency"`
+	CreditedAmount        int64     `json:"credited_amount"`
+	CreditedCurrency      string    `json:"credited_currency"`
+	Fee                   int64     `json:"fee,omitempty"`
+	Status                string    `json:"status"`
+	FailureReason         string    `json:"failure_reason,omitempty"`
+	OriginalTransactionID string    `json:"original_transaction_id,omitempty"`
+	QuoteID               string    `json:"quote_id,omitempty"`
+	SplitID               string    `json:"split_id,omitempty"`
+	CreatedAt             time.Time `json:"created_at"`
+}
+
+// Event is a message written to the outbox in the unit of work that commits
+// what it describes, and published after the commit at least once.
+type Event struct {
+	id          uuid.UUID
+	sequence    int64
+	eventType   EventType
+	aggregateID uuid.UUID
+	payload     []byte
+	createdAt   time.Time
+}
+
+// NewPaymentEvent describes a committed transaction: a failed one as
+// payment.failed, a successful refund as payment.refunded and any other
+// successful transfer as payment.completed.
+func NewPaymentEvent(t *Transaction) (*Event, error) {
+	eventType := EventPaymentCompleted
+	switch {
+	case t.Status() == StatusFailed:
+		eventType = EventPaymentFailed
+	case t.IsRefund():
+		eventType = EventPaymentRefunded
+	}
+
+	p := PaymentEvent{
+		TransactionID:    t.ID().String(),
+		FromAccountID:    t.FromAccount().String(),
+		ToAccountID:      t.ToAccount().String(),
+		Amount:           t.Amount(),
+		Currency:         string(t.Currency()),
+		CreditedAmount:   t.Credited().Amount(),
+		CreditedCurrency: string(t.Credited().Currency()),
+		Fee:              t.Fee().Amount(),
+		Status:           string(t.Status()),
+		FailureReason:    string(t.FailureReason()),
+		CreatedAt:        t.CreatedAt(),
+	}
+	if t.IsRefund() {
+		p.OriginalTransactionID = t.OriginalID().String()
+	}
+	if t.IsConversion() {
+		p.QuoteID = t.QuoteID().String()
+	}
+	if t.SplitID() != uuid.Nil {
+		p.SplitID = t.SplitID().String()
+	}
+	payload, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Event{
+		id:          uuid.New(),
+		eventType:   eventType,
+		aggregateID: t.ID(),
+		payload:     payload,
+		createdAt:   time.Now(),
+	}, nil
+}
+
+func ReconstructEvent(
+	id uuid.UUID,
+	sequence int64,
+	eventType EventType,
+	aggregateID uuid.UUID,
+	payload []byte,
+	createdAt time.Time,
+) *Event {
+	return &Event{
+		id:          id,
+		sequence:    sequence,
+		eventType:   eventType,
+		aggregateID: aggregateID,
+		payload:     payload,
+		createdAt:   createdAt,
+	}
+}
+
+// ID identifies the event; consumers deduplicate redeliveries by it.
+func (e *Event) ID() uuid.UUID {
+	return e.id
+}
+
+// Sequence orders events by when they were committed; an event has a higher
+// sequence than every event committed before it. It is zero until the event
+// is committed.
+func (e *Event) Sequence() int64 {
+	return e.sequence
+}
+
+func (e *Event) Type() EventType {
+	return e.eventType
+}
+
+// AggregateID is the transaction the event is about.
+func (e *Event) AggregateID() uuid.UUID {
+	return e.aggregateID
+}
+
+// Payload is the JSON-encoded body of the event, a PaymentEvent for payment
+// events.
+func (e *Event) Payload() []byte {
+	return e.payload
+}
+
+func (e *Event) CreatedAt() time.Time {
+	return e.createdAt
+}
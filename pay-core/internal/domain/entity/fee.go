@@ -0,0 +1,189 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidTier        = errors.New("invalid account tier")
+	ErrInvalidFeeSchedule = errors.New("invalid fee schedule")
+	ErrFeeExceedsAmount   = errors.New("fee exceeds the transfer amount")
+)
+
+// Tier is a customer's pricing plan, e.g. "standard" or "premium". Fee
+// schedules and limits are configured per tier.
+type Tier string
+
+const (
+	DefaultTier Tier = "standard"
+	// AnyTier keys a fee schedule that applies to every tier without a
+	// schedule of its own.
+	AnyTier Tier = ""
+)
+
+const maxTierLen = 32
+
+// ParseTier validates a tier name: lowercase letters, digits and underscores.
+// An empty name means DefaultTier.
+func ParseTier(name string) (Tier, error) {
+	if name == "" {
+		return DefaultTier, nil
+	}
+	if len(name) > maxTierLen {
+		return "", fmt.Errorf("%w: %q is longer than %d characters", ErrInvalidTier, name, maxTierLen)
+	}
+	for _, c := range name {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '_' {
+			return "", fmt.Errorf("%w: %q", ErrInvalidTier, name)
+		}
+	}
+	return Tier(name), nil
+}
+
+// TransferType distinguishes payments between people from payments to a
+// merchant by scanning its QR code; they are priced separately.
+type TransferType string
+
+const (
+	TransferP2P        TransferType = "p2p"
+	TransferQRMerchant TransferType = "qr_merchant"
+)
+
+// FeeBearer says who pays a fee.
+type FeeBearer string
+
+const (
+	// FeePayer debits the fee from the payer on top of the amount.
+	FeePayer FeeBearer = "payer"
+	// FeePayee nets the fee from what the payee receives.
+	FeePayee FeeBearer = "payee"
+)
+
+// FeeSchedule prices transfers of one type, in one currency, for accounts of
+// one tier: a fixed part plus a percentage of the amount, kept within
+// [min, max]. For P2P transfers the tier is the payer's; for QR merchant
+// payments it is the merchant's.
+type FeeSchedule struct {
+	transferType TransferType
+	tier         Tier
+	currency     Currency
+	fixed        int64
+	rateBps      int64
+	min          int64
+	max          int64
+	bearer       FeeBearer
+	updatedAt    time.Time
+}
+
+// NewFeeSchedule validates a schedule. Amounts are in minor units of
+// currency; max of zero leaves the fee uncapped.
+func NewFeeSchedule(
+	transferType TransferType,
+	tier Tier,
+	currency Currency,
+	fixed, rateBps, minFee, maxFee int64,
+	bearer FeeBearer,
+) (*FeeSchedule, error) {
+	if transferType != TransferP2P && transferType != TransferQRMerchant {
+		return nil, fmt.Errorf("%w: unknown transfer type %q", ErrInvalidFeeSchedule, string(transferType))
+	}
+	if bearer != FeePayer && bearer != FeePayee {
+		return nil, fmt.Errorf("%w: unknown bearer %q", ErrInvalidFeeSchedule, string(bearer))
+	}
+	if _, ok := currency.MinorUnits(); !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCurrency, string(currency))
+	}
+	if fixed < 0 || minFee < 0 || maxFee < 0 {
+		return nil, fmt.Errorf("%w: amounts must not be negative", ErrInvalidFeeSchedule)
+	}
+	if rateBps < 0 || rateBps >= basisPoints {
+		return nil, fmt.Errorf("%w: rate of %d bps", ErrInvalidFeeSchedule, rateBps)
+	}
+	if maxFee != 0 && maxFee < minFee {
+		return nil, fmt.Errorf("%w: max %d is below min %d", ErrInvalidFeeSchedule, maxFee, minFee)
+	}
+
+	return &FeeSchedule{
+		transferType: transferType,
+		tier:         tier,
+		currency:     currency,
+		fixed:        fixed,
+		rateBps:      rateBps,
+		min:          minFee,
+		max:          maxFee,
+		bearer:       bearer,
+		updatedAt:    time.Now(),
+	}, nil
+}
+
+func ReconstructFeeSchedule(
+	transferType TransferType,
+	tier Tier,
+	currency Currency,
+	fixed, rateBps, minFee, maxFee int64,
+	bearer FeeBearer,
+	updatedAt time.Time,
+) *FeeSchedule {
+	return &FeeSchedule{
+		transferType: transferType,
+		tier:         tier,
+		currency:     currency,
+		fixed:        fixed,
+		rateBps:      rateBps,
+		min:          minFee,
+		max:          maxFee,
+		bearer:       bearer,
+		updatedAt:    updatedAt,
+	}
+}
+
+func (s *FeeSchedule) TransferType() TransferType {
+	return s.transferType
+}
+
+func (s *FeeSchedule) Tier() Tier {
+	return s.tier
+}
+
+func (s *FeeSchedule) Currency() Currency {
+	return s.currency
+}
+
+func (s *FeeSchedule) Fixed() int64 {
+	return s.fixed
+}
+
+func (s *FeeSchedule) RateBps() int64 {
+	return s.rateBps
+}
+
+func (s *FeeSchedule) Min() int64 {
+	return s.min
+}
+
+// Max is the cap on the fee, or zero if there is none.
+func (s *FeeSchedule) Max() int64 {
+	return s.max
+}
+
+func (s *FeeSchedule) Bearer() FeeBearer {
+	return s.bearer
+}
+
+func (s *FeeSchedule) UpdatedAt() time.Time {
+	return s.updatedAt
+}
+
+// Calculate prices a transfer of amount. The percentage is rounded down to a
+// whole minor unit before the caps are applied.
+func (s *FeeSchedule) Calculate(amount Money) Money {
+	// Split amount so that multiplying by the rate cannot overflow int64.
+	pct := amount.Amount()/basisPoints*s.rateBps + amount.Amount()%basisPoints*s.rateBps/basisPoints
+	fee := max(s.fixed+pct, s.min)
+	if s.max != 0 {
+		fee = min(fee, s.max)
+	}
+	return amount.WithAmount(fee)
+}
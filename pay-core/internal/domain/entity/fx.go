@@ -0,0 +1,251 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidRate   = errors.New("invalid exchange rate")
+	ErrQuoteExpired  = errors.New("quote has expired")
+	ErrQuoteUsed     = errors.New("quote has already been used")
+	ErrQuoteMismatch = errors.New("payment does not match the quote")
+)
+
+const (
+	basisPoints = 10000
+	// rateDecimals is the precision rates are stored and shown with.
+	rateDecimals = 10
+)
+
+// Rate is the mid-market price of one major unit of Base in Quote, and the
+// spread in basis points the house keeps on conversions at that rate.
+type Rate struct {
+	base      Currency
+	quote     Currency
+	mid       *big.Rat
+	spreadBps int64
+	updatedAt time.Time
+}
+
+// NewRate parses mid as a decimal such as "92.1534". The currencies must be
+// distinct and known, the rate positive and the spread below 100%.
+func NewRate(base, quote Currency, mid string, spreadBps int64) (*Rate, error) {
+	if _, ok := base.MinorUnits(); !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCurrency, string(base))
+	}
+	if _, ok := quote.MinorUnits(); !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCurrency, string(quote))
+	}
+	if base == quote {
+		return nil, fmt.Errorf("%w: %s to itself", ErrInvalidRate, base)
+	}
+
+	r, ok := new(big.Rat).SetString(mid)
+	if !ok || r.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: %s/%s = %q", ErrInvalidRate, base, quote, mid)
+	}
+	if spreadBps < 0 || spreadBps >= basisPoints {
+		return nil, fmt.Errorf("%w: spread of %d bps", ErrInvalidRate, spreadBps)
+	}
+
+	return &Rate{
+		base:      base,
+		quote:     quote,
+		mid:       r,
+		spreadBps: spreadBps,
+		updatedAt: time.Now(),
+	}, nil
+}
+
+func ReconstructRate(base, quote Currency, mid string, spreadBps int64, updatedAt time.Time) (*Rate, error) {
+	r, err := NewRate(base, quote, mid, spreadBps)
+	if err != nil {
+		return nil, err
+	}
+	r.updatedAt = updatedAt
+	return r, nil
+}
+
+func (r *Rate) Base() Currency {
+	return r.base
+}
+
+func (r *Rate) Quote() Currency {
+	return r.quote
+}
+
+// Mid is the mid-market rate as a decimal string.
+func (r *Rate) Mid() string {
+	return r.mid.FloatString(rateDecimals)
+}
+
+func (r *Rate) SpreadBps() int64 {
+	return r.spreadBps
+}
+
+func (r *Rate) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+// Invert returns the rate for the opposite direction with the same spread.
+func (r *Rate) Invert() *Rate {
+	return &Rate{
+		base:      r.quote,
+		quote:     r.base,
+		mid:       new(big.Rat).Inv(r.mid),
+		spreadBps: r.spreadBps,
+		updatedAt: r.updatedAt,
+	}
+}
+
+// customer is the rate a payer gets: mid less the spread.
+func (r *Rate) customer() *big.Rat {
+	keep := big.NewRat(basisPoints-r.spreadBps, basisPoints)
+	return new(big.Rat).Mul(r.mid, keep)
+}
+
+// Convert prices amount of Base in Quote. It returns what the payee receives at
+// the customer rate and what the amount is worth at mid; the difference is the
+// house's spread. Both are rounded down to whole minor units.
+func (r *Rate) Convert(amount Money) (Money, Money, error) {
+	if amount.Currency() != r.base {
+		return Money{}, Money{}, fmt.Errorf("%w: rate is for %s, amount is in %s",
+			ErrCurrencyMismatch, r.base, amount.Currency())
+	}
+	if !amount.IsPositive() {
+		return Money{}, Money{}, ErrNegativeAmount
+	}
+
+	fromUnits, _ := r.base.MinorUnits()
+	toUnits, _ := r.quote.MinorUnits()
+	scale := new(big.Rat).SetFrac(pow10(toUnits), pow10(fromUnits))
+	minor := new(big.Rat).Mul(new(big.Rat).SetInt64(amount.Amount()), scale)
+
+	atMid := floor(new(big.Rat).Mul(minor, r.mid))
+	atCustomer := floor(new(big.Rat).Mul(minor, r.customer()))
+	if atCustomer <= 0 {
+		return Money{}, Money{}, fmt.Errorf("%w: %s is worth nothing in %s", ErrNegativeAmount, amount, r.quote)
+	}
+	return ReconstructMoney(atCustomer, r.quote), ReconstructMoney(atMid, r.quote), nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(decimalBase), big.NewInt(int64(n)), nil)
+}
+
+func floor(r *big.Rat) int64 {
+	return new(big.Int).Quo(r.Num(), r.Denom()).Int64()
+}
+
+// Quote locks a conversion for a short time: a payment that presents it
+// debits exactly Source from the payer and credits exactly Target to the
+// payee, whatever the rate table says by then. It can be used once.
+type Quote struct {
+	id            uuid.UUID
+	source        Money
+	target        Money
+	spread        Money
+	rate          string
+	transactionID uuid.UUID
+	expiresAt     time.Time
+	createdAt     time.Time
+}
+
+// NewQuote prices source at rate and keeps the result valid for ttl.
+func NewQuote(rate *Rate, source Money, ttl time.Duration) (*Quote, error) {
+	target, atMid, err := rate.Convert(source)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Quote{
+		id:        uuid.New(),
+		source:    source,
+		target:    target,
+		spread:    atMid.WithAmount(atMid.Amount() - target.Amount()),
+		rate:      rate.customer().FloatString(rateDecimals),
+		expiresAt: now.Add(ttl),
+		createdAt: now,
+	}, nil
+}
+
+func ReconstructQuote(
+	id uuid.UUID,
+	source, target, spread Money,
+	rate string,
+	transactionID uuid.UUID,
+	expiresAt, createdAt time.Time,
+) *Quote {
+	return &Quote{
+		id:            id,
+		source:        source,
+		target:        target,
+		spread:        spread,
+		rate:          rate,
+		transactionID: transactionID,
+		expiresAt:     expiresAt,
+		createdAt:     createdAt,
+	}
+}
+
+func (q *Quote) ID() uuid.UUID {
+	return q.id
+}
+
+// Source is what the payer is debited, in the currency converted from.
+func (q *Quote) Source() Money {
+	return q.source
+}
+
+// Target is what the payee is credited, in the currency converted to.
+func (q *Quote) Target() Money {
+	return q.target
+}
+
+// Spread is the house's revenue on the conversion, in the target currency.
+func (q *Quote) Spread() Money {
+	return q.spread
+}
+
+// Rate is the customer rate the quote was priced at, spread included.
+func (q *Quote) Rate() string {
+	return q.rate
+}
+
+// TransactionID is the payment that used the quote, or uuid.Nil.
+func (q *Quote) TransactionID() uuid.UUID {
+	return q.transactionID
+}
+
+func (q *Quote) ExpiresAt() time.Time {
+	return q.expiresAt
+}
+
+func (q *Quote) CreatedAt() time.Time {
+	return q.createdAt
+}
+
+// CheckUsable reports whether a payment of amount may settle at this quote.
+func (q *Quote) CheckUsable(amount Money, now time.Time) error {
+	if q.transactionID != uuid.Nil {
+		return ErrQuoteUsed
+	}
+	if !now.Before(q.expiresAt) {
+		return ErrQuoteExpired
+	}
+	if amount != q.source {
+		return fmt.Errorf("%w: quoted %s, paying %s", ErrQuoteMismatch, q.source, amount)
+	}
+	return nil
+}
+
+// Use marks the quote as consumed by the transaction txID.
+func (q *Quote) Use(txID uuid.UUID) {
+	q.transactionID = txID
+}
@@ -1,26 +1,43 @@
 package entity
 
-import "time"
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"time"
+)
+
+var ErrIdempotencyKeyReused = errors.New("idempotency key was already used with a different request")
 
 type IdempotencyRecord struct {
 	key          string
+	fingerprint  string
 	responseCode int
 	responseBody []byte
 	createdAt    time.Time
 }
 
-func NewIdempotencyRecord(key string, code int, body []byte) *IdempotencyRecord {
+func NewIdempotencyRecord(key, fingerprint string, code int, body []byte) *IdempotencyRecord {
 	return &IdempotencyRecord{
 		key:          key,
+		fingerprint:  fingerprint,
 		responseCode: code,
 		responseBody: body,
 		createdAt:    time.Now(),
 	}
 }
 
-func ReconstructIdempotencyRecord(key string, code int, body []byte, createdAt time.Time) *IdempotencyRecord {
+func ReconstructIdempotencyRecord(
+	key, fingerprint string,
+	code int,
+	body []byte,
+	createdAt time.Time,
+) *IdempotencyRecord {
 	return &IdempotencyRecord{
 		key:          key,
+		fingerprint:  fingerprint,
 		responseCode: code,
 		responseBody: body,
 		createdAt:    createdAt,
@@ -31,6 +48,10 @@ func (r *IdempotencyRecord) Key() string {
 	return r.key
 }
 
+func (r *IdempotencyRecord) Fingerprint() string {
+	return r.fingerprint
+}
+
 func (r *IdempotencyRecord) ResponseCode() int {
 	return r.responseCode
 }
@@ -42,3 +63,34 @@ func (r *IdempotencyRecord) ResponseBody() []byte {
 func (r *IdempotencyRecord) CreatedAt() time.Time {
 	return r.createdAt
 }
+
+// Matches reports whether a replayed request with the given fingerprint is the
+// same request that produced this record.
+func (r *IdempotencyRecord) Matches(fingerprint string) bool {
+	return r.fingerprint == fingerprint
+}
+
+// RequestFingerprint returns a SHA-256 hash over the canonical form of the
+// request fields: keys are sorted and every key and value is length-prefixed.
+// Empty values are skipped, so introducing a new optional field does not change
+// the fingerprint of requests that leave it unset.
+func RequestFingerprint(fields map[string]string) string {
+	keys := make([]string, 0, len(fields))
+	for k, v := range fields {
+		if v != "" {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	h := sha256.New()
+	var size [8]byte
+	for _, k := range keys {
+		for _, part := range []string{k, fields[k]} {
+			binary.BigEndian.PutUint64(size[:], uint64(len(part)))
+			_, _ = h.Write(size[:])
+			_, _ = h.Write([]byte(part))
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,175 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxIntentTTL bounds how long a payment intent stays payable.
+const MaxIntentTTL = 24 * time.Hour
+
+type IntentStatus string
+
+const (
+	IntentCreated   IntentStatus = "created"
+	IntentPaid      IntentStatus = "paid"
+	IntentExpired   IntentStatus = "expired"
+	IntentCancelled IntentStatus = "cancelled"
+)
+
+var (
+	ErrInvalidIntent = errors.New("invalid payment intent")
+	ErrIntentNotOpen = errors.New("payment intent is no longer open")
+	ErrIntentExpired = errors.New("payment intent has expired")
+)
+
+// PaymentIntent is a request for a payment of a fixed amount to a payee,
+// presented to the payer as a QR code that carries only its id. Whoever pays
+// it first settles it; it can be paid at most once, and not after it expired
+// or was cancelled.
+type PaymentIntent struct {
+	id            uuid.UUID
+	toAccount     uuid.UUID
+	amount        int64
+	currency      Currency
+	status        IntentStatus
+	fromAccount   uuid.UUID
+	transactionID uuid.UUID
+	expiresAt     time.Time
+	createdAt     time.Time
+	paidAt        time.Time
+}
+
+// NewPaymentIntent asks for amount to be paid to the account to within ttl,
+// which must be positive and at most MaxIntentTTL.
+func NewPaymentIntent(to uuid.UUID, amount Money, ttl time.Duration) (*PaymentIntent, error) {
+	if !amount.IsPositive() {
+		return nil, ErrNegativeAmount
+	}
+	if ttl <= 0 || ttl > MaxIntentTTL {
+		return nil, fmt.Errorf("%w: ttl must be positive and at most %s", ErrInvalidIntent, MaxIntentTTL)
+	}
+
+	now := time.Now()
+	return &PaymentIntent{
+		id:        uuid.New(),
+		toAccount: to,
+		amount:    amount.Amount(),
+		currency:  amount.Currency(),
+		status:    IntentCreated,
+		expiresAt: now.Add(ttl),
+		createdAt: now,
+	}, nil
+}
+
+func ReconstructPaymentIntent(
+	id, to uuid.UUID,
+	amount Money,
+	status IntentStatus,
+	from, transactionID uuid.UUID,
+	expiresAt, createdAt, paidAt time.Time,
+) *PaymentIntent {
+	return &PaymentIntent{
+		id:            id,
+		toAccount:     to,
+		amount:        amount.Amount(),
+		currency:      amount.Currency(),
+		status:        status,
+		fromAccount:   from,
+		transactionID: transactionID,
+		expiresAt:     expiresAt,
+		createdAt:     createdAt,
+		paidAt:        paidAt,
+	}
+}
+
+func (i *PaymentIntent) ID() uuid.UUID {
+	return i.id
+}
+
+// ToAccount is the payee.
+func (i *PaymentIntent) ToAccount() uuid.UUID {
+	return i.toAccount
+}
+
+func (i *PaymentIntent) Amount() int64 {
+	return i.amount
+}
+
+func (i *PaymentIntent) Currency() Currency {
+	return i.currency
+}
+
+func (i *PaymentIntent) Money() Money {
+	return ReconstructMoney(i.amount, i.currency)
+}
+
+func (i *PaymentIntent) Status() IntentStatus {
+	return i.status
+}
+
+// FromAccount is the payer, or uuid.Nil until the intent is paid.
+func (i *PaymentIntent) FromAccount() uuid.UUID {
+	return i.fromAccount
+}
+
+// TransactionID is the payment that settled the intent, or uuid.Nil.
+func (i *PaymentIntent) TransactionID() uuid.UUID {
+	return i.transactionID
+}
+
+func (i *PaymentIntent) ExpiresAt() time.Time {
+	return i.expiresAt
+}
+
+func (i *PaymentIntent) CreatedAt() time.Time {
+	return i.createdAt
+}
+
+// PaidAt is when the intent was paid, or the zero time.
+func (i *PaymentIntent) PaidAt() time.Time {
+	return i.paidAt
+}
+
+// Refresh moves an open intent whose expiry has passed at now to
+// IntentExpired and reports whether it did. Expiry is not swept: the stored
+// status of an unpaid intent changes the next time the intent is read.
+func (i *PaymentIntent) Refresh(now time.Time) bool {
+	if i.status != IntentCreated || now.Before(i.expiresAt) {
+		return false
+	}
+	i.status = IntentExpired
+	return true
+}
+
+// CheckPayable reports whether the intent may still be paid.
+func (i *PaymentIntent) CheckPayable() error {
+	switch i.status {
+	case IntentCreated:
+		return nil
+	case IntentExpired:
+		return ErrIntentExpired
+	default:
+		return fmt.Errorf("%w: %s", ErrIntentNotOpen, i.status)
+	}
+}
+
+// Pay marks the intent as settled by the transaction txID from the account
+// from.
+func (i *PaymentIntent) Pay(from, txID uuid.UUID, at time.Time) {
+	i.status = IntentPaid
+	i.fromAccount = from
+	i.transactionID = txID
+	i.paidAt = at
+}
+
+func (i *PaymentIntent) Cancel() error {
+	if err := i.CheckPayable(); err != nil {
+		return err
+	}
+	i.status = IntentCancelled
+	return nil
+}
@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrUnbalancedPostings = errors.New("postings do not sum to zero")
+
+// LedgerEntry is a single posting of a transaction: a negative amount debits
+// the account, a positive amount credits it. Postings of one transaction sum
+// to zero separately in each currency.
+type LedgerEntry struct {
+	id            uuid.UUID
+	transactionID uuid.UUID
+	accountID     uuid.UUID
+	amount        int64
+	currency      Currency
+	createdAt     time.Time
+}
+
+func NewLedgerEntry(transactionID, accountID uuid.UUID, amount Money) *LedgerEntry {
+	return &LedgerEntry{
+		id:            uuid.New(),
+		transactionID: transactionID,
+		accountID:     accountID,
+		amount:        amount.Amount(),
+		currency:      amount.Currency(),
+		createdAt:     time.Now(),
+	}
+}
+
+func (e *LedgerEntry) ID() uuid.UUID {
+	return e.id
+}
+
+func (e *LedgerEntry) TransactionID() uuid.UUID {
+	return e.transactionID
+}
+
+func (e *LedgerEntry) AccountID() uuid.UUID {
+	return e.accountID
+}
+
+func (e *LedgerEntry) Amount() int64 {
+	return e.amount
+}
+
+func (e *LedgerEntry) Currency() Currency {
+	return e.currency
+}
+
+func (e *LedgerEntry) CreatedAt() time.Time {
+	return e.createdAt
+}
@@ -0,0 +1,177 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidLimits = errors.New("invalid transfer limits")
+
+// LimitKind names one of the limits in TransferLimits.
+type LimitKind string
+
+const (
+	LimitSingle      LimitKind = "single"
+	LimitDaily       LimitKind = "daily"
+	LimitMonthly     LimitKind = "monthly"
+	LimitHourlyCount LimitKind = "hourly_count"
+)
+
+// LimitError reports which limit a transfer would breach. It matches
+// ErrLimitExceeded under errors.Is.
+type LimitError struct {
+	Kind LimitKind
+	// Max is the configured limit and Reached what the transfer would bring
+	// the total, or for LimitHourlyCount the number of transfers, to.
+	Max     int64
+	Reached int64
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s: %s limit of %d, would reach %d", ErrLimitExceeded, e.Kind, e.Max, e.Reached)
+}
+
+func (e *LimitError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// Usage is what an account has already sent in the windows limits apply to.
+type Usage struct {
+	Day           int64
+	Month         int64
+	LastHourCount int64
+}
+
+// UsageWindows are the starts of the windows Usage is summed over: the UTC
+// calendar day and month, and the last rolling hour.
+type UsageWindows struct {
+	Day   time.Time
+	Month time.Time
+	Hour  time.Time
+}
+
+func UsageWindowsAt(now time.Time) UsageWindows {
+	now = now.UTC()
+	return UsageWindows{
+		Day:   time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+		Month: time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC),
+		Hour:  now.Add(-time.Hour),
+	}
+}
+
+// TransferLimits cap what an account sends. They are set either for one
+// account, in its currency, or for every account of a tier in a currency;
+// the account's own limits take precedence. A zero limit is not enforced.
+type TransferLimits struct {
+	accountID   uuid.UUID
+	tier        Tier
+	currency    Currency
+	maxSingle   int64
+	daily       int64
+	monthly     int64
+	hourlyCount int64
+	updatedAt   time.Time
+}
+
+// NewTransferLimits validates limits scoped to accountID or, if it is
+// uuid.Nil, to tier. Amounts are in minor units of currency.
+func NewTransferLimits(
+	accountID uuid.UUID,
+	tier Tier,
+	currency Currency,
+	maxSingle, daily, monthly, hourlyCount int64,
+) (*TransferLimits, error) {
+	if (accountID == uuid.Nil) == (tier == "") {
+		return nil, fmt.Errorf("%w: exactly one of account and tier must be set", ErrInvalidLimits)
+	}
+	if _, ok := currency.MinorUnits(); !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCurrency, string(currency))
+	}
+	if maxSingle < 0 || daily < 0 || monthly < 0 || hourlyCount < 0 {
+		return nil, fmt.Errorf("%w: limits must not be negative", ErrInvalidLimits)
+	}
+
+	return &TransferLimits{
+		accountID:   accountID,
+		tier:        tier,
+		currency:    currency,
+		maxSingle:   maxSingle,
+		daily:       daily,
+		monthly:     monthly,
+		hourlyCount: hourlyCount,
+		updatedAt:   time.Now(),
+	}, nil
+}
+
+func ReconstructTransferLimits(
+	accountID uuid.UUID,
+	tier Tier,
+	currency Currency,
+	maxSingle, daily, monthly, hourlyCount int64,
+	updatedAt time.Time,
+) *TransferLimits {
+	return &TransferLimits{
+		accountID:   accountID,
+		tier:        tier,
+		currency:    currency,
+		maxSingle:   maxSingle,
+		daily:       daily,
+		monthly:     monthly,
+		hourlyCount: hourlyCount,
+		updatedAt:   updatedAt,
+	}
+}
+
+// AccountID is the account the limits apply to, or uuid.Nil for tier limits.
+func (l *TransferLimits) AccountID() uuid.UUID {
+	return l.accountID
+}
+
+func (l *TransferLimits) Tier() Tier {
+	return l.tier
+}
+
+func (l *TransferLimits) Currency() Currency {
+	return l.currency
+}
+
+func (l *TransferLimits) MaxSingle() int64 {
+	return l.maxSingle
+}
+
+func (l *TransferLimits) Daily() int64 {
+	return l.daily
+}
+
+func (l *TransferLimits) Monthly() int64 {
+	return l.monthly
+}
+
+func (l *TransferLimits) HourlyCount() int64 {
+	return l.hourlyCount
+}
+
+func (l *TransferLimits) UpdatedAt() time.Time {
+	return l.updatedAt
+}
+
+// Check reports the first limit that sending amount on top of usage would
+// breach, as a *LimitError.
+func (l *TransferLimits) Check(amount Money, usage Usage) error {
+	a := amount.Amount()
+	switch {
+	case l.maxSingle != 0 && a > l.maxSingle:
+		return &LimitError{Kind: LimitSingle, Max: l.maxSingle, Reached: a}
+	case l.daily != 0 && usage.Day+a > l.daily:
+		return &LimitError{Kind: LimitDaily, Max: l.daily, Reached: usage.Day + a}
+	case l.monthly != 0 && usage.Month+a > l.monthly:
+		return &LimitError{Kind: LimitMonthly, Max: l.monthly, Reached: usage.Month + a}
+	case l.hourlyCount != 0 && usage.LastHourCount+1 > l.hourlyCount:
+		return &LimitError{Kind: LimitHourlyCount, Max: l.hourlyCount, Reached: usage.LastHourCount + 1}
+	default:
+		return nil
+	}
+}
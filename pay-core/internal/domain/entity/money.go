@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnknownCurrency  = errors.New("unknown currency")
+	ErrCurrencyMismatch = errors.New("currencies do not match")
+)
+
+// Currency is an ISO 4217 alphabetic code such as "RUB" or "KZT".
+type Currency string
+
+// DefaultCurrency is assumed for requests and accounts that do not name a
+// currency, which is how the service worked before it became multi-currency.
+const DefaultCurrency Currency = "RUB"
+
+// ParseCurrency validates an ISO 4217 code, accepting it in any letter case.
+func ParseCurrency(code string) (Currency, error) {
+	c := Currency(strings.ToUpper(code))
+	if _, ok := c.MinorUnits(); !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownCurrency, code)
+	}
+	return c, nil
+}
+
+const (
+	cents = 2
+	mills = 3
+)
+
+// MinorUnits reports how many decimal places the currency has under ISO 4217,
+// i.e. how many minor units make up one major unit as a power of ten.
+func (c Currency) MinorUnits() (int, bool) {
+	switch c {
+	case "RUB", "KZT", "BYN", "UZS", "KGS", "AMD", "GEL", "AZN", "TJS",
+		"USD", "EUR", "GBP", "CNY", "TRY", "AED", "INR":
+		return cents, true
+	case "JPY", "KRW", "VND":
+		return 0, true
+	case "KWD", "BHD", "OMR":
+		return mills, true
+	default:
+		return 0, false
+	}
+}
+
+const decimalBase = 10
+
+// Money is an amount expressed in minor units of its currency: 12345 RUB is
+// 123.45 roubles, while 12345 JPY is 12345 yen.
+type Money struct {
+	amount   int64
+	currency Currency
+}
+
+func NewMoney(amount int64, currency Currency) (Money, error) {
+	if _, ok := currency.MinorUnits(); !ok {
+		return Money{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, string(currency))
+	}
+	return Money{amount: amount, currency: currency}, nil
+}
+
+// ReconstructMoney rebuilds a stored amount whose currency was validated when
+// it was first written.
+func ReconstructMoney(amount int64, currency Currency) Money {
+	return Money{amount: amount, currency: currency}
+}
+
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+func (m Money) IsPositive() bool {
+	return m.amount > 0
+}
+
+// WithAmount returns an amount of the same currency.
+func (m Money) WithAmount(amount int64) Money {
+	return Money{amount: amount, currency: m.currency}
+}
+
+// CheckSameCurrency returns ErrCurrencyMismatch unless both amounts are in
+// the same currency.
+func (m Money) CheckSameCurrency(other Money) error {
+	if m.currency != other.currency {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, other.currency)
+	}
+	return nil
+}
+
+// String formats the amount in major units, e.g. "123.45 RUB".
+func (m Money) String() string {
+	units, _ := m.currency.MinorUnits()
+	if units == 0 {
+		return fmt.Sprintf("%d %s", m.amount, m.currency)
+	}
+
+	sign, abs := "", m.amount
+	if abs < 0 {
+		sign, abs = "-", -abs
+	}
+	scale := int64(1)
+	for range units {
+		scale *= decimalBase
+	}
+	return fmt.Sprintf("%s%d.%0*d %s", sign, abs/scale, units, abs%scale, m.currency)
+}
@@ -0,0 +1,147 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidRequisites = errors.New("invalid account requisites")
+
+// Field sizes of Russian bank details, as GOST R 56042-2014 payment codes
+// carry them.
+const (
+	maxPayeeNameLen   = 160
+	maxBankNameLen    = 45
+	bankAccountDigits = 20
+	bicDigits         = 9
+	kppDigits         = 9
+	legalINNDigits    = 10
+	personalINNDigits = 12
+)
+
+// AccountRequisites are the bank details a payer outside QR-Pay-Hub pays an
+// account by: the payee and the settlement account behind it at a Russian
+// bank. Payment codes that banking apps read are filled in from them.
+type AccountRequisites struct {
+	accountID   uuid.UUID
+	name        string
+	personalAcc string
+	bankName    string
+	bic         string
+	correspAcc  string
+	payeeINN    string
+	kpp         string
+	updatedAt   time.Time
+}
+
+// NewAccountRequisites validates the requisites of accountID. The payee and
+// bank names, the 20-digit settlement account and the 9-digit BIC are
+// required; the bank's correspondent account, the payee's INN and KPP may be
+// left empty.
+func NewAccountRequisites(
+	accountID uuid.UUID,
+	name, personalAcc, bankName, bic, correspAcc, payeeINN, kpp string,
+) (*AccountRequisites, error) {
+	switch {
+	case name == "" || utf8.RuneCountInString(name) > maxPayeeNameLen:
+		return nil, fmt.Errorf("%w: name must be 1 to %d characters", ErrInvalidRequisites, maxPayeeNameLen)
+	case bankName == "" || utf8.RuneCountInString(bankName) > maxBankNameLen:
+		return nil, fmt.Errorf("%w: bank_name must be 1 to %d characters", ErrInvalidRequisites, maxBankNameLen)
+	case !isDigits(personalAcc, bankAccountDigits):
+		return nil, fmt.Errorf("%w: personal_acc must be %d digits", ErrInvalidRequisites, bankAccountDigits)
+	case !isDigits(bic, bicDigits):
+		return nil, fmt.Errorf("%w: bic must be %d digits", ErrInvalidRequisites, bicDigits)
+	case correspAcc != "" && !isDigits(correspAcc, bankAccountDigits):
+		return nil, fmt.Errorf("%w: corresp_acc must be %d digits", ErrInvalidRequisites, bankAccountDigits)
+	case payeeINN != "" && !isDigits(payeeINN, legalINNDigits) && !isDigits(payeeINN, personalINNDigits):
+		return nil, fmt.Errorf("%w: payee_inn must be %d or %d digits",
+			ErrInvalidRequisites, legalINNDigits, personalINNDigits)
+	case kpp != "" && !isDigits(kpp, kppDigits):
+		return nil, fmt.Errorf("%w: kpp must be %d digits", ErrInvalidRequisites, kppDigits)
+	}
+
+	return &AccountRequisites{
+		accountID:   accountID,
+		name:        name,
+		personalAcc: personalAcc,
+		bankName:    bankName,
+		bic:         bic,
+		correspAcc:  correspAcc,
+		payeeINN:    payeeINN,
+		kpp:         kpp,
+		updatedAt:   time.Now(),
+	}, nil
+}
+
+func ReconstructAccountRequisites(
+	accountID uuid.UUID,
+	name, personalAcc, bankName, bic, correspAcc, payeeINN, kpp string,
+	updatedAt time.Time,
+) *AccountRequisites {
+	return &AccountRequisites{
+		accountID:   accountID,
+		name:        name,
+		personalAcc: personalAcc,
+		bankName:    bankName,
+		bic:         bic,
+		correspAcc:  correspAcc,
+		payeeINN:    payeeINN,
+		kpp:         kpp,
+		updatedAt:   updatedAt,
+	}
+}
+
+func (r *AccountRequisites) AccountID() uuid.UUID {
+	return r.accountID
+}
+
+// Name is the payee as the bank knows them.
+func (r *AccountRequisites) Name() string {
+	return r.name
+}
+
+// PersonalAcc is the payee's settlement account number.
+func (r *AccountRequisites) PersonalAcc() string {
+	return r.personalAcc
+}
+
+func (r *AccountRequisites) BankName() string {
+	return r.bankName
+}
+
+func (r *AccountRequisites) BIC() string {
+	return r.bic
+}
+
+// CorrespAcc is the bank's correspondent account, or empty if it has none.
+func (r *AccountRequisites) CorrespAcc() string {
+	return r.correspAcc
+}
+
+func (r *AccountRequisites) PayeeINN() string {
+	return r.payeeINN
+}
+
+func (r *AccountRequisites) KPP() string {
+	return r.kpp
+}
+
+func (r *AccountRequisites) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+func isDigits(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
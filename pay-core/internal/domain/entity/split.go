@@ -0,0 +1,179 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidSplit = errors.New("invalid split payment")
+
+// MaxSplitLegs bounds the payees of one split payment, all of which stay
+// locked until it commits.
+const MaxSplitLegs = 100
+
+// SplitLeg is the part of a split payment credited to one payee.
+type SplitLeg struct {
+	position      int
+	toAccount     uuid.UUID
+	amount        Money
+	transactionID uuid.UUID
+}
+
+func NewSplitLeg(to uuid.UUID, amount Money) *SplitLeg {
+	return &SplitLeg{toAccount: to, amount: amount}
+}
+
+func ReconstructSplitLeg(position int, to uuid.UUID, amount Money, transactionID uuid.UUID) *SplitLeg {
+	return &SplitLeg{
+		position:      position,
+		toAccount:     to,
+		amount:        amount,
+		transactionID: transactionID,
+	}
+}
+
+// Position is the leg's zero-based index in the request.
+func (l *SplitLeg) Position() int {
+	return l.position
+}
+
+func (l *SplitLeg) ToAccountID() uuid.UUID {
+	return l.toAccount
+}
+
+func (l *SplitLeg) Amount() Money {
+	return l.amount
+}
+
+// TransactionID is the transaction that paid the leg; it is uuid.Nil if the
+// split payment was declined.
+func (l *SplitLeg) TransactionID() uuid.UUID {
+	return l.transactionID
+}
+
+// Pay records the transaction that paid the leg.
+func (l *SplitLeg) Pay(transactionID uuid.UUID) {
+	l.transactionID = transactionID
+}
+
+// SplitPayment debits one payer and credits several payees at once. It is the
+// parent of the transactions that pay its legs, which succeed or fail
+// together.
+type SplitPayment struct {
+	id           uuid.UUID
+	fromAccount  uuid.UUID
+	amount       Money
+	legs         []*SplitLeg
+	status       TransactionStatus
+	reason       FailureReason
+	errorMessage string
+	createdAt    time.Time
+}
+
+// NewSplitPayment starts a split payment of amount over 1 to MaxSplitLegs
+// legs, numbering them in order. The legs must be in the currency of amount,
+// go to distinct payees other than the payer and sum to amount exactly.
+func NewSplitPayment(from uuid.UUID, amount Money, legs []*SplitLeg) (*SplitPayment, error) {
+	if len(legs) == 0 || len(legs) > MaxSplitLegs {
+		return nil, fmt.Errorf("%w: %d legs, expected 1 to %d", ErrInvalidSplit, len(legs), MaxSplitLegs)
+	}
+
+	payees := make(map[uuid.UUID]bool, len(legs))
+	var sum int64
+	for pos, leg := range legs {
+		if leg.amount.Currency() != amount.Currency() {
+			return nil, fmt.Errorf("%w: leg %d is in %s, payment is in %s",
+				ErrCurrencyMismatch, pos, leg.amount.Currency(), amount.Currency())
+		}
+		if leg.toAccount == from {
+			return nil, fmt.Errorf("%w: leg %d pays the payer", ErrInvalidSplit, pos)
+		}
+		if payees[leg.toAccount] {
+			return nil, fmt.Errorf("%w: leg %d repeats payee %s", ErrInvalidSplit, pos, leg.toAccount)
+		}
+		payees[leg.toAccount] = true
+		leg.position = pos
+		sum += leg.amount.Amount()
+	}
+	if sum != amount.Amount() {
+		return nil, fmt.Errorf("%w: legs sum to %d, payment is %d", ErrInvalidSplit, sum, amount.Amount())
+	}
+
+	return &SplitPayment{
+		id:          uuid.New(),
+		fromAccount: from,
+		amount:      amount,
+		legs:        legs,
+		status:      StatusPending,
+		createdAt:   time.Now(),
+	}, nil
+}
+
+func ReconstructSplitPayment(
+	id, from uuid.UUID,
+	amount Money,
+	legs []*SplitLeg,
+	status TransactionStatus,
+	reason FailureReason,
+	errorMessage string,
+	createdAt time.Time,
+) *SplitPayment {
+	return &SplitPayment{
+		id:           id,
+		fromAccount:  from,
+		amount:       amount,
+		legs:         legs,
+		status:       status,
+		reason:       reason,
+		errorMessage: errorMessage,
+		createdAt:    createdAt,
+	}
+}
+
+func (s *SplitPayment) ID() uuid.UUID {
+	return s.id
+}
+
+func (s *SplitPayment) FromAccountID() uuid.UUID {
+	return s.fromAccount
+}
+
+// Amount is the total of the legs, excluding fees.
+func (s *SplitPayment) Amount() Money {
+	return s.amount
+}
+
+func (s *SplitPayment) Legs() []*SplitLeg {
+	return s.legs
+}
+
+func (s *SplitPayment) Status() TransactionStatus {
+	return s.status
+}
+
+func (s *SplitPayment) FailureReason() FailureReason {
+	return s.reason
+}
+
+func (s *SplitPayment) ErrorMessage() string {
+	return s.errorMessage
+}
+
+func (s *SplitPayment) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// Succeed marks the split payment paid once every leg has been.
+func (s *SplitPayment) Succeed() {
+	s.status = StatusSuccess
+}
+
+// Decline records why none of the legs was paid.
+func (s *SplitPayment) Decline(reason FailureReason, message string) {
+	s.status = StatusFailed
+	s.reason = reason
+	s.errorMessage = message
+}
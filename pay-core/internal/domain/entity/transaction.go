@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,38 +15,150 @@ const (
 	StatusFailed  TransactionStatus = "failed"
 )
 
+type FailureReason string
+
+const (
+	FailureNone              FailureReason = ""
+	FailureInsufficientFunds FailureReason = "insufficient_funds"
+	FailureInvalidAmount     FailureReason = "invalid_amount"
+	FailureAccountFrozen     FailureReason = "account_frozen"
+	FailureAccountClosed     FailureReason = "account_closed"
+	FailureLimitExceeded     FailureReason = "limit_exceeded"
+	FailureUnknown           FailureReason = "unknown"
+)
+
+// FailureReasonOf maps a business rule violation to the reason recorded on a
+// declined transaction.
+func FailureReasonOf(err error) FailureReason {
+	switch {
+	case err == nil:
+		return FailureNone
+	case errors.Is(err, ErrInsufficientFunds):
+		return FailureInsufficientFunds
+	case errors.Is(err, ErrNegativeAmount):
+		return FailureInvalidAmount
+	case errors.Is(err, ErrAccountFrozen):
+		return FailureAccountFrozen
+	case errors.Is(err, ErrAccountClosed):
+		return FailureAccountClosed
+	case errors.Is(err, ErrLimitExceeded):
+		return FailureLimitExceeded
+	default:
+		return FailureUnknown
+	}
+}
+
+var (
+	ErrNotRefundable         = errors.New("transaction cannot be refunded")
+	ErrRefundExceedsOriginal = errors.New("refund exceeds the unrefunded amount of the original payment")
+)
+
 type Transaction struct {
 	id          uuid.UUID
 	fromAccount uuid.UUID
 	toAccount   uuid.UUID
 	amount      int64
+	currency    Currency
+	credit      Money
+	fee         int64
 	status      TransactionStatus
+	reason      FailureReason
+	originalID  uuid.UUID
+	quoteID     uuid.UUID
+	splitID     uuid.UUID
 	createdAt   time.Time
+	postings    []*LedgerEntry
 }
 
-func NewTransaction(from, to uuid.UUID, amount int64, status TransactionStatus) *Transaction {
+func NewTransaction(from, to uuid.UUID, amount Money, status TransactionStatus) *Transaction {
 	return &Transaction{
 		id:          uuid.New(),
 		fromAccount: from,
 		toAccount:   to,
-		amount:      amount,
+		amount:      amount.Amount(),
+		currency:    amount.Currency(),
+		credit:      amount,
 		status:      status,
 		createdAt:   time.Now(),
 	}
 }
 
+func NewFailedTransaction(from, to uuid.UUID, amount Money, reason FailureReason) *Transaction {
+	t := NewTransaction(from, to, amount, StatusFailed)
+	t.reason = reason
+	return t
+}
+
+// ChargeFee records the fee applied to a transfer. A fee borne by the payee
+// is netted from what the payee is credited; one borne by the payer is
+// debited on top of the amount.
+func (t *Transaction) ChargeFee(fee Money, bearer FeeBearer) {
+	t.fee = fee.Amount()
+	if bearer == FeePayee {
+		t.credit = t.credit.WithAmount(t.credit.Amount() - fee.Amount())
+	}
+}
+
+// NewConversion creates a transaction that debits the quote's source amount
+// from the payer and credits its target amount, in another currency, to the
+// payee.
+func NewConversion(from, to uuid.UUID, quote *Quote, status TransactionStatus) *Transaction {
+	t := NewTransaction(from, to, quote.Source(), status)
+	t.credit = quote.Target()
+	t.quoteID = quote.ID()
+	return t
+}
+
+func NewFailedConversion(from, to uuid.UUID, quote *Quote, reason FailureReason) *Transaction {
+	t := NewConversion(from, to, quote, StatusFailed)
+	t.reason = reason
+	return t
+}
+
+// NewRefund creates a transaction that moves amount back from the receiver of
+// the original payment to its sender, in the currency of the payment.
+func NewRefund(original *Transaction, amount int64, status TransactionStatus) *Transaction {
+	t := NewTransaction(original.toAccount, original.fromAccount, original.Money().WithAmount(amount), status)
+	t.originalID = original.id
+	return t
+}
+
+// NewSplitLegTransaction creates the transaction that pays one leg of a split
+// payment, recorded under the split payment as its parent.
+func NewSplitLegTransaction(split *SplitPayment, leg *SplitLeg) *Transaction {
+	t := NewTransaction(split.FromAccountID(), leg.ToAccountID(), leg.Amount(), StatusSuccess)
+	t.splitID = split.ID()
+	return t
+}
+
+func NewFailedRefund(original *Transaction, amount int64, reason FailureReason) *Transaction {
+	t := NewRefund(original, amount, StatusFailed)
+	t.reason = reason
+	return t
+}
+
 func ReconstructTransaction(
 	id, from, to uuid.UUID,
-	amount int64,
+	amount, credit Money,
+	fee int64,
 	status TransactionStatus,
+	reason FailureReason,
+	originalID, quoteID, splitID uuid.UUID,
 	createdAt time.Time,
 ) *Transaction {
 	return &Transaction{
 		id:          id,
 		fromAccount: from,
 		toAccount:   to,
-		amount:      amount,
+		amount:      amount.Amount(),
+		currency:    amount.Currency(),
+		credit:      credit,
+		fee:         fee,
 		status:      status,
+		reason:      reason,
+		originalID:  originalID,
+		quoteID:     quoteID,
+		splitID:     splitID,
 		createdAt:   createdAt,
 	}
 }
@@ -66,10 +179,108 @@ func (t *Transaction) Amount() int64 {
 	return t.amount
 }
 
+func (t *Transaction) Currency() Currency {
+	return t.currency
+}
+
+// Money is what the payer is debited.
+func (t *Transaction) Money() Money {
+	return ReconstructMoney(t.amount, t.currency)
+}
+
+// Credited is what the payee is credited: the same as Money unless the
+// transaction is a conversion or the payee bore a fee.
+func (t *Transaction) Credited() Money {
+	return t.credit
+}
+
+// Debited is what the payer is debited: Money plus a fee borne by the payer.
+func (t *Transaction) Debited() Money {
+	if t.IsConversion() {
+		return t.Money()
+	}
+	return t.credit.WithAmount(t.credit.Amount() + t.fee)
+}
+
+// Fee is the transfer fee credited to fee revenue, in the payment currency.
+func (t *Transaction) Fee() Money {
+	return ReconstructMoney(t.fee, t.currency)
+}
+
+// QuoteID is the FX quote a conversion settled at, or uuid.Nil.
+func (t *Transaction) QuoteID() uuid.UUID {
+	return t.quoteID
+}
+
+// SplitID is the split payment the transaction is a leg of, or uuid.Nil.
+func (t *Transaction) SplitID() uuid.UUID {
+	return t.splitID
+}
+
+func (t *Transaction) IsConversion() bool {
+	return t.quoteID != uuid.Nil
+}
+
 func (t *Transaction) Status() TransactionStatus {
 	return t.status
 }
 
+func (t *Transaction) FailureReason() FailureReason {
+	return t.reason
+}
+
+// OriginalID is the payment a refund gives money back for, or uuid.Nil for
+// transactions that are not refunds.
+func (t *Transaction) OriginalID() uuid.UUID {
+	return t.originalID
+}
+
+func (t *Transaction) IsRefund() bool {
+	return t.originalID != uuid.Nil
+}
+
+// CheckRefundable reports whether amount can still be refunded, given the
+// total of successful refunds already made against this payment. Conversions
+// are not refundable: the money would have to be converted back at a new rate.
+// Fees are kept, so at most what the payee was credited can be refunded.
+func (t *Transaction) CheckRefundable(amount, refunded int64) error {
+	if t.status != StatusSuccess || t.IsRefund() || t.IsConversion() {
+		return ErrNotRefundable
+	}
+	if amount > t.credit.Amount()-refunded {
+		return ErrRefundExceedsOriginal
+	}
+	return nil
+}
+
 func (t *Transaction) CreatedAt() time.Time {
 	return t.createdAt
 }
+
+func (t *Transaction) Postings() []*LedgerEntry {
+	return t.postings
+}
+
+func (t *Transaction) Debit(accountID uuid.UUID, amount Money) {
+	t.postings = append(t.postings, NewLedgerEntry(t.id, accountID, amount.WithAmount(-amount.Amount())))
+}
+
+func (t *Transaction) Credit(accountID uuid.UUID, amount Money) {
+	t.postings = append(t.postings, NewLedgerEntry(t.id, accountID, amount))
+}
+
+// CheckBalanced verifies that the postings sum to zero in every currency they
+// touch; money is never created or destroyed, only converted by an explicit
+// pair of postings through an intermediary account.
+func (t *Transaction) CheckBalanced() error {
+	sums := make(map[Currency]int64)
+	for _, p := range t.postings {
+		sums[p.Currency()] += p.Amount()
+	}
+	for _, sum := range sums {
+		if sum != 0 {
+			return ErrUnbalancedPostings
+		}
+	}
+	return nil
+}
@@ -0,0 +1,292 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const webhookSecretBytes = 32
+
+var ErrInvalidWebhookURL = errors.New("webhook url must be an absolute http or https url")
+
+// PublicWebhookAddr reports whether webhooks may be sent to addr. Loopback,
+// private, link-local (such as the 169.254.169.254 metadata service) and other
+// special addresses reach pay-core's own network, not a merchant's.
+func PublicWebhookAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate()
+}
+
+// WebhookEndpoint is where an account is notified of payments it receives.
+// Every delivery is signed with the endpoint's secret, which is only handed
+// out when the endpoint is registered.
+type WebhookEndpoint struct {
+	id        uuid.UUID
+	accountID uuid.UUID
+	url       string
+	secret    string
+	createdAt time.Time
+}
+
+func NewWebhookEndpoint(accountID uuid.UUID, rawURL string) (*WebhookEndpoint, error) {
+	e := &WebhookEndpoint{
+		id:        uuid.New(),
+		accountID: accountID,
+		createdAt: time.Now(),
+	}
+	if err := e.Reregister(rawURL); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func ReconstructWebhookEndpoint(id, accountID uuid.UUID, rawURL, secret string, createdAt time.Time) *WebhookEndpoint {
+	return &WebhookEndpoint{
+		id:        id,
+		accountID: accountID,
+		url:       rawURL,
+		secret:    secret,
+		createdAt: createdAt,
+	}
+}
+
+// Reregister points the endpoint at rawURL with a fresh secret. Pending
+// deliveries go to the new URL, signed with the new secret. URLs naming an
+// address that is not public, or localhost, are rejected; names that resolve
+// to such addresses are caught when the dispatcher dials them.
+func (e *WebhookEndpoint) Reregister(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	addr, addrErr := netip.ParseAddr(host)
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || (addrErr == nil && !PublicWebhookAddr(addr)) {
+		return fmt.Errorf("%w: %s is not a public host", ErrInvalidWebhookURL, u.Hostname())
+	}
+
+	secret := make([]byte, webhookSecretBytes)
+	if _, randErr := rand.Read(secret); randErr != nil {
+		return randErr
+	}
+	e.url = u.String()
+	e.secret = "whsec_" + hex.EncodeToString(secret)
+	return nil
+}
+
+func (e *WebhookEndpoint) ID() uuid.UUID {
+	return e.id
+}
+
+func (e *WebhookEndpoint) AccountID() uuid.UUID {
+	return e.accountID
+}
+
+func (e *WebhookEndpoint) URL() string {
+	return e.url
+}
+
+func (e *WebhookEndpoint) Secret() string {
+	return e.secret
+}
+
+func (e *WebhookEndpoint) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookPending   WebhookDeliveryStatus = "pending"
+	WebhookDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookDead marks a delivery that ran out of attempts; it stays in the
+	// dead-letter queue until resent.
+	WebhookDead WebhookDeliveryStatus = "dead"
+)
+
+// webhookBody is the JSON body posted to webhook endpoints.
+type webhookBody struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// WebhookDelivery is one event to be posted to one endpoint, and the log of
+// how its attempts went so far.
+type WebhookDelivery struct {
+	id             uuid.UUID
+	endpointID     uuid.UUID
+	accountID      uuid.UUID
+	eventID        uuid.UUID
+	eventType      EventType
+	body           []byte
+	status         WebhookDeliveryStatus
+	attempts       int
+	nextAttemptAt  time.Time
+	lastStatusCode int
+	lastError      string
+	createdAt      time.Time
+	deliveredAt    time.Time
+}
+
+// NewWebhookDelivery queues the event for the endpoint, due at once. The body
+// carries the event ID, which stays the same across retries and resends.
+func NewWebhookDelivery(endpoint *WebhookEndpoint, event *Event) (*WebhookDelivery, error) {
+	body, err := json.Marshal(webhookBody{
+		ID:        event.ID().String(),
+		Type:      string(event.Type()),
+		CreatedAt: event.CreatedAt(),
+		Data:      event.Payload(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &WebhookDelivery{
+		id:            uuid.New(),
+		endpointID:    endpoint.ID(),
+		accountID:     endpoint.AccountID(),
+		eventID:       event.ID(),
+		eventType:     event.Type(),
+		body:          body,
+		status:        WebhookPending,
+		nextAttemptAt: now,
+		createdAt:     now,
+	}, nil
+}
+
+func ReconstructWebhookDelivery(
+	id, endpointID, accountID, eventID uuid.UUID,
+	eventType EventType,
+	body []byte,
+	status WebhookDeliveryStatus,
+	attempts int,
+	nextAttemptAt time.Time,
+	lastStatusCode int,
+	lastError string,
+	createdAt, deliveredAt time.Time,
+) *WebhookDelivery {
+	return &WebhookDelivery{
+		id:             id,
+		endpointID:     endpointID,
+		accountID:      accountID,
+		eventID:        eventID,
+		eventType:      eventType,
+		body:           body,
+		status:         status,
+		attempts:       attempts,
+		nextAttemptAt:  nextAttemptAt,
+		lastStatusCode: lastStatusCode,
+		lastError:      lastError,
+		createdAt:      createdAt,
+		deliveredAt:    deliveredAt,
+	}
+}
+
+// Succeed records an attempt the endpoint acknowledged.
+func (d *WebhookDelivery) Succeed(statusCode int, at time.Time) {
+	d.attempts++
+	d.status = WebhookDelivered
+	d.lastStatusCode = statusCode
+	d.lastError = ""
+	d.deliveredAt = at
+}
+
+// Fail records a failed attempt. The delivery is retried at retryAt unless
+// this was attempt maxAttempts, which sends it to the dead-letter queue.
+// statusCode is zero if the endpoint did not respond.
+func (d *WebhookDelivery) Fail(statusCode int, reason string, retryAt time.Time, maxAttempts int) {
+	d.attempts++
+	d.lastStatusCode = statusCode
+	d.lastError = reason
+	if d.attempts >= maxAttempts {
+		d.status = WebhookDead
+		return
+	}
+	d.nextAttemptAt = retryAt
+}
+
+// Lease claims the delivery for an attempt until the given time. Other
+// dispatchers skip it until then, and if the outcome is never recorded it is
+// attempted again once the lease runs out.
+func (d *WebhookDelivery) Lease(until time.Time) {
+	d.nextAttemptAt = until
+}
+
+// Leased reports whether the delivery is still pending under the lease that
+// ends at until, that is, whether nothing has changed it since it was leased.
+func (d *WebhookDelivery) Leased(until time.Time) bool {
+	return d.status == WebhookPending && d.nextAttemptAt.Equal(until)
+}
+
+// Resend queues the delivery again, due at once and with its attempts reset.
+func (d *WebhookDelivery) Resend(at time.Time) {
+	d.status = WebhookPending
+	d.attempts = 0
+	d.nextAttemptAt = at
+}
+
+func (d *WebhookDelivery) ID() uuid.UUID {
+	return d.id
+}
+
+func (d *WebhookDelivery) EndpointID() uuid.UUID {
+	return d.endpointID
+}
+
+func (d *WebhookDelivery) AccountID() uuid.UUID {
+	return d.accountID
+}
+
+func (d *WebhookDelivery) EventID() uuid.UUID {
+	return d.eventID
+}
+
+func (d *WebhookDelivery) EventType() EventType {
+	return d.eventType
+}
+
+// Body is the exact JSON posted to the endpoint, and signed.
+func (d *WebhookDelivery) Body() []byte {
+	return d.body
+}
+
+func (d *WebhookDelivery) Status() WebhookDeliveryStatus {
+	return d.status
+}
+
+func (d *WebhookDelivery) Attempts() int {
+	return d.attempts
+}
+
+func (d *WebhookDelivery) NextAttemptAt() time.Time {
+	return d.nextAttemptAt
+}
+
+func (d *WebhookDelivery) LastStatusCode() int {
+	return d.lastStatusCode
+}
+
+func (d *WebhookDelivery) LastError() string {
+	return d.lastError
+}
+
+func (d *WebhookDelivery) CreatedAt() time.Time {
+	return d.createdAt
+}
+
+// DeliveredAt is zero until the endpoint acknowledged the delivery.
+func (d *WebhookDelivery) DeliveredAt() time.Time {
+	return d.deliveredAt
+}
@@ -3,25 +3,229 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
 )
 
-var ErrNotFound = errors.New("not found")
+var (
+	ErrNotFound              = errors.New("not found")
+	ErrAccountNotFound       = fmt.Errorf("account %w", ErrNotFound)
+	ErrTransactionNotFound   = fmt.Errorf("transaction %w", ErrNotFound)
+	ErrAuthorizationNotFound = fmt.Errorf("authorization %w", ErrNotFound)
+	ErrRateNotFound          = fmt.Errorf("exchange rate %w", ErrNotFound)
+	ErrQuoteNotFound         = fmt.Errorf("quote %w", ErrNotFound)
+	ErrFeeScheduleNotFound   = fmt.Errorf("fee schedule %w", ErrNotFound)
+	ErrLimitsNotFound        = fmt.Errorf("transfer limits %w", ErrNotFound)
+	ErrWebhookNotFound       = fmt.Errorf("webhook endpoint %w", ErrNotFound)
+	ErrDeliveryNotFound      = fmt.Errorf("webhook delivery %w", ErrNotFound)
+	ErrBatchNotFound         = fmt.Errorf("batch %w", ErrNotFound)
+	ErrSplitNotFound         = fmt.Errorf("split payment %w", ErrNotFound)
+	ErrRequisitesNotFound    = fmt.Errorf("account requisites %w", ErrNotFound)
+	ErrIntentNotFound        = fmt.Errorf("payment intent %w", ErrNotFound)
+	ErrEventNotFound         = fmt.Errorf("event %w", ErrNotFound)
+	// ErrConflict marks a transient concurrency failure (deadlock or
+	// serialization failure); the whole unit of work may be retried.
+	ErrConflict = errors.New("concurrent update conflict")
+)
+
+// Cursor is a keyset position in a list ordered by (created_at, id).
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
 
 type AccountRepository interface {
 	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Account, error)
 	UpdateBalance(ctx context.Context, id uuid.UUID, newBalance int64) error
+	UpdateHeld(ctx context.Context, id uuid.UUID, newHeld int64) error
+	// UpdateStatus saves the account's status, with its reason and time.
+	UpdateStatus(ctx context.Context, account *entity.Account) error
+	Create(ctx context.Context, account *entity.Account) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Account, error)
+	List(ctx context.Context, after *Cursor, limit int) ([]*entity.Account, error)
+	// FindSystem returns the house account of the given kind and currency.
+	FindSystem(ctx context.Context, kind entity.AccountKind, currency entity.Currency) (*entity.Account, error)
+	// EnsureSystem opens the house account of the given kind and currency
+	// unless it already exists.
+	EnsureSystem(ctx context.Context, kind entity.AccountKind, currency entity.Currency) error
+}
+
+type Direction int
+
+const (
+	DirectionAny Direction = iota
+	DirectionIncoming
+	DirectionOutgoing
+)
+
+// TransactionFilter selects transactions newest first. Zero values leave the
+// corresponding criterion unrestricted.
+type TransactionFilter struct {
+	AccountID     uuid.UUID
+	Direction     Direction
+	Status        entity.TransactionStatus
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	After         *Cursor
+	Limit         int
 }
 
 type TransactionRepository interface {
 	Create(ctx context.Context, tx *entity.Transaction) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Transaction, error)
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Transaction, error)
+	List(ctx context.Context, filter TransactionFilter) ([]*entity.Transaction, error)
+	// RefundedAmount sums the successful refunds made against a payment.
+	RefundedAmount(ctx context.Context, originalID uuid.UUID) (int64, error)
+	// OutgoingUsage sums the successful transfers sent from an account in
+	// each of the windows, together with its active authorizations, whose
+	// holds count as sent until they are captured or released.
+	OutgoingUsage(ctx context.Context, accountID uuid.UUID, windows entity.UsageWindows) (entity.Usage, error)
+}
+
+type AuthorizationRepository interface {
+	Create(ctx context.Context, auth *entity.Authorization) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Authorization, error)
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Authorization, error)
+	// ListExpiredForUpdate locks up to limit active authorizations that expired
+	// before now, skipping rows already locked by another transaction.
+	ListExpiredForUpdate(ctx context.Context, now time.Time, limit int) ([]*entity.Authorization, error)
+	Update(ctx context.Context, auth *entity.Authorization) error
+}
+
+type RateRepository interface {
+	// Upsert replaces the rate for its currency pair.
+	Upsert(ctx context.Context, rate *entity.Rate) error
+	Find(ctx context.Context, base, quote entity.Currency) (*entity.Rate, error)
+	List(ctx context.Context) ([]*entity.Rate, error)
+}
+
+type QuoteRepository interface {
+	Create(ctx context.Context, quote *entity.Quote) error
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Quote, error)
+	Update(ctx context.Context, quote *entity.Quote) error
+}
+
+type FeeRepository interface {
+	// Upsert replaces the schedule for its transfer type, tier and currency.
+	Upsert(ctx context.Context, schedule *entity.FeeSchedule) error
+	// Find returns the schedule for the tier, falling back to the one for
+	// entity.AnyTier.
+	Find(
+		ctx context.Context,
+		transferType entity.TransferType,
+		tier entity.Tier,
+		currency entity.Currency,
+	) (*entity.FeeSchedule, error)
+	List(ctx context.Context) ([]*entity.FeeSchedule, error)
+}
+
+type LimitRepository interface {
+	// Upsert replaces the limits for their account, or for their tier and
+	// currency.
+	Upsert(ctx context.Context, limits *entity.TransferLimits) error
+	// Find returns the account's own limits, falling back to those of its
+	// tier in currency.
+	Find(
+		ctx context.Context,
+		accountID uuid.UUID,
+		tier entity.Tier,
+		currency entity.Currency,
+	) (*entity.TransferLimits, error)
+	List(ctx context.Context) ([]*entity.TransferLimits, error)
+}
+
+type RequisitesRepository interface {
+	// Upsert replaces the requisites of their account.
+	Upsert(ctx context.Context, requisites *entity.AccountRequisites) error
+	FindByAccountID(ctx context.Context, accountID uuid.UUID) (*entity.AccountRequisites, error)
+}
+
+// OutboxRepository stores events and the queue of events each sink has yet
+// to take.
+type OutboxRepository interface {
+	// Add stores the event and queues it for every registered sink.
+	Add(ctx context.Context, event *entity.Event) error
+	// RegisterSink makes Add queue events for the sink. Registering a sink
+	// again is a no-op.
+	RegisterSink(ctx context.Context, sink string) error
+	// ListPendingForUpdate returns up to limit of the oldest events queued
+	// for the sink whose lease has run out by now, and locks their queue
+	// entries until the unit of work ends. Entries another relay has locked
+	// are skipped.
+	ListPendingForUpdate(ctx context.Context, sink string, now time.Time, limit int) ([]*entity.Event, error)
+	LeasePending(ctx context.Context, sink string, ids []uuid.UUID, until time.Time) error
+	// ReleasePending ends the lease on the queue entries still leased until
+	// lease, so that the next pass takes them again.
+	ReleasePending(ctx context.Context, sink string, ids []uuid.UUID, lease time.Time) error
+	// MarkPublished removes the events from the sink's queue.
+	MarkPublished(ctx context.Context, sink string, ids []uuid.UUID) error
+	// DeletePublishedBefore deletes up to limit of the events created before
+	// the cutoff that no sink has queued any more.
+	DeletePublishedBefore(ctx context.Context, before time.Time, limit int) (int64, error)
+	FindBySequence(ctx context.Context, seq int64) (*entity.Event, error)
+	// ListByAccount returns up to limit of the payment events the account
+	// paid or was paid in that were committed after the event afterSeq, in
+	// commit order.
+	ListByAccount(ctx context.Context, accountID uuid.UUID, afterSeq int64, limit int) ([]*entity.Event, error)
+}
+
+// WebhookDeliveryFilter selects webhook deliveries newest first. Zero values
+// leave the corresponding criterion unrestricted.
+type WebhookDeliveryFilter struct {
+	AccountID uuid.UUID
+	Status    entity.WebhookDeliveryStatus
+	After     *Cursor
+	Limit     int
+}
+
+type WebhookRepository interface {
+	CreateEndpoint(ctx context.Context, endpoint *entity.WebhookEndpoint) error
+	UpdateEndpoint(ctx context.Context, endpoint *entity.WebhookEndpoint) error
+	FindEndpointByAccount(ctx context.Context, accountID uuid.UUID) (*entity.WebhookEndpoint, error)
+	FindEndpointByID(ctx context.Context, id uuid.UUID) (*entity.WebhookEndpoint, error)
+	// AddDelivery queues a delivery unless its event is already queued for
+	// the same endpoint.
+	AddDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+	// ListDueForUpdate locks up to limit pending deliveries due by now,
+	// skipping those another dispatcher has locked.
+	ListDueForUpdate(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error)
+	FindDeliveryForUpdate(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+	ListDeliveries(ctx context.Context, filter WebhookDeliveryFilter) ([]*entity.WebhookDelivery, error)
+}
+
+type BatchRepository interface {
+	// Create saves the batch together with its items.
+	Create(ctx context.Context, batch *entity.Batch) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.Batch, error)
+	// UpdateItem saves the outcome of one item of the batch.
+	UpdateItem(ctx context.Context, batchID uuid.UUID, item *entity.BatchItem) error
+	// UpdateStatus saves the batch's status and completion time.
+	UpdateStatus(ctx context.Context, batch *entity.Batch) error
+}
+
+type SplitRepository interface {
+	// Create saves the split payment together with its legs.
+	Create(ctx context.Context, split *entity.SplitPayment) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.SplitPayment, error)
+}
+
+type IntentRepository interface {
+	Create(ctx context.Context, intent *entity.PaymentIntent) error
+	FindByID(ctx context.Context, id uuid.UUID) (*entity.PaymentIntent, error)
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.PaymentIntent, error)
+	// Update saves the intent's status and, once it is paid, its payment.
+	Update(ctx context.Context, intent *entity.PaymentIntent) error
 }
 
 type IdempotencyRepository interface {
 	Find(ctx context.Context, key string) (*entity.IdempotencyRecord, error)
 	Save(ctx context.Context, record *entity.IdempotencyRecord) error
 	Lock(ctx context.Context, key string) error
+	DeleteCreatedBefore(ctx context.Context, before time.Time, limit int) (int64, error)
 }
@@ -9,5 +9,16 @@ type UnitOfWork interface {
 
 	Accounts() AccountRepository
 	Transactions() TransactionRepository
+	Authorizations() AuthorizationRepository
+	Rates() RateRepository
+	Quotes() QuoteRepository
+	Fees() FeeRepository
+	Limits() LimitRepository
+	Requisites() RequisitesRepository
+	Outbox() OutboxRepository
+	Webhooks() WebhookRepository
+	Batches() BatchRepository
+	Splits() SplitRepository
+	Intents() IntentRepository
 	Idempotency() IdempotencyRepository
 }
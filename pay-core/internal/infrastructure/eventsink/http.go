@@ -0,0 +1,69 @@
+package eventsink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+)
+
+// envelope is the JSON body HTTPSink posts for every event.
+type envelope struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	AggregateID string          `json:"aggregate_id"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Payload     json.RawMessage `json:"payload"`
+}
+
+// HTTPSink posts every event to an endpoint as JSON. Any status other than
+// 2xx fails the publish, and the relay retries the event on its next pass.
+type HTTPSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPSink(url string, timeout time.Duration) *HTTPSink {
+	return &HTTPSink{
+		url:    url,
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *HTTPSink) Publish(ctx context.Context, e *entity.Event) error {
+	body, err := json.Marshal(envelope{
+		ID:          e.ID().String(),
+		Type:        string(e.Type()),
+		AggregateID: e.AggregateID().String(),
+		CreatedAt:   e.CreatedAt(),
+		Payload:     e.Payload(),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// Redeliveries carry the same key, so the endpoint can drop duplicates.
+	req.Header.Set("Idempotency-Key", e.ID().String())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("event sink %s: %s", s.url, resp.Status)
+	}
+	return nil
+}
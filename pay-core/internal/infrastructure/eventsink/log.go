@@ -0,0 +1,31 @@
+// Package eventsink holds the outbox.EventSink implementations the relay can
+// publish to.
+package eventsink
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+)
+
+// LogSink writes every event to the log. It never fails, so it suits
+// development and deployments without consumers yet.
+type LogSink struct {
+	logger *slog.Logger
+}
+
+func NewLogSink(logger *slog.Logger) *LogSink {
+	return &LogSink{logger: logger}
+}
+
+func (s *LogSink) Publish(ctx context.Context, e *entity.Event) error {
+	s.logger.InfoContext(ctx, "event published",
+		"event_id", e.ID(),
+		"event_type", e.Type(),
+		"aggregate_id", e.AggregateID(),
+		"payload", json.RawMessage(e.Payload()),
+	)
+	return nil
+}
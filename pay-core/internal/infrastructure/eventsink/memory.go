@@ -0,0 +1,45 @@
+package eventsink
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+)
+
+// MemorySink keeps published events in memory, for tests.
+type MemorySink struct {
+	mu     sync.Mutex
+	events []*entity.Event
+	err    error
+}
+
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+func (s *MemorySink) Publish(_ context.Context, e *entity.Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	s.events = append(s.events, e)
+	return nil
+}
+
+// FailWith makes Publish return err until it is called again with nil.
+func (s *MemorySink) FailWith(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// Events returns the events published so far, in order.
+func (s *MemorySink) Events() []*entity.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.events)
+}
@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+const authorizationColumns = `id, from_account, to_account, amount, currency, captured_amount, status,
+	capture_transaction_id, expires_at, created_at`
+
+type AuthorizationRepo struct {
+	tx   pgx.Tx
+	pool *pgxpool.Pool
+}
+
+func (r *AuthorizationRepo) Create(ctx context.Context, a *entity.Authorization) error {
+	_, err := r.tx.Exec(ctx,
+		`INSERT INTO authorizations (id, from_account, to_account, amount, currency, status, expires_at, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		a.ID(), a.FromAccount(), a.ToAccount(), a.Amount(), string(a.Currency()),
+		string(a.Status()), a.ExpiresAt(), a.CreatedAt(),
+	)
+	return mapError(err)
+}
+
+func (r *AuthorizationRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Authorization, error) {
+	return r.findOne(ctx, `SELECT `+authorizationColumns+` FROM authorizations WHERE id = $1`, id)
+}
+
+func (r *AuthorizationRepo) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Authorization, error) {
+	return r.findOne(ctx, `SELECT `+authorizationColumns+` FROM authorizations WHERE id = $1 FOR UPDATE`, id)
+}
+
+func (r *AuthorizationRepo) ListExpiredForUpdate(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+) ([]*entity.Authorization, error) {
+	rows, err := r.tx.Query(ctx,
+		`SELECT `+authorizationColumns+` FROM authorizations
+		 WHERE status = 'active' AND expires_at <= $1
+		 ORDER BY expires_at
+		 LIMIT $2
+		 FOR UPDATE SKIP LOCKED`,
+		now, limit,
+	)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer rows.Close()
+
+	var auths []*entity.Authorization
+	for rows.Next() {
+		a, scanErr := scanAuthorization(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		auths = append(auths, a)
+	}
+	return auths, mapError(rows.Err())
+}
+
+func (r *AuthorizationRepo) Update(ctx context.Context, a *entity.Authorization) error {
+	_, err := r.tx.Exec(ctx,
+		`UPDATE authorizations SET status = $1, captured_amount = $2, capture_transaction_id = $3
+		 WHERE id = $4`,
+		string(a.Status()), a.CapturedAmount(), nullableUUID(a.CaptureTransactionID()), a.ID(),
+	)
+	return mapError(err)
+}
+
+func (r *AuthorizationRepo) findOne(ctx context.Context, query string, id uuid.UUID) (*entity.Authorization, error) {
+	a, err := scanAuthorization(r.db().QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrAuthorizationNotFound
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return a, nil
+}
+
+func (r *AuthorizationRepo) db() querier {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.pool
+}
+
+func scanAuthorization(row pgx.Row) (*entity.Authorization, error) {
+	var id, from, to uuid.UUID
+	var captureTxID *uuid.UUID
+	var amount, captured int64
+	var currency, status string
+	var expiresAt, createdAt time.Time
+	err := row.Scan(&id, &from, &to, &amount, &currency, &captured, &status, &captureTxID, &expiresAt, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	return entity.ReconstructAuthorization(
+		id, from, to, entity.ReconstructMoney(amount, entity.Currency(currency)), captured,
+		entity.AuthorizationStatus(status), uuidOrNil(captureTxID),
+		expiresAt, createdAt,
+	), nil
+}
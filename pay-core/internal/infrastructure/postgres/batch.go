@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+type BatchRepo struct {
+	tx   pgx.Tx
+	pool *pgxpool.Pool
+}
+
+// Create inserts the items with a single statement over arrays: there may
+// be up to entity.MaxBatchItems of them.
+func (r *BatchRepo) Create(ctx context.Context, b *entity.Batch) error {
+	_, err := r.tx.Exec(ctx,
+		`INSERT INTO payout_batches (id, mode, status, created_at, completed_at) VALUES ($1, $2, $3, $4, $5)`,
+		b.ID(), string(b.Mode()), string(b.Status()), b.CreatedAt(), nullableTime(b.CompletedAt()),
+	)
+	if err != nil {
+		return mapError(err)
+	}
+
+	n := len(b.Items())
+	var (
+		positions      = make([]int, 0, n)
+		fromAccounts   = make([]uuid.UUID, 0, n)
+		toAccounts     = make([]uuid.UUID, 0, n)
+		amounts        = make([]int64, 0, n)
+		currencies     = make([]string, 0, n)
+		transferTypes  = make([]string, 0, n)
+		statuses       = make([]string, 0, n)
+		transactionIDs = make([]*uuid.UUID, 0, n)
+		reasons        = make([]string, 0, n)
+		messages       = make([]string, 0, n)
+	)
+	for _, item := range b.Items() {
+		positions = append(positions, item.Position())
+		fromAccounts = append(fromAccounts, item.FromAccountID())
+		toAccounts = append(toAccounts, item.ToAccountID())
+		amounts = append(amounts, item.Amount().Amount())
+		currencies = append(currencies, string(item.Amount().Currency()))
+		transferTypes = append(transferTypes, string(item.TransferType()))
+		statuses = append(statuses, string(item.Status()))
+		transactionIDs = append(transactionIDs, nullableUUID(item.TransactionID()))
+		reasons = append(reasons, string(item.FailureReason()))
+		messages = append(messages, item.ErrorMessage())
+	}
+	_, err = r.tx.Exec(ctx,
+		`INSERT INTO payout_batch_items
+		     (batch_id, position, from_account, to_account, amount, currency, transfer_type, status,
+		      transaction_id, failure_reason, error_message)
+		 SELECT $1, u.position, u.from_account, u.to_account, u.amount, u.currency,
+		        u.transfer_type::transfer_type, u.status::batch_item_status,
+		        u.transaction_id, u.failure_reason, u.error_message
+		 FROM unnest($2::int[], $3::uuid[], $4::uuid[], $5::bigint[], $6::text[], $7::text[], $8::text[],
+		             $9::uuid[], $10::text[], $11::text[])
+		     AS u(position, from_account, to_account, amount, currency, transfer_type, status,
+		          transaction_id, failure_reason, error_message)`,
+		b.ID(), positions, fromAccounts, toAccounts, amounts, currencies, transferTypes, statuses,
+		transactionIDs, reasons, messages,
+	)
+	return mapError(err)
+}
+
+func (r *BatchRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Batch, error) {
+	var mode, status string
+	var createdAt time.Time
+	var completedAt *time.Time
+	err := r.db().QueryRow(ctx,
+		`SELECT mode, status, created_at, completed_at FROM payout_batches WHERE id = $1`,
+		id,
+	).Scan(&mode, &status, &createdAt, &completedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrBatchNotFound
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	rows, err := r.db().Query(ctx,
+		`SELECT position, from_account, to_account, amount, currency, transfer_type, status,
+		        transaction_id, failure_reason, error_message
+		 FROM payout_batch_items WHERE batch_id = $1 ORDER BY position`,
+		id,
+	)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	items, err := collectBatchItems(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	var completed time.Time
+	if completedAt != nil {
+		completed = *completedAt
+	}
+	return entity.ReconstructBatch(
+		id, entity.BatchMode(mode), entity.BatchStatus(status), items, createdAt, completed,
+	), nil
+}
+
+func (r *BatchRepo) UpdateItem(ctx context.Context, batchID uuid.UUID, item *entity.BatchItem) error {
+	_, err := r.db().Exec(ctx,
+		`UPDATE payout_batch_items
+		 SET status = $1, transaction_id = $2, failure_reason = $3, error_message = $4
+		 WHERE batch_id = $5 AND position = $6`,
+		string(item.Status()), nullableUUID(item.TransactionID()), string(item.FailureReason()),
+		item.ErrorMessage(), batchID, item.Position(),
+	)
+	return mapError(err)
+}
+
+func (r *BatchRepo) UpdateStatus(ctx context.Context, b *entity.Batch) error {
+	_, err := r.db().Exec(ctx,
+		`UPDATE payout_batches SET status = $1, completed_at = $2 WHERE id = $3`,
+		string(b.Status()), nullableTime(b.CompletedAt()), b.ID(),
+	)
+	return mapError(err)
+}
+
+func (r *BatchRepo) db() querier {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.pool
+}
+
+func collectBatchItems(rows pgx.Rows) ([]*entity.BatchItem, error) {
+	defer rows.Close()
+
+	var items []*entity.BatchItem
+	for rows.Next() {
+		var position int
+		var from, to uuid.UUID
+		var amount int64
+		var currency, transferType, status, failureReason, errorMessage string
+		var transactionID *uuid.UUID
+		err := rows.Scan(
+			&position, &from, &to, &amount, &currency, &transferType, &status,
+			&transactionID, &failureReason, &errorMessage,
+		)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, entity.ReconstructBatchItem(
+			position, from, to, entity.ReconstructMoney(amount, entity.Currency(currency)),
+			entity.TransferType(transferType), entity.BatchItemStatus(status), uuidOrNil(transactionID),
+			entity.FailureReason(failureReason), errorMessage,
+		))
+	}
+	return items, mapError(rows.Err())
+}
@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+const feeScheduleColumns = `transfer_type, tier, currency, fixed_amount, rate_bps, min_amount, max_amount,
+	bearer, updated_at`
+
+type FeeRepo struct {
+	tx   pgx.Tx
+	pool *pgxpool.Pool
+}
+
+func (r *FeeRepo) Upsert(ctx context.Context, s *entity.FeeSchedule) error {
+	_, err := r.db().Exec(ctx,
+		`INSERT INTO fee_schedules
+		     (transfer_type, tier, currency, fixed_amount, rate_bps, min_amount, max_amount, bearer, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		 ON CONFLICT (transfer_type, tier, currency)
+		 DO UPDATE SET fixed_amount = EXCLUDED.fixed_amount, rate_bps = EXCLUDED.rate_bps,
+		               min_amount = EXCLUDED.min_amount, max_amount = EXCLUDED.max_amount,
+		               bearer = EXCLUDED.bearer, updated_at = EXCLUDED.updated_at`,
+		string(s.TransferType()), string(s.Tier()), string(s.Currency()),
+		s.Fixed(), s.RateBps(), s.Min(), s.Max(), string(s.Bearer()), s.UpdatedAt(),
+	)
+	return mapError(err)
+}
+
+// Find prefers the tier's own schedule over the catch-all one, which is
+// stored with an empty tier and so sorts last.
+func (r *FeeRepo) Find(
+	ctx context.Context,
+	transferType entity.TransferType,
+	tier entity.Tier,
+	currency entity.Currency,
+) (*entity.FeeSchedule, error) {
+	s, err := scanFeeSchedule(r.db().QueryRow(ctx,
+		`SELECT `+feeScheduleColumns+` FROM fee_schedules
+		 WHERE transfer_type = $1 AND tier IN ($2, '') AND currency = $3
+		 ORDER BY tier DESC LIMIT 1`,
+		string(transferType), string(tier), string(currency),
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrFeeScheduleNotFound
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return s, nil
+}
+
+func (r *FeeRepo) List(ctx context.Context) ([]*entity.FeeSchedule, error) {
+	rows, err := r.db().Query(ctx,
+		`SELECT `+feeScheduleColumns+` FROM fee_schedules ORDER BY transfer_type, tier, currency`,
+	)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer rows.Close()
+
+	var schedules []*entity.FeeSchedule
+	for rows.Next() {
+		s, scanErr := scanFeeSchedule(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		schedules = append(schedules, s)
+	}
+	return schedules, mapError(rows.Err())
+}
+
+func (r *FeeRepo) db() querier {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.pool
+}
+
+func scanFeeSchedule(row pgx.Row) (*entity.FeeSchedule, error) {
+	var transferType, tier, currency, bearer string
+	var fixed, rateBps, minFee, maxFee int64
+	var updatedAt time.Time
+	err := row.Scan(&transferType, &tier, &currency, &fixed, &rateBps, &minFee, &maxFee, &bearer, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return entity.ReconstructFeeSchedule(
+		entity.TransferType(transferType), entity.Tier(tier), entity.Currency(currency),
+		fixed, rateBps, minFee, maxFee, entity.FeeBearer(bearer), updatedAt,
+	), nil
+}
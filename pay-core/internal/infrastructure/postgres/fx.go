@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+const rateColumns = `base_currency, quote_currency, mid_rate::text, spread_bps, updated_at`
+
+type RateRepo struct {
+	tx   pgx.Tx
+	pool *pgxpool.Pool
+}
+
+func (r *RateRepo) Upsert(ctx context.Context, rate *entity.Rate) error {
+	_, err := r.db().Exec(ctx,
+		`INSERT INTO fx_rates (base_currency, quote_currency, mid_rate, spread_bps, updated_at)
+		 VALUES ($1, $2, $3::numeric, $4, $5)
+		 ON CONFLICT (base_currency, quote_currency)
+		 DO UPDATE SET mid_rate = EXCLUDED.mid_rate, spread_bps = EXCLUDED.spread_bps, updated_at = EXCLUDED.updated_at`,
+		string(rate.Base()), string(rate.Quote()), rate.Mid(), rate.SpreadBps(), rate.UpdatedAt(),
+	)
+	return mapError(err)
+}
+
+func (r *RateRepo) Find(ctx context.Context, base, quote entity.Currency) (*entity.Rate, error) {
+	rate, err := scanRate(r.db().QueryRow(ctx,
+		`SELECT `+rateColumns+` FROM fx_rates WHERE base_currency = $1 AND quote_currency = $2`,
+		string(base), string(quote),
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrRateNotFound
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return rate, nil
+}
+
+func (r *RateRepo) List(ctx context.Context) ([]*entity.Rate, error) {
+	rows, err := r.db().Query(ctx,
+		`SELECT `+rateColumns+` FROM fx_rates ORDER BY base_currency, quote_currency`,
+	)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer rows.Close()
+
+	var rates []*entity.Rate
+	for rows.Next() {
+		rate, scanErr := scanRate(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		rates = append(rates, rate)
+	}
+	return rates, mapError(rows.Err())
+}
+
+func (r *RateRepo) db() querier {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.pool
+}
+
+func scanRate(row pgx.Row) (*entity.Rate, error) {
+	var base, quote, mid string
+	var spreadBps int64
+	var updatedAt time.Time
+	if err := row.Scan(&base, &quote, &mid, &spreadBps, &updatedAt); err != nil {
+		return nil, err
+	}
+	return entity.ReconstructRate(entity.Currency(base), entity.Currency(quote), mid, spreadBps, updatedAt)
+}
+
+const quoteColumns = `id, source_amount, source_currency, target_amount, target_currency, spread_amount,
+	rate::text, transaction_id, expires_at, created_at`
+
+type QuoteRepo struct {
+	tx   pgx.Tx
+	pool *pgxpool.Pool
+}
+
+func (r *QuoteRepo) Create(ctx context.Context, q *entity.Quote) error {
+	_, err := r.db().Exec(ctx,
+		`INSERT INTO fx_quotes
+		     (id, source_amount, source_currency, target_amount, target_currency, spread_amount, rate,
+		      expires_at, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7::numeric, $8, $9)`,
+		q.ID(), q.Source().Amount(), string(q.Source().Currency()),
+		q.Target().Amount(), string(q.Target().Currency()), q.Spread().Amount(),
+		q.Rate(), q.ExpiresAt(), q.CreatedAt(),
+	)
+	return mapError(err)
+}
+
+func (r *QuoteRepo) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Quote, error) {
+	q, err := scanQuote(r.tx.QueryRow(ctx,
+		`SELECT `+quoteColumns+` FROM fx_quotes WHERE id = $1 FOR UPDATE`,
+		id,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrQuoteNotFound
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return q, nil
+}
+
+func (r *QuoteRepo) Update(ctx context.Context, q *entity.Quote) error {
+	_, err := r.tx.Exec(ctx,
+		`UPDATE fx_quotes SET transaction_id = $1 WHERE id = $2`,
+		nullableUUID(q.TransactionID()), q.ID(),
+	)
+	return mapError(err)
+}
+
+func (r *QuoteRepo) db() querier {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.pool
+}
+
+func scanQuote(row pgx.Row) (*entity.Quote, error) {
+	var id uuid.UUID
+	var transactionID *uuid.UUID
+	var source, target, spread int64
+	var sourceCurrency, targetCurrency, rate string
+	var expiresAt, createdAt time.Time
+	err := row.Scan(
+		&id, &source, &sourceCurrency, &target, &targetCurrency, &spread,
+		&rate, &transactionID, &expiresAt, &createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return entity.ReconstructQuote(
+		id,
+		entity.ReconstructMoney(source, entity.Currency(sourceCurrency)),
+		entity.ReconstructMoney(target, entity.Currency(targetCurrency)),
+		entity.ReconstructMoney(spread, entity.Currency(targetCurrency)),
+		rate, uuidOrNil(transactionID), expiresAt, createdAt,
+	), nil
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+const intentColumns = `id, to_account, amount, currency, status, from_account, transaction_id,
+	expires_at, created_at, paid_at`
+
+type IntentRepo struct {
+	tx   pgx.Tx
+	pool *pgxpool.Pool
+}
+
+func (r *IntentRepo) Create(ctx context.Context, i *entity.PaymentIntent) error {
+	_, err := r.db().Exec(ctx,
+		`INSERT INTO payment_intents (id, to_account, amount, currency, status, expires_at, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		i.ID(), i.ToAccount(), i.Amount(), string(i.Currency()), string(i.Status()),
+		i.ExpiresAt(), i.CreatedAt(),
+	)
+	return mapError(err)
+}
+
+func (r *IntentRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.PaymentIntent, error) {
+	return r.findOne(ctx, `SELECT `+intentColumns+` FROM payment_intents WHERE id = $1`, id)
+}
+
+func (r *IntentRepo) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.PaymentIntent, error) {
+	return r.findOne(ctx, `SELECT `+intentColumns+` FROM payment_intents WHERE id = $1 FOR UPDATE`, id)
+}
+
+func (r *IntentRepo) Update(ctx context.Context, i *entity.PaymentIntent) error {
+	_, err := r.tx.Exec(ctx,
+		`UPDATE payment_intents SET status = $1, from_account = $2, transaction_id = $3, paid_at = $4
+		 WHERE id = $5`,
+		string(i.Status()), nullableUUID(i.FromAccount()), nullableUUID(i.TransactionID()),
+		nullableTime(i.PaidAt()), i.ID(),
+	)
+	return mapError(err)
+}
+
+func (r *IntentRepo) findOne(ctx context.Context, query string, id uuid.UUID) (*entity.PaymentIntent, error) {
+	i, err := scanIntent(r.db().QueryRow(ctx, query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrIntentNotFound
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return i, nil
+}
+
+func (r *IntentRepo) db() querier {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.pool
+}
+
+func scanIntent(row pgx.Row) (*entity.PaymentIntent, error) {
+	var id, to uuid.UUID
+	var from, transactionID *uuid.UUID
+	var amount int64
+	var currency, status string
+	var expiresAt, createdAt time.Time
+	var paidAt *time.Time
+	err := row.Scan(&id, &to, &amount, &currency, &status, &from, &transactionID, &expiresAt, &createdAt, &paidAt)
+	if err != nil {
+		return nil, err
+	}
+	var paid time.Time
+	if paidAt != nil {
+		paid = *paidAt
+	}
+	return entity.ReconstructPaymentIntent(
+		id, to, entity.ReconstructMoney(amount, entity.Currency(currency)), entity.IntentStatus(status),
+		uuidOrNil(from), uuidOrNil(transactionID), expiresAt, createdAt, paid,
+	), nil
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+const limitColumns = `account_id, tier, currency, max_single_amount, daily_amount, monthly_amount,
+	hourly_count, updated_at`
+
+type LimitRepo struct {
+	tx   pgx.Tx
+	pool *pgxpool.Pool
+}
+
+func (r *LimitRepo) Upsert(ctx context.Context, l *entity.TransferLimits) error {
+	conflict := `(tier, currency) WHERE tier IS NOT NULL`
+	if l.AccountID() != uuid.Nil {
+		conflict = `(account_id) WHERE account_id IS NOT NULL`
+	}
+	_, err := r.db().Exec(ctx,
+		`INSERT INTO transfer_limits
+		     (account_id, tier, currency, max_single_amount, daily_amount, monthly_amount, hourly_count, updated_at)
+		 VALUES ($1, NULLIF($2, ''), $3, $4, $5, $6, $7, $8)
+		 ON CONFLICT `+conflict+`
+		 DO UPDATE SET currency = EXCLUDED.currency, max_single_amount = EXCLUDED.max_single_amount,
+		               daily_amount = EXCLUDED.daily_amount, monthly_amount = EXCLUDED.monthly_amount,
+		               hourly_count = EXCLUDED.hourly_count, updated_at = EXCLUDED.updated_at`,
+		nullableUUID(l.AccountID()), string(l.Tier()), string(l.Currency()),
+		l.MaxSingle(), l.Daily(), l.Monthly(), l.HourlyCount(), l.UpdatedAt(),
+	)
+	return mapError(err)
+}
+
+// Find prefers the account's own limits, whose tier is NULL and so sorts
+// first, over the tier's.
+func (r *LimitRepo) Find(
+	ctx context.Context,
+	accountID uuid.UUID,
+	tier entity.Tier,
+	currency entity.Currency,
+) (*entity.TransferLimits, error) {
+	l, err := scanLimits(r.db().QueryRow(ctx,
+		`SELECT `+limitColumns+` FROM transfer_limits
+		 WHERE account_id = $1 OR (tier = $2 AND currency = $3)
+		 ORDER BY tier NULLS FIRST LIMIT 1`,
+		accountID, string(tier), string(currency),
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrLimitsNotFound
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return l, nil
+}
+
+func (r *LimitRepo) List(ctx context.Context) ([]*entity.TransferLimits, error) {
+	rows, err := r.db().Query(ctx,
+		`SELECT `+limitColumns+` FROM transfer_limits ORDER BY tier NULLS LAST, currency, account_id`,
+	)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer rows.Close()
+
+	var limits []*entity.TransferLimits
+	for rows.Next() {
+		l, scanErr := scanLimits(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		limits = append(limits, l)
+	}
+	return limits, mapError(rows.Err())
+}
+
+func (r *LimitRepo) db() querier {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.pool
+}
+
+func scanLimits(row pgx.Row) (*entity.TransferLimits, error) {
+	var accountID *uuid.UUID
+	var tier *string
+	var currency string
+	var maxSingle, daily, monthly, hourlyCount int64
+	var updatedAt time.Time
+	err := row.Scan(&accountID, &tier, &currency, &maxSingle, &daily, &monthly, &hourlyCount, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	var t entity.Tier
+	if tier != nil {
+		t = entity.Tier(*tier)
+	}
+	return entity.ReconstructTransferLimits(
+		uuidOrNil(accountID), t, entity.Currency(currency), maxSingle, daily, monthly, hourlyCount, updatedAt,
+	), nil
+}
@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+)
+
+const (
+	paymentEventsChannel = "payment_events"
+	listenRetryDelay     = time.Second
+)
+
+// EventPublisher receives the events an EventListener is notified of.
+type EventPublisher interface {
+	Publish(ctx context.Context, event *entity.Event) error
+	// Resync is called after the listener reconnects, since events committed
+	// while it was disconnected were not announced to it.
+	Resync()
+}
+
+// notification is the payload the outbox_notify trigger sends.
+type notification struct {
+	ID          uuid.UUID       `json:"id"`
+	Seq         int64           `json:"seq"`
+	Type        string          `json:"type"`
+	AggregateID uuid.UUID       `json:"aggregate_id"`
+	Payload     json.RawMessage `json:"payload"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// EventListener follows the events the outbox_notify trigger announces as
+// their transactions commit. It holds a connection of its own outside the
+// pool. Events committed while it reconnects are not announced, so it tells
+// the publisher to resync once it listens again.
+type EventListener struct {
+	pool   *pgxpool.Pool
+	logger *slog.Logger
+}
+
+func NewEventListener(pool *pgxpool.Pool, logger *slog.Logger) *EventListener {
+	return &EventListener{pool: pool, logger: logger}
+}
+
+// Run hands every notified event to sink until ctx is done, reconnecting
+// after connection failures.
+func (l *EventListener) Run(ctx context.Context, sink EventPublisher) {
+	for reconnect := false; ; reconnect = true {
+		err := l.listen(ctx, sink, reconnect)
+		if ctx.Err() != nil {
+			return
+		}
+		l.logger.ErrorContext(ctx, "event listener disconnected", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryDelay):
+		}
+	}
+}
+
+func (l *EventListener) listen(ctx context.Context, sink EventPublisher, reconnect bool) error {
+	conn, err := pgx.ConnectConfig(ctx, l.pool.Config().ConnConfig)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close(context.WithoutCancel(ctx)) }()
+
+	if _, err = conn.Exec(ctx, `LISTEN `+paymentEventsChannel); err != nil {
+		return err
+	}
+	if reconnect {
+		sink.Resync()
+	}
+
+	for {
+		n, waitErr := conn.WaitForNotification(ctx)
+		if waitErr != nil {
+			return waitErr
+		}
+
+		var msg notification
+		if jsonErr := json.Unmarshal([]byte(n.Payload), &msg); jsonErr != nil {
+			l.logger.ErrorContext(ctx, "malformed event notification", "error", jsonErr)
+			continue
+		}
+		event := entity.ReconstructEvent(
+			msg.ID, msg.Seq, entity.EventType(msg.Type), msg.AggregateID, msg.Payload, msg.CreatedAt,
+		)
+		if pubErr := sink.Publish(ctx, event); pubErr != nil {
+			l.logger.ErrorContext(ctx, "event notification not published", "event_id", msg.ID, "error", pubErr)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+const eventColumns = `id, seq, event_type, aggregate_id, payload, created_at`
+
+// OutboxRepo writes events and claims them for a sink only inside a unit of
+// work: an event is written together with what it describes, and the entries
+// a relay claims stay locked until it has leased them. Everything else runs
+// outside one.
+type OutboxRepo struct {
+	tx   pgx.Tx
+	pool *pgxpool.Pool
+}
+
+func (r *OutboxRepo) Add(ctx context.Context, e *entity.Event) error {
+	_, err := r.tx.Exec(ctx,
+		`WITH event AS (
+		     INSERT INTO outbox (id, event_type, aggregate_id, payload, created_at) VALUES ($1, $2, $3, $4, $5)
+		     RETURNING id
+		 )
+		 INSERT INTO outbox_pending (sink, event_id) SELECT s.name, event.id FROM outbox_sinks s, event`,
+		e.ID(), string(e.Type()), e.AggregateID(), e.Payload(), e.CreatedAt(),
+	)
+	return mapError(err)
+}
+
+func (r *OutboxRepo) RegisterSink(ctx context.Context, sink string) error {
+	_, err := r.db().Exec(ctx, `INSERT INTO outbox_sinks (name) VALUES ($1) ON CONFLICT DO NOTHING`, sink)
+	return mapError(err)
+}
+
+func (r *OutboxRepo) ListPendingForUpdate(
+	ctx context.Context,
+	sink string,
+	now time.Time,
+	limit int,
+) ([]*entity.Event, error) {
+	rows, err := r.tx.Query(ctx,
+		`SELECT `+eventColumns+` FROM outbox_pending p JOIN outbox o ON o.id = p.event_id
+		 WHERE p.sink = $1 AND p.leased_until <= $2
+		 ORDER BY seq
+		 LIMIT $3
+		 FOR UPDATE OF p SKIP LOCKED`,
+		sink, now, limit,
+	)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return collectEvents(rows)
+}
+
+func (r *OutboxRepo) LeasePending(ctx context.Context, sink string, ids []uuid.UUID, until time.Time) error {
+	_, err := r.tx.Exec(ctx,
+		`UPDATE outbox_pending SET leased_until = $3 WHERE sink = $1 AND event_id = ANY($2)`,
+		sink, ids, until,
+	)
+	return mapError(err)
+}
+
+func (r *OutboxRepo) ReleasePending(ctx context.Context, sink string, ids []uuid.UUID, lease time.Time) error {
+	_, err := r.db().Exec(ctx,
+		`UPDATE outbox_pending SET leased_until = '-infinity'
+		 WHERE sink = $1 AND event_id = ANY($2) AND leased_until = $3`,
+		sink, ids, lease,
+	)
+	return mapError(err)
+}
+
+func (r *OutboxRepo) MarkPublished(ctx context.Context, sink string, ids []uuid.UUID) error {
+	_, err := r.db().Exec(ctx,
+		`DELETE FROM outbox_pending WHERE sink = $1 AND event_id = ANY($2)`,
+		sink, ids,
+	)
+	return mapError(err)
+}
+
+func (r *OutboxRepo) DeletePublishedBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	tag, err := r.db().Exec(ctx,
+		`DELETE FROM outbox
+		 WHERE id IN (
+		     SELECT id FROM outbox o
+		     WHERE created_at < $1
+		       AND NOT EXISTS (SELECT 1 FROM outbox_pending p WHERE p.event_id = o.id)
+		     ORDER BY created_at
+		     LIMIT $2
+		     FOR UPDATE SKIP LOCKED
+		 )`,
+		before, limit,
+	)
+	if err != nil {
+		return 0, mapError(err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (r *OutboxRepo) FindBySequence(ctx context.Context, seq int64) (*entity.Event, error) {
+	e, err := scanEvent(r.db().QueryRow(ctx,
+		`SELECT `+eventColumns+` FROM outbox WHERE seq = $1`,
+		seq,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrEventNotFound
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return e, nil
+}
+
+func (r *OutboxRepo) ListByAccount(
+	ctx context.Context,
+	accountID uuid.UUID,
+	afterSeq int64,
+	limit int,
+) ([]*entity.Event, error) {
+	rows, err := r.db().Query(ctx,
+		`SELECT `+eventColumns+` FROM outbox
+		 WHERE (payload->>'from_account_id' = $1 OR payload->>'to_account_id' = $1) AND seq > $2
+		 ORDER BY seq
+		 LIMIT $3`,
+		accountID.String(), afterSeq, limit,
+	)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return collectEvents(rows)
+}
+
+func (r *OutboxRepo) db() querier {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.pool
+}
+
+func scanEvent(row pgx.Row) (*entity.Event, error) {
+	var id, aggregateID uuid.UUID
+	var seq int64
+	var eventType string
+	var payload []byte
+	var createdAt time.Time
+	if err := row.Scan(&id, &seq, &eventType, &aggregateID, &payload, &createdAt); err != nil {
+		return nil, err
+	}
+	return entity.ReconstructEvent(id, seq, entity.EventType(eventType), aggregateID, payload, createdAt), nil
+}
+
+func collectEvents(rows pgx.Rows) ([]*entity.Event, error) {
+	defer rows.Close()
+	var events []*entity.Event
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, mapError(rows.Err())
+}
@@ -5,16 +5,25 @@ import (
 	"errors"
 	"hash/fnv"
 	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
 	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
 )
 
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
 type UnitOfWork struct {
 	pool *pgxpool.Pool
 	tx   pgx.Tx
@@ -27,7 +36,7 @@ func NewUnitOfWork(pool *pgxpool.Pool) *UnitOfWork {
 func (u *UnitOfWork) Begin(ctx context.Context) (repository.UnitOfWork, error) {
 	tx, err := u.pool.Begin(ctx)
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 	return &UnitOfWork{pool: u.pool, tx: tx}, nil
 }
@@ -36,7 +45,7 @@ func (u *UnitOfWork) Commit(ctx context.Context) error {
 	if u.tx == nil {
 		return nil
 	}
-	return u.tx.Commit(ctx)
+	return mapError(u.tx.Commit(ctx))
 }
 
 func (u *UnitOfWork) Rollback(ctx context.Context) error {
@@ -51,7 +60,51 @@ func (u *UnitOfWork) Accounts() repository.AccountRepository {
 }
 
 func (u *UnitOfWork) Transactions() repository.TransactionRepository {
-	return &TransactionRepo{tx: u.tx}
+	return &TransactionRepo{tx: u.tx, pool: u.pool}
+}
+
+func (u *UnitOfWork) Authorizations() repository.AuthorizationRepository {
+	return &AuthorizationRepo{tx: u.tx, pool: u.pool}
+}
+
+func (u *UnitOfWork) Rates() repository.RateRepository {
+	return &RateRepo{tx: u.tx, pool: u.pool}
+}
+
+func (u *UnitOfWork) Quotes() repository.QuoteRepository {
+	return &QuoteRepo{tx: u.tx, pool: u.pool}
+}
+
+func (u *UnitOfWork) Fees() repository.FeeRepository {
+	return &FeeRepo{tx: u.tx, pool: u.pool}
+}
+
+func (u *UnitOfWork) Limits() repository.LimitRepository {
+	return &LimitRepo{tx: u.tx, pool: u.pool}
+}
+
+func (u *UnitOfWork) Requisites() repository.RequisitesRepository {
+	return &RequisitesRepo{tx: u.tx, pool: u.pool}
+}
+
+func (u *UnitOfWork) Outbox() repository.OutboxRepository {
+	return &OutboxRepo{tx: u.tx, pool: u.pool}
+}
+
+func (u *UnitOfWork) Webhooks() repository.WebhookRepository {
+	return &WebhookRepo{tx: u.tx, pool: u.pool}
+}
+
+func (u *UnitOfWork) Batches() repository.BatchRepository {
+	return &BatchRepo{tx: u.tx, pool: u.pool}
+}
+
+func (u *UnitOfWork) Splits() repository.SplitRepository {
+	return &SplitRepo{tx: u.tx, pool: u.pool}
+}
+
+func (u *UnitOfWork) Intents() repository.IntentRepository {
+	return &IntentRepo{tx: u.tx, pool: u.pool}
 }
 
 func (u *UnitOfWork) Idempotency() repository.IdempotencyRepository {
@@ -64,15 +117,17 @@ type AccountRepo struct {
 }
 
 func (r *AccountRepo) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Account, error) {
-	var balance int64
-	err := r.tx.QueryRow(ctx,
-		`SELECT balance FROM accounts WHERE id = $1 FOR UPDATE`,
+	a, err := scanAccount(r.tx.QueryRow(ctx,
+		`SELECT `+accountColumns+` FROM accounts WHERE id = $1 FOR UPDATE`,
 		id,
-	).Scan(&balance)
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrAccountNotFound
+	}
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
-	return entity.NewAccount(id, balance), nil
+	return a, nil
 }
 
 func (r *AccountRepo) UpdateBalance(ctx context.Context, id uuid.UUID, newBalance int64) error {
@@ -80,20 +135,340 @@ func (r *AccountRepo) UpdateBalance(ctx context.Context, id uuid.UUID, newBalanc
 		`UPDATE accounts SET balance = $1 WHERE id = $2`,
 		newBalance, id,
 	)
-	return err
+	return mapError(err)
+}
+
+func (r *AccountRepo) UpdateHeld(ctx context.Context, id uuid.UUID, newHeld int64) error {
+	_, err := r.tx.Exec(ctx,
+		`UPDATE accounts SET held = $1 WHERE id = $2`,
+		newHeld, id,
+	)
+	return mapError(err)
+}
+
+func (r *AccountRepo) UpdateStatus(ctx context.Context, a *entity.Account) error {
+	state := a.State()
+	_, err := r.tx.Exec(ctx,
+		`UPDATE accounts SET status = $1, status_reason = $2, status_changed_at = $3 WHERE id = $4`,
+		string(state.Status), state.Reason, state.ChangedAt, a.ID(),
+	)
+	return mapError(err)
+}
+
+func (r *AccountRepo) Create(ctx context.Context, a *entity.Account) error {
+	_, err := r.db().Exec(ctx,
+		`INSERT INTO accounts (id, kind, tier, currency, balance, created_at) VALUES ($1, $2, $3, $4, $5, $6)`,
+		a.ID(), string(a.Kind()), string(a.Tier()), string(a.Currency()), a.Balance(), a.CreatedAt(),
+	)
+	return mapError(err)
+}
+
+func (r *AccountRepo) FindSystem(
+	ctx context.Context,
+	kind entity.AccountKind,
+	currency entity.Currency,
+) (*entity.Account, error) {
+	a, err := scanAccount(r.db().QueryRow(ctx,
+		`SELECT `+accountColumns+` FROM accounts WHERE kind = $1 AND currency = $2`,
+		string(kind), string(currency),
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return a, nil
+}
+
+func (r *AccountRepo) EnsureSystem(ctx context.Context, kind entity.AccountKind, currency entity.Currency) error {
+	a := entity.NewSystemAccount(kind, currency)
+	_, err := r.db().Exec(ctx,
+		`INSERT INTO accounts (id, kind, currency, created_at) VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (kind, currency) WHERE kind != 'customer' DO NOTHING`,
+		a.ID(), string(a.Kind()), string(a.Currency()), a.CreatedAt(),
+	)
+	return mapError(err)
+}
+
+func (r *AccountRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Account, error) {
+	a, err := scanAccount(r.db().QueryRow(ctx,
+		`SELECT `+accountColumns+` FROM accounts WHERE id = $1`,
+		id,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return a, nil
+}
+
+func (r *AccountRepo) List(ctx context.Context, after *repository.Cursor, limit int) ([]*entity.Account, error) {
+	var rows pgx.Rows
+	var err error
+	if after == nil {
+		rows, err = r.db().Query(ctx,
+			`SELECT `+accountColumns+` FROM accounts
+			 ORDER BY created_at, id
+			 LIMIT $1`,
+			limit,
+		)
+	} else {
+		rows, err = r.db().Query(ctx,
+			`SELECT `+accountColumns+` FROM accounts
+			 WHERE (created_at, id) > ($1, $2)
+			 ORDER BY created_at, id
+			 LIMIT $3`,
+			after.CreatedAt, after.ID, limit,
+		)
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer rows.Close()
+
+	var accounts []*entity.Account
+	for rows.Next() {
+		a, scanErr := scanAccount(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		accounts = append(accounts, a)
+	}
+	return accounts, mapError(rows.Err())
+}
+
+func (r *AccountRepo) db() querier {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.pool
+}
+
+const accountColumns = `id, kind, tier, currency, balance, held, status, status_reason, status_changed_at,
+	created_at`
+
+func scanAccount(row pgx.Row) (*entity.Account, error) {
+	var id uuid.UUID
+	var kind, tier, currency, status string
+	var balance, held int64
+	var state entity.AccountState
+	var createdAt time.Time
+	if err := row.Scan(
+		&id, &kind, &tier, &currency, &balance, &held, &status, &state.Reason, &state.ChangedAt, &createdAt,
+	); err != nil {
+		return nil, err
+	}
+	state.Status = entity.AccountStatus(status)
+	return entity.ReconstructAccount(
+		id, entity.AccountKind(kind), entity.Tier(tier),
+		entity.ReconstructMoney(balance, entity.Currency(currency)), held, state, createdAt,
+	), nil
 }
 
 type TransactionRepo struct {
-	tx pgx.Tx
+	tx   pgx.Tx
+	pool *pgxpool.Pool
 }
 
 func (r *TransactionRepo) Create(ctx context.Context, t *entity.Transaction) error {
 	_, err := r.tx.Exec(ctx,
-		`INSERT INTO transactions (id, from_account, to_account, amount, status, created_at)
-		 VALUES ($1, $2, $3, $4, $5, $6)`,
-		t.ID(), t.FromAccount(), t.ToAccount(), t.Amount(), string(t.Status()), t.CreatedAt(),
+		`INSERT INTO transactions
+		     (id, from_account, to_account, amount, currency, credit_amount, credit_currency, fee_amount,
+		      status, failure_reason, original_transaction_id, quote_id, split_id, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, ''), $11, $12, $13, $14)`,
+		t.ID(), t.FromAccount(), t.ToAccount(), t.Amount(), string(t.Currency()),
+		t.Credited().Amount(), string(t.Credited().Currency()), t.Fee().Amount(),
+		string(t.Status()), string(t.FailureReason()),
+		nullableUUID(t.OriginalID()), nullableUUID(t.QuoteID()), nullableUUID(t.SplitID()), t.CreatedAt(),
+	)
+	if err != nil {
+		return mapError(err)
+	}
+
+	for _, p := range t.Postings() {
+		if _, err = r.tx.Exec(ctx,
+			`INSERT INTO ledger_entries (id, transaction_id, account_id, amount, currency, created_at)
+			 VALUES ($1, $2, $3, $4, $5, $6)`,
+			p.ID(), p.TransactionID(), p.AccountID(), p.Amount(), string(p.Currency()), p.CreatedAt(),
+		); err != nil {
+			return mapError(err)
+		}
+	}
+	return nil
+}
+
+const transactionColumns = `id, from_account, to_account, amount, currency, credit_amount, credit_currency,
+	fee_amount, status, COALESCE(failure_reason, ''), original_transaction_id, quote_id, split_id, created_at`
+
+func (r *TransactionRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Transaction, error) {
+	t, err := scanTransaction(r.db().QueryRow(ctx,
+		`SELECT `+transactionColumns+` FROM transactions WHERE id = $1`,
+		id,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrTransactionNotFound
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return t, nil
+}
+
+func (r *TransactionRepo) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Transaction, error) {
+	t, err := scanTransaction(r.tx.QueryRow(ctx,
+		`SELECT `+transactionColumns+` FROM transactions WHERE id = $1 FOR UPDATE`,
+		id,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrTransactionNotFound
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return t, nil
+}
+
+func (r *TransactionRepo) RefundedAmount(ctx context.Context, originalID uuid.UUID) (int64, error) {
+	var refunded int64
+	err := r.db().QueryRow(ctx,
+		`SELECT COALESCE(SUM(amount), 0) FROM transactions
+		 WHERE original_transaction_id = $1 AND status = 'success'`,
+		originalID,
+	).Scan(&refunded)
+	if err != nil {
+		return 0, mapError(err)
+	}
+	return refunded, nil
+}
+
+func (r *TransactionRepo) OutgoingUsage(
+	ctx context.Context,
+	accountID uuid.UUID,
+	w entity.UsageWindows,
+) (entity.Usage, error) {
+	var u entity.Usage
+	err := r.db().QueryRow(ctx,
+		`SELECT COALESCE(SUM(amount) FILTER (WHERE created_at >= $2), 0),
+		        COALESCE(SUM(amount) FILTER (WHERE created_at >= $3), 0),
+		        COUNT(*) FILTER (WHERE created_at >= $4)
+		 FROM (
+		     SELECT amount, created_at FROM transactions
+		     WHERE from_account = $1 AND status = 'success' AND created_at >= LEAST($2, $3, $4)
+		     UNION ALL
+		     SELECT amount, created_at FROM authorizations
+		     WHERE from_account = $1 AND status = 'active' AND created_at >= LEAST($2, $3, $4)
+		 ) AS outgoing`,
+		accountID, w.Day, w.Month, w.Hour,
+	).Scan(&u.Day, &u.Month, &u.LastHourCount)
+	if err != nil {
+		return entity.Usage{}, mapError(err)
+	}
+	return u, nil
+}
+
+func (r *TransactionRepo) List(
+	ctx context.Context,
+	f repository.TransactionFilter,
+) ([]*entity.Transaction, error) {
+	var conds []string
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if f.AccountID != uuid.Nil {
+		switch f.Direction {
+		case repository.DirectionIncoming:
+			conds = append(conds, "to_account = "+arg(f.AccountID))
+		case repository.DirectionOutgoing:
+			conds = append(conds, "from_account = "+arg(f.AccountID))
+		case repository.DirectionAny:
+			p := arg(f.AccountID)
+			conds = append(conds, "(from_account = "+p+" OR to_account = "+p+")")
+		}
+	}
+	if f.Status != "" {
+		conds = append(conds, "status = "+arg(string(f.Status)))
+	}
+	if !f.CreatedAfter.IsZero() {
+		conds = append(conds, "created_at >= "+arg(f.CreatedAfter))
+	}
+	if !f.CreatedBefore.IsZero() {
+		conds = append(conds, "created_at < "+arg(f.CreatedBefore))
+	}
+	if f.After != nil {
+		conds = append(conds, "(created_at, id) < ("+arg(f.After.CreatedAt)+", "+arg(f.After.ID)+")")
+	}
+
+	query := `SELECT ` + transactionColumns + ` FROM transactions`
+	if len(conds) > 0 {
+		query += ` WHERE ` + strings.Join(conds, " AND ")
+	}
+	query += ` ORDER BY created_at DESC, id DESC LIMIT ` + arg(f.Limit)
+
+	rows, err := r.db().Query(ctx, query, args...)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	defer rows.Close()
+
+	var txns []*entity.Transaction
+	for rows.Next() {
+		t, scanErr := scanTransaction(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		txns = append(txns, t)
+	}
+	return txns, mapError(rows.Err())
+}
+
+func (r *TransactionRepo) db() querier {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.pool
+}
+
+func scanTransaction(row pgx.Row) (*entity.Transaction, error) {
+	var id, from, to uuid.UUID
+	var originalID, quoteID, splitID *uuid.UUID
+	var amount, credit, fee int64
+	var currency, creditCurrency, status, reason string
+	var createdAt time.Time
+	err := row.Scan(
+		&id, &from, &to, &amount, &currency, &credit, &creditCurrency, &fee,
+		&status, &reason, &originalID, &quoteID, &splitID, &createdAt,
 	)
-	return err
+	if err != nil {
+		return nil, err
+	}
+	return entity.ReconstructTransaction(
+		id, from, to,
+		entity.ReconstructMoney(amount, entity.Currency(currency)),
+		entity.ReconstructMoney(credit, entity.Currency(creditCurrency)),
+		fee,
+		entity.TransactionStatus(status), entity.FailureReason(reason),
+		uuidOrNil(originalID), uuidOrNil(quoteID), uuidOrNil(splitID), createdAt,
+	), nil
+}
+
+func nullableUUID(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
+
+func uuidOrNil(id *uuid.UUID) uuid.UUID {
+	if id == nil {
+		return uuid.Nil
+	}
+	return *id
 }
 
 type IdempotencyRepo struct {
@@ -107,45 +482,49 @@ func (r *IdempotencyRepo) Find(ctx context.Context, key string) (*entity.Idempot
 		return r.findWithTx(ctx, key)
 	}
 
+	var fingerprint string
 	var code int
 	var body []byte
+	var createdAt time.Time
 	err := q.QueryRow(ctx,
-		`SELECT response_code, response_body FROM idempotency_keys WHERE key = $1`,
+		`SELECT request_fingerprint, response_code, response_body, created_at FROM idempotency_keys WHERE key = $1`,
 		key,
-	).Scan(&code, &body)
+	).Scan(&fingerprint, &code, &body, &createdAt)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, repository.ErrNotFound
 	}
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
-	return entity.ReconstructIdempotencyRecord(key, code, body, time.Time{}), nil
+	return entity.ReconstructIdempotencyRecord(key, fingerprint, code, body, createdAt), nil
 }
 
 func (r *IdempotencyRepo) findWithTx(ctx context.Context, key string) (*entity.IdempotencyRecord, error) {
+	var fingerprint string
 	var code int
 	var body []byte
+	var createdAt time.Time
 	err := r.tx.QueryRow(ctx,
-		`SELECT response_code, response_body FROM idempotency_keys WHERE key = $1`,
+		`SELECT request_fingerprint, response_code, response_body, created_at FROM idempotency_keys WHERE key = $1`,
 		key,
-	).Scan(&code, &body)
+	).Scan(&fingerprint, &code, &body, &createdAt)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, repository.ErrNotFound
 	}
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
-	return entity.ReconstructIdempotencyRecord(key, code, body, time.Time{}), nil
+	return entity.ReconstructIdempotencyRecord(key, fingerprint, code, body, createdAt), nil
 }
 
 func (r *IdempotencyRepo) Save(ctx context.Context, record *entity.IdempotencyRecord) error {
 	_, err := r.tx.Exec(ctx,
-		`INSERT INTO idempotency_keys (key, response_code, response_body, created_at)
-		 VALUES ($1, $2, $3, $4)
+		`INSERT INTO idempotency_keys (key, request_fingerprint, response_code, response_body, created_at)
+		 VALUES ($1, $2, $3, $4, $5)
 		 ON CONFLICT (key) DO NOTHING`,
-		record.Key(), record.ResponseCode(), record.ResponseBody(), record.CreatedAt(),
+		record.Key(), record.Fingerprint(), record.ResponseCode(), record.ResponseBody(), record.CreatedAt(),
 	)
-	return err
+	return mapError(err)
 }
 
 func (r *IdempotencyRepo) Lock(ctx context.Context, key string) error {
@@ -155,5 +534,25 @@ func (r *IdempotencyRepo) Lock(ctx context.Context, key string) error {
 		`SELECT pg_advisory_xact_lock($1)`,
 		int64(h.Sum64()&math.MaxInt64), //nolint:gosec // G115: safe conversion
 	)
-	return err
+	return mapError(err)
+}
+
+// DeleteCreatedBefore removes at most limit keys created before the given time.
+// Rows locked by in-flight requests are skipped and picked up by a later batch.
+func (r *IdempotencyRepo) DeleteCreatedBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	tag, err := r.pool.Exec(ctx,
+		`DELETE FROM idempotency_keys
+		 WHERE key IN (
+		     SELECT key FROM idempotency_keys
+		     WHERE created_at < $1
+		     ORDER BY created_at
+		     LIMIT $2
+		     FOR UPDATE SKIP LOCKED
+		 )`,
+		before, limit,
+	)
+	if err != nil {
+		return 0, mapError(err)
+	}
+	return tag.RowsAffected(), nil
 }
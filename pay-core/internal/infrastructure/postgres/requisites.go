@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+type RequisitesRepo struct {
+	tx   pgx.Tx
+	pool *pgxpool.Pool
+}
+
+func (r *RequisitesRepo) Upsert(ctx context.Context, req *entity.AccountRequisites) error {
+	_, err := r.db().Exec(ctx,
+		`INSERT INTO account_requisites
+		     (account_id, name, personal_acc, bank_name, bic, corresp_acc, payee_inn, kpp, updated_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		 ON CONFLICT (account_id)
+		 DO UPDATE SET name = EXCLUDED.name, personal_acc = EXCLUDED.personal_acc,
+		               bank_name = EXCLUDED.bank_name, bic = EXCLUDED.bic, corresp_acc = EXCLUDED.corresp_acc,
+		               payee_inn = EXCLUDED.payee_inn, kpp = EXCLUDED.kpp, updated_at = EXCLUDED.updated_at`,
+		req.AccountID(), req.Name(), req.PersonalAcc(), req.BankName(), req.BIC(),
+		req.CorrespAcc(), req.PayeeINN(), req.KPP(), req.UpdatedAt(),
+	)
+	return mapError(err)
+}
+
+func (r *RequisitesRepo) FindByAccountID(ctx context.Context, accountID uuid.UUID) (*entity.AccountRequisites, error) {
+	var name, personalAcc, bankName, bic, correspAcc, payeeINN, kpp string
+	var updatedAt time.Time
+	err := r.db().QueryRow(ctx,
+		`SELECT name, personal_acc, bank_name, bic, corresp_acc, payee_inn, kpp, updated_at
+		 FROM account_requisites WHERE account_id = $1`,
+		accountID,
+	).Scan(&name, &personalAcc, &bankName, &bic, &correspAcc, &payeeINN, &kpp, &updatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrRequisitesNotFound
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return entity.ReconstructAccountRequisites(
+		accountID, name, personalAcc, bankName, bic, correspAcc, payeeINN, kpp, updatedAt,
+	), nil
+}
+
+func (r *RequisitesRepo) db() querier {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.pool
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+type SplitRepo struct {
+	tx   pgx.Tx
+	pool *pgxpool.Pool
+}
+
+func (r *SplitRepo) Create(ctx context.Context, s *entity.SplitPayment) error {
+	_, err := r.tx.Exec(ctx,
+		`INSERT INTO split_payments (id, from_account, amount, currency, status, failure_reason, error_message, created_at)
+		 VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8)`,
+		s.ID(), s.FromAccountID(), s.Amount().Amount(), string(s.Amount().Currency()), string(s.Status()),
+		string(s.FailureReason()), s.ErrorMessage(), s.CreatedAt(),
+	)
+	if err != nil {
+		return mapError(err)
+	}
+
+	for _, leg := range s.Legs() {
+		if _, err = r.tx.Exec(ctx,
+			`INSERT INTO split_legs (split_id, position, to_account, amount, transaction_id)
+			 VALUES ($1, $2, $3, $4, $5)`,
+			s.ID(), leg.Position(), leg.ToAccountID(), leg.Amount().Amount(), nullableUUID(leg.TransactionID()),
+		); err != nil {
+			return mapError(err)
+		}
+	}
+	return nil
+}
+
+func (r *SplitRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.SplitPayment, error) {
+	var from uuid.UUID
+	var amount int64
+	var currency, status, reason, errorMessage string
+	var createdAt time.Time
+	err := r.db().QueryRow(ctx,
+		`SELECT from_account, amount, currency, status, COALESCE(failure_reason, ''), error_message, created_at
+		 FROM split_payments WHERE id = $1`,
+		id,
+	).Scan(&from, &amount, &currency, &status, &reason, &errorMessage, &createdAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrSplitNotFound
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+	total := entity.ReconstructMoney(amount, entity.Currency(currency))
+
+	rows, err := r.db().Query(ctx,
+		`SELECT position, to_account, amount, transaction_id
+		 FROM split_legs WHERE split_id = $1 ORDER BY position`,
+		id,
+	)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	legs, err := collectSplitLegs(rows, total.Currency())
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.ReconstructSplitPayment(
+		id, from, total, legs, entity.TransactionStatus(status), entity.FailureReason(reason), errorMessage, createdAt,
+	), nil
+}
+
+func (r *SplitRepo) db() querier {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.pool
+}
+
+func collectSplitLegs(rows pgx.Rows, currency entity.Currency) ([]*entity.SplitLeg, error) {
+	defer rows.Close()
+
+	var legs []*entity.SplitLeg
+	for rows.Next() {
+		var position int
+		var to uuid.UUID
+		var amount int64
+		var transactionID *uuid.UUID
+		if err := rows.Scan(&position, &to, &amount, &transactionID); err != nil {
+			return nil, err
+		}
+		legs = append(legs, entity.ReconstructSplitLeg(
+			position, to, entity.ReconstructMoney(amount, currency), uuidOrNil(transactionID),
+		))
+	}
+	return legs, mapError(rows.Err())
+}
@@ -0,0 +1,212 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+const (
+	webhookEndpointColumns = `id, account_id, url, secret, created_at`
+	webhookDeliveryColumns = `id, endpoint_id, account_id, event_id, event_type, body, status, attempts,
+	next_attempt_at, last_status_code, last_error, created_at, delivered_at`
+)
+
+type WebhookRepo struct {
+	tx   pgx.Tx
+	pool *pgxpool.Pool
+}
+
+func (r *WebhookRepo) CreateEndpoint(ctx context.Context, e *entity.WebhookEndpoint) error {
+	_, err := r.db().Exec(ctx,
+		`INSERT INTO webhook_endpoints (`+webhookEndpointColumns+`) VALUES ($1, $2, $3, $4, $5)`,
+		e.ID(), e.AccountID(), e.URL(), e.Secret(), e.CreatedAt(),
+	)
+	return mapError(err)
+}
+
+func (r *WebhookRepo) UpdateEndpoint(ctx context.Context, e *entity.WebhookEndpoint) error {
+	_, err := r.db().Exec(ctx,
+		`UPDATE webhook_endpoints SET url = $1, secret = $2 WHERE id = $3`,
+		e.URL(), e.Secret(), e.ID(),
+	)
+	return mapError(err)
+}
+
+func (r *WebhookRepo) FindEndpointByAccount(ctx context.Context, accountID uuid.UUID) (*entity.WebhookEndpoint, error) {
+	return r.findEndpoint(ctx, `account_id = $1`, accountID)
+}
+
+func (r *WebhookRepo) FindEndpointByID(ctx context.Context, id uuid.UUID) (*entity.WebhookEndpoint, error) {
+	return r.findEndpoint(ctx, `id = $1`, id)
+}
+
+func (r *WebhookRepo) findEndpoint(ctx context.Context, cond string, arg uuid.UUID) (*entity.WebhookEndpoint, error) {
+	var id, accountID uuid.UUID
+	var url, secret string
+	var createdAt time.Time
+	err := r.db().QueryRow(ctx,
+		`SELECT `+webhookEndpointColumns+` FROM webhook_endpoints WHERE `+cond,
+		arg,
+	).Scan(&id, &accountID, &url, &secret, &createdAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return entity.ReconstructWebhookEndpoint(id, accountID, url, secret, createdAt), nil
+}
+
+func (r *WebhookRepo) AddDelivery(ctx context.Context, d *entity.WebhookDelivery) error {
+	_, err := r.db().Exec(ctx,
+		`INSERT INTO webhook_deliveries
+		     (id, endpoint_id, account_id, event_id, event_type, body, status, next_attempt_at, created_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		 ON CONFLICT (endpoint_id, event_id) DO NOTHING`,
+		d.ID(), d.EndpointID(), d.AccountID(), d.EventID(), string(d.EventType()), d.Body(),
+		string(d.Status()), d.NextAttemptAt(), d.CreatedAt(),
+	)
+	return mapError(err)
+}
+
+func (r *WebhookRepo) ListDueForUpdate(
+	ctx context.Context,
+	now time.Time,
+	limit int,
+) ([]*entity.WebhookDelivery, error) {
+	rows, err := r.tx.Query(ctx,
+		`SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries
+		 WHERE status = 'pending' AND next_attempt_at <= $1
+		 ORDER BY next_attempt_at
+		 LIMIT $2
+		 FOR UPDATE SKIP LOCKED`,
+		now, limit,
+	)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return collectDeliveries(rows)
+}
+
+func (r *WebhookRepo) FindDeliveryForUpdate(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error) {
+	d, err := scanDelivery(r.tx.QueryRow(ctx,
+		`SELECT `+webhookDeliveryColumns+` FROM webhook_deliveries WHERE id = $1 FOR UPDATE`,
+		id,
+	))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, repository.ErrDeliveryNotFound
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return d, nil
+}
+
+func (r *WebhookRepo) UpdateDelivery(ctx context.Context, d *entity.WebhookDelivery) error {
+	_, err := r.tx.Exec(ctx,
+		`UPDATE webhook_deliveries
+		 SET status = $1, attempts = $2, next_attempt_at = $3, last_status_code = $4, last_error = $5,
+		     delivered_at = $6
+		 WHERE id = $7`,
+		string(d.Status()), d.Attempts(), d.NextAttemptAt(), d.LastStatusCode(), d.LastError(),
+		nullableTime(d.DeliveredAt()), d.ID(),
+	)
+	return mapError(err)
+}
+
+func (r *WebhookRepo) ListDeliveries(
+	ctx context.Context,
+	f repository.WebhookDeliveryFilter,
+) ([]*entity.WebhookDelivery, error) {
+	var conds []string
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if f.AccountID != uuid.Nil {
+		conds = append(conds, "account_id = "+arg(f.AccountID))
+	}
+	if f.Status != "" {
+		conds = append(conds, "status = "+arg(string(f.Status)))
+	}
+	if f.After != nil {
+		conds = append(conds, "(created_at, id) < ("+arg(f.After.CreatedAt)+", "+arg(f.After.ID)+")")
+	}
+
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries`
+	if len(conds) > 0 {
+		query += ` WHERE ` + strings.Join(conds, " AND ")
+	}
+	query += ` ORDER BY created_at DESC, id DESC LIMIT ` + arg(f.Limit)
+
+	rows, err := r.db().Query(ctx, query, args...)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return collectDeliveries(rows)
+}
+
+func (r *WebhookRepo) db() querier {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.pool
+}
+
+func collectDeliveries(rows pgx.Rows) ([]*entity.WebhookDelivery, error) {
+	defer rows.Close()
+
+	var deliveries []*entity.WebhookDelivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, mapError(rows.Err())
+}
+
+func scanDelivery(row pgx.Row) (*entity.WebhookDelivery, error) {
+	var id, endpointID, accountID, eventID uuid.UUID
+	var eventType, status, lastError string
+	var body []byte
+	var attempts, lastStatusCode int
+	var nextAttemptAt, createdAt time.Time
+	var deliveredAt *time.Time
+	err := row.Scan(
+		&id, &endpointID, &accountID, &eventID, &eventType, &body, &status, &attempts,
+		&nextAttemptAt, &lastStatusCode, &lastError, &createdAt, &deliveredAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	var delivered time.Time
+	if deliveredAt != nil {
+		delivered = *deliveredAt
+	}
+	return entity.ReconstructWebhookDelivery(
+		id, endpointID, accountID, eventID, entity.EventType(eventType), body,
+		entity.WebhookDeliveryStatus(status), attempts, nextAttemptAt, lastStatusCode, lastError,
+		createdAt, delivered,
+	), nil
+}
+
+func nullableTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
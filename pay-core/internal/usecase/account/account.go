@@ -0,0 +1,141 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/pagetoken"
+)
+
+type ListRequest struct {
+	PageSize  int
+	PageToken string
+}
+
+type ListResponse struct {
+	Accounts      []*entity.Account
+	NextPageToken string
+}
+
+type UseCase struct {
+	uow repository.UnitOfWork
+}
+
+func NewUseCase(uow repository.UnitOfWork) *UseCase {
+	return &UseCase{uow: uow}
+}
+
+// Create opens an empty account in the given currency on the given pricing
+// tier.
+func (uc *UseCase) Create(ctx context.Context, currency entity.Currency, tier entity.Tier) (*entity.Account, error) {
+	balance, err := entity.NewMoney(0, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	account := entity.NewAccount(uuid.New(), balance)
+	account.SetTier(tier)
+	if err := uc.uow.Accounts().Create(ctx, account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+func (uc *UseCase) Get(ctx context.Context, id uuid.UUID) (*entity.Account, error) {
+	return uc.uow.Accounts().FindByID(ctx, id)
+}
+
+// SetRequisites replaces the bank details of a customer account, which
+// payment codes read by banking apps are filled in from.
+func (uc *UseCase) SetRequisites(ctx context.Context, requisites *entity.AccountRequisites) error {
+	acc, err := uc.uow.Accounts().FindByID(ctx, requisites.AccountID())
+	if err != nil {
+		return err
+	}
+	if acc.Kind() != entity.AccountCustomer {
+		return fmt.Errorf("%w: %s", repository.ErrAccountNotFound, acc.ID())
+	}
+	return uc.uow.Requisites().Upsert(ctx, requisites)
+}
+
+func (uc *UseCase) GetRequisites(ctx context.Context, id uuid.UUID) (*entity.AccountRequisites, error) {
+	return uc.uow.Requisites().FindByAccountID(ctx, id)
+}
+
+// Freeze stops a customer account from sending money; it can still receive.
+func (uc *UseCase) Freeze(ctx context.Context, id uuid.UUID, reason string) (*entity.Account, error) {
+	return uc.changeStatus(ctx, id, func(a *entity.Account) error { return a.Freeze(reason) })
+}
+
+func (uc *UseCase) Unfreeze(ctx context.Context, id uuid.UUID, reason string) (*entity.Account, error) {
+	return uc.changeStatus(ctx, id, func(a *entity.Account) error { return a.Unfreeze(reason) })
+}
+
+// Close closes an empty customer account for good.
+func (uc *UseCase) Close(ctx context.Context, id uuid.UUID, reason string) (*entity.Account, error) {
+	return uc.changeStatus(ctx, id, func(a *entity.Account) error { return a.Close(reason) })
+}
+
+// changeStatus applies change to the account under its row lock, so that a
+// transfer in flight either completes before the new status takes effect or
+// sees it, and closing cannot race a credit that would leave money behind.
+func (uc *UseCase) changeStatus(
+	ctx context.Context,
+	id uuid.UUID,
+	change func(*entity.Account) error,
+) (*entity.Account, error) {
+	tx, err := uc.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	account, err := tx.Accounts().FindByIDForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if account.Kind() != entity.AccountCustomer {
+		return nil, fmt.Errorf("%w: %s", repository.ErrAccountNotFound, id)
+	}
+
+	if changeErr := change(account); changeErr != nil {
+		return nil, changeErr
+	}
+	if updErr := tx.Accounts().UpdateStatus(ctx, account); updErr != nil {
+		return nil, updErr
+	}
+
+	if commitErr := tx.Commit(ctx); commitErr != nil {
+		return nil, commitErr
+	}
+	return account, nil
+}
+
+func (uc *UseCase) List(ctx context.Context, req ListRequest) (*ListResponse, error) {
+	var after *repository.Cursor
+	if req.PageToken != "" {
+		cursor, err := pagetoken.Decode(req.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		after = &cursor
+	}
+
+	limit := pagetoken.Limit(req.PageSize)
+	accounts, err := uc.uow.Accounts().List(ctx, after, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ListResponse{Accounts: accounts}
+	if len(accounts) > limit {
+		resp.Accounts = accounts[:limit]
+		last := resp.Accounts[limit-1]
+		resp.NextPageToken = pagetoken.Encode(repository.Cursor{CreatedAt: last.CreatedAt(), ID: last.ID()})
+	}
+	return resp, nil
+}
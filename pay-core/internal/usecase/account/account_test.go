@@ -0,0 +1,187 @@
+package account_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/account"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/pagetoken"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer/mocks"
+)
+
+func TestAccountUseCase_List_Paginates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	uc := account.NewUseCase(uow)
+
+	now := time.Now()
+	active := entity.AccountState{Status: entity.AccountActive, ChangedAt: now}
+	page := []*entity.Account{
+		entity.ReconstructAccount(uuid.New(), entity.AccountCustomer, entity.DefaultTier, rub(100), 0, active, now),
+		entity.ReconstructAccount(uuid.New(), entity.AccountCustomer, entity.DefaultTier, rub(200), 0, active, now.Add(time.Second)),
+		entity.ReconstructAccount(uuid.New(), entity.AccountCustomer, entity.DefaultTier, rub(300), 0, active, now.Add(2*time.Second)),
+	}
+
+	uow.EXPECT().Accounts().Return(accountRepo).Times(2)
+	accountRepo.EXPECT().List(gomock.Any(), (*repository.Cursor)(nil), 3).Return(page, nil)
+
+	first, err := uc.List(context.Background(), account.ListRequest{PageSize: 2})
+	require.NoError(t, err)
+	require.Len(t, first.Accounts, 2)
+	require.NotEmpty(t, first.NextPageToken)
+
+	accountRepo.EXPECT().List(gomock.Any(), gomock.Any(), 3).DoAndReturn(
+		func(_ context.Context, after *repository.Cursor, _ int) ([]*entity.Account, error) {
+			require.NotNil(t, after)
+			assert.Equal(t, page[1].ID(), after.ID)
+			assert.True(t, page[1].CreatedAt().Equal(after.CreatedAt))
+			return page[2:], nil
+		},
+	)
+
+	second, err := uc.List(context.Background(), account.ListRequest{PageSize: 2, PageToken: first.NextPageToken})
+	require.NoError(t, err)
+	require.Len(t, second.Accounts, 1)
+	assert.Empty(t, second.NextPageToken)
+}
+
+func TestAccountUseCase_List_InvalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := account.NewUseCase(mocks.NewMockUnitOfWork(ctrl))
+
+	_, err := uc.List(context.Background(), account.ListRequest{PageToken: "not-a-token"})
+
+	require.ErrorIs(t, err, pagetoken.ErrInvalid)
+}
+
+func TestAccountUseCase_Freeze(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	uc := account.NewUseCase(uow)
+
+	id := uuid.New()
+
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	txUow.EXPECT().Accounts().Return(accountRepo).Times(2)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), id).Return(entity.NewAccount(id, rub(500)), nil)
+	accountRepo.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, a *entity.Account) error {
+			assert.Equal(t, entity.AccountFrozen, a.Status())
+			assert.Equal(t, "chargeback fraud", a.State().Reason)
+			return nil
+		},
+	)
+	txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	acc, err := uc.Freeze(context.Background(), id, "chargeback fraud")
+
+	require.NoError(t, err)
+	require.ErrorIs(t, acc.CheckCanSend(), entity.ErrAccountFrozen)
+	require.NoError(t, acc.CheckCanReceive())
+}
+
+func TestAccountUseCase_Close_RequiresZeroBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	uc := account.NewUseCase(uow)
+
+	id := uuid.New()
+
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	txUow.EXPECT().Accounts().Return(accountRepo)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), id).Return(entity.NewAccount(id, rub(1)), nil)
+
+	_, err := uc.Close(context.Background(), id, "customer request")
+
+	require.ErrorIs(t, err, entity.ErrAccountNotEmpty)
+}
+
+func TestAccountUseCase_SetRequisites(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	requisitesRepo := mocks.NewMockRequisitesRepository(ctrl)
+	uc := account.NewUseCase(uow)
+
+	id := uuid.New()
+	requisites, err := entity.NewAccountRequisites(
+		id, "ООО «Ромашка»", "40702810900000000001", "ПАО СБЕРБАНК", "044525225",
+		"30101810400000000225", "7701234567", "770101001",
+	)
+	require.NoError(t, err)
+
+	uow.EXPECT().Accounts().Return(accountRepo)
+	accountRepo.EXPECT().FindByID(gomock.Any(), id).Return(entity.NewAccount(id, rub(0)), nil)
+	uow.EXPECT().Requisites().Return(requisitesRepo)
+	requisitesRepo.EXPECT().Upsert(gomock.Any(), requisites).Return(nil)
+
+	require.NoError(t, uc.SetRequisites(context.Background(), requisites))
+}
+
+func TestAccountUseCase_SetRequisites_SystemAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	uc := account.NewUseCase(uow)
+
+	revenue := entity.NewSystemAccount(entity.AccountFeeRevenue, "RUB")
+	requisites, err := entity.NewAccountRequisites(
+		revenue.ID(), "QR Pay Hub", "40702810900000000001", "ПАО СБЕРБАНК", "044525225", "", "", "",
+	)
+	require.NoError(t, err)
+
+	uow.EXPECT().Accounts().Return(accountRepo)
+	accountRepo.EXPECT().FindByID(gomock.Any(), revenue.ID()).Return(revenue, nil)
+
+	err = uc.SetRequisites(context.Background(), requisites)
+
+	require.ErrorIs(t, err, repository.ErrAccountNotFound)
+}
+
+func TestNewAccountRequisites_Invalid(t *testing.T) {
+	id := uuid.New()
+	tests := map[string][]string{
+		"short account":     {"Payee", "4070281090000000000", "Bank", "044525225", "", "", ""},
+		"letters in bic":    {"Payee", "40702810900000000001", "Bank", "04452522X", "", "", ""},
+		"no bank name":      {"Payee", "40702810900000000001", "", "044525225", "", "", ""},
+		"eleven-digit inn":  {"Payee", "40702810900000000001", "Bank", "044525225", "", "77012345678", ""},
+		"short corresp acc": {"Payee", "40702810900000000001", "Bank", "044525225", "301018104", "", ""},
+	}
+	for name, f := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := entity.NewAccountRequisites(id, f[0], f[1], f[2], f[3], f[4], f[5], f[6])
+			require.ErrorIs(t, err, entity.ErrInvalidRequisites)
+		})
+	}
+}
+
+func rub(amount int64) entity.Money {
+	return entity.ReconstructMoney(amount, "RUB")
+}
@@ -0,0 +1,128 @@
+package expire
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+type Config struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+type Result struct {
+	Expired  int64
+	Duration time.Duration
+}
+
+// Worker periodically expires authorizations that were neither captured nor
+// voided before their TTL ran out, releasing their holds.
+type Worker struct {
+	uow    repository.UnitOfWork
+	cfg    Config
+	logger *slog.Logger
+}
+
+// NewWorker creates the worker. A BatchSize below one is raised to one, which
+// a batch can come back short of.
+func NewWorker(uow repository.UnitOfWork, cfg Config, logger *slog.Logger) *Worker {
+	cfg.BatchSize = max(cfg.BatchSize, 1)
+	return &Worker{
+		uow:    uow,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		res, err := w.ExpireOnce(ctx)
+		if err != nil {
+			w.logger.ErrorContext(ctx, "hold expiry failed",
+				"error", err, "expired", res.Expired, "duration", res.Duration)
+		} else if res.Expired > 0 {
+			w.logger.InfoContext(ctx, "holds expired", "expired", res.Expired, "duration", res.Duration)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ExpireOnce expires due authorizations in batches of BatchSize, one unit of
+// work per batch, until a batch comes back short.
+func (w *Worker) ExpireOnce(ctx context.Context) (Result, error) {
+	start := time.Now()
+
+	var res Result
+	for {
+		n, err := w.expireBatch(ctx, start)
+		res.Expired += int64(n)
+		if err != nil {
+			res.Duration = time.Since(start)
+			return res, err
+		}
+		if n < w.cfg.BatchSize || ctx.Err() != nil {
+			break
+		}
+	}
+
+	res.Duration = time.Since(start)
+	return res, nil
+}
+
+func (w *Worker) expireBatch(ctx context.Context, now time.Time) (int, error) {
+	tx, err := w.uow.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	auths, err := tx.Authorizations().ListExpiredForUpdate(ctx, now, w.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// Payers are locked in ascending id order, as transfers lock accounts, so
+	// that a batch cannot deadlock with a concurrent capture or another batch.
+	slices.SortFunc(auths, func(a, b *entity.Authorization) int {
+		from, to := a.FromAccount(), b.FromAccount()
+		return bytes.Compare(from[:], to[:])
+	})
+
+	for _, auth := range auths {
+		if expireErr := auth.Expire(); expireErr != nil {
+			return 0, expireErr
+		}
+
+		payer, findErr := tx.Accounts().FindByIDForUpdate(ctx, auth.FromAccount())
+		if findErr != nil {
+			return 0, findErr
+		}
+		payer.Release(auth.Amount())
+		if updErr := tx.Accounts().UpdateHeld(ctx, payer.ID(), payer.Held()); updErr != nil {
+			return 0, updErr
+		}
+
+		if updErr := tx.Authorizations().Update(ctx, auth); updErr != nil {
+			return 0, updErr
+		}
+	}
+
+	if commitErr := tx.Commit(ctx); commitErr != nil {
+		return 0, commitErr
+	}
+	return len(auths), nil
+}
@@ -0,0 +1,83 @@
+package expire_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/expire"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer/mocks"
+)
+
+func TestWorker_ExpireOnce_ReleasesHolds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	authRepo := mocks.NewMockAuthorizationRepository(ctrl)
+
+	worker := expire.NewWorker(uow, expire.Config{
+		Interval:  time.Minute,
+		BatchSize: 10,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	payerID := uuid.New()
+	past := time.Now().Add(-time.Hour)
+	active := entity.AccountState{Status: entity.AccountActive, ChangedAt: past}
+	auth := entity.ReconstructAuthorization(
+		uuid.New(), payerID, uuid.New(), entity.ReconstructMoney(300, entity.DefaultCurrency), 0,
+		entity.AuthorizationActive, uuid.Nil, past, past.Add(-time.Hour),
+	)
+
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	txUow.EXPECT().Authorizations().Return(authRepo).Times(2)
+	txUow.EXPECT().Accounts().Return(accountRepo).Times(2)
+
+	authRepo.EXPECT().ListExpiredForUpdate(gomock.Any(), gomock.Any(), 10).Return([]*entity.Authorization{auth}, nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), payerID).Return(
+		entity.ReconstructAccount(payerID, entity.AccountCustomer, entity.DefaultTier, entity.ReconstructMoney(1000, entity.DefaultCurrency), 500, active, past), nil,
+	)
+	accountRepo.EXPECT().UpdateHeld(gomock.Any(), payerID, int64(200)).Return(nil)
+	authRepo.EXPECT().Update(gomock.Any(), auth).Return(nil)
+	txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	res, err := worker.ExpireOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.Expired)
+	assert.Equal(t, entity.AuthorizationExpired, auth.Status())
+}
+
+func TestWorker_ExpireOnce_RaisesZeroBatchSize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	authRepo := mocks.NewMockAuthorizationRepository(ctrl)
+
+	worker := expire.NewWorker(uow, expire.Config{Interval: time.Minute},
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	txUow.EXPECT().Authorizations().Return(authRepo)
+	authRepo.EXPECT().ListExpiredForUpdate(gomock.Any(), gomock.Any(), 1).Return(nil, nil)
+	txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	res, err := worker.ExpireOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Zero(t, res.Expired)
+}
@@ -0,0 +1,42 @@
+package fee
+
+import (
+	"context"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+type UseCase struct {
+	uow repository.UnitOfWork
+}
+
+func NewUseCase(uow repository.UnitOfWork) *UseCase {
+	return &UseCase{uow: uow}
+}
+
+// SetSchedules replaces the given fee schedules in one unit of work and opens
+// the fee revenue account for each of their currencies, so that a transfer
+// priced by a schedule always has an account to credit the fee to.
+func (uc *UseCase) SetSchedules(ctx context.Context, schedules []*entity.FeeSchedule) error {
+	tx, err := uc.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	for _, s := range schedules {
+		if upsertErr := tx.Fees().Upsert(ctx, s); upsertErr != nil {
+			return upsertErr
+		}
+		if ensureErr := tx.Accounts().EnsureSystem(ctx, entity.AccountFeeRevenue, s.Currency()); ensureErr != nil {
+			return ensureErr
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (uc *UseCase) Schedules(ctx context.Context) ([]*entity.FeeSchedule, error) {
+	return uc.uow.Fees().List(ctx)
+}
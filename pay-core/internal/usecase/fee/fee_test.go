@@ -0,0 +1,41 @@
+package fee_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/fee"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer/mocks"
+)
+
+func TestFeeUseCase_SetSchedules_OpensRevenueAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	feeRepo := mocks.NewMockFeeRepository(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	uc := fee.NewUseCase(uow)
+
+	p2p, err := entity.NewFeeSchedule(entity.TransferP2P, entity.AnyTier, "RUB", 0, 50, 0, 0, entity.FeePayer)
+	require.NoError(t, err)
+	merchant, err := entity.NewFeeSchedule(entity.TransferQRMerchant, "premium", "USD", 10, 0, 0, 0, entity.FeePayee)
+	require.NoError(t, err)
+
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	txUow.EXPECT().Fees().Return(feeRepo).Times(2)
+	feeRepo.EXPECT().Upsert(gomock.Any(), p2p).Return(nil)
+	feeRepo.EXPECT().Upsert(gomock.Any(), merchant).Return(nil)
+	txUow.EXPECT().Accounts().Return(accountRepo).Times(2)
+	accountRepo.EXPECT().EnsureSystem(gomock.Any(), entity.AccountFeeRevenue, entity.Currency("RUB")).Return(nil)
+	accountRepo.EXPECT().EnsureSystem(gomock.Any(), entity.AccountFeeRevenue, entity.Currency("USD")).Return(nil)
+	txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	require.NoError(t, uc.SetSchedules(context.Background(), []*entity.FeeSchedule{p2p, merchant}))
+}
@@ -0,0 +1,117 @@
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+)
+
+type FeedConfig struct {
+	Path     string
+	Interval time.Duration
+}
+
+// feedRate is one entry of the rates file, e.g.
+//
+//	{"base": "USD", "quote": "RUB", "rate": "92.15", "spread_bps": 150}
+type feedRate struct {
+	Base      string `json:"base"`
+	Quote     string `json:"quote"`
+	Rate      string `json:"rate"`
+	SpreadBps int64  `json:"spread_bps"`
+}
+
+// Feed loads the rate table from a local JSON file, at startup and then
+// whenever the file changes. A file with any invalid entry is rejected as a
+// whole and the previously loaded rates stay in effect.
+type Feed struct {
+	uc      *UseCase
+	cfg     FeedConfig
+	logger  *slog.Logger
+	modTime time.Time
+}
+
+func NewFeed(uc *UseCase, cfg FeedConfig, logger *slog.Logger) *Feed {
+	return &Feed{
+		uc:     uc,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+func (f *Feed) Run(ctx context.Context) {
+	ticker := time.NewTicker(f.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		n, err := f.LoadOnce(ctx)
+		if err != nil {
+			f.logger.ErrorContext(ctx, "fx rates load failed", "error", err, "path", f.cfg.Path)
+		} else if n > 0 {
+			f.logger.InfoContext(ctx, "fx rates loaded", "rates", n, "path", f.cfg.Path)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// LoadOnce loads the file if it changed since the last successful load and
+// reports how many rates it set.
+func (f *Feed) LoadOnce(ctx context.Context) (int, error) {
+	info, err := os.Stat(f.cfg.Path)
+	if err != nil {
+		return 0, err
+	}
+	if info.ModTime().Equal(f.modTime) {
+		return 0, nil
+	}
+
+	body, err := os.ReadFile(f.cfg.Path)
+	if err != nil {
+		return 0, err
+	}
+	rates, err := parseFeed(body)
+	if err != nil {
+		return 0, err
+	}
+
+	if setErr := f.uc.SetRates(ctx, rates); setErr != nil {
+		return 0, setErr
+	}
+	f.modTime = info.ModTime()
+	return len(rates), nil
+}
+
+func parseFeed(body []byte) ([]*entity.Rate, error) {
+	var entries []feedRate
+	if err := json.Unmarshal(body, &entries); err != nil {
+		return nil, err
+	}
+
+	rates := make([]*entity.Rate, 0, len(entries))
+	for i, e := range entries {
+		base, err := entity.ParseCurrency(e.Base)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+		quote, err := entity.ParseCurrency(e.Quote)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+		rate, err := entity.NewRate(base, quote, e.Rate, e.SpreadBps)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
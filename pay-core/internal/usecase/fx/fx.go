@@ -0,0 +1,96 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+const DefaultQuoteTTL = 30 * time.Second
+
+type UseCase struct {
+	uow      repository.UnitOfWork
+	quoteTTL time.Duration
+}
+
+type Option func(*UseCase)
+
+// WithQuoteTTL sets how long a quote can be paid with after it is issued.
+func WithQuoteTTL(ttl time.Duration) Option {
+	return func(uc *UseCase) {
+		uc.quoteTTL = ttl
+	}
+}
+
+func NewUseCase(uow repository.UnitOfWork, opts ...Option) *UseCase {
+	uc := &UseCase{
+		uow:      uow,
+		quoteTTL: DefaultQuoteTTL,
+	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+// Quote prices a conversion of amount into the currency to and stores the
+// result so that a payment can settle at it. A rate loaded for the opposite
+// direction is inverted when there is none for this one.
+func (uc *UseCase) Quote(ctx context.Context, amount entity.Money, to entity.Currency) (*entity.Quote, error) {
+	if !amount.IsPositive() {
+		return nil, entity.ErrNegativeAmount
+	}
+
+	rate, err := uc.uow.Rates().Find(ctx, amount.Currency(), to)
+	if errors.Is(err, repository.ErrRateNotFound) {
+		var inverse *entity.Rate
+		inverse, err = uc.uow.Rates().Find(ctx, to, amount.Currency())
+		if err == nil {
+			rate = inverse.Invert()
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := entity.NewQuote(rate, amount, uc.quoteTTL)
+	if err != nil {
+		return nil, err
+	}
+	if createErr := uc.uow.Quotes().Create(ctx, quote); createErr != nil {
+		return nil, createErr
+	}
+	return quote, nil
+}
+
+// SetRates replaces the given rates in one unit of work and opens the house
+// accounts conversions between their currencies book against.
+func (uc *UseCase) SetRates(ctx context.Context, rates []*entity.Rate) error {
+	tx, err := uc.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	for _, rate := range rates {
+		if upsertErr := tx.Rates().Upsert(ctx, rate); upsertErr != nil {
+			return upsertErr
+		}
+		for _, c := range []entity.Currency{rate.Base(), rate.Quote()} {
+			for _, kind := range []entity.AccountKind{entity.AccountFXLiquidity, entity.AccountFXRevenue} {
+				if ensureErr := tx.Accounts().EnsureSystem(ctx, kind, c); ensureErr != nil {
+					return ensureErr
+				}
+			}
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (uc *UseCase) Rates(ctx context.Context) ([]*entity.Rate, error) {
+	return uc.uow.Rates().List(ctx)
+}
@@ -0,0 +1,70 @@
+package fx_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/fx"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer/mocks"
+)
+
+func TestFXUseCase_Quote_InvertsOppositeRate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	rateRepo := mocks.NewMockRateRepository(ctrl)
+	quoteRepo := mocks.NewMockQuoteRepository(ctrl)
+	uc := fx.NewUseCase(uow, fx.WithQuoteTTL(time.Minute))
+
+	usdRub, err := entity.NewRate("USD", "RUB", "90", 100)
+	require.NoError(t, err)
+
+	uow.EXPECT().Rates().Return(rateRepo).Times(2)
+	rateRepo.EXPECT().Find(gomock.Any(), entity.Currency("RUB"), entity.Currency("USD")).
+		Return(nil, repository.ErrRateNotFound)
+	rateRepo.EXPECT().Find(gomock.Any(), entity.Currency("USD"), entity.Currency("RUB")).Return(usdRub, nil)
+
+	uow.EXPECT().Quotes().Return(quoteRepo)
+	quoteRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	quote, err := uc.Quote(context.Background(), entity.ReconstructMoney(900000, "RUB"), "USD")
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.ReconstructMoney(900000, "RUB"), quote.Source())
+	assert.Equal(t, entity.ReconstructMoney(9900, "USD"), quote.Target())
+	assert.Equal(t, entity.ReconstructMoney(100, "USD"), quote.Spread())
+	assert.WithinDuration(t, time.Now().Add(time.Minute), quote.ExpiresAt(), time.Second)
+}
+
+func TestFXUseCase_Quote_ScalesMinorUnits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	rateRepo := mocks.NewMockRateRepository(ctrl)
+	quoteRepo := mocks.NewMockQuoteRepository(ctrl)
+	uc := fx.NewUseCase(uow)
+
+	usdJpy, err := entity.NewRate("USD", "JPY", "150.5", 0)
+	require.NoError(t, err)
+
+	uow.EXPECT().Rates().Return(rateRepo)
+	rateRepo.EXPECT().Find(gomock.Any(), entity.Currency("USD"), entity.Currency("JPY")).Return(usdJpy, nil)
+	uow.EXPECT().Quotes().Return(quoteRepo)
+	quoteRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+
+	// 12.34 USD is 1857.17 yen, rounded down to whole yen.
+	quote, err := uc.Quote(context.Background(), entity.ReconstructMoney(1234, "USD"), "JPY")
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.ReconstructMoney(1857, "JPY"), quote.Target())
+	assert.Equal(t, entity.ReconstructMoney(0, "JPY"), quote.Spread())
+}
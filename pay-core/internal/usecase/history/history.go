@@ -0,0 +1,100 @@
+package history
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/pagetoken"
+)
+
+var ErrInvalidFilter = errors.New("invalid transaction filter")
+
+type ListRequest struct {
+	AccountID     uuid.UUID
+	Direction     repository.Direction
+	Status        entity.TransactionStatus
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	PageSize      int
+	PageToken     string
+}
+
+type ListResponse struct {
+	Transactions  []*entity.Transaction
+	NextPageToken string
+}
+
+type UseCase struct {
+	uow repository.UnitOfWork
+}
+
+func NewUseCase(uow repository.UnitOfWork) *UseCase {
+	return &UseCase{uow: uow}
+}
+
+func (uc *UseCase) Get(ctx context.Context, id uuid.UUID) (*entity.Transaction, error) {
+	return uc.uow.Transactions().FindByID(ctx, id)
+}
+
+// List returns transactions newest first. A page token is only meaningful
+// with the same filter it was issued for.
+func (uc *UseCase) List(ctx context.Context, req ListRequest) (*ListResponse, error) {
+	if req.Direction != repository.DirectionAny && req.AccountID == uuid.Nil {
+		return nil, fmt.Errorf("%w: direction requires an account", ErrInvalidFilter)
+	}
+	if !req.CreatedAfter.IsZero() && !req.CreatedBefore.IsZero() && !req.CreatedAfter.Before(req.CreatedBefore) {
+		return nil, fmt.Errorf("%w: created_after must precede created_before", ErrInvalidFilter)
+	}
+
+	filter := repository.TransactionFilter{
+		AccountID:     req.AccountID,
+		Direction:     req.Direction,
+		Status:        req.Status,
+		CreatedAfter:  req.CreatedAfter,
+		CreatedBefore: req.CreatedBefore,
+	}
+	if req.PageToken != "" {
+		cursor, err := pagetoken.Decode(req.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = &cursor
+	}
+
+	limit := pagetoken.Limit(req.PageSize)
+	filter.Limit = limit + 1
+	txns, err := uc.uow.Transactions().List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ListResponse{Transactions: txns}
+	if len(txns) > limit {
+		resp.Transactions = txns[:limit]
+		last := resp.Transactions[limit-1]
+		resp.NextPageToken = pagetoken.Encode(repository.Cursor{CreatedAt: last.CreatedAt(), ID: last.ID()})
+	}
+	return resp, nil
+}
+
+// EventsAfter returns up to limit of the payment events of the account that
+// were committed after the event afterSeq, in commit order, to replay what a
+// subscriber missed. It fails with repository.ErrEventNotFound if there is no
+// such event, for one because it has been pruned.
+func (uc *UseCase) EventsAfter(
+	ctx context.Context,
+	accountID uuid.UUID,
+	afterSeq int64,
+	limit int,
+) ([]*entity.Event, error) {
+	if _, err := uc.uow.Outbox().FindBySequence(ctx, afterSeq); err != nil {
+		return nil, err
+	}
+	return uc.uow.Outbox().ListByAccount(ctx, accountID, afterSeq, limit)
+}
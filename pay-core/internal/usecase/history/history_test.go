@@ -0,0 +1,103 @@
+package history_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/history"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer/mocks"
+)
+
+func TestHistoryUseCase_List_PaginatesWithFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txRepo := mocks.NewMockTransactionRepository(ctrl)
+	uc := history.NewUseCase(uow)
+
+	accountID := uuid.New()
+	now := time.Now()
+	page := []*entity.Transaction{
+		entity.ReconstructTransaction(uuid.New(), accountID, uuid.New(), rub(100), rub(100), 0, entity.StatusSuccess, entity.FailureNone, uuid.Nil, uuid.Nil, uuid.Nil, now),
+		entity.ReconstructTransaction(uuid.New(), accountID, uuid.New(), rub(200), rub(200), 0, entity.StatusSuccess, entity.FailureNone, uuid.Nil, uuid.Nil, uuid.Nil, now.Add(-time.Second)),
+		entity.ReconstructTransaction(uuid.New(), accountID, uuid.New(), rub(300), rub(300), 0, entity.StatusSuccess, entity.FailureNone, uuid.Nil, uuid.Nil, uuid.Nil, now.Add(-2*time.Second)),
+	}
+
+	req := history.ListRequest{
+		AccountID: accountID,
+		Direction: repository.DirectionOutgoing,
+		Status:    entity.StatusSuccess,
+		PageSize:  2,
+	}
+
+	uow.EXPECT().Transactions().Return(txRepo).Times(2)
+	txRepo.EXPECT().List(gomock.Any(), repository.TransactionFilter{
+		AccountID: accountID,
+		Direction: repository.DirectionOutgoing,
+		Status:    entity.StatusSuccess,
+		Limit:     3,
+	}).Return(page, nil)
+
+	first, err := uc.List(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, first.Transactions, 2)
+	require.NotEmpty(t, first.NextPageToken)
+
+	txRepo.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, f repository.TransactionFilter) ([]*entity.Transaction, error) {
+			require.NotNil(t, f.After)
+			assert.Equal(t, page[1].ID(), f.After.ID)
+			assert.Equal(t, accountID, f.AccountID)
+			assert.Equal(t, repository.DirectionOutgoing, f.Direction)
+			return page[2:], nil
+		},
+	)
+
+	req.PageToken = first.NextPageToken
+	second, err := uc.List(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, second.Transactions, 1)
+	assert.Empty(t, second.NextPageToken)
+}
+
+func TestHistoryUseCase_List_InvalidFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := history.NewUseCase(mocks.NewMockUnitOfWork(ctrl))
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		req  history.ListRequest
+	}{
+		{
+			name: "direction without account",
+			req:  history.ListRequest{Direction: repository.DirectionIncoming},
+		},
+		{
+			name: "inverted time range",
+			req:  history.ListRequest{CreatedAfter: now, CreatedBefore: now.Add(-time.Hour)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.List(context.Background(), tt.req)
+			require.ErrorIs(t, err, history.ErrInvalidFilter)
+		})
+	}
+}
+
+func rub(amount int64) entity.Money {
+	return entity.ReconstructMoney(amount, "RUB")
+}
@@ -0,0 +1,43 @@
+package limit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+type UseCase struct {
+	uow repository.UnitOfWork
+}
+
+func NewUseCase(uow repository.UnitOfWork) *UseCase {
+	return &UseCase{uow: uow}
+}
+
+// Set replaces the limits of one customer account or tier. Limits of an
+// account must be in its currency: they are compared with what it sends.
+func (uc *UseCase) Set(ctx context.Context, limits *entity.TransferLimits) error {
+	if limits.AccountID() != uuid.Nil {
+		acc, err := uc.uow.Accounts().FindByID(ctx, limits.AccountID())
+		if err != nil {
+			return err
+		}
+		if acc.Kind() != entity.AccountCustomer {
+			return fmt.Errorf("%w: %s", repository.ErrAccountNotFound, acc.ID())
+		}
+		if acc.Currency() != limits.Currency() {
+			return fmt.Errorf("%w: account is in %s, limits in %s",
+				entity.ErrCurrencyMismatch, acc.Currency(), limits.Currency())
+		}
+	}
+
+	return uc.uow.Limits().Upsert(ctx, limits)
+}
+
+func (uc *UseCase) List(ctx context.Context) ([]*entity.TransferLimits, error) {
+	return uc.uow.Limits().List(ctx)
+}
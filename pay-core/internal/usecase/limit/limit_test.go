@@ -0,0 +1,52 @@
+package limit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/limit"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer/mocks"
+)
+
+func TestLimitUseCase_Set_RejectsOtherCurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	uc := limit.NewUseCase(uow)
+
+	accountID := uuid.New()
+	limits, err := entity.NewTransferLimits(accountID, "", "USD", 0, 100000, 0, 0)
+	require.NoError(t, err)
+
+	uow.EXPECT().Accounts().Return(accountRepo)
+	accountRepo.EXPECT().FindByID(gomock.Any(), accountID).
+		Return(entity.NewAccount(accountID, entity.ReconstructMoney(0, "RUB")), nil)
+
+	err = uc.Set(context.Background(), limits)
+
+	require.ErrorIs(t, err, entity.ErrCurrencyMismatch)
+}
+
+func TestLimitUseCase_Set_TierLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	limitRepo := mocks.NewMockLimitRepository(ctrl)
+	uc := limit.NewUseCase(uow)
+
+	limits, err := entity.NewTransferLimits(uuid.Nil, entity.DefaultTier, "RUB", 5000000, 10000000, 0, 10)
+	require.NoError(t, err)
+
+	uow.EXPECT().Limits().Return(limitRepo)
+	limitRepo.EXPECT().Upsert(gomock.Any(), limits).Return(nil)
+
+	require.NoError(t, uc.Set(context.Background(), limits))
+}
@@ -0,0 +1,85 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+type PruneConfig struct {
+	Retention time.Duration
+	Interval  time.Duration
+	BatchSize int
+}
+
+type PruneResult struct {
+	Pruned   int64
+	Duration time.Duration
+}
+
+// Pruner periodically deletes events older than the retention window that
+// every sink has taken. An event a sink has yet to take is kept however old it
+// is. A pruned event can no longer be replayed to an event stream, so a
+// subscriber that resumes after it has to resync.
+type Pruner struct {
+	uow    repository.UnitOfWork
+	cfg    PruneConfig
+	logger *slog.Logger
+}
+
+// NewPruner creates the pruner. A BatchSize below one is raised to one, which
+// a batch can come back short of.
+func NewPruner(uow repository.UnitOfWork, cfg PruneConfig, logger *slog.Logger) *Pruner {
+	cfg.BatchSize = max(cfg.BatchSize, 1)
+	return &Pruner{
+		uow:    uow,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+func (p *Pruner) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		res, err := p.PruneOnce(ctx)
+		if err != nil {
+			p.logger.ErrorContext(ctx, "outbox prune failed",
+				"error", err, "pruned", res.Pruned, "duration", res.Duration)
+		} else {
+			p.logger.InfoContext(ctx, "outbox events pruned", "pruned", res.Pruned, "duration", res.Duration)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PruneOnce deletes published events in batches of BatchSize until a batch
+// comes back short, so that no single statement holds locks on many rows.
+func (p *Pruner) PruneOnce(ctx context.Context) (PruneResult, error) {
+	start := time.Now()
+	cutoff := start.Add(-p.cfg.Retention)
+
+	var res PruneResult
+	for {
+		n, err := p.uow.Outbox().DeletePublishedBefore(ctx, cutoff, p.cfg.BatchSize)
+		res.Pruned += n
+		if err != nil {
+			res.Duration = time.Since(start)
+			return res, err
+		}
+		if n < int64(p.cfg.BatchSize) || ctx.Err() != nil {
+			break
+		}
+	}
+
+	res.Duration = time.Since(start)
+	return res, nil
+}
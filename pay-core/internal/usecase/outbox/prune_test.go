@@ -0,0 +1,67 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/outbox"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer/mocks"
+)
+
+func TestPruner_PruneOnce_DeletesInBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+
+	pruner := outbox.NewPruner(uow, outbox.PruneConfig{
+		Retention: 24 * time.Hour,
+		Interval:  time.Minute,
+		BatchSize: 100,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	before := time.Now().Add(-24 * time.Hour)
+	cutoff := gomock.Cond(func(x any) bool {
+		ts, ok := x.(time.Time)
+		return ok && !ts.Before(before) && ts.Before(time.Now().Add(-23*time.Hour))
+	})
+
+	uow.EXPECT().Outbox().Return(outboxRepo).Times(2)
+	gomock.InOrder(
+		outboxRepo.EXPECT().DeletePublishedBefore(gomock.Any(), cutoff, 100).Return(int64(100), nil),
+		outboxRepo.EXPECT().DeletePublishedBefore(gomock.Any(), cutoff, 100).Return(int64(7), nil),
+	)
+
+	res, err := pruner.PruneOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(107), res.Pruned)
+}
+
+func TestPruner_PruneOnce_StopsOnError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+
+	pruner := outbox.NewPruner(uow, outbox.PruneConfig{Retention: time.Hour, Interval: time.Minute},
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	uow.EXPECT().Outbox().Return(outboxRepo)
+	outboxRepo.EXPECT().DeletePublishedBefore(gomock.Any(), gomock.Any(), 1).Return(int64(0), errors.New("db down"))
+
+	res, err := pruner.PruneOnce(context.Background())
+
+	require.Error(t, err)
+	assert.Zero(t, res.Pruned)
+}
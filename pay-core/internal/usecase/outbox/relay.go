@@ -0,0 +1,187 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+// EventSink delivers events to downstream services. Publish may be called
+// more than once for the same event, so consumers must deduplicate by its ID.
+type EventSink interface {
+	Publish(ctx context.Context, event *entity.Event) error
+}
+
+// leaseSlack is how much longer than its publishes may take a batch of events
+// is leased for.
+const leaseSlack = 30 * time.Second
+
+type Config struct {
+	Interval  time.Duration
+	BatchSize int
+	// Timeout bounds one Publish. A batch is leased for as long as its
+	// publishes may take, so that a relay that dies mid-batch holds its
+	// events back no longer than that.
+	Timeout time.Duration
+}
+
+type Result struct {
+	Published int64
+	Duration  time.Duration
+}
+
+// Relay periodically publishes the events transfers write to the outbox to
+// one sink. Each sink has its own relay and its own queue of pending events,
+// so a sink that is down does not hold back the others. A relay leases a
+// batch from the queue and commits before it publishes, so relays for the
+// same sink share the queue without waiting on each other, and no transaction
+// stays open while the sink responds. An event leaves the queue only after
+// the sink accepted it: it is delivered at least once, and again once its
+// lease runs out if the relay dies between publishing and removing it.
+type Relay struct {
+	uow    repository.UnitOfWork
+	name   string
+	sink   EventSink
+	cfg    Config
+	logger *slog.Logger
+}
+
+// NewRelay creates a relay that publishes to sink under name. A sink receives
+// the events written after a relay first registered it, so a renamed sink
+// starts from the events that follow. A BatchSize below one is raised to one,
+// which a batch can come back short of.
+func NewRelay(uow repository.UnitOfWork, name string, sink EventSink, cfg Config, logger *slog.Logger) *Relay {
+	cfg.BatchSize = max(cfg.BatchSize, 1)
+	return &Relay{
+		uow:    uow,
+		name:   name,
+		sink:   sink,
+		cfg:    cfg,
+		logger: logger.With("sink", name),
+	}
+}
+
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		res, err := r.RelayOnce(ctx)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "outbox relay failed",
+				"error", err, "published", res.Published, "duration", res.Duration)
+		} else if res.Published > 0 {
+			r.logger.InfoContext(ctx, "outbox events published", "published", res.Published, "duration", res.Duration)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RelayOnce registers the sink and publishes pending events in batches of
+// BatchSize until a batch comes back short.
+func (r *Relay) RelayOnce(ctx context.Context) (Result, error) {
+	start := time.Now()
+
+	var res Result
+	if err := r.uow.Outbox().RegisterSink(ctx, r.name); err != nil {
+		res.Duration = time.Since(start)
+		return res, err
+	}
+	for {
+		n, err := r.relayBatch(ctx)
+		res.Published += int64(n)
+		if err != nil {
+			res.Duration = time.Since(start)
+			return res, err
+		}
+		if n < r.cfg.BatchSize || ctx.Err() != nil {
+			break
+		}
+	}
+
+	res.Duration = time.Since(start)
+	return res, nil
+}
+
+// relayBatch publishes a batch in order and stops at the first event the sink
+// rejects. The events published before it leave the queue and the rest are
+// released, so that a sink that is down only holds back the events it has not
+// taken yet, and retries them on the next pass.
+func (r *Relay) relayBatch(ctx context.Context) (int, error) {
+	events, lease, err := r.claim(ctx)
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+
+	published := make([]uuid.UUID, 0, len(events))
+	var publishErr error
+	for _, e := range events {
+		if publishErr = r.publish(ctx, e); publishErr != nil {
+			break
+		}
+		published = append(published, e.ID())
+	}
+
+	if len(published) > 0 {
+		if err = r.uow.Outbox().MarkPublished(ctx, r.name, published); err != nil {
+			return 0, err
+		}
+	}
+	if publishErr != nil {
+		rest := eventIDs(events[len(published):])
+		return len(published), errors.Join(publishErr, r.uow.Outbox().ReleasePending(ctx, r.name, rest, lease))
+	}
+	return len(published), nil
+}
+
+// claim leases the next batch to this relay in a unit of work of its own, so
+// that other relays of the sink skip it while it is being published.
+func (r *Relay) claim(ctx context.Context) ([]*entity.Event, time.Time, error) {
+	tx, err := r.uow.Begin(ctx)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	now := time.Now()
+	events, err := tx.Outbox().ListPendingForUpdate(ctx, r.name, now, r.cfg.BatchSize)
+	if err != nil || len(events) == 0 {
+		return nil, time.Time{}, err
+	}
+
+	// Postgres keeps microseconds, so the lease is rounded to compare equal
+	// when the rest of the batch is released.
+	lease := now.Add(time.Duration(len(events))*r.cfg.Timeout + leaseSlack).Truncate(time.Microsecond)
+	if err = tx.Outbox().LeasePending(ctx, r.name, eventIDs(events), lease); err != nil {
+		return nil, time.Time{}, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, time.Time{}, err
+	}
+	return events, lease, nil
+}
+
+func (r *Relay) publish(ctx context.Context, e *entity.Event) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.Timeout)
+	defer cancel()
+	return r.sink.Publish(ctx, e)
+}
+
+func eventIDs(events []*entity.Event) []uuid.UUID {
+	ids := make([]uuid.UUID, len(events))
+	for i, e := range events {
+		ids[i] = e.ID()
+	}
+	return ids
+}
@@ -0,0 +1,224 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/infrastructure/eventsink"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/outbox"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer/mocks"
+)
+
+func TestRelay_RelayOnce_PublishesAndMarks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+	sink := eventsink.NewMemorySink()
+
+	relay := outbox.NewRelay(uow, "events", sink, relayConfig(10), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	events := []*entity.Event{newEvent(t), newEvent(t)}
+	ids := []uuid.UUID{events[0].ID(), events[1].ID()}
+
+	uow.EXPECT().Outbox().Return(outboxRepo).Times(2)
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	txUow.EXPECT().Outbox().Return(outboxRepo).Times(2)
+	outboxRepo.EXPECT().RegisterSink(gomock.Any(), "events").Return(nil)
+	outboxRepo.EXPECT().ListPendingForUpdate(gomock.Any(), "events", gomock.Any(), 10).Return(events, nil)
+	outboxRepo.EXPECT().LeasePending(gomock.Any(), "events", ids, gomock.Any()).Return(nil)
+	txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+	outboxRepo.EXPECT().MarkPublished(gomock.Any(), "events", ids).Return(nil)
+
+	res, err := relay.RelayOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.Published)
+	assert.Equal(t, events, sink.Events())
+}
+
+func TestRelay_RelayOnce_CommitsLeaseBeforePublishing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+
+	committed := false
+	sink := publishFunc(func(context.Context, *entity.Event) error {
+		assert.True(t, committed, "published inside the unit of work")
+		return nil
+	})
+	relay := outbox.NewRelay(uow, "events", sink, relayConfig(10), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	events := []*entity.Event{newEvent(t), newEvent(t)}
+	start := time.Now()
+	lease := gomock.Cond(func(x any) bool {
+		until, ok := x.(time.Time)
+		return ok && !until.Before(start.Add(2*time.Second+30*time.Second).Truncate(time.Microsecond)) &&
+			until.Before(time.Now().Add(2*time.Second+30*time.Second))
+	})
+
+	uow.EXPECT().Outbox().Return(outboxRepo).Times(2)
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	txUow.EXPECT().Outbox().Return(outboxRepo).Times(2)
+	outboxRepo.EXPECT().RegisterSink(gomock.Any(), "events").Return(nil)
+	outboxRepo.EXPECT().ListPendingForUpdate(gomock.Any(), "events", gomock.Any(), 10).Return(events, nil)
+	outboxRepo.EXPECT().LeasePending(gomock.Any(), "events", gomock.Any(), lease).Return(nil)
+	txUow.EXPECT().Commit(gomock.Any()).DoAndReturn(func(context.Context) error {
+		committed = true
+		return nil
+	})
+	outboxRepo.EXPECT().MarkPublished(gomock.Any(), "events", gomock.Any()).Return(nil)
+
+	res, err := relay.RelayOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), res.Published)
+}
+
+func TestRelay_RelayOnce_ReleasesRejectedEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+	sinkDown := errors.New("sink unavailable")
+	published := 0
+	sink := publishFunc(func(context.Context, *entity.Event) error {
+		if published == 1 {
+			return sinkDown
+		}
+		published++
+		return nil
+	})
+
+	relay := outbox.NewRelay(uow, "events", sink, relayConfig(10), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	events := []*entity.Event{newEvent(t), newEvent(t), newEvent(t)}
+	var lease time.Time
+
+	uow.EXPECT().Outbox().Return(outboxRepo).Times(3)
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	txUow.EXPECT().Outbox().Return(outboxRepo).Times(2)
+	outboxRepo.EXPECT().RegisterSink(gomock.Any(), "events").Return(nil)
+	outboxRepo.EXPECT().ListPendingForUpdate(gomock.Any(), "events", gomock.Any(), 10).Return(events, nil)
+	outboxRepo.EXPECT().LeasePending(gomock.Any(), "events", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ []uuid.UUID, until time.Time) error {
+			lease = until
+			return nil
+		})
+	txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+	outboxRepo.EXPECT().MarkPublished(gomock.Any(), "events", []uuid.UUID{events[0].ID()}).Return(nil)
+	outboxRepo.EXPECT().ReleasePending(gomock.Any(), "events", []uuid.UUID{events[1].ID(), events[2].ID()}, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ []uuid.UUID, until time.Time) error {
+			assert.Equal(t, lease, until)
+			return nil
+		})
+
+	res, err := relay.RelayOnce(context.Background())
+
+	require.ErrorIs(t, err, sinkDown)
+	assert.Equal(t, int64(1), res.Published)
+}
+
+func TestRelay_RelayOnce_SinkDownDoesNotHoldBackOthers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+	webhooks := eventsink.NewMemorySink()
+	events := eventsink.NewMemorySink()
+	sinkDown := errors.New("sink unavailable")
+	events.FailWith(sinkDown)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	webhookRelay := outbox.NewRelay(uow, "webhooks", webhooks, relayConfig(10), logger)
+	eventRelay := outbox.NewRelay(uow, "events", events, relayConfig(10), logger)
+
+	event := newEvent(t)
+
+	uow.EXPECT().Outbox().Return(outboxRepo).Times(4)
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil).Times(2)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil).Times(2)
+	txUow.EXPECT().Outbox().Return(outboxRepo).Times(4)
+	txUow.EXPECT().Commit(gomock.Any()).Return(nil).Times(2)
+	for _, sink := range []string{"events", "webhooks"} {
+		outboxRepo.EXPECT().RegisterSink(gomock.Any(), sink).Return(nil)
+		outboxRepo.EXPECT().ListPendingForUpdate(gomock.Any(), sink, gomock.Any(), 10).
+			Return([]*entity.Event{event}, nil)
+		outboxRepo.EXPECT().LeasePending(gomock.Any(), sink, []uuid.UUID{event.ID()}, gomock.Any()).Return(nil)
+	}
+	outboxRepo.EXPECT().ReleasePending(gomock.Any(), "events", []uuid.UUID{event.ID()}, gomock.Any()).Return(nil)
+	outboxRepo.EXPECT().MarkPublished(gomock.Any(), "webhooks", []uuid.UUID{event.ID()}).Return(nil)
+
+	_, err := eventRelay.RelayOnce(context.Background())
+	require.ErrorIs(t, err, sinkDown)
+
+	res, err := webhookRelay.RelayOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.Published)
+	assert.Equal(t, []*entity.Event{event}, webhooks.Events())
+}
+
+func TestRelay_RelayOnce_RaisesZeroBatchSize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+
+	relay := outbox.NewRelay(uow, "events", eventsink.NewMemorySink(), relayConfig(0),
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	uow.EXPECT().Outbox().Return(outboxRepo)
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	txUow.EXPECT().Outbox().Return(outboxRepo)
+	outboxRepo.EXPECT().RegisterSink(gomock.Any(), "events").Return(nil)
+	outboxRepo.EXPECT().ListPendingForUpdate(gomock.Any(), "events", gomock.Any(), 1).Return(nil, nil)
+
+	res, err := relay.RelayOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Zero(t, res.Published)
+}
+
+type publishFunc func(ctx context.Context, event *entity.Event) error
+
+func (f publishFunc) Publish(ctx context.Context, event *entity.Event) error {
+	return f(ctx, event)
+}
+
+func relayConfig(batchSize int) outbox.Config {
+	return outbox.Config{Interval: time.Minute, BatchSize: batchSize, Timeout: time.Second}
+}
+
+func newEvent(t *testing.T) *entity.Event {
+	t.Helper()
+	from, to := uuid.New(), uuid.New()
+	txn := entity.NewTransaction(from, to, entity.ReconstructMoney(1000, "RUB"), entity.StatusSuccess)
+	e, err := entity.NewPaymentEvent(txn)
+	require.NoError(t, err)
+	return e
+}
@@ -0,0 +1,52 @@
+package pagetoken
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
+
+var ErrInvalid = errors.New("invalid page token")
+
+type token struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uuid.UUID `json:"id"`
+}
+
+// Encode turns a keyset position into an opaque token. Clients must pass it
+// back unchanged; its format is not part of the API.
+func Encode(c repository.Cursor) string {
+	body, _ := json.Marshal(token{CreatedAt: c.CreatedAt, ID: c.ID}) //nolint:errchkjson // plain struct
+	return base64.RawURLEncoding.EncodeToString(body)
+}
+
+func Decode(s string) (repository.Cursor, error) {
+	body, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return repository.Cursor{}, ErrInvalid
+	}
+	var t token
+	if jsonErr := json.Unmarshal(body, &t); jsonErr != nil || t.ID == uuid.Nil {
+		return repository.Cursor{}, ErrInvalid
+	}
+	return repository.Cursor{CreatedAt: t.CreatedAt, ID: t.ID}, nil
+}
+
+// Limit clamps a requested page size to [1, MaxPageSize], using
+// DefaultPageSize when none was requested.
+func Limit(pageSize int) int {
+	if pageSize <= 0 {
+		return DefaultPageSize
+	}
+	return min(pageSize, MaxPageSize)
+}
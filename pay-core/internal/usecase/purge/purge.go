@@ -0,0 +1,85 @@
+package purge
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+type Config struct {
+	Retention time.Duration
+	Interval  time.Duration
+	BatchSize int
+}
+
+type Result struct {
+	Purged   int64
+	Duration time.Duration
+}
+
+// Worker periodically deletes idempotency keys older than the retention
+// window. A key is honoured for at least Retention; once it has been purged a
+// request carrying it is no longer recognised as a replay and is executed as a
+// new payment.
+type Worker struct {
+	uow    repository.UnitOfWork
+	cfg    Config
+	logger *slog.Logger
+}
+
+// NewWorker creates the worker. A BatchSize below one is raised to one, which
+// a batch can come back short of.
+func NewWorker(uow repository.UnitOfWork, cfg Config, logger *slog.Logger) *Worker {
+	cfg.BatchSize = max(cfg.BatchSize, 1)
+	return &Worker{
+		uow:    uow,
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		res, err := w.PurgeOnce(ctx)
+		if err != nil {
+			w.logger.ErrorContext(ctx, "idempotency purge failed",
+				"error", err, "purged", res.Purged, "duration", res.Duration)
+		} else {
+			w.logger.InfoContext(ctx, "idempotency keys purged", "purged", res.Purged, "duration", res.Duration)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PurgeOnce deletes expired keys in batches of BatchSize until a batch comes
+// back short, so that no single statement holds locks on many rows.
+func (w *Worker) PurgeOnce(ctx context.Context) (Result, error) {
+	start := time.Now()
+	cutoff := start.Add(-w.cfg.Retention)
+
+	var res Result
+	for {
+		n, err := w.uow.Idempotency().DeleteCreatedBefore(ctx, cutoff, w.cfg.BatchSize)
+		res.Purged += n
+		if err != nil {
+			res.Duration = time.Since(start)
+			return res, err
+		}
+		if n < int64(w.cfg.BatchSize) || ctx.Err() != nil {
+			break
+		}
+	}
+
+	res.Duration = time.Since(start)
+	return res, nil
+}
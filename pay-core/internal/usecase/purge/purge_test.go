@@ -0,0 +1,95 @@
+package purge_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/purge"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer/mocks"
+)
+
+func TestWorker_PurgeOnce_DeletesInBatches(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+
+	worker := purge.NewWorker(uow, purge.Config{
+		Retention: 24 * time.Hour,
+		Interval:  time.Minute,
+		BatchSize: 100,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	before := time.Now().Add(-24 * time.Hour)
+	cutoff := gomock.Cond(func(x any) bool {
+		ts, ok := x.(time.Time)
+		return ok && !ts.Before(before) && ts.Before(time.Now().Add(-23*time.Hour))
+	})
+
+	uow.EXPECT().Idempotency().Return(idempotencyRepo).Times(3)
+	gomock.InOrder(
+		idempotencyRepo.EXPECT().DeleteCreatedBefore(gomock.Any(), cutoff, 100).Return(int64(100), nil),
+		idempotencyRepo.EXPECT().DeleteCreatedBefore(gomock.Any(), cutoff, 100).Return(int64(100), nil),
+		idempotencyRepo.EXPECT().DeleteCreatedBefore(gomock.Any(), cutoff, 100).Return(int64(42), nil),
+	)
+
+	res, err := worker.PurgeOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(242), res.Purged)
+}
+
+func TestWorker_PurgeOnce_StopsOnError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+
+	worker := purge.NewWorker(uow, purge.Config{
+		Retention: time.Hour,
+		Interval:  time.Minute,
+		BatchSize: 10,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	uow.EXPECT().Idempotency().Return(idempotencyRepo).Times(2)
+	gomock.InOrder(
+		idempotencyRepo.EXPECT().DeleteCreatedBefore(gomock.Any(), gomock.Any(), 10).Return(int64(10), nil),
+		idempotencyRepo.EXPECT().DeleteCreatedBefore(gomock.Any(), gomock.Any(), 10).Return(int64(0), errors.New("db down")),
+	)
+
+	res, err := worker.PurgeOnce(context.Background())
+
+	require.Error(t, err)
+	assert.Equal(t, int64(10), res.Purged)
+}
+
+func TestWorker_PurgeOnce_RaisesZeroBatchSize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+
+	worker := purge.NewWorker(uow, purge.Config{
+		Retention: time.Hour,
+		Interval:  time.Minute,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	uow.EXPECT().Idempotency().Return(idempotencyRepo)
+	idempotencyRepo.EXPECT().DeleteCreatedBefore(gomock.Any(), gomock.Any(), 1).Return(int64(0), nil)
+
+	res, err := worker.PurgeOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Zero(t, res.Purged)
+}
@@ -0,0 +1,198 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+var (
+	// ErrLagged ends a subscription whose reader let its buffer fill up.
+	ErrLagged = errors.New("subscriber fell behind the event stream")
+	// ErrClosed ends every subscription when the broker shuts down.
+	ErrClosed = errors.New("event stream closed")
+	// ErrResync ends every subscription when the broker may have missed
+	// events, so that subscribers resubscribe from the last event they saw.
+	ErrResync = errors.New("event stream interrupted: resubscribe from the last event")
+)
+
+// Update is a payment event as seen from one subscribed account.
+type Update struct {
+	Event *entity.Event
+	// Direction is DirectionIncoming if the account is the payee and
+	// DirectionOutgoing if it is the payer.
+	Direction repository.Direction
+	Payment   entity.PaymentEvent
+}
+
+// Broker fans committed payment events out to the subscribers of the accounts
+// they move money between. It only keeps live subscriptions: an account's
+// earlier events are read back from the outbox.
+type Broker struct {
+	mu     sync.Mutex
+	subs   map[uuid.UUID]map[*Subscription]struct{}
+	buffer int
+	closed bool
+}
+
+// NewBroker returns a broker that buffers up to buffer updates for each
+// subscriber. A subscriber that falls further behind is dropped with
+// ErrLagged rather than allowed to hold up the others. A buffer below one is
+// raised to one.
+func NewBroker(buffer int) *Broker {
+	return &Broker{
+		subs:   make(map[uuid.UUID]map[*Subscription]struct{}),
+		buffer: max(buffer, 1),
+	}
+}
+
+func (b *Broker) Subscribe(accountID uuid.UUID) *Subscription {
+	s := &Subscription{
+		broker:    b,
+		accountID: accountID,
+		updates:   make(chan Update, b.buffer),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		s.err = ErrClosed
+		close(s.updates)
+		return s
+	}
+	if b.subs[accountID] == nil {
+		b.subs[accountID] = make(map[*Subscription]struct{})
+	}
+	b.subs[accountID][s] = struct{}{}
+	return s
+}
+
+// Publish hands a payment event to the subscribers of its payer and payee.
+// It never blocks on a subscriber.
+func (b *Broker) Publish(_ context.Context, event *entity.Event) error {
+	payment, from, to, err := parsePayment(event)
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.deliver(from, Update{Event: event, Direction: repository.DirectionOutgoing, Payment: payment})
+	b.deliver(to, Update{Event: event, Direction: repository.DirectionIncoming, Payment: payment})
+	return nil
+}
+
+// Resync ends all subscriptions with ErrResync. The event source calls it
+// when it may have lost events, such as after reconnecting to the database.
+func (b *Broker) Resync() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, subs := range b.subs {
+		for s := range subs {
+			b.drop(s, ErrResync)
+		}
+	}
+}
+
+// UpdateFor returns a payment event as seen from the account, which is its
+// payer or payee.
+func UpdateFor(event *entity.Event, accountID uuid.UUID) (Update, error) {
+	payment, from, _, err := parsePayment(event)
+	if err != nil {
+		return Update{}, err
+	}
+	direction := repository.DirectionIncoming
+	if from == accountID {
+		direction = repository.DirectionOutgoing
+	}
+	return Update{Event: event, Direction: direction, Payment: payment}, nil
+}
+
+func parsePayment(event *entity.Event) (entity.PaymentEvent, uuid.UUID, uuid.UUID, error) {
+	var payment entity.PaymentEvent
+	if err := json.Unmarshal(event.Payload(), &payment); err != nil {
+		return payment, uuid.Nil, uuid.Nil, fmt.Errorf("event %s: %w", event.ID(), err)
+	}
+	from, err := uuid.Parse(payment.FromAccountID)
+	if err != nil {
+		return payment, uuid.Nil, uuid.Nil, fmt.Errorf("event %s: %w", event.ID(), err)
+	}
+	to, err := uuid.Parse(payment.ToAccountID)
+	if err != nil {
+		return payment, uuid.Nil, uuid.Nil, fmt.Errorf("event %s: %w", event.ID(), err)
+	}
+	return payment, from, to, nil
+}
+
+// Close ends all subscriptions with ErrClosed and refuses new ones, so that
+// streaming RPCs return and the server can stop.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for _, subs := range b.subs {
+		for s := range subs {
+			b.drop(s, ErrClosed)
+		}
+	}
+}
+
+func (b *Broker) deliver(accountID uuid.UUID, u Update) {
+	for s := range b.subs[accountID] {
+		select {
+		case s.updates <- u:
+		default:
+			b.drop(s, ErrLagged)
+		}
+	}
+}
+
+// drop removes a subscription and closes its channel; err is nil when the
+// subscriber itself closed it. The caller holds b.mu.
+func (b *Broker) drop(s *Subscription, err error) {
+	subs, ok := b.subs[s.accountID]
+	if _, live := subs[s]; !ok || !live {
+		return
+	}
+	delete(subs, s)
+	if len(subs) == 0 {
+		delete(b.subs, s.accountID)
+	}
+	s.err = err
+	close(s.updates)
+}
+
+// Subscription receives the updates of one account until it is closed, by
+// its owner or by the broker.
+type Subscription struct {
+	broker    *Broker
+	accountID uuid.UUID
+	updates   chan Update
+	err       error
+}
+
+// Updates is closed when the subscription ends; Err then tells why.
+func (s *Subscription) Updates() <-chan Update {
+	return s.updates
+}
+
+// Err is ErrLagged, ErrClosed or ErrResync if the broker ended the
+// subscription, and nil otherwise.
+func (s *Subscription) Err() error {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	return s.err
+}
+
+func (s *Subscription) Close() {
+	s.broker.mu.Lock()
+	defer s.broker.mu.Unlock()
+	s.broker.drop(s, nil)
+}
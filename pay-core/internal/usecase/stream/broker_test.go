@@ -0,0 +1,132 @@
+package stream_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/stream"
+)
+
+func paymentEvent(t *testing.T, from, to uuid.UUID) *entity.Event {
+	t.Helper()
+	txn := entity.NewTransaction(from, to, entity.ReconstructMoney(1000, "RUB"), entity.StatusSuccess)
+	e, err := entity.NewPaymentEvent(txn)
+	require.NoError(t, err)
+	return e
+}
+
+func TestBroker_Publish_DeliversToPayerAndPayee(t *testing.T) {
+	b := stream.NewBroker(1)
+	payer, payee := uuid.New(), uuid.New()
+	payerSub := b.Subscribe(payer)
+	defer payerSub.Close()
+	payeeSub := b.Subscribe(payee)
+	defer payeeSub.Close()
+	other := b.Subscribe(uuid.New())
+	defer other.Close()
+
+	event := paymentEvent(t, payer, payee)
+	require.NoError(t, b.Publish(context.Background(), event))
+
+	out := <-payerSub.Updates()
+	assert.Equal(t, event.ID(), out.Event.ID())
+	assert.Equal(t, repository.DirectionOutgoing, out.Direction)
+	assert.Equal(t, payee.String(), out.Payment.ToAccountID)
+
+	in := <-payeeSub.Updates()
+	assert.Equal(t, event.ID(), in.Event.ID())
+	assert.Equal(t, repository.DirectionIncoming, in.Direction)
+	assert.Equal(t, int64(1000), in.Payment.Amount)
+
+	assert.Empty(t, other.Updates())
+}
+
+func TestBroker_Publish_DropsLaggingSubscriber(t *testing.T) {
+	b := stream.NewBroker(1)
+	payer, payee := uuid.New(), uuid.New()
+	sub := b.Subscribe(payee)
+	defer sub.Close()
+
+	require.NoError(t, b.Publish(context.Background(), paymentEvent(t, payer, payee)))
+	require.NoError(t, b.Publish(context.Background(), paymentEvent(t, payer, payee)))
+
+	_, ok := <-sub.Updates()
+	require.True(t, ok)
+	_, ok = <-sub.Updates()
+	require.False(t, ok)
+	require.ErrorIs(t, sub.Err(), stream.ErrLagged)
+}
+
+func TestBroker_RaisesNegativeBuffer(t *testing.T) {
+	b := stream.NewBroker(-1)
+	payer, payee := uuid.New(), uuid.New()
+	sub := b.Subscribe(payee)
+	defer sub.Close()
+
+	event := paymentEvent(t, payer, payee)
+	require.NoError(t, b.Publish(context.Background(), event))
+
+	out := <-sub.Updates()
+	assert.Equal(t, event.ID(), out.Event.ID())
+}
+
+func TestBroker_Close_EndsSubscriptions(t *testing.T) {
+	b := stream.NewBroker(1)
+	sub := b.Subscribe(uuid.New())
+
+	b.Close()
+
+	_, ok := <-sub.Updates()
+	require.False(t, ok)
+	require.ErrorIs(t, sub.Err(), stream.ErrClosed)
+
+	late := b.Subscribe(uuid.New())
+	_, ok = <-late.Updates()
+	require.False(t, ok)
+	require.ErrorIs(t, late.Err(), stream.ErrClosed)
+
+	// Closing a subscription the broker has ended is a no-op.
+	sub.Close()
+	require.ErrorIs(t, sub.Err(), stream.ErrClosed)
+}
+
+func TestBroker_Resync_EndsSubscriptionsButNotBroker(t *testing.T) {
+	b := stream.NewBroker(1)
+	account := uuid.New()
+	sub := b.Subscribe(account)
+
+	b.Resync()
+
+	_, ok := <-sub.Updates()
+	require.False(t, ok)
+	require.ErrorIs(t, sub.Err(), stream.ErrResync)
+
+	again := b.Subscribe(account)
+	defer again.Close()
+	event := paymentEvent(t, uuid.New(), account)
+	require.NoError(t, b.Publish(context.Background(), event))
+	out, ok := <-again.Updates()
+	require.True(t, ok)
+	assert.Equal(t, event.ID(), out.Event.ID())
+}
+
+func TestUpdateFor_TakesDirectionFromAccount(t *testing.T) {
+	payer, payee := uuid.New(), uuid.New()
+	event := paymentEvent(t, payer, payee)
+
+	out, err := stream.UpdateFor(event, payer)
+	require.NoError(t, err)
+	assert.Equal(t, repository.DirectionOutgoing, out.Direction)
+	assert.Equal(t, event.ID(), out.Event.ID())
+
+	in, err := stream.UpdateFor(event, payee)
+	require.NoError(t, err)
+	assert.Equal(t, repository.DirectionIncoming, in.Direction)
+	assert.Equal(t, payer.String(), in.Payment.FromAccountID)
+}
@@ -0,0 +1,327 @@
+package transfer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+const DefaultHoldTTL = 7 * 24 * time.Hour
+
+type AuthorizeRequest struct {
+	IdempotencyKey string
+	FromAccountID  uuid.UUID
+	ToAccountID    uuid.UUID
+	Amount         entity.Money
+}
+
+func (r AuthorizeRequest) Fingerprint() string {
+	return entity.RequestFingerprint(map[string]string{
+		"operation":       "authorize",
+		"from_account_id": r.FromAccountID.String(),
+		"to_account_id":   r.ToAccountID.String(),
+		"amount":          strconv.FormatInt(r.Amount.Amount(), 10),
+		"currency":        currencyField(r.Amount),
+	})
+}
+
+type CaptureRequest struct {
+	IdempotencyKey  string
+	AuthorizationID uuid.UUID
+	// Amount to settle; zero captures the full authorized amount.
+	Amount int64
+}
+
+func (r CaptureRequest) Fingerprint() string {
+	return entity.RequestFingerprint(map[string]string{
+		"authorization_id": r.AuthorizationID.String(),
+		"amount":           strconv.FormatInt(r.Amount, 10),
+	})
+}
+
+type authorizationCache struct {
+	AuthorizationID string `json:"authorization_id"`
+}
+
+// Authorize places a hold on the payer's available balance. No money moves
+// until the authorization is captured; an uncaptured hold expires after the
+// configured TTL. The hold counts against the payer's transfer limits from
+// the moment it is placed, so an authorization over a limit is rejected with
+// entity.ErrLimitExceeded.
+func (uc *UseCase) Authorize(ctx context.Context, req AuthorizeRequest) (*entity.Authorization, error) {
+	if !req.Amount.IsPositive() {
+		return nil, entity.ErrNegativeAmount
+	}
+	if req.FromAccountID == req.ToAccountID {
+		return nil, ErrSameAccount
+	}
+
+	cached, err := uc.uow.Idempotency().Find(ctx, req.IdempotencyKey)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	if cached != nil {
+		return uc.replayAuthorization(ctx, uc.uow, cached, req.Fingerprint())
+	}
+
+	var auth *entity.Authorization
+	err = uc.retry(ctx, func() error {
+		var execErr error
+		auth, execErr = uc.authorize(ctx, req)
+		return execErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return auth, nil
+}
+
+func (uc *UseCase) authorize(ctx context.Context, req AuthorizeRequest) (*entity.Authorization, error) {
+	tx, err := uc.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	cached, err := lockKey(ctx, tx, req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil {
+		return uc.replayAuthorization(ctx, tx, cached, req.Fingerprint())
+	}
+
+	payee, err := tx.Accounts().FindByID(ctx, req.ToAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	payer, err := tx.Accounts().FindByIDForUpdate(ctx, req.FromAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if custErr := checkCustomer(payer, payee); custErr != nil {
+		return nil, custErr
+	}
+
+	if currErr := checkCurrency(req.Amount, payer, payee); currErr != nil {
+		return nil, currErr
+	}
+
+	if sendErr := checkSendable(ctx, tx, payer, payee, req.Amount); sendErr != nil {
+		return nil, sendErr
+	}
+
+	if holdErr := payer.Hold(req.Amount); holdErr != nil {
+		return nil, holdErr
+	}
+
+	if updErr := tx.Accounts().UpdateHeld(ctx, payer.ID(), payer.Held()); updErr != nil {
+		return nil, updErr
+	}
+
+	auth := entity.NewAuthorization(req.FromAccountID, req.ToAccountID, req.Amount, uc.holdTTL)
+	if createErr := tx.Authorizations().Create(ctx, auth); createErr != nil {
+		return nil, createErr
+	}
+
+	cache := authorizationCache{AuthorizationID: auth.ID().String()}
+	if saveErr := saveAndCommit(ctx, tx, req.IdempotencyKey, req.Fingerprint(), statusCodePending, cache); saveErr != nil {
+		return nil, saveErr
+	}
+	return auth, nil
+}
+
+// replayAuthorization answers a repeated Authorize with the current state of
+// the authorization it created, which may since have been captured or voided.
+func (uc *UseCase) replayAuthorization(
+	ctx context.Context,
+	uow repository.UnitOfWork,
+	cached *entity.IdempotencyRecord,
+	fingerprint string,
+) (*entity.Authorization, error) {
+	if !cached.Matches(fingerprint) {
+		return nil, entity.ErrIdempotencyKeyReused
+	}
+
+	var cache authorizationCache
+	if err := json.Unmarshal(cached.ResponseBody(), &cache); err != nil {
+		return nil, err
+	}
+	id, err := uuid.Parse(cache.AuthorizationID)
+	if err != nil {
+		return nil, err
+	}
+	return uow.Authorizations().FindByID(ctx, id)
+}
+
+// Capture settles an active authorization: Amount (or the whole authorized
+// amount) moves from payer to payee and the rest of the hold is released.
+// Holds are taken by merchants, so the capture is priced as a QR merchant
+// payment; a fee the payer bears is charged on top of the settled amount and
+// must fit in the payer's balance once the hold is released.
+func (uc *UseCase) Capture(ctx context.Context, req CaptureRequest) (*Response, error) {
+	if req.Amount < 0 {
+		return nil, entity.ErrNegativeAmount
+	}
+
+	cached, err := uc.uow.Idempotency().Find(ctx, req.IdempotencyKey)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	if cached != nil {
+		return uc.replay(cached, req.Fingerprint())
+	}
+
+	var resp *Response
+	err = uc.retry(ctx, func() error {
+		var execErr error
+		resp, execErr = uc.capture(ctx, req)
+		return execErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (uc *UseCase) capture(ctx context.Context, req CaptureRequest) (*Response, error) {
+	tx, err := uc.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	cached, err := lockKey(ctx, tx, req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil {
+		return uc.replay(cached, req.Fingerprint())
+	}
+
+	auth, err := tx.Authorizations().FindByIDForUpdate(ctx, req.AuthorizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := req.Amount
+	if amount == 0 {
+		amount = auth.Amount()
+	}
+
+	settled := auth.Money().WithAmount(amount)
+	txn := entity.NewTransaction(auth.FromAccount(), auth.ToAccount(), settled, entity.StatusSuccess)
+	if captureErr := auth.Capture(amount, txn.ID(), time.Now()); captureErr != nil {
+		return nil, captureErr
+	}
+
+	payer, payee, err := lockAccounts(ctx, tx, auth.FromAccount(), auth.ToAccount())
+	if err != nil {
+		return nil, err
+	}
+
+	bearer, err := chargeFee(ctx, tx, entity.TransferQRMerchant, payer, payee, txn)
+	if err != nil {
+		return nil, err
+	}
+
+	payer.Release(auth.Amount())
+	if debitErr := payer.Debit(txn.Debited()); debitErr != nil {
+		return nil, debitErr
+	}
+
+	if updErr := tx.Accounts().UpdateHeld(ctx, payer.ID(), payer.Held()); updErr != nil {
+		return nil, updErr
+	}
+
+	var revenue *entity.Account
+	if txn.Fee().IsPositive() {
+		if revenue, err = lockFeeRevenue(ctx, tx, txn.Currency()); err != nil {
+			return nil, err
+		}
+	}
+
+	if bookErr := book(ctx, tx, payer, payee, revenue, txn); bookErr != nil {
+		return nil, bookErr
+	}
+
+	if updErr := tx.Authorizations().Update(ctx, auth); updErr != nil {
+		return nil, updErr
+	}
+
+	return uc.saveAndReturn(ctx, tx, req.IdempotencyKey, req.Fingerprint(), &Response{
+		TransactionID: txn.ID().String(),
+		Status:        entity.StatusSuccess,
+		Fee:           txn.Fee().Amount(),
+		FeeBearer:     bearer,
+	})
+}
+
+// Void cancels an active authorization and releases its hold. Voiding an
+// authorization that is already voided returns it unchanged.
+func (uc *UseCase) Void(ctx context.Context, id uuid.UUID) (*entity.Authorization, error) {
+	var auth *entity.Authorization
+	err := uc.retry(ctx, func() error {
+		var execErr error
+		auth, execErr = uc.void(ctx, id)
+		return execErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return auth, nil
+}
+
+func (uc *UseCase) void(ctx context.Context, id uuid.UUID) (*entity.Authorization, error) {
+	tx, err := uc.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	auth, err := tx.Authorizations().FindByIDForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if auth.Status() == entity.AuthorizationVoided {
+		return auth, nil
+	}
+
+	if voidErr := auth.Void(); voidErr != nil {
+		return nil, voidErr
+	}
+
+	if releaseErr := release(ctx, tx, auth); releaseErr != nil {
+		return nil, releaseErr
+	}
+
+	if commitErr := tx.Commit(ctx); commitErr != nil {
+		return nil, commitErr
+	}
+	return auth, nil
+}
+
+// release gives the hold of a voided or expired authorization back to the
+// payer and persists the authorization's new status.
+func release(ctx context.Context, tx repository.UnitOfWork, auth *entity.Authorization) error {
+	payer, err := tx.Accounts().FindByIDForUpdate(ctx, auth.FromAccount())
+	if err != nil {
+		return err
+	}
+
+	payer.Release(auth.Amount())
+	if updErr := tx.Accounts().UpdateHeld(ctx, payer.ID(), payer.Held()); updErr != nil {
+		return updErr
+	}
+
+	return tx.Authorizations().Update(ctx, auth)
+}
@@ -0,0 +1,162 @@
+package transfer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer/mocks"
+)
+
+func TestTransferUseCase_Authorize_HoldsAvailableBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	authRepo := mocks.NewMockAuthorizationRepository(ctrl)
+	idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+
+	uc := transfer.NewUseCase(uow, transfer.WithHoldTTL(time.Hour))
+
+	payerID := uuid.New()
+	payeeID := uuid.New()
+
+	uow.EXPECT().Idempotency().Return(idempotencyRepo)
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	txUow.EXPECT().Idempotency().Return(idempotencyRepo).Times(3)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "auth-key").Return(nil, nil).Times(2)
+	idempotencyRepo.EXPECT().Lock(gomock.Any(), "auth-key").Return(nil)
+
+	txUow.EXPECT().Accounts().Return(accountRepo).Times(3)
+	accountRepo.EXPECT().FindByID(gomock.Any(), payeeID).Return(entity.NewAccount(payeeID, rub(0)), nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), payerID).Return(
+		entity.ReconstructAccount(payerID, entity.AccountCustomer, entity.DefaultTier, rub(1000), 600, active, time.Now()), nil,
+	)
+	expectNoLimits(ctrl, txUow)
+	accountRepo.EXPECT().UpdateHeld(gomock.Any(), payerID, int64(1000)).Return(nil)
+
+	txUow.EXPECT().Authorizations().Return(authRepo)
+	authRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	idempotencyRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	auth, err := uc.Authorize(context.Background(), transfer.AuthorizeRequest{
+		IdempotencyKey: "auth-key",
+		FromAccountID:  payerID,
+		ToAccountID:    payeeID,
+		Amount:         rub(400),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.AuthorizationActive, auth.Status())
+	assert.WithinDuration(t, time.Now().Add(time.Hour), auth.ExpiresAt(), time.Minute)
+}
+
+func TestTransferUseCase_Authorize_InsufficientAvailableBalance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+
+	uc := transfer.NewUseCase(uow)
+
+	payerID := uuid.New()
+	payeeID := uuid.New()
+
+	uow.EXPECT().Idempotency().Return(idempotencyRepo)
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	txUow.EXPECT().Idempotency().Return(idempotencyRepo).Times(2)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "auth-key").Return(nil, nil).Times(2)
+	idempotencyRepo.EXPECT().Lock(gomock.Any(), "auth-key").Return(nil)
+
+	txUow.EXPECT().Accounts().Return(accountRepo).Times(2)
+	accountRepo.EXPECT().FindByID(gomock.Any(), payeeID).Return(entity.NewAccount(payeeID, rub(0)), nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), payerID).Return(
+		entity.ReconstructAccount(payerID, entity.AccountCustomer, entity.DefaultTier, rub(1000), 700, active, time.Now()), nil,
+	)
+	expectNoLimits(ctrl, txUow)
+
+	_, err := uc.Authorize(context.Background(), transfer.AuthorizeRequest{
+		IdempotencyKey: "auth-key",
+		FromAccountID:  payerID,
+		ToAccountID:    payeeID,
+		Amount:         rub(400),
+	})
+
+	require.ErrorIs(t, err, entity.ErrInsufficientFunds)
+}
+
+func TestTransferUseCase_Capture_PartialReleasesRemainder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	txnRepo := mocks.NewMockTransactionRepository(ctrl)
+	authRepo := mocks.NewMockAuthorizationRepository(ctrl)
+	idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+
+	uc := transfer.NewUseCase(uow)
+
+	payerID := uuid.New()
+	payeeID := uuid.New()
+	now := time.Now()
+	auth := entity.ReconstructAuthorization(
+		uuid.New(), payerID, payeeID, rub(500), 0,
+		entity.AuthorizationActive, uuid.Nil, now.Add(time.Hour), now,
+	)
+
+	uow.EXPECT().Idempotency().Return(idempotencyRepo)
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	txUow.EXPECT().Idempotency().Return(idempotencyRepo).Times(3)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "capture-key").Return(nil, nil).Times(2)
+	idempotencyRepo.EXPECT().Lock(gomock.Any(), "capture-key").Return(nil)
+
+	txUow.EXPECT().Authorizations().Return(authRepo).Times(2)
+	authRepo.EXPECT().FindByIDForUpdate(gomock.Any(), auth.ID()).Return(auth, nil)
+
+	txUow.EXPECT().Accounts().Return(accountRepo).Times(5)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), payerID).Return(
+		entity.ReconstructAccount(payerID, entity.AccountCustomer, entity.DefaultTier, rub(1000), 500, active, now), nil,
+	)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), payeeID).Return(entity.NewAccount(payeeID, rub(0)), nil)
+	expectNoFee(ctrl, txUow)
+	accountRepo.EXPECT().UpdateHeld(gomock.Any(), payerID, int64(0)).Return(nil)
+	accountRepo.EXPECT().UpdateBalance(gomock.Any(), payerID, int64(700)).Return(nil)
+	accountRepo.EXPECT().UpdateBalance(gomock.Any(), payeeID, int64(300)).Return(nil)
+
+	txUow.EXPECT().Transactions().Return(txnRepo)
+	expectEvent(ctrl, txUow, entity.EventPaymentCompleted)
+	txnRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	authRepo.EXPECT().Update(gomock.Any(), auth).Return(nil)
+	idempotencyRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	resp, err := uc.Capture(context.Background(), transfer.CaptureRequest{
+		IdempotencyKey:  "capture-key",
+		AuthorizationID: auth.ID(),
+		Amount:          300,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.StatusSuccess, resp.Status)
+	assert.Equal(t, entity.AuthorizationCaptured, auth.Status())
+	assert.Equal(t, int64(300), auth.CapturedAmount())
+	assert.Equal(t, resp.TransactionID, auth.CaptureTransactionID().String())
+}
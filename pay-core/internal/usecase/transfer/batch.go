@@ -0,0 +1,363 @@
+package transfer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+type BatchItem struct {
+	FromAccountID uuid.UUID
+	ToAccountID   uuid.UUID
+	Amount        entity.Money
+	// Type selects the fee schedule; empty means entity.TransferP2P.
+	Type entity.TransferType
+}
+
+type BatchRequest struct {
+	IdempotencyKey string
+	Mode           entity.BatchMode
+	Items          []BatchItem
+}
+
+// Fingerprint covers every item in order, so that resubmitting a key with
+// the items reordered is detected as a different request.
+func (r BatchRequest) Fingerprint() string {
+	fields := map[string]string{"mode": string(r.Mode)}
+	for pos, item := range r.Items {
+		prefix := "items." + strconv.Itoa(pos) + "."
+		fields[prefix+"from_account_id"] = item.FromAccountID.String()
+		fields[prefix+"to_account_id"] = item.ToAccountID.String()
+		fields[prefix+"amount"] = strconv.FormatInt(item.Amount.Amount(), 10)
+		fields[prefix+"currency"] = string(item.Amount.Currency())
+		fields[prefix+"transfer_type"] = Request{Type: item.Type}.typeField()
+	}
+	return entity.RequestFingerprint(fields)
+}
+
+type batchCache struct {
+	BatchID string `json:"batch_id"`
+}
+
+// ProcessBatch pays a batch of transfers submitted under one idempotency key.
+//
+// An atomic batch pays every item in a single unit of work, holding the locks
+// of all accounts involved until it commits; if any item is declined, none is
+// paid and the batch fails on that item. A best-effort batch is recorded
+// first and then pays each item as a transfer of its own, recording failed
+// items and going on with the rest. If a best-effort batch is interrupted, it
+// stays processing and a retry with the same key pays the remaining items.
+func (uc *UseCase) ProcessBatch(ctx context.Context, req BatchRequest) (*entity.Batch, error) {
+	items := make([]*entity.BatchItem, 0, len(req.Items))
+	for pos, item := range req.Items {
+		if !item.Amount.IsPositive() {
+			return nil, fmt.Errorf("item %d: %w", pos, entity.ErrNegativeAmount)
+		}
+		if item.FromAccountID == item.ToAccountID {
+			return nil, fmt.Errorf("item %d: %w", pos, ErrSameAccount)
+		}
+		transferType := Request{Type: item.Type}.transferType()
+		items = append(items, entity.NewBatchItem(item.FromAccountID, item.ToAccountID, item.Amount, transferType))
+	}
+	batch, err := entity.NewBatch(req.Mode, items)
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint := req.Fingerprint()
+	cached, err := uc.uow.Idempotency().Find(ctx, req.IdempotencyKey)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	if cached != nil {
+		if batch, err = uc.replayBatch(ctx, uc.uow, cached, fingerprint); err != nil {
+			return nil, err
+		}
+		return uc.payItems(ctx, batch)
+	}
+
+	var started *entity.Batch
+	err = uc.retry(ctx, func() error {
+		var execErr error
+		if req.Mode == entity.BatchAtomic {
+			started, execErr = uc.payAtomic(ctx, req.IdempotencyKey, fingerprint, batch)
+		} else {
+			started, execErr = uc.startBatch(ctx, req.IdempotencyKey, fingerprint, batch)
+		}
+		return execErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uc.payItems(ctx, started)
+}
+
+func (uc *UseCase) GetBatch(ctx context.Context, id uuid.UUID) (*entity.Batch, error) {
+	return uc.uow.Batches().FindByID(ctx, id)
+}
+
+func (uc *UseCase) payAtomic(
+	ctx context.Context,
+	key, fingerprint string,
+	batch *entity.Batch,
+) (*entity.Batch, error) {
+	tx, err := uc.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	cached, err := lockKey(ctx, tx, key)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil {
+		return uc.replayBatch(ctx, tx, cached, fingerprint)
+	}
+
+	var ids []uuid.UUID
+	for _, item := range batch.Items() {
+		ids = append(ids, item.FromAccountID(), item.ToAccountID())
+	}
+	locked, err := lockAll(ctx, tx, ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, item := range batch.Items() {
+		txn, payErr := payLocked(ctx, tx, item, locked[item.FromAccountID()], locked[item.ToAccountID()])
+		if payErr != nil {
+			if !declinesItem(payErr) {
+				return nil, payErr
+			}
+			_ = tx.Rollback(ctx)
+			return uc.abortBatch(ctx, key, fingerprint, batch, item.Position(), payErr)
+		}
+		item.Succeed(txn.ID())
+	}
+	batch.Complete(time.Now())
+
+	if createErr := tx.Batches().Create(ctx, batch); createErr != nil {
+		return nil, createErr
+	}
+	cache := batchCache{BatchID: batch.ID().String()}
+	if saveErr := saveAndCommit(ctx, tx, key, fingerprint, statusCodeSuccess, cache); saveErr != nil {
+		return nil, saveErr
+	}
+	return batch, nil
+}
+
+// payLocked pays one item of an atomic batch between accounts that stay
+// locked for the whole batch. The accounts carry the balances left by the
+// items before it, and the limits check sees their transactions, which are
+// part of the same unit of work.
+func payLocked(
+	ctx context.Context,
+	tx repository.UnitOfWork,
+	item *entity.BatchItem,
+	sender, receiver *entity.Account,
+) (*entity.Transaction, error) {
+	if err := checkCustomer(sender, receiver); err != nil {
+		return nil, err
+	}
+	if err := checkCurrency(item.Amount(), sender, receiver); err != nil {
+		return nil, err
+	}
+	if err := checkSendable(ctx, tx, sender, receiver, item.Amount()); err != nil {
+		return nil, err
+	}
+
+	txn := entity.NewTransaction(item.FromAccountID(), item.ToAccountID(), item.Amount(), entity.StatusSuccess)
+	if _, err := chargeFee(ctx, tx, item.TransferType(), sender, receiver, txn); err != nil {
+		return nil, err
+	}
+	if err := sender.Debit(txn.Debited()); err != nil {
+		return nil, err
+	}
+
+	var revenue *entity.Account
+	if txn.Fee().IsPositive() {
+		var err error
+		if revenue, err = lockFeeRevenue(ctx, tx, txn.Currency()); err != nil {
+			return nil, err
+		}
+	}
+	if err := book(ctx, tx, sender, receiver, revenue, txn); err != nil {
+		return nil, err
+	}
+	return txn, nil
+}
+
+// abortBatch records an atomic batch that failed on the item at position, in
+// a unit of work of its own now that the one paying it has been rolled back.
+func (uc *UseCase) abortBatch(
+	ctx context.Context,
+	key, fingerprint string,
+	batch *entity.Batch,
+	position int,
+	cause error,
+) (*entity.Batch, error) {
+	tx, err := uc.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	cached, err := lockKey(ctx, tx, key)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil {
+		return uc.replayBatch(ctx, tx, cached, fingerprint)
+	}
+
+	batch.Abort(position, itemFailureReason(cause), cause.Error(), time.Now())
+	if createErr := tx.Batches().Create(ctx, batch); createErr != nil {
+		return nil, createErr
+	}
+	cache := batchCache{BatchID: batch.ID().String()}
+	if saveErr := saveAndCommit(ctx, tx, key, fingerprint, statusCodeFailed, cache); saveErr != nil {
+		return nil, saveErr
+	}
+	return batch, nil
+}
+
+// startBatch records a best-effort batch with all its items pending.
+func (uc *UseCase) startBatch(
+	ctx context.Context,
+	key, fingerprint string,
+	batch *entity.Batch,
+) (*entity.Batch, error) {
+	tx, err := uc.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	cached, err := lockKey(ctx, tx, key)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil {
+		return uc.replayBatch(ctx, tx, cached, fingerprint)
+	}
+
+	if createErr := tx.Batches().Create(ctx, batch); createErr != nil {
+		return nil, createErr
+	}
+	cache := batchCache{BatchID: batch.ID().String()}
+	if saveErr := saveAndCommit(ctx, tx, key, fingerprint, statusCodePending, cache); saveErr != nil {
+		return nil, saveErr
+	}
+	return batch, nil
+}
+
+// payItems pays the pending items of a processing best-effort batch one by
+// one and then settles its status. Each item is paid under an idempotency
+// key derived from the batch, so an item paid before an interruption is
+// replayed rather than paid again.
+func (uc *UseCase) payItems(ctx context.Context, batch *entity.Batch) (*entity.Batch, error) {
+	if batch.Status() != entity.BatchProcessing {
+		return batch, nil
+	}
+
+	for _, item := range batch.Items() {
+		if item.Status() != entity.BatchItemPending {
+			continue
+		}
+		resp, err := uc.Execute(ctx, Request{
+			IdempotencyKey: itemKey(batch.ID(), item.Position()),
+			FromAccountID:  item.FromAccountID(),
+			ToAccountID:    item.ToAccountID(),
+			Amount:         item.Amount(),
+			Type:           item.TransferType(),
+		})
+		if err != nil && !declinesItem(err) {
+			return nil, err
+		}
+		if recErr := recordItem(item, resp, err); recErr != nil {
+			return nil, recErr
+		}
+		if updErr := uc.uow.Batches().UpdateItem(ctx, batch.ID(), item); updErr != nil {
+			return nil, updErr
+		}
+	}
+
+	batch.Complete(time.Now())
+	if err := uc.uow.Batches().UpdateStatus(ctx, batch); err != nil {
+		return nil, err
+	}
+	return batch, nil
+}
+
+// recordItem records on item the outcome of paying it: resp, or the error
+// that declined it before a transaction was recorded.
+func recordItem(item *entity.BatchItem, resp *Response, err error) error {
+	if err != nil {
+		item.Fail(uuid.Nil, itemFailureReason(err), err.Error())
+		return nil
+	}
+	txnID, err := uuid.Parse(resp.TransactionID)
+	if err != nil {
+		return err
+	}
+	if resp.Status == entity.StatusSuccess {
+		item.Succeed(txnID)
+	} else {
+		item.Fail(txnID, resp.FailureReason, resp.ErrorMessage)
+	}
+	return nil
+}
+
+func (uc *UseCase) replayBatch(
+	ctx context.Context,
+	uow repository.UnitOfWork,
+	cached *entity.IdempotencyRecord,
+	fingerprint string,
+) (*entity.Batch, error) {
+	if !cached.Matches(fingerprint) {
+		return nil, entity.ErrIdempotencyKeyReused
+	}
+
+	var cache batchCache
+	if err := json.Unmarshal(cached.ResponseBody(), &cache); err != nil {
+		return nil, err
+	}
+	id, err := uuid.Parse(cache.BatchID)
+	if err != nil {
+		return nil, err
+	}
+	return uow.Batches().FindByID(ctx, id)
+}
+
+func itemKey(batchID uuid.UUID, position int) string {
+	return "batch:" + batchID.String() + ":" + strconv.Itoa(position)
+}
+
+// declinesItem reports whether err rejects a single transfer rather than
+// the batch as a whole: the item fails and the others are unaffected by it.
+func declinesItem(err error) bool {
+	return entity.FailureReasonOf(err) != entity.FailureUnknown ||
+		errors.Is(err, repository.ErrAccountNotFound) ||
+		errors.Is(err, entity.ErrCurrencyMismatch) ||
+		errors.Is(err, entity.ErrFeeExceedsAmount)
+}
+
+// itemFailureReason is the failure reason recorded on an item rejected with
+// err; rejections that are not a declined transfer leave it empty and are
+// described by the error message alone.
+func itemFailureReason(err error) entity.FailureReason {
+	reason := entity.FailureReasonOf(err)
+	if reason == entity.FailureUnknown {
+		return entity.FailureNone
+	}
+	return reason
+}
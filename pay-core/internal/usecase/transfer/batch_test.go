@@ -0,0 +1,262 @@
+package transfer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer/mocks"
+)
+
+func TestTransferUseCase_ProcessBatch_AtomicPaysAllItems(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	batchRepo := mocks.NewMockBatchRepository(ctrl)
+	uc := transfer.NewUseCase(uow)
+
+	payer, alice, bob := uuid.New(), uuid.New(), uuid.New()
+	req := transfer.BatchRequest{
+		IdempotencyKey: "payroll-1",
+		Mode:           entity.BatchAtomic,
+		Items: []transfer.BatchItem{
+			{FromAccountID: payer, ToAccountID: alice, Amount: rub(1000)},
+			{FromAccountID: payer, ToAccountID: bob, Amount: rub(500)},
+		},
+	}
+
+	expectNewKey(ctrl, uow, txUow, "payroll-1")
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil).AnyTimes()
+	expectBatchTransfers(ctrl, txUow)
+
+	txUow.EXPECT().Accounts().Return(accountRepo).AnyTimes()
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), payer).Return(entity.NewAccount(payer, rub(5000)), nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), alice).Return(entity.NewAccount(alice, rub(0)), nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), bob).Return(entity.NewAccount(bob, rub(0)), nil)
+	// The payer is debited cumulatively across items.
+	gomock.InOrder(
+		accountRepo.EXPECT().UpdateBalance(gomock.Any(), payer, int64(4000)).Return(nil),
+		accountRepo.EXPECT().UpdateBalance(gomock.Any(), payer, int64(3500)).Return(nil),
+	)
+	accountRepo.EXPECT().UpdateBalance(gomock.Any(), alice, int64(1000)).Return(nil)
+	accountRepo.EXPECT().UpdateBalance(gomock.Any(), bob, int64(500)).Return(nil)
+
+	txUow.EXPECT().Batches().Return(batchRepo)
+	batchRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	batch, err := uc.ProcessBatch(context.Background(), req)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.BatchCompleted, batch.Status())
+	assert.Equal(t, 2, batch.Succeeded())
+	for _, item := range batch.Items() {
+		assert.Equal(t, entity.BatchItemSuccess, item.Status())
+		assert.NotEqual(t, uuid.Nil, item.TransactionID())
+	}
+	assert.False(t, batch.CompletedAt().IsZero())
+}
+
+func TestTransferUseCase_ProcessBatch_AtomicAbortsOnDeclinedItem(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	abortUow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	batchRepo := mocks.NewMockBatchRepository(ctrl)
+	uc := transfer.NewUseCase(uow)
+
+	payer, alice, bob := uuid.New(), uuid.New(), uuid.New()
+	req := transfer.BatchRequest{
+		IdempotencyKey: "payroll-2",
+		Mode:           entity.BatchAtomic,
+		Items: []transfer.BatchItem{
+			{FromAccountID: payer, ToAccountID: alice, Amount: rub(1000)},
+			{FromAccountID: payer, ToAccountID: bob, Amount: rub(500)},
+		},
+	}
+
+	expectNewKey(ctrl, uow, txUow, "payroll-2")
+	gomock.InOrder(
+		uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil),
+		uow.EXPECT().Begin(gomock.Any()).Return(abortUow, nil),
+	)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil).AnyTimes()
+	expectBatchTransfers(ctrl, txUow)
+
+	txUow.EXPECT().Accounts().Return(accountRepo).AnyTimes()
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), payer).Return(entity.NewAccount(payer, rub(1200)), nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), alice).Return(entity.NewAccount(alice, rub(0)), nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), bob).Return(entity.NewAccount(bob, rub(0)), nil)
+	accountRepo.EXPECT().UpdateBalance(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	// The failed batch is recorded once the payments are rolled back.
+	idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+	abortUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	abortUow.EXPECT().Idempotency().Return(idempotencyRepo).Times(3)
+	idempotencyRepo.EXPECT().Lock(gomock.Any(), "payroll-2").Return(nil)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "payroll-2").Return(nil, repository.ErrNotFound)
+	idempotencyRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	abortUow.EXPECT().Batches().Return(batchRepo)
+	batchRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	abortUow.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	batch, err := uc.ProcessBatch(context.Background(), req)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.BatchFailed, batch.Status())
+	assert.Zero(t, batch.Succeeded())
+
+	items := batch.Items()
+	assert.Equal(t, entity.BatchItemSkipped, items[0].Status())
+	assert.Equal(t, uuid.Nil, items[0].TransactionID())
+	assert.Equal(t, entity.BatchItemFailed, items[1].Status())
+	assert.Equal(t, entity.FailureInsufficientFunds, items[1].FailureReason())
+	assert.NotEmpty(t, items[1].ErrorMessage())
+}
+
+func TestTransferUseCase_ProcessBatch_ResumesBestEffortBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+	batchRepo := mocks.NewMockBatchRepository(ctrl)
+	uc := transfer.NewUseCase(uow)
+
+	payer, alice, bob := uuid.New(), uuid.New(), uuid.New()
+	req := transfer.BatchRequest{
+		IdempotencyKey: "payouts-3",
+		Mode:           entity.BatchBestEffort,
+		Items: []transfer.BatchItem{
+			{FromAccountID: payer, ToAccountID: alice, Amount: rub(1000)},
+			{FromAccountID: payer, ToAccountID: bob, Amount: rub(500)},
+		},
+	}
+
+	// The first item was paid before the batch was interrupted, and the
+	// second one was declined, but its outcome was not yet recorded.
+	paid, declined := uuid.New(), uuid.New()
+	stored := entity.ReconstructBatch(uuid.New(), entity.BatchBestEffort, entity.BatchProcessing,
+		[]*entity.BatchItem{
+			entity.ReconstructBatchItem(0, payer, alice, rub(1000), entity.TransferP2P,
+				entity.BatchItemSuccess, paid, entity.FailureNone, ""),
+			entity.ReconstructBatchItem(1, payer, bob, rub(500), entity.TransferP2P,
+				entity.BatchItemPending, uuid.Nil, entity.FailureNone, ""),
+		},
+		time.Now(), time.Time{},
+	)
+	batchRecord := entity.ReconstructIdempotencyRecord("payouts-3", req.Fingerprint(), 1,
+		[]byte(`{"batch_id":"`+stored.ID().String()+`"}`), time.Time{})
+	itemRecord := entity.ReconstructIdempotencyRecord("", transfer.Request{
+		FromAccountID: payer, ToAccountID: bob, Amount: rub(500),
+	}.Fingerprint(), 3, []byte(`{"transaction_id":"`+declined.String()+
+		`","status":"failed","error_message":"insufficient funds","failure_reason":"insufficient_funds"}`),
+		time.Time{})
+
+	uow.EXPECT().Idempotency().Return(idempotencyRepo).Times(2)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, key string) (*entity.IdempotencyRecord, error) {
+			if key == "payouts-3" {
+				return batchRecord, nil
+			}
+			return itemRecord, nil
+		},
+	).Times(2)
+	uow.EXPECT().Batches().Return(batchRepo).Times(3)
+	batchRepo.EXPECT().FindByID(gomock.Any(), stored.ID()).Return(stored, nil)
+	batchRepo.EXPECT().UpdateItem(gomock.Any(), stored.ID(), stored.Items()[1]).Return(nil)
+	batchRepo.EXPECT().UpdateStatus(gomock.Any(), stored).Return(nil)
+
+	batch, err := uc.ProcessBatch(context.Background(), req)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.BatchPartiallyCompleted, batch.Status())
+	assert.Equal(t, paid, batch.Items()[0].TransactionID())
+	assert.Equal(t, entity.BatchItemFailed, batch.Items()[1].Status())
+	assert.Equal(t, declined, batch.Items()[1].TransactionID())
+	assert.Equal(t, entity.FailureInsufficientFunds, batch.Items()[1].FailureReason())
+}
+
+func TestTransferUseCase_ProcessBatch_RejectsInvalidBatch(t *testing.T) {
+	uc := transfer.NewUseCase(nil)
+	account := uuid.New()
+
+	tests := []struct {
+		name  string
+		req   transfer.BatchRequest
+		error error
+	}{
+		{
+			name:  "no items",
+			req:   transfer.BatchRequest{IdempotencyKey: "k", Mode: entity.BatchAtomic},
+			error: entity.ErrInvalidBatch,
+		},
+		{
+			name: "unknown mode",
+			req: transfer.BatchRequest{IdempotencyKey: "k", Items: []transfer.BatchItem{
+				{FromAccountID: uuid.New(), ToAccountID: uuid.New(), Amount: rub(1)},
+			}},
+			error: entity.ErrInvalidBatch,
+		},
+		{
+			name: "same account",
+			req: transfer.BatchRequest{IdempotencyKey: "k", Mode: entity.BatchBestEffort, Items: []transfer.BatchItem{
+				{FromAccountID: account, ToAccountID: account, Amount: rub(1)},
+			}},
+			error: transfer.ErrSameAccount,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.ProcessBatch(context.Background(), tt.req)
+			require.ErrorIs(t, err, tt.error)
+		})
+	}
+}
+
+// expectNewKey answers the lookups of key, outside and under its lock, as if
+// it had never been used, and expects the result to be cached under it.
+func expectNewKey(ctrl *gomock.Controller, uow, tx *mocks.MockUnitOfWork, key string) {
+	idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+	uow.EXPECT().Idempotency().Return(idempotencyRepo)
+	tx.EXPECT().Idempotency().Return(idempotencyRepo).MinTimes(2)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), key).Return(nil, repository.ErrNotFound).Times(2)
+	idempotencyRepo.EXPECT().Lock(gomock.Any(), key).Return(nil)
+	idempotencyRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).MaxTimes(1)
+}
+
+// expectBatchTransfers lets the transfers booked together in tx, those of an
+// atomic batch or the legs of a split payment, go through without fees or
+// limits and record their transactions.
+func expectBatchTransfers(ctrl *gomock.Controller, tx *mocks.MockUnitOfWork) {
+	limitRepo := mocks.NewMockLimitRepository(ctrl)
+	feeRepo := mocks.NewMockFeeRepository(ctrl)
+	txnRepo := mocks.NewMockTransactionRepository(ctrl)
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+
+	tx.EXPECT().Limits().Return(limitRepo).AnyTimes()
+	limitRepo.EXPECT().Find(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, repository.ErrLimitsNotFound).AnyTimes()
+	tx.EXPECT().Fees().Return(feeRepo).AnyTimes()
+	feeRepo.EXPECT().Find(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, repository.ErrFeeScheduleNotFound).AnyTimes()
+	tx.EXPECT().Transactions().Return(txnRepo).AnyTimes()
+	txnRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	tx.EXPECT().Outbox().Return(outboxRepo).AnyTimes()
+	outboxRepo.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+}
@@ -0,0 +1,165 @@
+package transfer
+
+import (
+	"context"
+	"time"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+// convert settles a payment between accounts in different currencies at the
+// quote it names. The payer's money goes to the house's liquidity account in
+// the source currency, the payee is paid from the liquidity account in the
+// target currency, and the spread between the quote and mid-market is booked
+// to FX revenue, all in the same unit of work.
+func (uc *UseCase) convert(ctx context.Context, req Request) (*Response, error) {
+	tx, err := uc.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	cached, err := lockKey(ctx, tx, req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil {
+		return uc.replay(cached, req.Fingerprint())
+	}
+
+	quote, err := tx.Quotes().FindByIDForUpdate(ctx, req.QuoteID)
+	if err != nil {
+		return nil, err
+	}
+	if useErr := quote.CheckUsable(req.Amount, time.Now()); useErr != nil {
+		return nil, useErr
+	}
+
+	house, err := findHouse(ctx, tx, quote)
+	if err != nil {
+		return nil, err
+	}
+
+	locked, err := lockAll(ctx, tx,
+		req.FromAccountID, req.ToAccountID, house.source.ID(), house.target.ID(), house.revenue.ID())
+	if err != nil {
+		return nil, err
+	}
+	payer, payee := locked[req.FromAccountID], locked[req.ToAccountID]
+	source, target, revenue := locked[house.source.ID()], locked[house.target.ID()], locked[house.revenue.ID()]
+
+	if custErr := checkCustomer(payer, payee); custErr != nil {
+		return nil, custErr
+	}
+	if currErr := checkCurrency(quote.Source(), payer); currErr != nil {
+		return nil, currErr
+	}
+	if currErr := checkCurrency(quote.Target(), payee); currErr != nil {
+		return nil, currErr
+	}
+
+	if sendErr := checkSendable(ctx, tx, payer, payee, quote.Source()); sendErr != nil {
+		reason := entity.FailureReasonOf(sendErr)
+		if reason == entity.FailureUnknown {
+			return nil, sendErr
+		}
+		txn := entity.NewFailedConversion(req.FromAccountID, req.ToAccountID, quote, reason)
+		return uc.decline(ctx, tx, req.IdempotencyKey, req.Fingerprint(), txn, sendErr)
+	}
+
+	if debitErr := payer.Debit(quote.Source()); debitErr != nil {
+		txn := entity.NewFailedConversion(req.FromAccountID, req.ToAccountID, quote, entity.FailureReasonOf(debitErr))
+		return uc.decline(ctx, tx, req.IdempotencyKey, req.Fingerprint(), txn, debitErr)
+	}
+
+	txn := entity.NewConversion(req.FromAccountID, req.ToAccountID, quote, entity.StatusSuccess)
+	if bookErr := bookConversion(ctx, tx, quote, txn, payer, payee, source, target, revenue); bookErr != nil {
+		return nil, bookErr
+	}
+
+	quote.Use(txn.ID())
+	if updErr := tx.Quotes().Update(ctx, quote); updErr != nil {
+		return nil, updErr
+	}
+
+	return uc.saveAndReturn(ctx, tx, req.IdempotencyKey, req.Fingerprint(), &Response{
+		TransactionID: txn.ID().String(),
+		Status:        entity.StatusSuccess,
+	})
+}
+
+// houseAccounts are the house's side of a conversion.
+type houseAccounts struct {
+	source  *entity.Account
+	target  *entity.Account
+	revenue *entity.Account
+}
+
+// findHouse looks up, without locking, the house accounts a conversion at
+// quote books against; they are locked together with the customer accounts.
+func findHouse(ctx context.Context, tx repository.UnitOfWork, quote *entity.Quote) (*houseAccounts, error) {
+	source, err := tx.Accounts().FindSystem(ctx, entity.AccountFXLiquidity, quote.Source().Currency())
+	if err != nil {
+		return nil, err
+	}
+	target, err := tx.Accounts().FindSystem(ctx, entity.AccountFXLiquidity, quote.Target().Currency())
+	if err != nil {
+		return nil, err
+	}
+	revenue, err := tx.Accounts().FindSystem(ctx, entity.AccountFXRevenue, quote.Target().Currency())
+	if err != nil {
+		return nil, err
+	}
+	return &houseAccounts{source: source, target: target, revenue: revenue}, nil
+}
+
+// bookConversion completes a conversion whose source amount has already been
+// debited from payer. Postings net to zero in each currency: the liquidity
+// account in the target currency pays out the mid-market value, of which the
+// payee receives the quoted target and FX revenue the spread.
+func bookConversion(
+	ctx context.Context,
+	tx repository.UnitOfWork,
+	quote *entity.Quote,
+	txn *entity.Transaction,
+	payer, payee, source, target, revenue *entity.Account,
+) error {
+	atMid := quote.Target().WithAmount(quote.Target().Amount() + quote.Spread().Amount())
+
+	if err := source.Credit(quote.Source()); err != nil {
+		return err
+	}
+	if err := target.Debit(atMid); err != nil {
+		return err
+	}
+	if err := payee.Credit(quote.Target()); err != nil {
+		return err
+	}
+	touched := []*entity.Account{payer, source, target, payee}
+	if quote.Spread().IsPositive() {
+		if err := revenue.Credit(quote.Spread()); err != nil {
+			return err
+		}
+		touched = append(touched, revenue)
+	}
+
+	for _, a := range touched {
+		if err := tx.Accounts().UpdateBalance(ctx, a.ID(), a.Balance()); err != nil {
+			return err
+		}
+	}
+
+	txn.Debit(payer.ID(), quote.Source())
+	txn.Credit(source.ID(), quote.Source())
+	txn.Debit(target.ID(), atMid)
+	txn.Credit(payee.ID(), quote.Target())
+	if quote.Spread().IsPositive() {
+		txn.Credit(revenue.ID(), quote.Spread())
+	}
+	if err := txn.CheckBalanced(); err != nil {
+		return err
+	}
+
+	return record(ctx, tx, txn)
+}
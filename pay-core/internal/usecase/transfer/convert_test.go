@@ -0,0 +1,156 @@
+package transfer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer/mocks"
+)
+
+func TestTransferUseCase_Execute_ConversionBooksSpread(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	txnRepo := mocks.NewMockTransactionRepository(ctrl)
+	quoteRepo := mocks.NewMockQuoteRepository(ctrl)
+	idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+
+	uc := transfer.NewUseCase(uow)
+
+	payerID := uuid.New()
+	payeeID := uuid.New()
+	rubLiquidity := entity.NewSystemAccount(entity.AccountFXLiquidity, "RUB")
+	usdLiquidity := entity.NewSystemAccount(entity.AccountFXLiquidity, "USD")
+	usdRevenue := entity.NewSystemAccount(entity.AccountFXRevenue, "USD")
+
+	// 9000.00 RUB at a mid of 90 is worth 100.00 USD; the payee gets 99.00.
+	now := time.Now()
+	quote := entity.ReconstructQuote(
+		uuid.New(), rub(900000), usd(9900), usd(100), "0.0110000000", uuid.Nil, now.Add(time.Minute), now,
+	)
+
+	uow.EXPECT().Idempotency().Return(idempotencyRepo)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "fx-key").Return(nil, nil)
+
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+
+	txUow.EXPECT().Idempotency().Return(idempotencyRepo).Times(3)
+	idempotencyRepo.EXPECT().Lock(gomock.Any(), "fx-key").Return(nil)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "fx-key").Return(nil, nil)
+
+	txUow.EXPECT().Quotes().Return(quoteRepo).Times(2)
+	quoteRepo.EXPECT().FindByIDForUpdate(gomock.Any(), quote.ID()).Return(quote, nil)
+
+	txUow.EXPECT().Accounts().Return(accountRepo).Times(13)
+	expectNoLimits(ctrl, txUow)
+	accountRepo.EXPECT().FindSystem(gomock.Any(), entity.AccountFXLiquidity, entity.Currency("RUB")).
+		Return(rubLiquidity, nil)
+	accountRepo.EXPECT().FindSystem(gomock.Any(), entity.AccountFXLiquidity, entity.Currency("USD")).
+		Return(usdLiquidity, nil)
+	accountRepo.EXPECT().FindSystem(gomock.Any(), entity.AccountFXRevenue, entity.Currency("USD")).
+		Return(usdRevenue, nil)
+
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), payerID).Return(entity.NewAccount(payerID, rub(1000000)), nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), payeeID).Return(entity.NewAccount(payeeID, usd(0)), nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), rubLiquidity.ID()).Return(rubLiquidity, nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), usdLiquidity.ID()).Return(usdLiquidity, nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), usdRevenue.ID()).Return(usdRevenue, nil)
+
+	accountRepo.EXPECT().UpdateBalance(gomock.Any(), payerID, int64(100000)).Return(nil)
+	accountRepo.EXPECT().UpdateBalance(gomock.Any(), rubLiquidity.ID(), int64(900000)).Return(nil)
+	accountRepo.EXPECT().UpdateBalance(gomock.Any(), usdLiquidity.ID(), int64(-10000)).Return(nil)
+	accountRepo.EXPECT().UpdateBalance(gomock.Any(), payeeID, int64(9900)).Return(nil)
+	accountRepo.EXPECT().UpdateBalance(gomock.Any(), usdRevenue.ID(), int64(100)).Return(nil)
+
+	var created *entity.Transaction
+	txUow.EXPECT().Transactions().Return(txnRepo)
+	expectEvent(ctrl, txUow, entity.EventPaymentCompleted)
+	txnRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, txn *entity.Transaction) error {
+			created = txn
+			assert.Equal(t, rub(900000), txn.Money())
+			assert.Equal(t, usd(9900), txn.Credited())
+			assert.Equal(t, quote.ID(), txn.QuoteID())
+			assert.Len(t, txn.Postings(), 5)
+			assert.NoError(t, txn.CheckBalanced())
+			return nil
+		},
+	)
+	quoteRepo.EXPECT().Update(gomock.Any(), quote).DoAndReturn(
+		func(_ context.Context, q *entity.Quote) error {
+			assert.Equal(t, created.ID(), q.TransactionID())
+			return nil
+		},
+	)
+
+	idempotencyRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	resp, err := uc.Execute(context.Background(), transfer.Request{
+		IdempotencyKey: "fx-key",
+		FromAccountID:  payerID,
+		ToAccountID:    payeeID,
+		Amount:         rub(900000),
+		QuoteID:        quote.ID(),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.StatusSuccess, resp.Status)
+	assert.Equal(t, created.ID().String(), resp.TransactionID)
+}
+
+func TestTransferUseCase_Execute_ConversionRejectsUsedQuote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	quoteRepo := mocks.NewMockQuoteRepository(ctrl)
+	idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+
+	uc := transfer.NewUseCase(uow)
+
+	now := time.Now()
+	quote := entity.ReconstructQuote(
+		uuid.New(), rub(900000), usd(9900), usd(100), "0.0110000000", uuid.New(), now.Add(time.Minute), now,
+	)
+
+	uow.EXPECT().Idempotency().Return(idempotencyRepo)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "fx-key").Return(nil, nil)
+
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+
+	txUow.EXPECT().Idempotency().Return(idempotencyRepo).Times(2)
+	idempotencyRepo.EXPECT().Lock(gomock.Any(), "fx-key").Return(nil)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "fx-key").Return(nil, nil)
+
+	txUow.EXPECT().Quotes().Return(quoteRepo)
+	quoteRepo.EXPECT().FindByIDForUpdate(gomock.Any(), quote.ID()).Return(quote, nil)
+
+	_, err := uc.Execute(context.Background(), transfer.Request{
+		IdempotencyKey: "fx-key",
+		FromAccountID:  uuid.New(),
+		ToAccountID:    uuid.New(),
+		Amount:         rub(900000),
+		QuoteID:        quote.ID(),
+	})
+
+	require.ErrorIs(t, err, entity.ErrQuoteUsed)
+}
+
+func usd(amount int64) entity.Money {
+	return entity.ReconstructMoney(amount, "USD")
+}
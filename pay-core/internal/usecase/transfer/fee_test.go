@@ -0,0 +1,211 @@
+package transfer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer/mocks"
+)
+
+func TestTransferUseCase_Execute_ChargesFee(t *testing.T) {
+	tests := []struct {
+		name         string
+		transferType entity.TransferType
+		bearer       entity.FeeBearer
+		payerBalance int64
+		payeeBalance int64
+	}{
+		{
+			name:         "borne by payer",
+			transferType: entity.TransferP2P,
+			bearer:       entity.FeePayer,
+			payerBalance: 3950,
+			payeeBalance: 1000,
+		},
+		{
+			name:         "netted from merchant",
+			transferType: entity.TransferQRMerchant,
+			bearer:       entity.FeePayee,
+			payerBalance: 4000,
+			payeeBalance: 950,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uow := mocks.NewMockUnitOfWork(ctrl)
+			txUow := mocks.NewMockUnitOfWork(ctrl)
+			accountRepo := mocks.NewMockAccountRepository(ctrl)
+			txnRepo := mocks.NewMockTransactionRepository(ctrl)
+			feeRepo := mocks.NewMockFeeRepository(ctrl)
+			idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+
+			uc := transfer.NewUseCase(uow)
+
+			payerID := uuid.New()
+			payeeID := uuid.New()
+			payee := entity.NewAccount(payeeID, rub(0))
+			payee.SetTier("merchant")
+			revenue := entity.NewSystemAccount(entity.AccountFeeRevenue, "RUB")
+
+			// 10 + 5% of 1000 is 60, over the cap of 50.
+			schedule, err := entity.NewFeeSchedule(tt.transferType, entity.AnyTier, "RUB", 10, 500, 30, 50, tt.bearer)
+			require.NoError(t, err)
+			wantTier := entity.DefaultTier
+			if tt.transferType == entity.TransferQRMerchant {
+				wantTier = "merchant"
+			}
+
+			uow.EXPECT().Idempotency().Return(idempotencyRepo)
+			idempotencyRepo.EXPECT().Find(gomock.Any(), "fee-key").Return(nil, nil)
+
+			uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+			txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+
+			txUow.EXPECT().Idempotency().Return(idempotencyRepo).Times(3)
+			idempotencyRepo.EXPECT().Lock(gomock.Any(), "fee-key").Return(nil)
+			idempotencyRepo.EXPECT().Find(gomock.Any(), "fee-key").Return(nil, nil)
+
+			expectNoLimits(ctrl, txUow)
+			txUow.EXPECT().Fees().Return(feeRepo)
+			feeRepo.EXPECT().Find(gomock.Any(), tt.transferType, wantTier, entity.Currency("RUB")).Return(schedule, nil)
+
+			txUow.EXPECT().Accounts().Return(accountRepo).Times(7)
+			accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), payerID).Return(entity.NewAccount(payerID, rub(5000)), nil)
+			accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), payeeID).Return(payee, nil)
+			accountRepo.EXPECT().FindSystem(gomock.Any(), entity.AccountFeeRevenue, entity.Currency("RUB")).
+				Return(revenue, nil)
+			accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), revenue.ID()).Return(revenue, nil)
+
+			accountRepo.EXPECT().UpdateBalance(gomock.Any(), payerID, tt.payerBalance).Return(nil)
+			accountRepo.EXPECT().UpdateBalance(gomock.Any(), payeeID, tt.payeeBalance).Return(nil)
+			accountRepo.EXPECT().UpdateBalance(gomock.Any(), revenue.ID(), int64(50)).Return(nil)
+
+			txUow.EXPECT().Transactions().Return(txnRepo)
+			expectEvent(ctrl, txUow, entity.EventPaymentCompleted)
+			txnRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, txn *entity.Transaction) error {
+					assert.Equal(t, rub(1000), txn.Money())
+					assert.Equal(t, rub(50), txn.Fee())
+					assert.Len(t, txn.Postings(), 3)
+					assert.NoError(t, txn.CheckBalanced())
+					return nil
+				},
+			)
+			idempotencyRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+			txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+
+			resp, err := uc.Execute(context.Background(), transfer.Request{
+				IdempotencyKey: "fee-key",
+				FromAccountID:  payerID,
+				ToAccountID:    payeeID,
+				Amount:         rub(1000),
+				Type:           tt.transferType,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, entity.StatusSuccess, resp.Status)
+			assert.Equal(t, int64(50), resp.Fee)
+			assert.Equal(t, tt.bearer, resp.FeeBearer)
+		})
+	}
+}
+
+func TestTransferUseCase_Capture_ChargesMerchantFee(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	txnRepo := mocks.NewMockTransactionRepository(ctrl)
+	authRepo := mocks.NewMockAuthorizationRepository(ctrl)
+	feeRepo := mocks.NewMockFeeRepository(ctrl)
+	idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+
+	uc := transfer.NewUseCase(uow)
+
+	payerID := uuid.New()
+	payeeID := uuid.New()
+	payee := entity.NewAccount(payeeID, rub(0))
+	payee.SetTier("merchant")
+	revenue := entity.NewSystemAccount(entity.AccountFeeRevenue, "RUB")
+	now := time.Now()
+	auth := entity.ReconstructAuthorization(
+		uuid.New(), payerID, payeeID, rub(500), 0,
+		entity.AuthorizationActive, uuid.Nil, now.Add(time.Hour), now,
+	)
+
+	// 10 + 5% of 500 is 35, borne by the payer on top of the capture.
+	schedule, err := entity.NewFeeSchedule(entity.TransferQRMerchant, entity.AnyTier, "RUB", 10, 500, 0, 50, entity.FeePayer)
+	require.NoError(t, err)
+
+	uow.EXPECT().Idempotency().Return(idempotencyRepo)
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	txUow.EXPECT().Idempotency().Return(idempotencyRepo).Times(3)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "capture-fee-key").Return(nil, nil).Times(2)
+	idempotencyRepo.EXPECT().Lock(gomock.Any(), "capture-fee-key").Return(nil)
+
+	txUow.EXPECT().Authorizations().Return(authRepo).Times(2)
+	authRepo.EXPECT().FindByIDForUpdate(gomock.Any(), auth.ID()).Return(auth, nil)
+
+	txUow.EXPECT().Fees().Return(feeRepo)
+	feeRepo.EXPECT().Find(gomock.Any(), entity.TransferQRMerchant, entity.Tier("merchant"), entity.Currency("RUB")).
+		Return(schedule, nil)
+
+	txUow.EXPECT().Accounts().Return(accountRepo).Times(8)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), payerID).Return(
+		entity.ReconstructAccount(payerID, entity.AccountCustomer, entity.DefaultTier, rub(1000), 500, active, now), nil,
+	)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), payeeID).Return(payee, nil)
+	accountRepo.EXPECT().UpdateHeld(gomock.Any(), payerID, int64(0)).Return(nil)
+	accountRepo.EXPECT().FindSystem(gomock.Any(), entity.AccountFeeRevenue, entity.Currency("RUB")).Return(revenue, nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), revenue.ID()).Return(revenue, nil)
+	accountRepo.EXPECT().UpdateBalance(gomock.Any(), payerID, int64(465)).Return(nil)
+	accountRepo.EXPECT().UpdateBalance(gomock.Any(), payeeID, int64(500)).Return(nil)
+	accountRepo.EXPECT().UpdateBalance(gomock.Any(), revenue.ID(), int64(35)).Return(nil)
+
+	txUow.EXPECT().Transactions().Return(txnRepo)
+	expectEvent(ctrl, txUow, entity.EventPaymentCompleted)
+	txnRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, txn *entity.Transaction) error {
+			assert.Equal(t, rub(35), txn.Fee())
+			assert.NoError(t, txn.CheckBalanced())
+			return nil
+		},
+	)
+	authRepo.EXPECT().Update(gomock.Any(), auth).Return(nil)
+	idempotencyRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	resp, err := uc.Capture(context.Background(), transfer.CaptureRequest{
+		IdempotencyKey:  "capture-fee-key",
+		AuthorizationID: auth.ID(),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(35), resp.Fee)
+	assert.Equal(t, entity.FeePayer, resp.FeeBearer)
+}
+
+// expectNoFee answers the fee schedule lookup of a transfer as if no schedule
+// were configured.
+func expectNoFee(ctrl *gomock.Controller, tx *mocks.MockUnitOfWork) {
+	feeRepo := mocks.NewMockFeeRepository(ctrl)
+	tx.EXPECT().Fees().Return(feeRepo)
+	feeRepo.EXPECT().Find(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, repository.ErrFeeScheduleNotFound)
+}
@@ -0,0 +1,251 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+const DefaultIntentTTL = 15 * time.Minute
+
+type CreateIntentRequest struct {
+	ToAccountID uuid.UUID
+	Amount      entity.Money
+	// TTL is how long the intent stays payable; zero means DefaultIntentTTL.
+	TTL time.Duration
+}
+
+type PayIntentRequest struct {
+	IdempotencyKey string
+	FromAccountID  uuid.UUID
+	IntentID       uuid.UUID
+}
+
+func (r PayIntentRequest) Fingerprint() string {
+	return entity.RequestFingerprint(map[string]string{
+		"operation":       "pay_intent",
+		"from_account_id": r.FromAccountID.String(),
+		"intent_id":       r.IntentID.String(),
+	})
+}
+
+// CreateIntent opens a payment intent for the payee, which must be a customer
+// account in the amount's currency that may receive payments.
+func (uc *UseCase) CreateIntent(ctx context.Context, req CreateIntentRequest) (*entity.PaymentIntent, error) {
+	ttl := req.TTL
+	if ttl == 0 {
+		ttl = DefaultIntentTTL
+	}
+	intent, err := entity.NewPaymentIntent(req.ToAccountID, req.Amount, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	payee, err := uc.uow.Accounts().FindByID(ctx, req.ToAccountID)
+	if err != nil {
+		return nil, err
+	}
+	if custErr := checkCustomer(payee); custErr != nil {
+		return nil, custErr
+	}
+	if currErr := checkCurrency(req.Amount, payee); currErr != nil {
+		return nil, currErr
+	}
+	if recvErr := payee.CheckCanReceive(); recvErr != nil {
+		return nil, recvErr
+	}
+
+	if createErr := uc.uow.Intents().Create(ctx, intent); createErr != nil {
+		return nil, createErr
+	}
+	return intent, nil
+}
+
+// GetIntent returns the intent with its status as of now, expired if its
+// expiry has passed while it was open.
+func (uc *UseCase) GetIntent(ctx context.Context, id uuid.UUID) (*entity.PaymentIntent, error) {
+	intent, err := uc.uow.Intents().FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	intent.Refresh(time.Now())
+	return intent, nil
+}
+
+// CancelIntent withdraws an open intent so that it can no longer be paid.
+// Cancelling an intent that is already cancelled returns it unchanged.
+func (uc *UseCase) CancelIntent(ctx context.Context, id uuid.UUID) (*entity.PaymentIntent, error) {
+	var intent *entity.PaymentIntent
+	err := uc.retry(ctx, func() error {
+		var execErr error
+		intent, execErr = uc.cancelIntent(ctx, id)
+		return execErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return intent, nil
+}
+
+func (uc *UseCase) cancelIntent(ctx context.Context, id uuid.UUID) (*entity.PaymentIntent, error) {
+	tx, err := uc.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	intent, err := lockIntent(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if intent.Status() == entity.IntentCancelled {
+		return intent, nil
+	}
+
+	if cancelErr := intent.Cancel(); cancelErr != nil {
+		return nil, cancelErr
+	}
+	if updErr := tx.Intents().Update(ctx, intent); updErr != nil {
+		return nil, updErr
+	}
+
+	if commitErr := tx.Commit(ctx); commitErr != nil {
+		return nil, commitErr
+	}
+	return intent, nil
+}
+
+// PayIntent pays an open intent from the payer's account as a QR merchant
+// payment of the intent's amount to its payee. The intent is marked paid in
+// the same unit of work, so that of concurrent attempts to pay it exactly one
+// succeeds and the others see it paid. A payment the payer's account declines
+// is recorded as a plain declined transfer and leaves the intent open.
+func (uc *UseCase) PayIntent(ctx context.Context, req PayIntentRequest) (*Response, error) {
+	cached, err := uc.uow.Idempotency().Find(ctx, req.IdempotencyKey)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	if cached != nil {
+		return uc.replay(cached, req.Fingerprint())
+	}
+
+	var resp *Response
+	err = uc.retry(ctx, func() error {
+		var execErr error
+		resp, execErr = uc.payIntent(ctx, req)
+		return execErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (uc *UseCase) payIntent(ctx context.Context, req PayIntentRequest) (*Response, error) {
+	tx, err := uc.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	fingerprint := req.Fingerprint()
+	cached, err := lockKey(ctx, tx, req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil {
+		return uc.replay(cached, fingerprint)
+	}
+
+	intent, err := lockIntent(ctx, tx, req.IntentID)
+	if err != nil {
+		return nil, err
+	}
+	if payErr := intent.CheckPayable(); payErr != nil {
+		return nil, payErr
+	}
+	if req.FromAccountID == intent.ToAccount() {
+		return nil, ErrSameAccount
+	}
+
+	sender, receiver, err := lockAccounts(ctx, tx, req.FromAccountID, intent.ToAccount())
+	if err != nil {
+		return nil, err
+	}
+	if custErr := checkCustomer(sender, receiver); custErr != nil {
+		return nil, custErr
+	}
+	if currErr := checkCurrency(intent.Money(), sender, receiver); currErr != nil {
+		return nil, currErr
+	}
+
+	if sendErr := checkSendable(ctx, tx, sender, receiver, intent.Money()); sendErr != nil {
+		reason := entity.FailureReasonOf(sendErr)
+		if reason == entity.FailureUnknown {
+			return nil, sendErr
+		}
+		failed := entity.NewFailedTransaction(req.FromAccountID, intent.ToAccount(), intent.Money(), reason)
+		return uc.decline(ctx, tx, req.IdempotencyKey, fingerprint, failed, sendErr)
+	}
+
+	txn := entity.NewTransaction(req.FromAccountID, intent.ToAccount(), intent.Money(), entity.StatusSuccess)
+	bearer, err := chargeFee(ctx, tx, entity.TransferQRMerchant, sender, receiver, txn)
+	if err != nil {
+		return nil, err
+	}
+
+	if debitErr := sender.Debit(txn.Debited()); debitErr != nil {
+		failed := entity.NewFailedTransaction(
+			req.FromAccountID, intent.ToAccount(), intent.Money(), entity.FailureReasonOf(debitErr))
+		return uc.decline(ctx, tx, req.IdempotencyKey, fingerprint, failed, debitErr)
+	}
+
+	var revenue *entity.Account
+	if txn.Fee().IsPositive() {
+		if revenue, err = lockFeeRevenue(ctx, tx, txn.Currency()); err != nil {
+			return nil, err
+		}
+	}
+
+	if bookErr := book(ctx, tx, sender, receiver, revenue, txn); bookErr != nil {
+		return nil, bookErr
+	}
+
+	intent.Pay(req.FromAccountID, txn.ID(), time.Now())
+	if updErr := tx.Intents().Update(ctx, intent); updErr != nil {
+		return nil, updErr
+	}
+
+	return uc.saveAndReturn(ctx, tx, req.IdempotencyKey, fingerprint, &Response{
+		TransactionID: txn.ID().String(),
+		Status:        entity.StatusSuccess,
+		Fee:           txn.Fee().Amount(),
+		FeeBearer:     bearer,
+	})
+}
+
+// lockIntent locks the intent for the rest of tx. An intent found to have
+// expired is saved and committed as expired, and entity.ErrIntentExpired is
+// returned in its place.
+func lockIntent(ctx context.Context, tx repository.UnitOfWork, id uuid.UUID) (*entity.PaymentIntent, error) {
+	intent, err := tx.Intents().FindByIDForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !intent.Refresh(time.Now()) {
+		return intent, nil
+	}
+
+	if updErr := tx.Intents().Update(ctx, intent); updErr != nil {
+		return nil, updErr
+	}
+	if commitErr := tx.Commit(ctx); commitErr != nil {
+		return nil, commitErr
+	}
+	return nil, entity.ErrIntentExpired
+}
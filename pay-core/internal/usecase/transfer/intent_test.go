@@ -0,0 +1,197 @@
+package transfer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer/mocks"
+)
+
+func TestTransferUseCase_PayIntent_MarksIntentPaid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	txnRepo := mocks.NewMockTransactionRepository(ctrl)
+	intentRepo := mocks.NewMockIntentRepository(ctrl)
+	idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+
+	uc := transfer.NewUseCase(uow)
+
+	payerID := uuid.New()
+	payeeID := uuid.New()
+	now := time.Now()
+	intent := entity.ReconstructPaymentIntent(
+		uuid.New(), payeeID, rub(1500), entity.IntentCreated, uuid.Nil, uuid.Nil,
+		now.Add(time.Minute), now, time.Time{},
+	)
+
+	uow.EXPECT().Idempotency().Return(idempotencyRepo)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "pos-key").Return(nil, nil)
+
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+
+	txUow.EXPECT().Idempotency().Return(idempotencyRepo).Times(3)
+	idempotencyRepo.EXPECT().Lock(gomock.Any(), "pos-key").Return(nil)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "pos-key").Return(nil, nil)
+
+	txUow.EXPECT().Intents().Return(intentRepo).Times(2)
+	intentRepo.EXPECT().FindByIDForUpdate(gomock.Any(), intent.ID()).Return(intent, nil)
+
+	txUow.EXPECT().Accounts().Return(accountRepo).Times(4)
+	expectNoLimits(ctrl, txUow)
+	feeRepo := mocks.NewMockFeeRepository(ctrl)
+	txUow.EXPECT().Fees().Return(feeRepo)
+	feeRepo.EXPECT().Find(gomock.Any(), entity.TransferQRMerchant, gomock.Any(), entity.Currency("RUB")).
+		Return(nil, repository.ErrFeeScheduleNotFound)
+
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), payerID).Return(entity.NewAccount(payerID, rub(5000)), nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), payeeID).Return(entity.NewAccount(payeeID, rub(0)), nil)
+	accountRepo.EXPECT().UpdateBalance(gomock.Any(), payerID, int64(3500)).Return(nil)
+	accountRepo.EXPECT().UpdateBalance(gomock.Any(), payeeID, int64(1500)).Return(nil)
+
+	var created *entity.Transaction
+	txUow.EXPECT().Transactions().Return(txnRepo)
+	expectEvent(ctrl, txUow, entity.EventPaymentCompleted)
+	txnRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, txn *entity.Transaction) error {
+			created = txn
+			return nil
+		},
+	)
+	intentRepo.EXPECT().Update(gomock.Any(), intent).DoAndReturn(
+		func(_ context.Context, i *entity.PaymentIntent) error {
+			assert.Equal(t, entity.IntentPaid, i.Status())
+			assert.Equal(t, payerID, i.FromAccount())
+			assert.Equal(t, created.ID(), i.TransactionID())
+			return nil
+		},
+	)
+
+	idempotencyRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	resp, err := uc.PayIntent(context.Background(), transfer.PayIntentRequest{
+		IdempotencyKey: "pos-key",
+		FromAccountID:  payerID,
+		IntentID:       intent.ID(),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.StatusSuccess, resp.Status)
+	assert.Equal(t, created.ID().String(), resp.TransactionID)
+}
+
+func TestTransferUseCase_PayIntent_RejectsPaidIntent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	intentRepo := mocks.NewMockIntentRepository(ctrl)
+	idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+
+	uc := transfer.NewUseCase(uow)
+
+	now := time.Now()
+	intent := entity.ReconstructPaymentIntent(
+		uuid.New(), uuid.New(), rub(1500), entity.IntentPaid, uuid.New(), uuid.New(),
+		now.Add(time.Minute), now, now,
+	)
+
+	uow.EXPECT().Idempotency().Return(idempotencyRepo)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "second-key").Return(nil, nil)
+
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+
+	txUow.EXPECT().Idempotency().Return(idempotencyRepo).Times(2)
+	idempotencyRepo.EXPECT().Lock(gomock.Any(), "second-key").Return(nil)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "second-key").Return(nil, nil)
+
+	txUow.EXPECT().Intents().Return(intentRepo)
+	intentRepo.EXPECT().FindByIDForUpdate(gomock.Any(), intent.ID()).Return(intent, nil)
+
+	_, err := uc.PayIntent(context.Background(), transfer.PayIntentRequest{
+		IdempotencyKey: "second-key",
+		FromAccountID:  uuid.New(),
+		IntentID:       intent.ID(),
+	})
+
+	require.ErrorIs(t, err, entity.ErrIntentNotOpen)
+}
+
+func TestTransferUseCase_PayIntent_RecordsExpiry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	intentRepo := mocks.NewMockIntentRepository(ctrl)
+	idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+
+	uc := transfer.NewUseCase(uow)
+
+	past := time.Now().Add(-time.Hour)
+	intent := entity.ReconstructPaymentIntent(
+		uuid.New(), uuid.New(), rub(1500), entity.IntentCreated, uuid.Nil, uuid.Nil,
+		past, past.Add(-time.Minute), time.Time{},
+	)
+
+	uow.EXPECT().Idempotency().Return(idempotencyRepo)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "late-key").Return(nil, nil)
+
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+
+	txUow.EXPECT().Idempotency().Return(idempotencyRepo).Times(2)
+	idempotencyRepo.EXPECT().Lock(gomock.Any(), "late-key").Return(nil)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "late-key").Return(nil, nil)
+
+	txUow.EXPECT().Intents().Return(intentRepo).Times(2)
+	intentRepo.EXPECT().FindByIDForUpdate(gomock.Any(), intent.ID()).Return(intent, nil)
+	intentRepo.EXPECT().Update(gomock.Any(), intent).Return(nil)
+	txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	_, err := uc.PayIntent(context.Background(), transfer.PayIntentRequest{
+		IdempotencyKey: "late-key",
+		FromAccountID:  uuid.New(),
+		IntentID:       intent.ID(),
+	})
+
+	require.ErrorIs(t, err, entity.ErrIntentExpired)
+	assert.Equal(t, entity.IntentExpired, intent.Status())
+}
+
+func TestTransferUseCase_CreateIntent_RejectsCurrencyOtherThanPayees(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+
+	uc := transfer.NewUseCase(uow)
+
+	payeeID := uuid.New()
+	uow.EXPECT().Accounts().Return(accountRepo)
+	accountRepo.EXPECT().FindByID(gomock.Any(), payeeID).Return(entity.NewAccount(payeeID, rub(0)), nil)
+
+	_, err := uc.CreateIntent(context.Background(), transfer.CreateIntentRequest{
+		ToAccountID: payeeID,
+		Amount:      usd(1000),
+	})
+
+	require.ErrorIs(t, err, entity.ErrCurrencyMismatch)
+}
@@ -0,0 +1,166 @@
+package transfer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer/mocks"
+)
+
+func TestTransferUseCase_Execute_DeclinesOverLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		usage entity.Usage
+		want  entity.LimitKind
+	}{
+		{
+			name:  "single",
+			usage: entity.Usage{},
+			want:  entity.LimitSingle,
+		},
+		{
+			name:  "daily",
+			usage: entity.Usage{Day: 9500, Month: 9500},
+			want:  entity.LimitDaily,
+		},
+		{
+			name:  "hourly count",
+			usage: entity.Usage{Day: 100, Month: 100, LastHourCount: 3},
+			want:  entity.LimitHourlyCount,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uow := mocks.NewMockUnitOfWork(ctrl)
+			txUow := mocks.NewMockUnitOfWork(ctrl)
+			accountRepo := mocks.NewMockAccountRepository(ctrl)
+			txnRepo := mocks.NewMockTransactionRepository(ctrl)
+			limitRepo := mocks.NewMockLimitRepository(ctrl)
+			idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+
+			uc := transfer.NewUseCase(uow)
+
+			fromID := uuid.New()
+			toID := uuid.New()
+			amount := rub(1000)
+			if tt.want == entity.LimitSingle {
+				amount = rub(3000)
+			}
+
+			limits, err := entity.NewTransferLimits(uuid.Nil, entity.DefaultTier, "RUB", 2000, 10000, 0, 3)
+			require.NoError(t, err)
+
+			uow.EXPECT().Idempotency().Return(idempotencyRepo)
+			idempotencyRepo.EXPECT().Find(gomock.Any(), "limit-key").Return(nil, nil)
+
+			uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+			txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+
+			txUow.EXPECT().Idempotency().Return(idempotencyRepo).Times(3)
+			idempotencyRepo.EXPECT().Lock(gomock.Any(), "limit-key").Return(nil)
+			idempotencyRepo.EXPECT().Find(gomock.Any(), "limit-key").Return(nil, nil)
+
+			txUow.EXPECT().Accounts().Return(accountRepo).Times(2)
+			accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), fromID).Return(entity.NewAccount(fromID, rub(50000)), nil)
+			accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), toID).Return(entity.NewAccount(toID, rub(0)), nil)
+
+			txUow.EXPECT().Limits().Return(limitRepo)
+			limitRepo.EXPECT().Find(gomock.Any(), fromID, entity.DefaultTier, entity.Currency("RUB")).Return(limits, nil)
+
+			txUow.EXPECT().Transactions().Return(txnRepo).Times(2)
+			txnRepo.EXPECT().OutgoingUsage(gomock.Any(), fromID, gomock.Any()).Return(tt.usage, nil)
+			expectEvent(ctrl, txUow, entity.EventPaymentFailed)
+			txnRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, txn *entity.Transaction) error {
+					assert.Equal(t, entity.StatusFailed, txn.Status())
+					assert.Equal(t, entity.FailureLimitExceeded, txn.FailureReason())
+					assert.Empty(t, txn.Postings())
+					return nil
+				},
+			)
+			idempotencyRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+			txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+
+			resp, err := uc.Execute(context.Background(), transfer.Request{
+				IdempotencyKey: "limit-key",
+				FromAccountID:  fromID,
+				ToAccountID:    toID,
+				Amount:         amount,
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, entity.StatusFailed, resp.Status)
+			assert.Equal(t, entity.FailureLimitExceeded, resp.FailureReason)
+			assert.Equal(t, tt.want, resp.ExceededLimit)
+		})
+	}
+}
+
+func TestTransferUseCase_Authorize_DeclinesOverSingleLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	txnRepo := mocks.NewMockTransactionRepository(ctrl)
+	limitRepo := mocks.NewMockLimitRepository(ctrl)
+	idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+
+	uc := transfer.NewUseCase(uow)
+
+	payerID := uuid.New()
+	payeeID := uuid.New()
+
+	limits, err := entity.NewTransferLimits(uuid.Nil, entity.DefaultTier, "RUB", 2000, 10000, 0, 3)
+	require.NoError(t, err)
+
+	uow.EXPECT().Idempotency().Return(idempotencyRepo)
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	txUow.EXPECT().Idempotency().Return(idempotencyRepo).Times(2)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "auth-limit-key").Return(nil, nil).Times(2)
+	idempotencyRepo.EXPECT().Lock(gomock.Any(), "auth-limit-key").Return(nil)
+
+	txUow.EXPECT().Accounts().Return(accountRepo).Times(2)
+	accountRepo.EXPECT().FindByID(gomock.Any(), payeeID).Return(entity.NewAccount(payeeID, rub(0)), nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), payerID).Return(entity.NewAccount(payerID, rub(50000)), nil)
+
+	txUow.EXPECT().Limits().Return(limitRepo)
+	limitRepo.EXPECT().Find(gomock.Any(), payerID, entity.DefaultTier, entity.Currency("RUB")).Return(limits, nil)
+	txUow.EXPECT().Transactions().Return(txnRepo)
+	txnRepo.EXPECT().OutgoingUsage(gomock.Any(), payerID, gomock.Any()).Return(entity.Usage{}, nil)
+
+	_, err = uc.Authorize(context.Background(), transfer.AuthorizeRequest{
+		IdempotencyKey: "auth-limit-key",
+		FromAccountID:  payerID,
+		ToAccountID:    payeeID,
+		Amount:         rub(3000),
+	})
+
+	require.ErrorIs(t, err, entity.ErrLimitExceeded)
+	var limitErr *entity.LimitError
+	require.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, entity.LimitSingle, limitErr.Kind)
+}
+
+// expectNoLimits answers the limits lookup of a transfer as if none were
+// configured for the sender.
+func expectNoLimits(ctrl *gomock.Controller, tx *mocks.MockUnitOfWork) {
+	limitRepo := mocks.NewMockLimitRepository(ctrl)
+	tx.EXPECT().Limits().Return(limitRepo)
+	limitRepo.EXPECT().Find(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, repository.ErrLimitsNotFound)
+}
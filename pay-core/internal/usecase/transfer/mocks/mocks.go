@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/Xausdorf/qr-pay-hub/internal/domain/repository (interfaces: UnitOfWork,AccountRepository,TransactionRepository,IdempotencyRepository)
+// Source: github.com/Xausdorf/qr-pay-hub/internal/domain/repository (interfaces: UnitOfWork,AccountRepository,TransactionRepository,AuthorizationRepository,RateRepository,QuoteRepository,FeeRepository,LimitRepository,RequisitesRepository,OutboxRepository,WebhookRepository,BatchRepository,SplitRepository,IntentRepository,IdempotencyRepository)
 
 package mocks
 
@@ -94,6 +94,138 @@ func (mr *MockUnitOfWorkMockRecorder) Transactions() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transactions", reflect.TypeOf((*MockUnitOfWork)(nil).Transactions))
 }
 
+func (m *MockUnitOfWork) Authorizations() repository.AuthorizationRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Authorizations")
+	ret0, _ := ret[0].(repository.AuthorizationRepository)
+	return ret0
+}
+
+func (mr *MockUnitOfWorkMockRecorder) Authorizations() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Authorizations", reflect.TypeOf((*MockUnitOfWork)(nil).Authorizations))
+}
+
+func (m *MockUnitOfWork) Rates() repository.RateRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rates")
+	ret0, _ := ret[0].(repository.RateRepository)
+	return ret0
+}
+
+func (mr *MockUnitOfWorkMockRecorder) Rates() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rates", reflect.TypeOf((*MockUnitOfWork)(nil).Rates))
+}
+
+func (m *MockUnitOfWork) Quotes() repository.QuoteRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Quotes")
+	ret0, _ := ret[0].(repository.QuoteRepository)
+	return ret0
+}
+
+func (mr *MockUnitOfWorkMockRecorder) Quotes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Quotes", reflect.TypeOf((*MockUnitOfWork)(nil).Quotes))
+}
+
+func (m *MockUnitOfWork) Fees() repository.FeeRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fees")
+	ret0, _ := ret[0].(repository.FeeRepository)
+	return ret0
+}
+
+func (mr *MockUnitOfWorkMockRecorder) Fees() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fees", reflect.TypeOf((*MockUnitOfWork)(nil).Fees))
+}
+
+func (m *MockUnitOfWork) Limits() repository.LimitRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Limits")
+	ret0, _ := ret[0].(repository.LimitRepository)
+	return ret0
+}
+
+func (mr *MockUnitOfWorkMockRecorder) Limits() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Limits", reflect.TypeOf((*MockUnitOfWork)(nil).Limits))
+}
+
+func (m *MockUnitOfWork) Requisites() repository.RequisitesRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Requisites")
+	ret0, _ := ret[0].(repository.RequisitesRepository)
+	return ret0
+}
+
+func (mr *MockUnitOfWorkMockRecorder) Requisites() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Requisites", reflect.TypeOf((*MockUnitOfWork)(nil).Requisites))
+}
+
+func (m *MockUnitOfWork) Outbox() repository.OutboxRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Outbox")
+	ret0, _ := ret[0].(repository.OutboxRepository)
+	return ret0
+}
+
+func (mr *MockUnitOfWorkMockRecorder) Outbox() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Outbox", reflect.TypeOf((*MockUnitOfWork)(nil).Outbox))
+}
+
+func (m *MockUnitOfWork) Webhooks() repository.WebhookRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Webhooks")
+	ret0, _ := ret[0].(repository.WebhookRepository)
+	return ret0
+}
+
+func (mr *MockUnitOfWorkMockRecorder) Webhooks() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Webhooks", reflect.TypeOf((*MockUnitOfWork)(nil).Webhooks))
+}
+
+func (m *MockUnitOfWork) Batches() repository.BatchRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Batches")
+	ret0, _ := ret[0].(repository.BatchRepository)
+	return ret0
+}
+
+func (mr *MockUnitOfWorkMockRecorder) Batches() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Batches", reflect.TypeOf((*MockUnitOfWork)(nil).Batches))
+}
+
+func (m *MockUnitOfWork) Splits() repository.SplitRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Splits")
+	ret0, _ := ret[0].(repository.SplitRepository)
+	return ret0
+}
+
+func (mr *MockUnitOfWorkMockRecorder) Splits() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Splits", reflect.TypeOf((*MockUnitOfWork)(nil).Splits))
+}
+
+func (m *MockUnitOfWork) Intents() repository.IntentRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Intents")
+	ret0, _ := ret[0].(repository.IntentRepository)
+	return ret0
+}
+
+func (mr *MockUnitOfWorkMockRecorder) Intents() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Intents", reflect.TypeOf((*MockUnitOfWork)(nil).Intents))
+}
+
 func (m *MockUnitOfWork) Idempotency() repository.IdempotencyRepository {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Idempotency")
@@ -150,6 +282,93 @@ func (mr *MockAccountRepositoryMockRecorder) UpdateBalance(ctx, id, newBalance a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBalance", reflect.TypeOf((*MockAccountRepository)(nil).UpdateBalance), ctx, id, newBalance)
 }
 
+func (m *MockAccountRepository) UpdateHeld(ctx context.Context, id uuid.UUID, newHeld int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHeld", ctx, id, newHeld)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockAccountRepositoryMockRecorder) UpdateHeld(ctx, id, newHeld any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHeld", reflect.TypeOf((*MockAccountRepository)(nil).UpdateHeld), ctx, id, newHeld)
+}
+
+func (m *MockAccountRepository) UpdateStatus(ctx context.Context, account *entity.Account) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, account)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockAccountRepositoryMockRecorder) UpdateStatus(ctx, account any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockAccountRepository)(nil).UpdateStatus), ctx, account)
+}
+
+func (m *MockAccountRepository) Create(ctx context.Context, account *entity.Account) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, account)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockAccountRepositoryMockRecorder) Create(ctx, account any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAccountRepository)(nil).Create), ctx, account)
+}
+
+func (m *MockAccountRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockAccountRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockAccountRepository)(nil).FindByID), ctx, id)
+}
+
+func (m *MockAccountRepository) List(ctx context.Context, after *repository.Cursor, limit int) ([]*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, after, limit)
+	ret0, _ := ret[0].([]*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockAccountRepositoryMockRecorder) List(ctx, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAccountRepository)(nil).List), ctx, after, limit)
+}
+
+func (m *MockAccountRepository) FindSystem(ctx context.Context, kind entity.AccountKind, currency entity.Currency) (*entity.Account, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindSystem", ctx, kind, currency)
+	ret0, _ := ret[0].(*entity.Account)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockAccountRepositoryMockRecorder) FindSystem(ctx, kind, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindSystem", reflect.TypeOf((*MockAccountRepository)(nil).FindSystem), ctx, kind, currency)
+}
+
+func (m *MockAccountRepository) EnsureSystem(ctx context.Context, kind entity.AccountKind, currency entity.Currency) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureSystem", ctx, kind, currency)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockAccountRepositoryMockRecorder) EnsureSystem(ctx, kind, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureSystem", reflect.TypeOf((*MockAccountRepository)(nil).EnsureSystem), ctx, kind, currency)
+}
+
 type MockTransactionRepository struct {
 	ctrl     *gomock.Controller
 	recorder *MockTransactionRepositoryMockRecorder
@@ -181,60 +400,935 @@ func (mr *MockTransactionRepositoryMockRecorder) Create(ctx, tx any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTransactionRepository)(nil).Create), ctx, tx)
 }
 
-type MockIdempotencyRepository struct {
+func (m *MockTransactionRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockTransactionRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockTransactionRepository)(nil).FindByID), ctx, id)
+}
+
+func (m *MockTransactionRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockTransactionRepositoryMockRecorder) FindByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDForUpdate", reflect.TypeOf((*MockTransactionRepository)(nil).FindByIDForUpdate), ctx, id)
+}
+
+func (m *MockTransactionRepository) RefundedAmount(ctx context.Context, originalID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefundedAmount", ctx, originalID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockTransactionRepositoryMockRecorder) RefundedAmount(ctx, originalID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefundedAmount", reflect.TypeOf((*MockTransactionRepository)(nil).RefundedAmount), ctx, originalID)
+}
+
+func (m *MockTransactionRepository) List(ctx context.Context, filter repository.TransactionFilter) ([]*entity.Transaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, filter)
+	ret0, _ := ret[0].([]*entity.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockTransactionRepositoryMockRecorder) List(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTransactionRepository)(nil).List), ctx, filter)
+}
+
+func (m *MockTransactionRepository) OutgoingUsage(ctx context.Context, accountID uuid.UUID, windows entity.UsageWindows) (entity.Usage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutgoingUsage", ctx, accountID, windows)
+	ret0, _ := ret[0].(entity.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockTransactionRepositoryMockRecorder) OutgoingUsage(ctx, accountID, windows any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutgoingUsage", reflect.TypeOf((*MockTransactionRepository)(nil).OutgoingUsage), ctx, accountID, windows)
+}
+
+type MockAuthorizationRepository struct {
 	ctrl     *gomock.Controller
-	recorder *MockIdempotencyRepositoryMockRecorder
+	recorder *MockAuthorizationRepositoryMockRecorder
 }
 
-type MockIdempotencyRepositoryMockRecorder struct {
-	mock *MockIdempotencyRepository
+type MockAuthorizationRepositoryMockRecorder struct {
+	mock *MockAuthorizationRepository
 }
 
-func NewMockIdempotencyRepository(ctrl *gomock.Controller) *MockIdempotencyRepository {
-	mock := &MockIdempotencyRepository{ctrl: ctrl}
-	mock.recorder = &MockIdempotencyRepositoryMockRecorder{mock}
+func NewMockAuthorizationRepository(ctrl *gomock.Controller) *MockAuthorizationRepository {
+	mock := &MockAuthorizationRepository{ctrl: ctrl}
+	mock.recorder = &MockAuthorizationRepositoryMockRecorder{mock}
 	return mock
 }
 
-func (m *MockIdempotencyRepository) EXPECT() *MockIdempotencyRepositoryMockRecorder {
+func (m *MockAuthorizationRepository) EXPECT() *MockAuthorizationRepositoryMockRecorder {
 	return m.recorder
 }
 
-func (m *MockIdempotencyRepository) Find(ctx context.Context, key string) (*entity.IdempotencyRecord, error) {
+func (m *MockAuthorizationRepository) Create(ctx context.Context, auth *entity.Authorization) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Find", ctx, key)
-	ret0, _ := ret[0].(*entity.IdempotencyRecord)
+	ret := m.ctrl.Call(m, "Create", ctx, auth)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockAuthorizationRepositoryMockRecorder) Create(ctx, auth any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAuthorizationRepository)(nil).Create), ctx, auth)
+}
+
+func (m *MockAuthorizationRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Authorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Authorization)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-func (mr *MockIdempotencyRepositoryMockRecorder) Find(ctx, key any) *gomock.Call {
+func (mr *MockAuthorizationRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockIdempotencyRepository)(nil).Find), ctx, key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockAuthorizationRepository)(nil).FindByID), ctx, id)
 }
 
-func (m *MockIdempotencyRepository) Save(ctx context.Context, record *entity.IdempotencyRecord) error {
+func (m *MockAuthorizationRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Authorization, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Save", ctx, record)
+	ret := m.ctrl.Call(m, "FindByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*entity.Authorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockAuthorizationRepositoryMockRecorder) FindByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDForUpdate", reflect.TypeOf((*MockAuthorizationRepository)(nil).FindByIDForUpdate), ctx, id)
+}
+
+func (m *MockAuthorizationRepository) ListExpiredForUpdate(ctx context.Context, now time.Time, limit int) ([]*entity.Authorization, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListExpiredForUpdate", ctx, now, limit)
+	ret0, _ := ret[0].([]*entity.Authorization)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockAuthorizationRepositoryMockRecorder) ListExpiredForUpdate(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListExpiredForUpdate", reflect.TypeOf((*MockAuthorizationRepository)(nil).ListExpiredForUpdate), ctx, now, limit)
+}
+
+func (m *MockAuthorizationRepository) Update(ctx context.Context, auth *entity.Authorization) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, auth)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-func (mr *MockIdempotencyRepositoryMockRecorder) Save(ctx, record any) *gomock.Call {
+func (mr *MockAuthorizationRepositoryMockRecorder) Update(ctx, auth any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockIdempotencyRepository)(nil).Save), ctx, record)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAuthorizationRepository)(nil).Update), ctx, auth)
 }
 
-func (m *MockIdempotencyRepository) Lock(ctx context.Context, key string) error {
+type MockRateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRateRepositoryMockRecorder
+}
+
+type MockRateRepositoryMockRecorder struct {
+	mock *MockRateRepository
+}
+
+func NewMockRateRepository(ctrl *gomock.Controller) *MockRateRepository {
+	mock := &MockRateRepository{ctrl: ctrl}
+	mock.recorder = &MockRateRepositoryMockRecorder{mock}
+	return mock
+}
+
+func (m *MockRateRepository) EXPECT() *MockRateRepositoryMockRecorder {
+	return m.recorder
+}
+
+func (m *MockRateRepository) Upsert(ctx context.Context, rate *entity.Rate) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Lock", ctx, key)
+	ret := m.ctrl.Call(m, "Upsert", ctx, rate)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-func (mr *MockIdempotencyRepositoryMockRecorder) Lock(ctx, key any) *gomock.Call {
+func (mr *MockRateRepositoryMockRecorder) Upsert(ctx, rate any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockIdempotencyRepository)(nil).Lock), ctx, key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockRateRepository)(nil).Upsert), ctx, rate)
+}
+
+func (m *MockRateRepository) Find(ctx context.Context, base entity.Currency, quote entity.Currency) (*entity.Rate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, base, quote)
+	ret0, _ := ret[0].(*entity.Rate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockRateRepositoryMockRecorder) Find(ctx, base, quote any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockRateRepository)(nil).Find), ctx, base, quote)
+}
+
+func (m *MockRateRepository) List(ctx context.Context) ([]*entity.Rate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*entity.Rate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockRateRepositoryMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRateRepository)(nil).List), ctx)
+}
+
+type MockQuoteRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockQuoteRepositoryMockRecorder
+}
+
+type MockQuoteRepositoryMockRecorder struct {
+	mock *MockQuoteRepository
+}
+
+func NewMockQuoteRepository(ctrl *gomock.Controller) *MockQuoteRepository {
+	mock := &MockQuoteRepository{ctrl: ctrl}
+	mock.recorder = &MockQuoteRepositoryMockRecorder{mock}
+	return mock
+}
+
+func (m *MockQuoteRepository) EXPECT() *MockQuoteRepositoryMockRecorder {
+	return m.recorder
+}
+
+func (m *MockQuoteRepository) Create(ctx context.Context, quote *entity.Quote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, quote)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockQuoteRepositoryMockRecorder) Create(ctx, quote any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockQuoteRepository)(nil).Create), ctx, quote)
+}
+
+func (m *MockQuoteRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*entity.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockQuoteRepositoryMockRecorder) FindByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDForUpdate", reflect.TypeOf((*MockQuoteRepository)(nil).FindByIDForUpdate), ctx, id)
+}
+
+func (m *MockQuoteRepository) Update(ctx context.Context, quote *entity.Quote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, quote)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockQuoteRepositoryMockRecorder) Update(ctx, quote any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockQuoteRepository)(nil).Update), ctx, quote)
+}
+
+type MockFeeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeeRepositoryMockRecorder
+}
+
+type MockFeeRepositoryMockRecorder struct {
+	mock *MockFeeRepository
+}
+
+func NewMockFeeRepository(ctrl *gomock.Controller) *MockFeeRepository {
+	mock := &MockFeeRepository{ctrl: ctrl}
+	mock.recorder = &MockFeeRepositoryMockRecorder{mock}
+	return mock
+}
+
+func (m *MockFeeRepository) EXPECT() *MockFeeRepositoryMockRecorder {
+	return m.recorder
+}
+
+func (m *MockFeeRepository) Upsert(ctx context.Context, schedule *entity.FeeSchedule) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, schedule)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockFeeRepositoryMockRecorder) Upsert(ctx, schedule any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockFeeRepository)(nil).Upsert), ctx, schedule)
+}
+
+func (m *MockFeeRepository) Find(ctx context.Context, transferType entity.TransferType, tier entity.Tier, currency entity.Currency) (*entity.FeeSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, transferType, tier, currency)
+	ret0, _ := ret[0].(*entity.FeeSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockFeeRepositoryMockRecorder) Find(ctx, transferType, tier, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockFeeRepository)(nil).Find), ctx, transferType, tier, currency)
+}
+
+func (m *MockFeeRepository) List(ctx context.Context) ([]*entity.FeeSchedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*entity.FeeSchedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockFeeRepositoryMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockFeeRepository)(nil).List), ctx)
+}
+
+type MockLimitRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLimitRepositoryMockRecorder
+}
+
+type MockLimitRepositoryMockRecorder struct {
+	mock *MockLimitRepository
+}
+
+func NewMockLimitRepository(ctrl *gomock.Controller) *MockLimitRepository {
+	mock := &MockLimitRepository{ctrl: ctrl}
+	mock.recorder = &MockLimitRepositoryMockRecorder{mock}
+	return mock
+}
+
+func (m *MockLimitRepository) EXPECT() *MockLimitRepositoryMockRecorder {
+	return m.recorder
+}
+
+func (m *MockLimitRepository) Upsert(ctx context.Context, limits *entity.TransferLimits) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, limits)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockLimitRepositoryMockRecorder) Upsert(ctx, limits any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockLimitRepository)(nil).Upsert), ctx, limits)
+}
+
+func (m *MockLimitRepository) Find(ctx context.Context, accountID uuid.UUID, tier entity.Tier, currency entity.Currency) (*entity.TransferLimits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, accountID, tier, currency)
+	ret0, _ := ret[0].(*entity.TransferLimits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockLimitRepositoryMockRecorder) Find(ctx, accountID, tier, currency any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockLimitRepository)(nil).Find), ctx, accountID, tier, currency)
+}
+
+func (m *MockLimitRepository) List(ctx context.Context) ([]*entity.TransferLimits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*entity.TransferLimits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockLimitRepositoryMockRecorder) List(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockLimitRepository)(nil).List), ctx)
+}
+
+type MockRequisitesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRequisitesRepositoryMockRecorder
+}
+
+type MockRequisitesRepositoryMockRecorder struct {
+	mock *MockRequisitesRepository
+}
+
+func NewMockRequisitesRepository(ctrl *gomock.Controller) *MockRequisitesRepository {
+	mock := &MockRequisitesRepository{ctrl: ctrl}
+	mock.recorder = &MockRequisitesRepositoryMockRecorder{mock}
+	return mock
+}
+
+func (m *MockRequisitesRepository) EXPECT() *MockRequisitesRepositoryMockRecorder {
+	return m.recorder
+}
+
+func (m *MockRequisitesRepository) Upsert(ctx context.Context, requisites *entity.AccountRequisites) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, requisites)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockRequisitesRepositoryMockRecorder) Upsert(ctx, requisites any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockRequisitesRepository)(nil).Upsert), ctx, requisites)
+}
+
+func (m *MockRequisitesRepository) FindByAccountID(ctx context.Context, accountID uuid.UUID) (*entity.AccountRequisites, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByAccountID", ctx, accountID)
+	ret0, _ := ret[0].(*entity.AccountRequisites)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockRequisitesRepositoryMockRecorder) FindByAccountID(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAccountID", reflect.TypeOf((*MockRequisitesRepository)(nil).FindByAccountID), ctx, accountID)
+}
+
+type MockOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOutboxRepositoryMockRecorder
+}
+
+type MockOutboxRepositoryMockRecorder struct {
+	mock *MockOutboxRepository
+}
+
+func NewMockOutboxRepository(ctrl *gomock.Controller) *MockOutboxRepository {
+	mock := &MockOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+func (m *MockOutboxRepository) EXPECT() *MockOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+func (m *MockOutboxRepository) Add(ctx context.Context, event *entity.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockOutboxRepositoryMockRecorder) Add(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockOutboxRepository)(nil).Add), ctx, event)
+}
+
+func (m *MockOutboxRepository) RegisterSink(ctx context.Context, sink string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterSink", ctx, sink)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockOutboxRepositoryMockRecorder) RegisterSink(ctx, sink any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterSink", reflect.TypeOf((*MockOutboxRepository)(nil).RegisterSink), ctx, sink)
+}
+
+func (m *MockOutboxRepository) ListPendingForUpdate(ctx context.Context, sink string, now time.Time, limit int) ([]*entity.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingForUpdate", ctx, sink, now, limit)
+	ret0, _ := ret[0].([]*entity.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockOutboxRepositoryMockRecorder) ListPendingForUpdate(ctx, sink, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingForUpdate", reflect.TypeOf((*MockOutboxRepository)(nil).ListPendingForUpdate), ctx, sink, now, limit)
+}
+
+func (m *MockOutboxRepository) LeasePending(ctx context.Context, sink string, ids []uuid.UUID, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeasePending", ctx, sink, ids, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockOutboxRepositoryMockRecorder) LeasePending(ctx, sink, ids, until any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeasePending", reflect.TypeOf((*MockOutboxRepository)(nil).LeasePending), ctx, sink, ids, until)
+}
+
+func (m *MockOutboxRepository) ReleasePending(ctx context.Context, sink string, ids []uuid.UUID, lease time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleasePending", ctx, sink, ids, lease)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockOutboxRepositoryMockRecorder) ReleasePending(ctx, sink, ids, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleasePending", reflect.TypeOf((*MockOutboxRepository)(nil).ReleasePending), ctx, sink, ids, lease)
+}
+
+func (m *MockOutboxRepository) MarkPublished(ctx context.Context, sink string, ids []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPublished", ctx, sink, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockOutboxRepositoryMockRecorder) MarkPublished(ctx, sink, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPublished", reflect.TypeOf((*MockOutboxRepository)(nil).MarkPublished), ctx, sink, ids)
+}
+
+func (m *MockOutboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePublishedBefore", ctx, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockOutboxRepositoryMockRecorder) DeletePublishedBefore(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePublishedBefore", reflect.TypeOf((*MockOutboxRepository)(nil).DeletePublishedBefore), ctx, before, limit)
+}
+
+func (m *MockOutboxRepository) FindBySequence(ctx context.Context, seq int64) (*entity.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySequence", ctx, seq)
+	ret0, _ := ret[0].(*entity.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockOutboxRepositoryMockRecorder) FindBySequence(ctx, seq any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySequence", reflect.TypeOf((*MockOutboxRepository)(nil).FindBySequence), ctx, seq)
+}
+
+func (m *MockOutboxRepository) ListByAccount(ctx context.Context, accountID uuid.UUID, afterSeq int64, limit int) ([]*entity.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByAccount", ctx, accountID, afterSeq, limit)
+	ret0, _ := ret[0].([]*entity.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockOutboxRepositoryMockRecorder) ListByAccount(ctx, accountID, afterSeq, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAccount", reflect.TypeOf((*MockOutboxRepository)(nil).ListByAccount), ctx, accountID, afterSeq, limit)
+}
+
+type MockWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepositoryMockRecorder
+}
+
+type MockWebhookRepositoryMockRecorder struct {
+	mock *MockWebhookRepository
+}
+
+func NewMockWebhookRepository(ctrl *gomock.Controller) *MockWebhookRepository {
+	mock := &MockWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+func (m *MockWebhookRepository) EXPECT() *MockWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+func (m *MockWebhookRepository) CreateEndpoint(ctx context.Context, endpoint *entity.WebhookEndpoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEndpoint", ctx, endpoint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockWebhookRepositoryMockRecorder) CreateEndpoint(ctx, endpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEndpoint", reflect.TypeOf((*MockWebhookRepository)(nil).CreateEndpoint), ctx, endpoint)
+}
+
+func (m *MockWebhookRepository) UpdateEndpoint(ctx context.Context, endpoint *entity.WebhookEndpoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEndpoint", ctx, endpoint)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockWebhookRepositoryMockRecorder) UpdateEndpoint(ctx, endpoint any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEndpoint", reflect.TypeOf((*MockWebhookRepository)(nil).UpdateEndpoint), ctx, endpoint)
+}
+
+func (m *MockWebhookRepository) FindEndpointByAccount(ctx context.Context, accountID uuid.UUID) (*entity.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEndpointByAccount", ctx, accountID)
+	ret0, _ := ret[0].(*entity.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockWebhookRepositoryMockRecorder) FindEndpointByAccount(ctx, accountID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEndpointByAccount", reflect.TypeOf((*MockWebhookRepository)(nil).FindEndpointByAccount), ctx, accountID)
+}
+
+func (m *MockWebhookRepository) FindEndpointByID(ctx context.Context, id uuid.UUID) (*entity.WebhookEndpoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindEndpointByID", ctx, id)
+	ret0, _ := ret[0].(*entity.WebhookEndpoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockWebhookRepositoryMockRecorder) FindEndpointByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindEndpointByID", reflect.TypeOf((*MockWebhookRepository)(nil).FindEndpointByID), ctx, id)
+}
+
+func (m *MockWebhookRepository) AddDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockWebhookRepositoryMockRecorder) AddDelivery(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDelivery", reflect.TypeOf((*MockWebhookRepository)(nil).AddDelivery), ctx, delivery)
+}
+
+func (m *MockWebhookRepository) ListDueForUpdate(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueForUpdate", ctx, now, limit)
+	ret0, _ := ret[0].([]*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockWebhookRepositoryMockRecorder) ListDueForUpdate(ctx, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueForUpdate", reflect.TypeOf((*MockWebhookRepository)(nil).ListDueForUpdate), ctx, now, limit)
+}
+
+func (m *MockWebhookRepository) FindDeliveryForUpdate(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeliveryForUpdate", ctx, id)
+	ret0, _ := ret[0].(*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockWebhookRepositoryMockRecorder) FindDeliveryForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeliveryForUpdate", reflect.TypeOf((*MockWebhookRepository)(nil).FindDeliveryForUpdate), ctx, id)
+}
+
+func (m *MockWebhookRepository) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDelivery", ctx, delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockWebhookRepositoryMockRecorder) UpdateDelivery(ctx, delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDelivery", reflect.TypeOf((*MockWebhookRepository)(nil).UpdateDelivery), ctx, delivery)
+}
+
+func (m *MockWebhookRepository) ListDeliveries(ctx context.Context, filter repository.WebhookDeliveryFilter) ([]*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, filter)
+	ret0, _ := ret[0].([]*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockWebhookRepositoryMockRecorder) ListDeliveries(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockWebhookRepository)(nil).ListDeliveries), ctx, filter)
+}
+
+type MockBatchRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchRepositoryMockRecorder
+}
+
+type MockBatchRepositoryMockRecorder struct {
+	mock *MockBatchRepository
+}
+
+func NewMockBatchRepository(ctrl *gomock.Controller) *MockBatchRepository {
+	mock := &MockBatchRepository{ctrl: ctrl}
+	mock.recorder = &MockBatchRepositoryMockRecorder{mock}
+	return mock
+}
+
+func (m *MockBatchRepository) EXPECT() *MockBatchRepositoryMockRecorder {
+	return m.recorder
+}
+
+func (m *MockBatchRepository) Create(ctx context.Context, batch *entity.Batch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, batch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockBatchRepositoryMockRecorder) Create(ctx, batch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBatchRepository)(nil).Create), ctx, batch)
+}
+
+func (m *MockBatchRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.Batch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Batch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockBatchRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockBatchRepository)(nil).FindByID), ctx, id)
+}
+
+func (m *MockBatchRepository) UpdateItem(ctx context.Context, batchID uuid.UUID, item *entity.BatchItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateItem", ctx, batchID, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockBatchRepositoryMockRecorder) UpdateItem(ctx, batchID, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockBatchRepository)(nil).UpdateItem), ctx, batchID, item)
+}
+
+func (m *MockBatchRepository) UpdateStatus(ctx context.Context, batch *entity.Batch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, batch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockBatchRepositoryMockRecorder) UpdateStatus(ctx, batch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockBatchRepository)(nil).UpdateStatus), ctx, batch)
+}
+
+type MockSplitRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSplitRepositoryMockRecorder
+}
+
+type MockSplitRepositoryMockRecorder struct {
+	mock *MockSplitRepository
+}
+
+func NewMockSplitRepository(ctrl *gomock.Controller) *MockSplitRepository {
+	mock := &MockSplitRepository{ctrl: ctrl}
+	mock.recorder = &MockSplitRepositoryMockRecorder{mock}
+	return mock
+}
+
+func (m *MockSplitRepository) EXPECT() *MockSplitRepositoryMockRecorder {
+	return m.recorder
+}
+
+func (m *MockSplitRepository) Create(ctx context.Context, split *entity.SplitPayment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, split)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockSplitRepositoryMockRecorder) Create(ctx, split any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSplitRepository)(nil).Create), ctx, split)
+}
+
+func (m *MockSplitRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.SplitPayment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*entity.SplitPayment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockSplitRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockSplitRepository)(nil).FindByID), ctx, id)
+}
+
+type MockIntentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIntentRepositoryMockRecorder
+}
+
+type MockIntentRepositoryMockRecorder struct {
+	mock *MockIntentRepository
+}
+
+func NewMockIntentRepository(ctrl *gomock.Controller) *MockIntentRepository {
+	mock := &MockIntentRepository{ctrl: ctrl}
+	mock.recorder = &MockIntentRepositoryMockRecorder{mock}
+	return mock
+}
+
+func (m *MockIntentRepository) EXPECT() *MockIntentRepositoryMockRecorder {
+	return m.recorder
+}
+
+func (m *MockIntentRepository) Create(ctx context.Context, intent *entity.PaymentIntent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, intent)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockIntentRepositoryMockRecorder) Create(ctx, intent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIntentRepository)(nil).Create), ctx, intent)
+}
+
+func (m *MockIntentRepository) FindByID(ctx context.Context, id uuid.UUID) (*entity.PaymentIntent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*entity.PaymentIntent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockIntentRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockIntentRepository)(nil).FindByID), ctx, id)
+}
+
+func (m *MockIntentRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*entity.PaymentIntent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDForUpdate", ctx, id)
+	ret0, _ := ret[0].(*entity.PaymentIntent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockIntentRepositoryMockRecorder) FindByIDForUpdate(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDForUpdate", reflect.TypeOf((*MockIntentRepository)(nil).FindByIDForUpdate), ctx, id)
+}
+
+func (m *MockIntentRepository) Update(ctx context.Context, intent *entity.PaymentIntent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, intent)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockIntentRepositoryMockRecorder) Update(ctx, intent any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockIntentRepository)(nil).Update), ctx, intent)
+}
+
+type MockIdempotencyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockIdempotencyRepositoryMockRecorder
+}
+
+type MockIdempotencyRepositoryMockRecorder struct {
+	mock *MockIdempotencyRepository
+}
+
+func NewMockIdempotencyRepository(ctrl *gomock.Controller) *MockIdempotencyRepository {
+	mock := &MockIdempotencyRepository{ctrl: ctrl}
+	mock.recorder = &MockIdempotencyRepositoryMockRecorder{mock}
+	return mock
+}
+
+func (m *MockIdempotencyRepository) EXPECT() *MockIdempotencyRepositoryMockRecorder {
+	return m.recorder
+}
+
+func (m *MockIdempotencyRepository) Find(ctx context.Context, key string) (*entity.IdempotencyRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, key)
+	ret0, _ := ret[0].(*entity.IdempotencyRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockIdempotencyRepositoryMockRecorder) Find(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockIdempotencyRepository)(nil).Find), ctx, key)
+}
+
+func (m *MockIdempotencyRepository) Save(ctx context.Context, record *entity.IdempotencyRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockIdempotencyRepositoryMockRecorder) Save(ctx, record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockIdempotencyRepository)(nil).Save), ctx, record)
+}
+
+func (m *MockIdempotencyRepository) Lock(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockIdempotencyRepositoryMockRecorder) Lock(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockIdempotencyRepository)(nil).Lock), ctx, key)
+}
+
+func (m *MockIdempotencyRepository) DeleteCreatedBefore(ctx context.Context, before time.Time, limit int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCreatedBefore", ctx, before, limit)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockIdempotencyRepositoryMockRecorder) DeleteCreatedBefore(ctx, before, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCreatedBefore", reflect.TypeOf((*MockIdempotencyRepository)(nil).DeleteCreatedBefore), ctx, before, limit)
 }
 
 var _ = time.Now
@@ -0,0 +1,105 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+type RefundRequest struct {
+	IdempotencyKey string
+	TransactionID  uuid.UUID
+	Amount         int64
+}
+
+func (r RefundRequest) Fingerprint() string {
+	return entity.RequestFingerprint(map[string]string{
+		"original_transaction_id": r.TransactionID.String(),
+		"amount":                  strconv.FormatInt(r.Amount, 10),
+	})
+}
+
+// Refund moves Amount back from the receiver of a successful payment to its
+// sender. Partial refunds are allowed as long as their successful total never
+// exceeds the original amount.
+func (uc *UseCase) Refund(ctx context.Context, req RefundRequest) (*Response, error) {
+	if req.Amount <= 0 {
+		return nil, entity.ErrNegativeAmount
+	}
+
+	cached, err := uc.uow.Idempotency().Find(ctx, req.IdempotencyKey)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	if cached != nil {
+		return uc.replay(cached, req.Fingerprint())
+	}
+
+	var resp *Response
+	err = uc.retry(ctx, func() error {
+		var execErr error
+		resp, execErr = uc.refund(ctx, req)
+		return execErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (uc *UseCase) refund(ctx context.Context, req RefundRequest) (*Response, error) {
+	tx, err := uc.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	cached, err := lockKey(ctx, tx, req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil {
+		return uc.replay(cached, req.Fingerprint())
+	}
+
+	// Locking the original payment serialises concurrent refunds of it, so the
+	// refunded total read below cannot change before this one commits.
+	original, err := tx.Transactions().FindByIDForUpdate(ctx, req.TransactionID)
+	if err != nil {
+		return nil, err
+	}
+
+	refunded, err := tx.Transactions().RefundedAmount(ctx, original.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	if checkErr := original.CheckRefundable(req.Amount, refunded); checkErr != nil {
+		return nil, checkErr
+	}
+
+	merchant, customer, err := lockAccounts(ctx, tx, original.ToAccount(), original.FromAccount())
+	if err != nil {
+		return nil, err
+	}
+
+	if debitErr := merchant.Debit(original.Money().WithAmount(req.Amount)); debitErr != nil {
+		txn := entity.NewFailedRefund(original, req.Amount, entity.FailureReasonOf(debitErr))
+		return uc.decline(ctx, tx, req.IdempotencyKey, req.Fingerprint(), txn, debitErr)
+	}
+
+	txn := entity.NewRefund(original, req.Amount, entity.StatusSuccess)
+	if bookErr := book(ctx, tx, merchant, customer, nil, txn); bookErr != nil {
+		return nil, bookErr
+	}
+
+	return uc.saveAndReturn(ctx, tx, req.IdempotencyKey, req.Fingerprint(), &Response{
+		TransactionID: txn.ID().String(),
+		Status:        entity.StatusSuccess,
+	})
+}
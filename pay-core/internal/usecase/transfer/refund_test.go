@@ -0,0 +1,154 @@
+package transfer_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer/mocks"
+)
+
+func TestTransferUseCase_Refund_PartialRefund(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	txnRepo := mocks.NewMockTransactionRepository(ctrl)
+	idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+
+	uc := transfer.NewUseCase(uow)
+
+	customerID := uuid.New()
+	merchantID := uuid.New()
+	original := entity.ReconstructTransaction(
+		uuid.New(), customerID, merchantID, rub(1000), rub(1000), 0,
+		entity.StatusSuccess, entity.FailureNone, uuid.Nil, uuid.Nil, uuid.Nil, time.Now(),
+	)
+
+	uow.EXPECT().Idempotency().Return(idempotencyRepo)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "refund-key").Return(nil, nil)
+
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+
+	txUow.EXPECT().Idempotency().Return(idempotencyRepo).Times(3)
+	idempotencyRepo.EXPECT().Lock(gomock.Any(), "refund-key").Return(nil)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "refund-key").Return(nil, nil)
+
+	txUow.EXPECT().Transactions().Return(txnRepo).Times(3)
+	txnRepo.EXPECT().FindByIDForUpdate(gomock.Any(), original.ID()).Return(original, nil)
+	txnRepo.EXPECT().RefundedAmount(gomock.Any(), original.ID()).Return(int64(600), nil)
+
+	txUow.EXPECT().Accounts().Return(accountRepo).Times(4)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), customerID).Return(entity.NewAccount(customerID, rub(0)), nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), merchantID).Return(entity.NewAccount(merchantID, rub(5000)), nil)
+	accountRepo.EXPECT().UpdateBalance(gomock.Any(), merchantID, int64(4600)).Return(nil)
+	accountRepo.EXPECT().UpdateBalance(gomock.Any(), customerID, int64(400)).Return(nil)
+
+	expectEvent(ctrl, txUow, entity.EventPaymentRefunded)
+	txnRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, txn *entity.Transaction) error {
+			assert.Equal(t, original.ID(), txn.OriginalID())
+			assert.Equal(t, merchantID, txn.FromAccount())
+			assert.Equal(t, customerID, txn.ToAccount())
+			assert.Equal(t, int64(400), txn.Amount())
+			assert.NoError(t, txn.CheckBalanced())
+			return nil
+		},
+	)
+	idempotencyRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	resp, err := uc.Refund(context.Background(), transfer.RefundRequest{
+		IdempotencyKey: "refund-key",
+		TransactionID:  original.ID(),
+		Amount:         400,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.StatusSuccess, resp.Status)
+}
+
+func TestTransferUseCase_Refund_Rejected(t *testing.T) {
+	customerID := uuid.New()
+	merchantID := uuid.New()
+
+	tests := []struct {
+		name     string
+		original *entity.Transaction
+		refunded int64
+		amount   int64
+		wantErr  error
+	}{
+		{
+			name: "exceeds remaining amount",
+			original: entity.ReconstructTransaction(
+				uuid.New(), customerID, merchantID, rub(1000), rub(1000), 0,
+				entity.StatusSuccess, entity.FailureNone, uuid.Nil, uuid.Nil, uuid.Nil, time.Now(),
+			),
+			refunded: 700,
+			amount:   301,
+			wantErr:  entity.ErrRefundExceedsOriginal,
+		},
+		{
+			name: "failed payment",
+			original: entity.ReconstructTransaction(
+				uuid.New(), customerID, merchantID, rub(1000), rub(1000), 0,
+				entity.StatusFailed, entity.FailureInsufficientFunds, uuid.Nil, uuid.Nil, uuid.Nil, time.Now(),
+			),
+			amount:  100,
+			wantErr: entity.ErrNotRefundable,
+		},
+		{
+			name: "refund of a refund",
+			original: entity.ReconstructTransaction(
+				uuid.New(), merchantID, customerID, rub(1000), rub(1000), 0,
+				entity.StatusSuccess, entity.FailureNone, uuid.New(), uuid.Nil, uuid.Nil, time.Now(),
+			),
+			amount:  100,
+			wantErr: entity.ErrNotRefundable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uow := mocks.NewMockUnitOfWork(ctrl)
+			txUow := mocks.NewMockUnitOfWork(ctrl)
+			txnRepo := mocks.NewMockTransactionRepository(ctrl)
+			idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+
+			uc := transfer.NewUseCase(uow)
+
+			uow.EXPECT().Idempotency().Return(idempotencyRepo)
+			uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+			txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+			txUow.EXPECT().Idempotency().Return(idempotencyRepo).Times(2)
+			idempotencyRepo.EXPECT().Find(gomock.Any(), "refund-key").Return(nil, nil).Times(2)
+			idempotencyRepo.EXPECT().Lock(gomock.Any(), "refund-key").Return(nil)
+
+			txUow.EXPECT().Transactions().Return(txnRepo).Times(2)
+			txnRepo.EXPECT().FindByIDForUpdate(gomock.Any(), tt.original.ID()).Return(tt.original, nil)
+			txnRepo.EXPECT().RefundedAmount(gomock.Any(), tt.original.ID()).Return(tt.refunded, nil)
+
+			_, err := uc.Refund(context.Background(), transfer.RefundRequest{
+				IdempotencyKey: "refund-key",
+				TransactionID:  tt.original.ID(),
+				Amount:         tt.amount,
+			})
+
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+const (
+	defaultMaxAttempts = 5
+	defaultBaseDelay   = 10 * time.Millisecond
+	defaultMaxDelay    = 200 * time.Millisecond
+)
+
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: defaultMaxAttempts,
+		BaseDelay:   defaultBaseDelay,
+		MaxDelay:    defaultMaxDelay,
+	}
+}
+
+type Stats struct {
+	Retries   uint64
+	Exhausted uint64
+}
+
+// retry runs fn until it succeeds, fails with a non-retryable error or the
+// policy runs out of attempts. Sleeps use full jitter over an exponentially
+// growing window capped by MaxDelay.
+func (uc *UseCase) retry(ctx context.Context, fn func() error) error {
+	attempts := max(uc.retryPolicy.MaxAttempts, 1)
+
+	var err error
+	for attempt := range attempts {
+		err = fn()
+		if err == nil || !errors.Is(err, repository.ErrConflict) {
+			return err
+		}
+		if attempt == attempts-1 {
+			break
+		}
+
+		uc.retries.Add(1)
+		if sleepErr := sleep(ctx, uc.backoff(attempt)); sleepErr != nil {
+			return sleepErr
+		}
+	}
+
+	uc.exhausted.Add(1)
+	return err
+}
+
+func (uc *UseCase) backoff(attempt int) time.Duration {
+	window := min(uc.retryPolicy.BaseDelay<<attempt, uc.retryPolicy.MaxDelay)
+	if window <= 0 {
+		return 0
+	}
+	return rand.N(window) //nolint:gosec // G404: jitter does not need a cryptographic source
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,251 @@
+package transfer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+type SplitLeg struct {
+	ToAccountID uuid.UUID
+	Amount      entity.Money
+}
+
+type SplitRequest struct {
+	IdempotencyKey string
+	FromAccountID  uuid.UUID
+	// Amount is the total the legs must sum to.
+	Amount entity.Money
+	Legs   []SplitLeg
+	// Type selects the fee schedule of every leg; empty means
+	// entity.TransferP2P.
+	Type entity.TransferType
+}
+
+// Fingerprint covers every leg in order, so that resubmitting a key with the
+// legs reordered is detected as a different request.
+func (r SplitRequest) Fingerprint() string {
+	fields := map[string]string{
+		"from_account_id": r.FromAccountID.String(),
+		"amount":          strconv.FormatInt(r.Amount.Amount(), 10),
+		"currency":        string(r.Amount.Currency()),
+		"transfer_type":   Request{Type: r.Type}.typeField(),
+	}
+	for pos, leg := range r.Legs {
+		prefix := "legs." + strconv.Itoa(pos) + "."
+		fields[prefix+"to_account_id"] = leg.ToAccountID.String()
+		fields[prefix+"amount"] = strconv.FormatInt(leg.Amount.Amount(), 10)
+	}
+	return entity.RequestFingerprint(fields)
+}
+
+type splitCache struct {
+	SplitID string `json:"split_id"`
+}
+
+// ProcessSplit debits the payer once and credits every leg's payee in a
+// single unit of work. Each leg is booked as a transaction of its own, priced
+// by the fee schedule like a plain transfer, and recorded under the split
+// payment as their parent. The payer's status and limits are checked against
+// the total; if they or the payer's balance decline it, no leg is paid and
+// the split payment is recorded as failed, as ProcessPayment records a
+// declined transfer.
+func (uc *UseCase) ProcessSplit(ctx context.Context, req SplitRequest) (*entity.SplitPayment, error) {
+	if !req.Amount.IsPositive() {
+		return nil, entity.ErrNegativeAmount
+	}
+	legs := make([]*entity.SplitLeg, 0, len(req.Legs))
+	for pos, leg := range req.Legs {
+		if !leg.Amount.IsPositive() {
+			return nil, fmt.Errorf("leg %d: %w", pos, entity.ErrNegativeAmount)
+		}
+		legs = append(legs, entity.NewSplitLeg(leg.ToAccountID, leg.Amount))
+	}
+	split, err := entity.NewSplitPayment(req.FromAccountID, req.Amount, legs)
+	if err != nil {
+		return nil, err
+	}
+
+	fingerprint := req.Fingerprint()
+	cached, err := uc.uow.Idempotency().Find(ctx, req.IdempotencyKey)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	if cached != nil {
+		return uc.replaySplit(ctx, uc.uow, cached, fingerprint)
+	}
+
+	var paid *entity.SplitPayment
+	err = uc.retry(ctx, func() error {
+		var execErr error
+		paid, execErr = uc.paySplit(ctx, req.IdempotencyKey, fingerprint, req.Type, split)
+		return execErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return paid, nil
+}
+
+func (uc *UseCase) GetSplit(ctx context.Context, id uuid.UUID) (*entity.SplitPayment, error) {
+	return uc.uow.Splits().FindByID(ctx, id)
+}
+
+func (uc *UseCase) paySplit(
+	ctx context.Context,
+	key, fingerprint string,
+	transferType entity.TransferType,
+	split *entity.SplitPayment,
+) (*entity.SplitPayment, error) {
+	tx, err := uc.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	cached, err := lockKey(ctx, tx, key)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil {
+		return uc.replaySplit(ctx, tx, cached, fingerprint)
+	}
+
+	ids := []uuid.UUID{split.FromAccountID()}
+	for _, leg := range split.Legs() {
+		ids = append(ids, leg.ToAccountID())
+	}
+	locked, err := lockAll(ctx, tx, ids...)
+	if err != nil {
+		return nil, err
+	}
+	sender := locked[split.FromAccountID()]
+	accounts := make([]*entity.Account, 0, len(ids))
+	for _, id := range ids {
+		accounts = append(accounts, locked[id])
+	}
+
+	if custErr := checkCustomer(accounts...); custErr != nil {
+		return nil, custErr
+	}
+	if currErr := checkCurrency(split.Amount(), accounts...); currErr != nil {
+		return nil, currErr
+	}
+	if sendErr := checkSplitSendable(ctx, tx, split, locked); sendErr != nil {
+		if entity.FailureReasonOf(sendErr) == entity.FailureUnknown {
+			return nil, sendErr
+		}
+		return uc.declineSplit(ctx, tx, key, fingerprint, split, sendErr)
+	}
+
+	// Every leg is priced and debited before any is booked, so that a payer
+	// who cannot cover the total with fees is declined with nothing written.
+	txns := make([]*entity.Transaction, 0, len(split.Legs()))
+	for _, leg := range split.Legs() {
+		txn := entity.NewSplitLegTransaction(split, leg)
+		if _, feeErr := chargeFee(ctx, tx, transferType, sender, locked[leg.ToAccountID()], txn); feeErr != nil {
+			return nil, feeErr
+		}
+		if debitErr := sender.Debit(txn.Debited()); debitErr != nil {
+			return uc.declineSplit(ctx, tx, key, fingerprint, split, debitErr)
+		}
+		txns = append(txns, txn)
+	}
+
+	var revenue *entity.Account
+	for i, leg := range split.Legs() {
+		txn := txns[i]
+		var feeRevenue *entity.Account
+		if txn.Fee().IsPositive() {
+			if revenue == nil {
+				if revenue, err = lockFeeRevenue(ctx, tx, txn.Currency()); err != nil {
+					return nil, err
+				}
+			}
+			feeRevenue = revenue
+		}
+		if bookErr := book(ctx, tx, sender, locked[leg.ToAccountID()], feeRevenue, txn); bookErr != nil {
+			return nil, bookErr
+		}
+		leg.Pay(txn.ID())
+	}
+	split.Succeed()
+
+	if createErr := tx.Splits().Create(ctx, split); createErr != nil {
+		return nil, createErr
+	}
+	cache := splitCache{SplitID: split.ID().String()}
+	if saveErr := saveAndCommit(ctx, tx, key, fingerprint, statusCodeSuccess, cache); saveErr != nil {
+		return nil, saveErr
+	}
+	return split, nil
+}
+
+// checkSplitSendable is checkSendable for a payer paying every leg's payee at
+// once: the limits see the split payment as a single transfer of its total.
+func checkSplitSendable(
+	ctx context.Context,
+	tx repository.UnitOfWork,
+	split *entity.SplitPayment,
+	locked map[uuid.UUID]*entity.Account,
+) error {
+	sender := locked[split.FromAccountID()]
+	if err := sender.CheckCanSend(); err != nil {
+		return err
+	}
+	for _, leg := range split.Legs() {
+		if err := locked[leg.ToAccountID()].CheckCanReceive(); err != nil {
+			return err
+		}
+	}
+	return checkLimits(ctx, tx, sender, split.Amount())
+}
+
+// declineSplit records the split payment as failed without paying any leg and
+// caches it under the idempotency key. Nothing else has been written in tx:
+// the payer's balance was only debited in memory.
+func (uc *UseCase) declineSplit(
+	ctx context.Context,
+	tx repository.UnitOfWork,
+	key, fingerprint string,
+	split *entity.SplitPayment,
+	cause error,
+) (*entity.SplitPayment, error) {
+	split.Decline(entity.FailureReasonOf(cause), cause.Error())
+	if err := tx.Splits().Create(ctx, split); err != nil {
+		return nil, err
+	}
+	cache := splitCache{SplitID: split.ID().String()}
+	if err := saveAndCommit(ctx, tx, key, fingerprint, statusCodeFailed, cache); err != nil {
+		return nil, err
+	}
+	return split, nil
+}
+
+func (uc *UseCase) replaySplit(
+	ctx context.Context,
+	uow repository.UnitOfWork,
+	cached *entity.IdempotencyRecord,
+	fingerprint string,
+) (*entity.SplitPayment, error) {
+	if !cached.Matches(fingerprint) {
+		return nil, entity.ErrIdempotencyKeyReused
+	}
+
+	var cache splitCache
+	if err := json.Unmarshal(cached.ResponseBody(), &cache); err != nil {
+		return nil, err
+	}
+	id, err := uuid.Parse(cache.SplitID)
+	if err != nil {
+		return nil, err
+	}
+	return uow.Splits().FindByID(ctx, id)
+}
@@ -0,0 +1,174 @@
+package transfer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer/mocks"
+)
+
+func TestTransferUseCase_ProcessSplit_PaysAllLegs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	splitRepo := mocks.NewMockSplitRepository(ctrl)
+	uc := transfer.NewUseCase(uow)
+
+	buyer, seller, platform := uuid.New(), uuid.New(), uuid.New()
+	req := transfer.SplitRequest{
+		IdempotencyKey: "checkout-1",
+		FromAccountID:  buyer,
+		Amount:         rub(1000),
+		Legs: []transfer.SplitLeg{
+			{ToAccountID: seller, Amount: rub(900)},
+			{ToAccountID: platform, Amount: rub(100)},
+		},
+	}
+
+	expectNewKey(ctrl, uow, txUow, "checkout-1")
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	txnRepo := mocks.NewMockTransactionRepository(ctrl)
+	var booked []*entity.Transaction
+	txUow.EXPECT().Transactions().Return(txnRepo).Times(2)
+	txnRepo.EXPECT().Create(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, txn *entity.Transaction) error {
+			booked = append(booked, txn)
+			return nil
+		}).Times(2)
+	expectBatchTransfers(ctrl, txUow)
+
+	txUow.EXPECT().Accounts().Return(accountRepo).AnyTimes()
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), buyer).Return(entity.NewAccount(buyer, rub(5000)), nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), seller).Return(entity.NewAccount(seller, rub(0)), nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), platform).Return(entity.NewAccount(platform, rub(0)), nil)
+	// The buyer is debited the total before any leg is booked.
+	accountRepo.EXPECT().UpdateBalance(gomock.Any(), buyer, int64(4000)).Return(nil).Times(2)
+	accountRepo.EXPECT().UpdateBalance(gomock.Any(), seller, int64(900)).Return(nil)
+	accountRepo.EXPECT().UpdateBalance(gomock.Any(), platform, int64(100)).Return(nil)
+
+	txUow.EXPECT().Splits().Return(splitRepo)
+	splitRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	split, err := uc.ProcessSplit(context.Background(), req)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.StatusSuccess, split.Status())
+	require.Len(t, split.Legs(), 2)
+	assert.NotEqual(t, split.Legs()[0].TransactionID(), split.Legs()[1].TransactionID())
+	require.Len(t, booked, 2)
+	for i, leg := range split.Legs() {
+		assert.Equal(t, booked[i].ID(), leg.TransactionID())
+		assert.Equal(t, split.ID(), booked[i].SplitID())
+	}
+}
+
+func TestTransferUseCase_ProcessSplit_DeclinesWithoutPayingAnyLeg(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	splitRepo := mocks.NewMockSplitRepository(ctrl)
+	uc := transfer.NewUseCase(uow)
+
+	buyer, seller, platform := uuid.New(), uuid.New(), uuid.New()
+	req := transfer.SplitRequest{
+		IdempotencyKey: "checkout-2",
+		FromAccountID:  buyer,
+		Amount:         rub(1000),
+		Legs: []transfer.SplitLeg{
+			{ToAccountID: seller, Amount: rub(900)},
+			{ToAccountID: platform, Amount: rub(100)},
+		},
+	}
+
+	expectNewKey(ctrl, uow, txUow, "checkout-2")
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	expectBatchTransfers(ctrl, txUow)
+
+	// The buyer covers the first leg but not the total: no balance is saved.
+	txUow.EXPECT().Accounts().Return(accountRepo).AnyTimes()
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), buyer).Return(entity.NewAccount(buyer, rub(950)), nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), seller).Return(entity.NewAccount(seller, rub(0)), nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), platform).Return(entity.NewAccount(platform, rub(0)), nil)
+
+	txUow.EXPECT().Splits().Return(splitRepo)
+	splitRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	split, err := uc.ProcessSplit(context.Background(), req)
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.StatusFailed, split.Status())
+	assert.Equal(t, entity.FailureInsufficientFunds, split.FailureReason())
+	for _, leg := range split.Legs() {
+		assert.Equal(t, uuid.Nil, leg.TransactionID())
+	}
+}
+
+func TestTransferUseCase_ProcessSplit_RejectsInvalidSplit(t *testing.T) {
+	uc := transfer.NewUseCase(nil)
+	buyer, seller := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name  string
+		legs  []transfer.SplitLeg
+		error error
+	}{
+		{
+			name:  "no legs",
+			error: entity.ErrInvalidSplit,
+		},
+		{
+			name: "legs do not sum to the total",
+			legs: []transfer.SplitLeg{
+				{ToAccountID: seller, Amount: rub(600)},
+				{ToAccountID: uuid.New(), Amount: rub(300)},
+			},
+			error: entity.ErrInvalidSplit,
+		},
+		{
+			name: "repeated payee",
+			legs: []transfer.SplitLeg{
+				{ToAccountID: seller, Amount: rub(500)},
+				{ToAccountID: seller, Amount: rub(500)},
+			},
+			error: entity.ErrInvalidSplit,
+		},
+		{
+			name:  "payer is a payee",
+			legs:  []transfer.SplitLeg{{ToAccountID: buyer, Amount: rub(1000)}},
+			error: entity.ErrInvalidSplit,
+		},
+		{
+			name:  "non-positive leg",
+			legs:  []transfer.SplitLeg{{ToAccountID: seller, Amount: rub(0)}},
+			error: entity.ErrNegativeAmount,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.ProcessSplit(context.Background(), transfer.SplitRequest{
+				IdempotencyKey: "k",
+				FromAccountID:  buyer,
+				Amount:         rub(1000),
+				Legs:           tt.legs,
+			})
+			require.ErrorIs(t, err, tt.error)
+		})
+	}
+}
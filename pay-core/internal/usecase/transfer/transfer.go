@@ -1,9 +1,15 @@
 package transfer
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync/atomic"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -11,126 +17,521 @@ import (
 	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
 )
 
+var ErrSameAccount = errors.New("from and to accounts must differ")
+
 type Request struct {
 	IdempotencyKey string
 	FromAccountID  uuid.UUID
 	ToAccountID    uuid.UUID
-	Amount         int64
+	Amount         entity.Money
+	// QuoteID settles the payment as a conversion at an FX quote from
+	// GetQuote: Amount must equal the quote's source amount, and the payee is
+	// credited its target amount in the payee's own currency.
+	QuoteID uuid.UUID
+	// Type selects the fee schedule; empty means entity.TransferP2P.
+	// Conversions are not charged transfer fees.
+	Type entity.TransferType
+}
+
+// Fingerprint identifies the request behind an idempotency key. New fields
+// must be added to the map so that reusing a key with a different value of
+// that field is detected, and must map their unset value to "" so that keys
+// stored before the field existed still match.
+func (r Request) Fingerprint() string {
+	var quoteID string
+	if r.QuoteID != uuid.Nil {
+		quoteID = r.QuoteID.String()
+	}
+	return entity.RequestFingerprint(map[string]string{
+		"from_account_id": r.FromAccountID.String(),
+		"to_account_id":   r.ToAccountID.String(),
+		"amount":          strconv.FormatInt(r.Amount.Amount(), 10),
+		"currency":        currencyField(r.Amount),
+		"quote_id":        quoteID,
+		"transfer_type":   r.typeField(),
+	})
+}
+
+func (r Request) transferType() entity.TransferType {
+	if r.Type == "" {
+		return entity.TransferP2P
+	}
+	return r.Type
+}
+
+// currencyField is the fingerprint value of a request's currency. Requests
+// from before accounts had currencies were all in entity.DefaultCurrency and
+// were fingerprinted without one, so it is left out for that currency to keep
+// their keys replayable.
+func currencyField(m entity.Money) string {
+	if m.Currency() == entity.DefaultCurrency {
+		return ""
+	}
+	return string(m.Currency())
+}
+
+// typeField is the fingerprint value of the transfer type. The default,
+// entity.TransferP2P, is left out as if unset, so that a request naming it
+// matches one that does not and keys stored before transfer types existed.
+func (r Request) typeField() string {
+	if r.transferType() == entity.TransferP2P {
+		return ""
+	}
+	return string(r.Type)
 }
 
 type Response struct {
 	TransactionID string
 	Status        entity.TransactionStatus
 	ErrorMessage  string
+	FailureReason entity.FailureReason
+	// Fee is the fee applied to the transfer, in minor units of its currency,
+	// and FeeBearer who paid it; both are zero when no fee was charged.
+	Fee       int64
+	FeeBearer entity.FeeBearer
+	// ExceededLimit names the limit a transfer declined with
+	// entity.FailureLimitExceeded would have breached.
+	ExceededLimit entity.LimitKind
 }
 
 type responseCache struct {
 	TransactionID string `json:"transaction_id"`
 	Status        string `json:"status"`
 	ErrorMessage  string `json:"error_message"`
+	FailureReason string `json:"failure_reason,omitempty"`
+	Fee           int64  `json:"fee,omitempty"`
+	FeeBearer     string `json:"fee_bearer,omitempty"`
+	ExceededLimit string `json:"exceeded_limit,omitempty"`
 }
 
 type UseCase struct {
-	uow repository.UnitOfWork
+	uow         repository.UnitOfWork
+	retryPolicy RetryPolicy
+	holdTTL     time.Duration
+	retries     atomic.Uint64
+	exhausted   atomic.Uint64
+}
+
+type Option func(*UseCase)
+
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(uc *UseCase) {
+		uc.retryPolicy = p
+	}
 }
 
-func NewUseCase(uow repository.UnitOfWork) *UseCase {
-	return &UseCase{uow: uow}
+// WithHoldTTL sets how long an uncaptured authorization keeps its hold.
+func WithHoldTTL(ttl time.Duration) Option {
+	return func(uc *UseCase) {
+		uc.holdTTL = ttl
+	}
+}
+
+func NewUseCase(uow repository.UnitOfWork, opts ...Option) *UseCase {
+	uc := &UseCase{
+		uow:         uow,
+		retryPolicy: DefaultRetryPolicy(),
+		holdTTL:     DefaultHoldTTL,
+	}
+	for _, opt := range opts {
+		opt(uc)
+	}
+	return uc
+}
+
+func (uc *UseCase) Stats() Stats {
+	return Stats{
+		Retries:   uc.retries.Load(),
+		Exhausted: uc.exhausted.Load(),
+	}
 }
 
 func (uc *UseCase) Execute(ctx context.Context, req Request) (*Response, error) {
+	if !req.Amount.IsPositive() {
+		return nil, entity.ErrNegativeAmount
+	}
+	if req.FromAccountID == req.ToAccountID {
+		return nil, ErrSameAccount
+	}
+
 	cached, err := uc.uow.Idempotency().Find(ctx, req.IdempotencyKey)
 	if err != nil && !errors.Is(err, repository.ErrNotFound) {
 		return nil, err
 	}
 	if cached != nil {
-		return uc.parseCache(cached.ResponseBody())
+		return uc.replay(cached, req.Fingerprint())
+	}
+
+	var resp *Response
+	err = uc.retry(ctx, func() error {
+		var execErr error
+		if req.QuoteID != uuid.Nil {
+			resp, execErr = uc.convert(ctx, req)
+		} else {
+			resp, execErr = uc.execute(ctx, req)
+		}
+		return execErr
+	})
+	if err != nil {
+		return nil, err
 	}
+	return resp, nil
+}
 
+func (uc *UseCase) execute(ctx context.Context, req Request) (*Response, error) {
 	tx, err := uc.uow.Begin(ctx)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = tx.Rollback(ctx) }()
 
-	if lockErr := tx.Idempotency().Lock(ctx, req.IdempotencyKey); lockErr != nil {
-		return nil, lockErr
+	cached, err := lockKey(ctx, tx, req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil {
+		return uc.replay(cached, req.Fingerprint())
 	}
 
-	cached, err = tx.Idempotency().Find(ctx, req.IdempotencyKey)
-	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+	sender, receiver, err := lockAccounts(ctx, tx, req.FromAccountID, req.ToAccountID)
+	if err != nil {
 		return nil, err
 	}
-	if cached != nil {
-		return uc.parseCache(cached.ResponseBody())
+
+	if custErr := checkCustomer(sender, receiver); custErr != nil {
+		return nil, custErr
+	}
+
+	if currErr := checkCurrency(req.Amount, sender, receiver); currErr != nil {
+		return nil, currErr
 	}
 
-	sender, err := tx.Accounts().FindByIDForUpdate(ctx, req.FromAccountID)
+	if sendErr := checkSendable(ctx, tx, sender, receiver, req.Amount); sendErr != nil {
+		reason := entity.FailureReasonOf(sendErr)
+		if reason == entity.FailureUnknown {
+			return nil, sendErr
+		}
+		failed := entity.NewFailedTransaction(req.FromAccountID, req.ToAccountID, req.Amount, reason)
+		return uc.decline(ctx, tx, req.IdempotencyKey, req.Fingerprint(), failed, sendErr)
+	}
+
+	txn := entity.NewTransaction(req.FromAccountID, req.ToAccountID, req.Amount, entity.StatusSuccess)
+	bearer, err := chargeFee(ctx, tx, req.transferType(), sender, receiver, txn)
 	if err != nil {
 		return nil, err
 	}
 
-	if debitErr := sender.Debit(req.Amount); debitErr != nil {
-		return uc.saveAndReturn(ctx, tx, req.IdempotencyKey, uuid.Nil, entity.StatusFailed, debitErr.Error())
+	if debitErr := sender.Debit(txn.Debited()); debitErr != nil {
+		failed := entity.NewFailedTransaction(req.FromAccountID, req.ToAccountID, req.Amount, entity.FailureReasonOf(debitErr))
+		return uc.decline(ctx, tx, req.IdempotencyKey, req.Fingerprint(), failed, debitErr)
+	}
+
+	var revenue *entity.Account
+	if txn.Fee().IsPositive() {
+		if revenue, err = lockFeeRevenue(ctx, tx, txn.Currency()); err != nil {
+			return nil, err
+		}
+	}
+
+	if bookErr := book(ctx, tx, sender, receiver, revenue, txn); bookErr != nil {
+		return nil, bookErr
+	}
+
+	return uc.saveAndReturn(ctx, tx, req.IdempotencyKey, req.Fingerprint(), &Response{
+		TransactionID: txn.ID().String(),
+		Status:        entity.StatusSuccess,
+		Fee:           txn.Fee().Amount(),
+		FeeBearer:     bearer,
+	})
+}
+
+// checkSendable checks that the sender may send amount to the receiver: that
+// neither account's status forbids it and that the sender stays within its
+// transfer limits. It must run under the sender's row lock: every debit of
+// the account takes that lock, so the usage read here already includes any
+// transfer that committed before it and none can commit until this one does.
+func checkSendable(
+	ctx context.Context,
+	tx repository.UnitOfWork,
+	sender, receiver *entity.Account,
+	amount entity.Money,
+) error {
+	if err := sender.CheckCanSend(); err != nil {
+		return err
+	}
+	if err := receiver.CheckCanReceive(); err != nil {
+		return err
+	}
+	return checkLimits(ctx, tx, sender, amount)
+}
+
+// checkLimits checks that sending amount keeps the sender within its transfer
+// limits, under the same row lock as checkSendable.
+func checkLimits(ctx context.Context, tx repository.UnitOfWork, sender *entity.Account, amount entity.Money) error {
+	limits, err := tx.Limits().Find(ctx, sender.ID(), sender.Tier(), sender.Currency())
+	if errors.Is(err, repository.ErrLimitsNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	usage, err := tx.Transactions().OutgoingUsage(ctx, sender.ID(), entity.UsageWindowsAt(time.Now()))
+	if err != nil {
+		return err
+	}
+	return limits.Check(amount, usage)
+}
+
+// chargeFee prices txn by the schedule for its transfer type, keyed by the
+// payer's tier for P2P transfers and by the merchant's for QR payments, and
+// returns who bears the fee. Transfers without a schedule are free.
+func chargeFee(
+	ctx context.Context,
+	tx repository.UnitOfWork,
+	transferType entity.TransferType,
+	sender, receiver *entity.Account,
+	txn *entity.Transaction,
+) (entity.FeeBearer, error) {
+	tier := sender.Tier()
+	if transferType == entity.TransferQRMerchant {
+		tier = receiver.Tier()
 	}
 
-	receiver, err := tx.Accounts().FindByIDForUpdate(ctx, req.ToAccountID)
+	schedule, err := tx.Fees().Find(ctx, transferType, tier, txn.Currency())
+	if errors.Is(err, repository.ErrFeeScheduleNotFound) {
+		return "", nil
+	}
 	if err != nil {
+		return "", err
+	}
+
+	fee := schedule.Calculate(txn.Money())
+	if !fee.IsPositive() {
+		return "", nil
+	}
+	txn.ChargeFee(fee, schedule.Bearer())
+	if !txn.Credited().IsPositive() {
+		return "", fmt.Errorf("%w: fee of %d on %d", entity.ErrFeeExceedsAmount, fee.Amount(), txn.Amount())
+	}
+	return schedule.Bearer(), nil
+}
+
+// lockFeeRevenue locks the fee revenue account in currency. It is locked
+// after, not together with, the customer accounts: nothing that holds it goes
+// on to lock another account, so waiting for it cannot close a cycle, and it
+// stays locked only for the few statements that book the transfer.
+func lockFeeRevenue(ctx context.Context, tx repository.UnitOfWork, currency entity.Currency) (*entity.Account, error) {
+	revenue, err := tx.Accounts().FindSystem(ctx, entity.AccountFeeRevenue, currency)
+	if err != nil {
+		return nil, err
+	}
+	return tx.Accounts().FindByIDForUpdate(ctx, revenue.ID())
+}
+
+// lockKey serialises requests sharing an idempotency key and returns the
+// response cached by whichever of them committed first, if any.
+func lockKey(ctx context.Context, tx repository.UnitOfWork, key string) (*entity.IdempotencyRecord, error) {
+	if err := tx.Idempotency().Lock(ctx, key); err != nil {
 		return nil, err
 	}
 
-	if creditErr := receiver.Credit(req.Amount); creditErr != nil {
-		return nil, creditErr
+	cached, err := tx.Idempotency().Find(ctx, key)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
 	}
+	return cached, nil
+}
 
-	if updErr := tx.Accounts().UpdateBalance(ctx, sender.ID(), sender.Balance()); updErr != nil {
-		return nil, updErr
+// book completes a transfer whose amount has already been debited from sender:
+// it credits receiver, and feeRevenue with the fee if one was charged, persists
+// the balances and records txn with its postings. feeRevenue is nil for
+// transfers without a fee.
+func book(
+	ctx context.Context,
+	tx repository.UnitOfWork,
+	sender, receiver, feeRevenue *entity.Account,
+	txn *entity.Transaction,
+) error {
+	if err := receiver.Credit(txn.Credited()); err != nil {
+		return err
+	}
+	touched := []*entity.Account{sender, receiver}
+	if feeRevenue != nil {
+		if err := feeRevenue.Credit(txn.Fee()); err != nil {
+			return err
+		}
+		touched = append(touched, feeRevenue)
 	}
 
-	if updErr := tx.Accounts().UpdateBalance(ctx, receiver.ID(), receiver.Balance()); updErr != nil {
-		return nil, updErr
+	for _, a := range touched {
+		if err := tx.Accounts().UpdateBalance(ctx, a.ID(), a.Balance()); err != nil {
+			return err
+		}
 	}
 
-	txn := entity.NewTransaction(req.FromAccountID, req.ToAccountID, req.Amount, entity.StatusSuccess)
-	if createErr := tx.Transactions().Create(ctx, txn); createErr != nil {
-		return nil, createErr
+	txn.Debit(sender.ID(), txn.Debited())
+	txn.Credit(receiver.ID(), txn.Credited())
+	if feeRevenue != nil {
+		txn.Credit(feeRevenue.ID(), txn.Fee())
+	}
+	if err := txn.CheckBalanced(); err != nil {
+		return err
 	}
 
-	return uc.saveAndReturn(ctx, tx, req.IdempotencyKey, txn.ID(), entity.StatusSuccess, "")
+	return record(ctx, tx, txn)
+}
+
+// record saves the transaction together with the outbox event announcing it,
+// so that the event is published if and only if the transaction commits.
+func record(ctx context.Context, tx repository.UnitOfWork, txn *entity.Transaction) error {
+	if err := tx.Transactions().Create(ctx, txn); err != nil {
+		return err
+	}
+	event, err := entity.NewPaymentEvent(txn)
+	if err != nil {
+		return err
+	}
+	return tx.Outbox().Add(ctx, event)
+}
+
+// decline records the attempt as a failed transaction, so that it can be looked
+// up by id, and caches the failed response under the idempotency key.
+func (uc *UseCase) decline(
+	ctx context.Context,
+	tx repository.UnitOfWork,
+	key, fingerprint string,
+	txn *entity.Transaction,
+	cause error,
+) (*Response, error) {
+	if recordErr := record(ctx, tx, txn); recordErr != nil {
+		return nil, recordErr
+	}
+
+	resp := &Response{
+		TransactionID: txn.ID().String(),
+		Status:        entity.StatusFailed,
+		ErrorMessage:  cause.Error(),
+		FailureReason: txn.FailureReason(),
+	}
+	var limitErr *entity.LimitError
+	if errors.As(cause, &limitErr) {
+		resp.ExceededLimit = limitErr.Kind
+	}
+	return uc.saveAndReturn(ctx, tx, key, fingerprint, resp)
+}
+
+// checkCurrency rejects amounts in a currency other than that of every account
+// involved. Moving money between currencies needs an explicit conversion and
+// is never done implicitly by a plain transfer.
+func checkCurrency(amount entity.Money, accounts ...*entity.Account) error {
+	for _, a := range accounts {
+		if a.Currency() != amount.Currency() {
+			return fmt.Errorf("%w: account %s holds %s, amount is in %s",
+				entity.ErrCurrencyMismatch, a.ID(), a.Currency(), amount.Currency())
+		}
+	}
+	return nil
+}
+
+// lockAccounts takes row locks on both accounts in ascending id order, so that
+// concurrent A->B and B->A transfers can never wait on each other in a cycle.
+func lockAccounts(
+	ctx context.Context,
+	tx repository.UnitOfWork,
+	fromID, toID uuid.UUID,
+) (*entity.Account, *entity.Account, error) {
+	locked, err := lockAll(ctx, tx, fromID, toID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return locked[fromID], locked[toID], nil
+}
+
+// lockAll locks any number of accounts in the same ascending id order as
+// lockAccounts, so that transfers touching overlapping sets of accounts,
+// house accounts included, cannot deadlock either.
+func lockAll(
+	ctx context.Context,
+	tx repository.UnitOfWork,
+	ids ...uuid.UUID,
+) (map[uuid.UUID]*entity.Account, error) {
+	sorted := slices.Clone(ids)
+	slices.SortFunc(sorted, func(a, b uuid.UUID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+	sorted = slices.Compact(sorted)
+
+	locked := make(map[uuid.UUID]*entity.Account, len(sorted))
+	for _, id := range sorted {
+		a, err := tx.Accounts().FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		locked[id] = a
+	}
+	return locked, nil
+}
+
+// checkCustomer hides house accounts from payment requests: naming one as
+// payer or payee is answered as if it did not exist.
+func checkCustomer(accounts ...*entity.Account) error {
+	for _, a := range accounts {
+		if a.Kind() != entity.AccountCustomer {
+			return fmt.Errorf("%w: %s", repository.ErrAccountNotFound, a.ID())
+		}
+	}
+	return nil
 }
 
 func (uc *UseCase) saveAndReturn(
 	ctx context.Context,
 	tx repository.UnitOfWork,
-	key string,
-	txID uuid.UUID,
-	status entity.TransactionStatus,
-	errMsg string,
+	key, fingerprint string,
+	resp *Response,
 ) (*Response, error) {
 	cache := responseCache{
-		TransactionID: txID.String(),
-		Status:        string(status),
-		ErrorMessage:  errMsg,
+		TransactionID: resp.TransactionID,
+		Status:        string(resp.Status),
+		ErrorMessage:  resp.ErrorMessage,
+		FailureReason: string(resp.FailureReason),
+		Fee:           resp.Fee,
+		FeeBearer:     string(resp.FeeBearer),
+		ExceededLimit: string(resp.ExceededLimit),
 	}
+	if err := saveAndCommit(ctx, tx, key, fingerprint, statusToCode(resp.Status), cache); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// saveAndCommit stores the response cached under an idempotency key and
+// commits the unit of work that produced it.
+func saveAndCommit(
+	ctx context.Context,
+	tx repository.UnitOfWork,
+	key, fingerprint string,
+	code int,
+	cache any,
+) error {
 	body, err := json.Marshal(cache)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	record := entity.NewIdempotencyRecord(key, statusToCode(status), body)
+	record := entity.NewIdempotencyRecord(key, fingerprint, code, body)
 	if saveErr := tx.Idempotency().Save(ctx, record); saveErr != nil {
-		return nil, saveErr
+		return saveErr
 	}
 
-	if commitErr := tx.Commit(ctx); commitErr != nil {
-		return nil, commitErr
-	}
+	return tx.Commit(ctx)
+}
 
-	return &Response{
-		TransactionID: txID.String(),
-		Status:        status,
-		ErrorMessage:  errMsg,
-	}, nil
+func (uc *UseCase) replay(cached *entity.IdempotencyRecord, fingerprint string) (*Response, error) {
+	if !cached.Matches(fingerprint) {
+		return nil, entity.ErrIdempotencyKeyReused
+	}
+	return uc.parseCache(cached.ResponseBody())
 }
 
 func (uc *UseCase) parseCache(body []byte) (*Response, error) {
@@ -142,6 +543,10 @@ func (uc *UseCase) parseCache(body []byte) (*Response, error) {
 		TransactionID: cache.TransactionID,
 		Status:        entity.TransactionStatus(cache.Status),
 		ErrorMessage:  cache.ErrorMessage,
+		FailureReason: entity.FailureReason(cache.FailureReason),
+		Fee:           cache.Fee,
+		FeeBearer:     entity.FeeBearer(cache.FeeBearer),
+		ExceededLimit: entity.LimitKind(cache.ExceededLimit),
 	}, nil
 }
 
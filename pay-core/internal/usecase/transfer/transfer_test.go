@@ -2,7 +2,6 @@ package transfer_test
 
 import (
 	"context"
-	"errors"
 	"testing"
 	"time"
 
@@ -12,6 +11,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
 	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer"
 	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer/mocks"
 )
@@ -25,24 +25,104 @@ func TestTransferUseCase_Execute_Idempotency(t *testing.T) {
 
 	uc := transfer.NewUseCase(uow)
 
+	req := transfer.Request{
+		IdempotencyKey: "test-key",
+		FromAccountID:  uuid.New(),
+		ToAccountID:    uuid.New(),
+		Amount:         rub(1000),
+	}
+
 	cachedBody := []byte(`{"transaction_id":"cached-tx-id","status":"success","error_message":""}`)
-	record := entity.ReconstructIdempotencyRecord("test-key", 2, cachedBody, time.Time{})
+	record := entity.ReconstructIdempotencyRecord("test-key", req.Fingerprint(), 2, cachedBody, time.Time{})
 
 	uow.EXPECT().Idempotency().Return(idempotencyRepo)
 	idempotencyRepo.EXPECT().Find(gomock.Any(), "test-key").Return(record, nil)
 
-	resp, err := uc.Execute(context.Background(), transfer.Request{
-		IdempotencyKey: "test-key",
-		FromAccountID:  uuid.New(),
-		ToAccountID:    uuid.New(),
-		Amount:         1000,
-	})
+	resp, err := uc.Execute(context.Background(), req)
 
 	require.NoError(t, err)
 	assert.Equal(t, "cached-tx-id", resp.TransactionID)
 	assert.Equal(t, entity.StatusSuccess, resp.Status)
 }
 
+func TestRequest_FingerprintMatchesKeysStoredBeforeOptionalFields(t *testing.T) {
+	from := uuid.New()
+	to := uuid.New()
+	// The fingerprint of a payment from before currencies, quotes and
+	// transfer types were added.
+	stored := entity.RequestFingerprint(map[string]string{
+		"from_account_id": from.String(),
+		"to_account_id":   to.String(),
+		"amount":          "1000",
+	})
+
+	tests := []struct {
+		name string
+		req  transfer.Request
+		same bool
+	}{
+		{
+			name: "defaults",
+			req:  transfer.Request{FromAccountID: from, ToAccountID: to, Amount: rub(1000)},
+			same: true,
+		},
+		{
+			name: "explicit p2p",
+			req:  transfer.Request{FromAccountID: from, ToAccountID: to, Amount: rub(1000), Type: entity.TransferP2P},
+			same: true,
+		},
+		{
+			name: "other currency",
+			req:  transfer.Request{FromAccountID: from, ToAccountID: to, Amount: entity.ReconstructMoney(1000, "USD")},
+		},
+		{
+			name: "quote",
+			req:  transfer.Request{FromAccountID: from, ToAccountID: to, Amount: rub(1000), QuoteID: uuid.New()},
+		},
+		{
+			name: "merchant payment",
+			req: transfer.Request{
+				FromAccountID: from, ToAccountID: to, Amount: rub(1000), Type: entity.TransferQRMerchant,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.same, tt.req.Fingerprint() == stored)
+		})
+	}
+}
+
+func TestTransferUseCase_Execute_IdempotencyKeyReused(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+
+	uc := transfer.NewUseCase(uow)
+
+	original := transfer.Request{
+		IdempotencyKey: "reused-key",
+		FromAccountID:  uuid.New(),
+		ToAccountID:    uuid.New(),
+		Amount:         rub(1000),
+	}
+	replayed := original
+	replayed.Amount = rub(5000)
+
+	cachedBody := []byte(`{"transaction_id":"cached-tx-id","status":"success","error_message":""}`)
+	record := entity.ReconstructIdempotencyRecord("reused-key", original.Fingerprint(), 2, cachedBody, time.Time{})
+
+	uow.EXPECT().Idempotency().Return(idempotencyRepo)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "reused-key").Return(record, nil)
+
+	_, err := uc.Execute(context.Background(), replayed)
+
+	require.ErrorIs(t, err, entity.ErrIdempotencyKeyReused)
+}
+
 func TestTransferUseCase_Execute_SuccessfulTransfer(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -71,19 +151,33 @@ func TestTransferUseCase_Execute_SuccessfulTransfer(t *testing.T) {
 	txUow.EXPECT().Accounts().Return(accountRepo).Times(4)
 	txUow.EXPECT().Transactions().Return(txnRepo)
 	txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+	expectNoLimits(ctrl, txUow)
+	expectNoFee(ctrl, txUow)
 
-	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), fromID).Return(entity.NewAccount(fromID, 5000), nil)
-	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), toID).Return(entity.NewAccount(toID, 1000), nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), fromID).Return(entity.NewAccount(fromID, rub(5000)), nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), toID).Return(entity.NewAccount(toID, rub(1000)), nil)
 	accountRepo.EXPECT().UpdateBalance(gomock.Any(), fromID, int64(4000)).Return(nil)
 	accountRepo.EXPECT().UpdateBalance(gomock.Any(), toID, int64(2000)).Return(nil)
-	txnRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	expectEvent(ctrl, txUow, entity.EventPaymentCompleted)
+	txnRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, txn *entity.Transaction) error {
+			postings := txn.Postings()
+			require.Len(t, postings, 2)
+			assert.Equal(t, fromID, postings[0].AccountID())
+			assert.Equal(t, int64(-1000), postings[0].Amount())
+			assert.Equal(t, toID, postings[1].AccountID())
+			assert.Equal(t, int64(1000), postings[1].Amount())
+			assert.NoError(t, txn.CheckBalanced())
+			return nil
+		},
+	)
 	idempotencyRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
 
 	resp, err := uc.Execute(context.Background(), transfer.Request{
 		IdempotencyKey: "new-key",
 		FromAccountID:  fromID,
 		ToAccountID:    toID,
-		Amount:         1000,
+		Amount:         rub(1000),
 	})
 
 	require.NoError(t, err)
@@ -97,6 +191,7 @@ func TestTransferUseCase_Execute_InsufficientFunds(t *testing.T) {
 	uow := mocks.NewMockUnitOfWork(ctrl)
 	txUow := mocks.NewMockUnitOfWork(ctrl)
 	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	txnRepo := mocks.NewMockTransactionRepository(ctrl)
 	idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
 
 	uc := transfer.NewUseCase(uow)
@@ -114,22 +209,164 @@ func TestTransferUseCase_Execute_InsufficientFunds(t *testing.T) {
 	idempotencyRepo.EXPECT().Lock(gomock.Any(), "insufficient-key").Return(nil)
 	idempotencyRepo.EXPECT().Find(gomock.Any(), "insufficient-key").Return(nil, nil)
 
-	txUow.EXPECT().Accounts().Return(accountRepo)
+	txUow.EXPECT().Accounts().Return(accountRepo).Times(2)
+	txUow.EXPECT().Transactions().Return(txnRepo)
 	txUow.EXPECT().Commit(gomock.Any()).Return(nil)
-
-	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), fromID).Return(entity.NewAccount(fromID, 500), nil)
+	expectNoLimits(ctrl, txUow)
+	expectNoFee(ctrl, txUow)
+
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), fromID).Return(entity.NewAccount(fromID, rub(500)), nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), toID).Return(entity.NewAccount(toID, rub(0)), nil)
+	var declined *entity.Transaction
+	expectEvent(ctrl, txUow, entity.EventPaymentFailed)
+	txnRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, txn *entity.Transaction) error {
+			declined = txn
+			return nil
+		},
+	)
 	idempotencyRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
 
 	resp, err := uc.Execute(context.Background(), transfer.Request{
 		IdempotencyKey: "insufficient-key",
 		FromAccountID:  fromID,
 		ToAccountID:    toID,
-		Amount:         1000,
+		Amount:         rub(1000),
 	})
 
 	require.NoError(t, err)
 	assert.Equal(t, entity.StatusFailed, resp.Status)
 	assert.Equal(t, "insufficient funds", resp.ErrorMessage)
+	assert.Equal(t, entity.FailureInsufficientFunds, resp.FailureReason)
+
+	require.NotNil(t, declined)
+	assert.Equal(t, declined.ID().String(), resp.TransactionID)
+	assert.Equal(t, entity.StatusFailed, declined.Status())
+	assert.Equal(t, entity.FailureInsufficientFunds, declined.FailureReason())
+	assert.Empty(t, declined.Postings())
+}
+
+func TestTransferUseCase_Execute_DeclinesByAccountStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		sender   func(*entity.Account) error
+		receiver func(*entity.Account) error
+		want     entity.FailureReason
+	}{
+		{
+			name:   "frozen sender",
+			sender: func(a *entity.Account) error { return a.Freeze("compromised") },
+			want:   entity.FailureAccountFrozen,
+		},
+		{
+			name:     "closed receiver",
+			receiver: func(a *entity.Account) error { return a.Close("customer request") },
+			want:     entity.FailureAccountClosed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uow := mocks.NewMockUnitOfWork(ctrl)
+			txUow := mocks.NewMockUnitOfWork(ctrl)
+			accountRepo := mocks.NewMockAccountRepository(ctrl)
+			txnRepo := mocks.NewMockTransactionRepository(ctrl)
+			idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+
+			uc := transfer.NewUseCase(uow)
+
+			fromID := uuid.New()
+			toID := uuid.New()
+			sender := entity.NewAccount(fromID, rub(5000))
+			receiver := entity.NewAccount(toID, rub(0))
+			if tt.sender != nil {
+				require.NoError(t, tt.sender(sender))
+			}
+			if tt.receiver != nil {
+				require.NoError(t, tt.receiver(receiver))
+			}
+
+			uow.EXPECT().Idempotency().Return(idempotencyRepo)
+			idempotencyRepo.EXPECT().Find(gomock.Any(), "status-key").Return(nil, nil)
+
+			uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+			txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+
+			txUow.EXPECT().Idempotency().Return(idempotencyRepo).Times(3)
+			idempotencyRepo.EXPECT().Lock(gomock.Any(), "status-key").Return(nil)
+			idempotencyRepo.EXPECT().Find(gomock.Any(), "status-key").Return(nil, nil)
+
+			txUow.EXPECT().Accounts().Return(accountRepo).Times(2)
+			accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), fromID).Return(sender, nil)
+			accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), toID).Return(receiver, nil)
+
+			txUow.EXPECT().Transactions().Return(txnRepo)
+			expectEvent(ctrl, txUow, entity.EventPaymentFailed)
+			txnRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, txn *entity.Transaction) error {
+					assert.Equal(t, tt.want, txn.FailureReason())
+					assert.Empty(t, txn.Postings())
+					return nil
+				},
+			)
+			idempotencyRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+			txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+
+			resp, err := uc.Execute(context.Background(), transfer.Request{
+				IdempotencyKey: "status-key",
+				FromAccountID:  fromID,
+				ToAccountID:    toID,
+				Amount:         rub(1000),
+			})
+
+			require.NoError(t, err)
+			assert.Equal(t, entity.StatusFailed, resp.Status)
+			assert.Equal(t, tt.want, resp.FailureReason)
+		})
+	}
+}
+
+func TestTransferUseCase_Execute_RejectsCrossCurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+
+	uc := transfer.NewUseCase(uow)
+
+	fromID := uuid.New()
+	toID := uuid.New()
+
+	uow.EXPECT().Idempotency().Return(idempotencyRepo)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "fx-key").Return(nil, nil)
+
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+
+	txUow.EXPECT().Idempotency().Return(idempotencyRepo).Times(2)
+	idempotencyRepo.EXPECT().Lock(gomock.Any(), "fx-key").Return(nil)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "fx-key").Return(nil, nil)
+
+	txUow.EXPECT().Accounts().Return(accountRepo).Times(2)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), fromID).Return(entity.NewAccount(fromID, rub(5000)), nil)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), toID).Return(
+		entity.NewAccount(toID, entity.ReconstructMoney(0, "KZT")), nil,
+	)
+
+	_, err := uc.Execute(context.Background(), transfer.Request{
+		IdempotencyKey: "fx-key",
+		FromAccountID:  fromID,
+		ToAccountID:    toID,
+		Amount:         rub(1000),
+	})
+
+	require.ErrorIs(t, err, entity.ErrCurrencyMismatch)
 }
 
 func TestTransferUseCase_Execute_SenderNotFound(t *testing.T) {
@@ -156,17 +393,116 @@ func TestTransferUseCase_Execute_SenderNotFound(t *testing.T) {
 	idempotencyRepo.EXPECT().Lock(gomock.Any(), "notfound-key").Return(nil)
 	idempotencyRepo.EXPECT().Find(gomock.Any(), "notfound-key").Return(nil, nil)
 
-	txUow.EXPECT().Accounts().Return(accountRepo)
+	txUow.EXPECT().Accounts().Return(accountRepo).MinTimes(1).MaxTimes(2)
 
-	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), fromID).Return(nil, errors.New("account not found"))
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), fromID).Return(nil, repository.ErrAccountNotFound)
+	accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), toID).Return(entity.NewAccount(toID, rub(0)), nil).MaxTimes(1)
 
 	_, err := uc.Execute(context.Background(), transfer.Request{
 		IdempotencyKey: "notfound-key",
 		FromAccountID:  fromID,
 		ToAccountID:    toID,
-		Amount:         1000,
+		Amount:         rub(1000),
+	})
+
+	require.ErrorIs(t, err, repository.ErrAccountNotFound)
+}
+
+func TestTransferUseCase_Execute_LocksAccountsInIDOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	txnRepo := mocks.NewMockTransactionRepository(ctrl)
+	idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+
+	uc := transfer.NewUseCase(uow)
+
+	lowID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	highID := uuid.MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff")
+
+	uow.EXPECT().Idempotency().Return(idempotencyRepo)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "order-key").Return(nil, nil).Times(2)
+
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	txUow.EXPECT().Idempotency().Return(idempotencyRepo).Times(3)
+	idempotencyRepo.EXPECT().Lock(gomock.Any(), "order-key").Return(nil)
+
+	txUow.EXPECT().Accounts().Return(accountRepo).Times(4)
+	txUow.EXPECT().Transactions().Return(txnRepo)
+	txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+	expectNoLimits(ctrl, txUow)
+	expectNoFee(ctrl, txUow)
+
+	gomock.InOrder(
+		accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), lowID).Return(entity.NewAccount(lowID, rub(0)), nil),
+		accountRepo.EXPECT().FindByIDForUpdate(gomock.Any(), highID).Return(entity.NewAccount(highID, rub(5000)), nil),
+	)
+	accountRepo.EXPECT().UpdateBalance(gomock.Any(), highID, int64(4000)).Return(nil)
+	accountRepo.EXPECT().UpdateBalance(gomock.Any(), lowID, int64(1000)).Return(nil)
+	expectEvent(ctrl, txUow, entity.EventPaymentCompleted)
+	txnRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	idempotencyRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+	resp, err := uc.Execute(context.Background(), transfer.Request{
+		IdempotencyKey: "order-key",
+		FromAccountID:  highID,
+		ToAccountID:    lowID,
+		Amount:         rub(1000),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.StatusSuccess, resp.Status)
+}
+
+func TestTransferUseCase_Execute_RetriesOnConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	idempotencyRepo := mocks.NewMockIdempotencyRepository(ctrl)
+
+	uc := transfer.NewUseCase(uow, transfer.WithRetryPolicy(transfer.RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    time.Millisecond,
+	}))
+
+	uow.EXPECT().Idempotency().Return(idempotencyRepo)
+	idempotencyRepo.EXPECT().Find(gomock.Any(), "conflict-key").Return(nil, nil)
+
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil).Times(3)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil).Times(3)
+	txUow.EXPECT().Idempotency().Return(idempotencyRepo).Times(3)
+	idempotencyRepo.EXPECT().Lock(gomock.Any(), "conflict-key").Return(repository.ErrConflict).Times(3)
+
+	_, err := uc.Execute(context.Background(), transfer.Request{
+		IdempotencyKey: "conflict-key",
+		FromAccountID:  uuid.New(),
+		ToAccountID:    uuid.New(),
+		Amount:         rub(1000),
 	})
 
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "account not found")
+	require.ErrorIs(t, err, repository.ErrConflict)
+	assert.Equal(t, transfer.Stats{Retries: 2, Exhausted: 1}, uc.Stats())
+}
+
+func rub(amount int64) entity.Money {
+	return entity.ReconstructMoney(amount, "RUB")
+}
+
+var active = entity.AccountState{Status: entity.AccountActive}
+
+// expectEvent expects the outbox event a recorded transaction is announced
+// with.
+func expectEvent(ctrl *gomock.Controller, tx *mocks.MockUnitOfWork, eventType entity.EventType) {
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+	tx.EXPECT().Outbox().Return(outboxRepo)
+	outboxRepo.EXPECT().Add(gomock.Any(), gomock.Cond(func(e *entity.Event) bool {
+		return e.Type() == eventType
+	})).Return(nil)
 }
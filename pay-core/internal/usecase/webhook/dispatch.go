@@ -0,0 +1,327 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+)
+
+// Headers of every delivery. The signature is "sha256=" followed by the hex
+// HMAC-SHA256, keyed with the endpoint's secret, of the timestamp, a dot and
+// the raw body; endpoints should recompute it and reject stale timestamps.
+const (
+	HeaderDeliveryID = "X-Webhook-Id"
+	HeaderEventType  = "X-Webhook-Event"
+	HeaderTimestamp  = "X-Webhook-Timestamp"
+	HeaderSignature  = "X-Webhook-Signature"
+)
+
+const (
+	// maxErrorLength bounds the response excerpt kept in the delivery log.
+	maxErrorLength = 512
+	// maxBackoffShift keeps BaseDelay << n from overflowing.
+	maxBackoffShift = 30
+	// leaseSlack is how much longer than its attempts may take a batch of
+	// deliveries is leased for.
+	leaseSlack = 30 * time.Second
+)
+
+var errBlockedAddr = errors.New("webhook address not allowed")
+
+// Sign returns the X-Webhook-Signature value for a body sent at timestamp,
+// given in Unix seconds.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type Config struct {
+	Interval  time.Duration
+	BatchSize int
+	// MaxAttempts is how many attempts a delivery gets before it goes to the
+	// dead-letter queue.
+	MaxAttempts int
+	// The n-th retry waits BaseDelay * 2^(n-1), at most MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// Timeout bounds one attempt.
+	Timeout time.Duration
+	// AllowPrivateHosts lets deliveries reach loopback and private addresses,
+	// for local development; otherwise only public addresses are dialed.
+	AllowPrivateHosts bool
+}
+
+type Result struct {
+	Delivered    int64
+	Failed       int64
+	DeadLettered int64
+	Duration     time.Duration
+}
+
+// Dispatcher periodically posts due webhook deliveries. Deliveries are leased
+// under FOR UPDATE SKIP LOCKED and sent after the lease is committed, so
+// several dispatchers can run side by side and a delivery whose dispatcher
+// died is attempted again once its lease runs out.
+// Any 2xx response acknowledges a delivery; anything else is retried with
+// exponential backoff until MaxAttempts.
+type Dispatcher struct {
+	uow    repository.UnitOfWork
+	cfg    Config
+	client *http.Client
+	logger *slog.Logger
+}
+
+// NewDispatcher creates the dispatcher. A BatchSize below one is raised to
+// one, which a batch can come back short of.
+func NewDispatcher(uow repository.UnitOfWork, cfg Config, logger *slog.Logger) *Dispatcher {
+	cfg.BatchSize = max(cfg.BatchSize, 1)
+	dialer := &net.Dialer{}
+	if !cfg.AllowPrivateHosts {
+		dialer.Control = dialPublicOnly
+	}
+	return &Dispatcher{
+		uow: uow,
+		cfg: cfg,
+		client: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: &http.Transport{DialContext: dialer.DialContext},
+		},
+		logger: logger,
+	}
+}
+
+// dialPublicOnly refuses connections to addresses that are not public. It
+// runs on the resolved address of every connection, redirects included, so a
+// registered name cannot be pointed at an internal one later.
+func dialPublicOnly(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+	if !entity.PublicWebhookAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s is not a public address", errBlockedAddr, addrPort.Addr())
+	}
+	return nil
+}
+
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		res, err := d.DispatchOnce(ctx)
+		if err != nil {
+			d.logger.ErrorContext(ctx, "webhook dispatch failed", "error", err, "duration", res.Duration)
+		} else if res.Delivered+res.Failed+res.DeadLettered > 0 {
+			d.logger.InfoContext(ctx, "webhooks dispatched",
+				"delivered", res.Delivered, "failed", res.Failed, "dead_lettered", res.DeadLettered,
+				"duration", res.Duration)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce sends due deliveries in batches of BatchSize, one unit of work
+// per batch, until a batch comes back short.
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (Result, error) {
+	start := time.Now()
+
+	var res Result
+	for {
+		n, err := d.dispatchBatch(ctx, &res)
+		if err != nil {
+			res.Duration = time.Since(start)
+			return res, err
+		}
+		if n < d.cfg.BatchSize || ctx.Err() != nil {
+			break
+		}
+	}
+
+	res.Duration = time.Since(start)
+	return res, nil
+}
+
+// dispatchBatch leases a batch of due deliveries in one unit of work, sends
+// them outside of it, and records each outcome in a unit of work of its own,
+// so that no transaction or row lock is held while an endpoint responds.
+func (d *Dispatcher) dispatchBatch(ctx context.Context, res *Result) (int, error) {
+	deliveries, endpoints, lease, err := d.claim(ctx)
+	if err != nil || len(deliveries) == 0 {
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+		statusCode, sendErr := d.send(ctx, endpoints[delivery.EndpointID()], delivery)
+		if ctx.Err() != nil {
+			// Shutting down: the attempt was not the endpoint's fault, and
+			// the deliveries left are attempted again when the lease ends.
+			return 0, ctx.Err()
+		}
+		if err = d.record(ctx, delivery.ID(), lease, statusCode, sendErr, res); err != nil {
+			return 0, err
+		}
+	}
+	return len(deliveries), nil
+}
+
+// claim leases up to BatchSize due deliveries for long enough to send them
+// all one after another, and loads their endpoints.
+func (d *Dispatcher) claim(ctx context.Context) (
+	[]*entity.WebhookDelivery,
+	map[uuid.UUID]*entity.WebhookEndpoint,
+	time.Time,
+	error,
+) {
+	tx, err := d.uow.Begin(ctx)
+	if err != nil {
+		return nil, nil, time.Time{}, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	now := time.Now()
+	deliveries, err := tx.Webhooks().ListDueForUpdate(ctx, now, d.cfg.BatchSize)
+	if err != nil || len(deliveries) == 0 {
+		return nil, nil, time.Time{}, err
+	}
+
+	// Postgres keeps microseconds, so the lease is rounded to compare equal
+	// once it is read back.
+	lease := now.Add(time.Duration(len(deliveries))*d.cfg.Timeout + leaseSlack).Truncate(time.Microsecond)
+	endpoints := make(map[uuid.UUID]*entity.WebhookEndpoint)
+	for _, delivery := range deliveries {
+		if _, ok := endpoints[delivery.EndpointID()]; !ok {
+			endpoint, findErr := tx.Webhooks().FindEndpointByID(ctx, delivery.EndpointID())
+			if findErr != nil {
+				return nil, nil, time.Time{}, findErr
+			}
+			endpoints[endpoint.ID()] = endpoint
+		}
+		delivery.Lease(lease)
+		if err = tx.Webhooks().UpdateDelivery(ctx, delivery); err != nil {
+			return nil, nil, time.Time{}, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, nil, time.Time{}, err
+	}
+	return deliveries, endpoints, lease, nil
+}
+
+// record saves the outcome of an attempt, unless the delivery changed since
+// it was leased: a delivery resent meanwhile keeps its fresh attempts.
+func (d *Dispatcher) record(
+	ctx context.Context,
+	id uuid.UUID,
+	lease time.Time,
+	statusCode int,
+	sendErr error,
+	res *Result,
+) error {
+	tx, err := d.uow.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	delivery, err := tx.Webhooks().FindDeliveryForUpdate(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !delivery.Leased(lease) {
+		return nil
+	}
+
+	now := time.Now()
+	if sendErr == nil {
+		delivery.Succeed(statusCode, now)
+	} else {
+		delivery.Fail(statusCode, sendErr.Error(), now.Add(d.backoff(delivery.Attempts())), d.cfg.MaxAttempts)
+	}
+	if err = tx.Webhooks().UpdateDelivery(ctx, delivery); err != nil {
+		return err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	switch {
+	case sendErr == nil:
+		res.Delivered++
+	case delivery.Status() == entity.WebhookDead:
+		res.DeadLettered++
+		d.logger.WarnContext(ctx, "webhook delivery dead-lettered",
+			"delivery_id", delivery.ID(), "account_id", delivery.AccountID(), "error", sendErr)
+	default:
+		res.Failed++
+	}
+	return nil
+}
+
+// send posts the delivery and returns the response status, or zero if there
+// was no response.
+func (d *Dispatcher) send(
+	ctx context.Context,
+	endpoint *entity.WebhookEndpoint,
+	delivery *entity.WebhookDelivery,
+) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.URL(), bytes.NewReader(delivery.Body()))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderDeliveryID, delivery.ID().String())
+	req.Header.Set(HeaderEventType, string(delivery.EventType()))
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(endpoint.Secret(), timestamp, delivery.Body()))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorLength))
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, fmt.Errorf("%s: %s", resp.Status, bytes.TrimSpace(excerpt))
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff is how long to wait after a failure that follows the given number
+// of earlier attempts.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.cfg.BaseDelay << min(attempts, maxBackoffShift)
+	if delay <= 0 || delay > d.cfg.MaxDelay {
+		return d.cfg.MaxDelay
+	}
+	return delay
+}
@@ -0,0 +1,240 @@
+package webhook_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer/mocks"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/webhook"
+)
+
+var testConfig = webhook.Config{
+	Interval:    time.Minute,
+	BatchSize:   10,
+	MaxAttempts: 3,
+	BaseDelay:   time.Minute,
+	MaxDelay:    time.Hour,
+	Timeout:     time.Second,
+	// Test servers listen on loopback.
+	AllowPrivateHosts: true,
+}
+
+// expectBatch sets up one dispatch pass over the delivery to endpoint: the
+// unit of work that leases it and the one that records the attempt. It
+// returns the delivery as it is saved.
+func expectBatch(
+	ctrl *gomock.Controller,
+	uow *mocks.MockUnitOfWork,
+	endpoint *entity.WebhookEndpoint,
+	delivery *entity.WebhookDelivery,
+) *entity.WebhookDelivery {
+	webhookRepo := expectClaim(ctrl, uow, endpoint, delivery)
+
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	txUow.EXPECT().Webhooks().Return(webhookRepo).AnyTimes()
+	webhookRepo.EXPECT().FindDeliveryForUpdate(gomock.Any(), delivery.ID()).Return(delivery, nil)
+	webhookRepo.EXPECT().UpdateDelivery(gomock.Any(), delivery).Return(nil)
+	txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+	return delivery
+}
+
+// expectClaim sets up the unit of work that leases the delivery.
+func expectClaim(
+	ctrl *gomock.Controller,
+	uow *mocks.MockUnitOfWork,
+	endpoint *entity.WebhookEndpoint,
+	delivery *entity.WebhookDelivery,
+) *mocks.MockWebhookRepository {
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	webhookRepo := mocks.NewMockWebhookRepository(ctrl)
+
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	txUow.EXPECT().Webhooks().Return(webhookRepo).AnyTimes()
+	webhookRepo.EXPECT().ListDueForUpdate(gomock.Any(), gomock.Any(), testConfig.BatchSize).
+		Return([]*entity.WebhookDelivery{delivery}, nil)
+	webhookRepo.EXPECT().FindEndpointByID(gomock.Any(), delivery.EndpointID()).Return(endpoint, nil)
+	webhookRepo.EXPECT().UpdateDelivery(gomock.Any(), delivery).DoAndReturn(
+		func(_ context.Context, d *entity.WebhookDelivery) error {
+			// Other dispatchers skip the delivery while it is being sent.
+			assert.True(ctrl.T, d.NextAttemptAt().After(time.Now()))
+			return nil
+		})
+	txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+	return webhookRepo
+}
+
+func newDispatcher(uow *mocks.MockUnitOfWork) *webhook.Dispatcher {
+	return webhook.NewDispatcher(uow, testConfig, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestDispatcher_DispatchOnce_DeliversSigned(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var got *http.Request
+	var gotBody []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	endpoint, delivery := newDelivery(t, srv.URL, 0)
+	saved := expectBatch(ctrl, uow, endpoint, delivery)
+
+	res, err := newDispatcher(uow).DispatchOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.Delivered)
+
+	require.NotNil(t, got)
+	assert.Equal(t, http.MethodPost, got.Method)
+	assert.Equal(t, delivery.Body(), gotBody)
+	assert.Equal(t, delivery.ID().String(), got.Header.Get(webhook.HeaderDeliveryID))
+	assert.Equal(t, string(entity.EventPaymentCompleted), got.Header.Get(webhook.HeaderEventType))
+	timestamp := got.Header.Get(webhook.HeaderTimestamp)
+	assert.Equal(t, webhook.Sign(endpoint.Secret(), timestamp, gotBody), got.Header.Get(webhook.HeaderSignature))
+
+	assert.Equal(t, entity.WebhookDelivered, saved.Status())
+	assert.Equal(t, 1, saved.Attempts())
+	assert.Equal(t, http.StatusNoContent, saved.LastStatusCode())
+	assert.False(t, saved.DeliveredAt().IsZero())
+}
+
+func TestDispatcher_DispatchOnce_RetriesWithBackoff(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "try later", http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	endpoint, delivery := newDelivery(t, srv.URL, 1)
+	saved := expectBatch(ctrl, uow, endpoint, delivery)
+
+	res, err := newDispatcher(uow).DispatchOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.Failed)
+	assert.Equal(t, entity.WebhookPending, saved.Status())
+	assert.Equal(t, 2, saved.Attempts())
+	assert.Equal(t, http.StatusServiceUnavailable, saved.LastStatusCode())
+	assert.Contains(t, saved.LastError(), "try later")
+	// The second failure waits twice the base delay.
+	assert.WithinDuration(t, time.Now().Add(2*testConfig.BaseDelay), saved.NextAttemptAt(), time.Second)
+}
+
+func TestDispatcher_DispatchOnce_DeadLettersAfterMaxAttempts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	endpoint, delivery := newDelivery(t, srv.URL, testConfig.MaxAttempts-1)
+	saved := expectBatch(ctrl, uow, endpoint, delivery)
+
+	res, err := newDispatcher(uow).DispatchOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.DeadLettered)
+	assert.Equal(t, entity.WebhookDead, saved.Status())
+	assert.Equal(t, testConfig.MaxAttempts, saved.Attempts())
+}
+
+func TestDispatcher_DispatchOnce_DropsOutcomeOfResentDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	endpoint, delivery := newDelivery(t, srv.URL, testConfig.MaxAttempts-1)
+	webhookRepo := expectClaim(ctrl, uow, endpoint, delivery)
+
+	// The delivery is resent while the attempt is in flight.
+	_, resent := newDelivery(t, srv.URL, 0)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	txUow.EXPECT().Webhooks().Return(webhookRepo)
+	webhookRepo.EXPECT().FindDeliveryForUpdate(gomock.Any(), delivery.ID()).Return(resent, nil)
+
+	res, err := newDispatcher(uow).DispatchOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Zero(t, res.DeadLettered)
+	assert.Equal(t, entity.WebhookPending, resent.Status())
+	assert.Zero(t, resent.Attempts())
+}
+
+func TestDispatcher_DispatchOnce_RefusesPrivateAddresses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var called bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	endpoint, delivery := newDelivery(t, srv.URL, 0)
+	saved := expectBatch(ctrl, uow, endpoint, delivery)
+
+	cfg := testConfig
+	cfg.AllowPrivateHosts = false
+	res, err := webhook.NewDispatcher(uow, cfg, slog.New(slog.NewTextHandler(io.Discard, nil))).
+		DispatchOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.False(t, called)
+	assert.Equal(t, int64(1), res.Failed)
+	assert.Contains(t, saved.LastError(), "not a public address")
+}
+
+func TestDispatcher_DispatchOnce_RaisesZeroBatchSize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	webhookRepo := mocks.NewMockWebhookRepository(ctrl)
+
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	txUow.EXPECT().Webhooks().Return(webhookRepo)
+	webhookRepo.EXPECT().ListDueForUpdate(gomock.Any(), gomock.Any(), 1).Return(nil, nil)
+
+	cfg := testConfig
+	cfg.BatchSize = 0
+	res, err := webhook.NewDispatcher(uow, cfg, slog.New(slog.NewTextHandler(io.Discard, nil))).
+		DispatchOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Zero(t, res.Delivered+res.Failed+res.DeadLettered)
+}
@@ -0,0 +1,151 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/pagetoken"
+)
+
+type ListRequest struct {
+	AccountID uuid.UUID
+	Status    entity.WebhookDeliveryStatus
+	PageSize  int
+	PageToken string
+}
+
+type ListResponse struct {
+	Deliveries    []*entity.WebhookDelivery
+	NextPageToken string
+}
+
+type UseCase struct {
+	uow repository.UnitOfWork
+}
+
+func NewUseCase(uow repository.UnitOfWork) *UseCase {
+	return &UseCase{uow: uow}
+}
+
+// Register points the customer account's webhook at rawURL. An account has a
+// single endpoint: registering again replaces its URL and secret.
+func (uc *UseCase) Register(ctx context.Context, accountID uuid.UUID, rawURL string) (*entity.WebhookEndpoint, error) {
+	acc, err := uc.uow.Accounts().FindByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if acc.Kind() != entity.AccountCustomer {
+		return nil, fmt.Errorf("%w: %s", repository.ErrAccountNotFound, acc.ID())
+	}
+
+	endpoint, err := uc.uow.Webhooks().FindEndpointByAccount(ctx, accountID)
+	if errors.Is(err, repository.ErrWebhookNotFound) {
+		endpoint, err = entity.NewWebhookEndpoint(accountID, rawURL)
+		if err != nil {
+			return nil, err
+		}
+		return endpoint, uc.uow.Webhooks().CreateEndpoint(ctx, endpoint)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = endpoint.Reregister(rawURL); err != nil {
+		return nil, err
+	}
+	return endpoint, uc.uow.Webhooks().UpdateEndpoint(ctx, endpoint)
+}
+
+// Deliveries lists deliveries newest first. A page token is only meaningful
+// with the same filter it was issued for.
+func (uc *UseCase) Deliveries(ctx context.Context, req ListRequest) (*ListResponse, error) {
+	filter := repository.WebhookDeliveryFilter{
+		AccountID: req.AccountID,
+		Status:    req.Status,
+	}
+	if req.PageToken != "" {
+		cursor, err := pagetoken.Decode(req.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = &cursor
+	}
+
+	limit := pagetoken.Limit(req.PageSize)
+	filter.Limit = limit + 1
+	deliveries, err := uc.uow.Webhooks().ListDeliveries(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &ListResponse{Deliveries: deliveries}
+	if len(deliveries) > limit {
+		resp.Deliveries = deliveries[:limit]
+		last := resp.Deliveries[limit-1]
+		resp.NextPageToken = pagetoken.Encode(repository.Cursor{CreatedAt: last.CreatedAt(), ID: last.ID()})
+	}
+	return resp, nil
+}
+
+// Resend queues a delivery again with a fresh set of attempts, whatever its
+// status; it is how deliveries leave the dead-letter queue. The outcome of an
+// attempt in flight is then dropped, since it belongs to the old lease.
+func (uc *UseCase) Resend(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error) {
+	tx, err := uc.uow.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	delivery, err := tx.Webhooks().FindDeliveryForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	delivery.Resend(time.Now())
+	if err = tx.Webhooks().UpdateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// Publish queues a delivery of a completed payment to the payee's endpoint,
+// if it has one. It lets the outbox relay feed webhooks: an event the relay
+// publishes twice is still queued once.
+func (uc *UseCase) Publish(ctx context.Context, event *entity.Event) error {
+	if event.Type() != entity.EventPaymentCompleted {
+		return nil
+	}
+
+	var payment entity.PaymentEvent
+	if err := json.Unmarshal(event.Payload(), &payment); err != nil {
+		return fmt.Errorf("event %s: %w", event.ID(), err)
+	}
+	payee, err := uuid.Parse(payment.ToAccountID)
+	if err != nil {
+		return fmt.Errorf("event %s: %w", event.ID(), err)
+	}
+
+	endpoint, err := uc.uow.Webhooks().FindEndpointByAccount(ctx, payee)
+	if errors.Is(err, repository.ErrWebhookNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	delivery, err := entity.NewWebhookDelivery(endpoint, event)
+	if err != nil {
+		return err
+	}
+	return uc.uow.Webhooks().AddDelivery(ctx, delivery)
+}
@@ -0,0 +1,200 @@
+package webhook_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/entity"
+	"github.com/Xausdorf/qr-pay-hub/internal/domain/repository"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/transfer/mocks"
+	"github.com/Xausdorf/qr-pay-hub/internal/usecase/webhook"
+)
+
+func TestUseCase_Register_ReplacesEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	accountRepo := mocks.NewMockAccountRepository(ctrl)
+	webhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	uc := webhook.NewUseCase(uow)
+
+	accountID := uuid.New()
+	existing, err := entity.NewWebhookEndpoint(accountID, "https://old.example.com/hook")
+	require.NoError(t, err)
+	oldSecret := existing.Secret()
+
+	uow.EXPECT().Accounts().Return(accountRepo)
+	accountRepo.EXPECT().FindByID(gomock.Any(), accountID).
+		Return(entity.NewAccount(accountID, entity.ReconstructMoney(0, "RUB")), nil)
+	uow.EXPECT().Webhooks().Return(webhookRepo).Times(2)
+	webhookRepo.EXPECT().FindEndpointByAccount(gomock.Any(), accountID).Return(existing, nil)
+	webhookRepo.EXPECT().UpdateEndpoint(gomock.Any(), existing).Return(nil)
+
+	endpoint, err := uc.Register(context.Background(), accountID, "https://new.example.com/hook")
+
+	require.NoError(t, err)
+	assert.Equal(t, existing.ID(), endpoint.ID())
+	assert.Equal(t, "https://new.example.com/hook", endpoint.URL())
+	assert.NotEqual(t, oldSecret, endpoint.Secret())
+}
+
+func TestUseCase_Register_RejectsInvalidURL(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{name: "scheme", url: "ftp://example.com"},
+		{name: "no host", url: "https:///hook"},
+		{name: "localhost", url: "http://localhost:8080/hook"},
+		{name: "loopback", url: "http://127.0.0.1/hook"},
+		{name: "loopback v6", url: "http://[::1]/hook"},
+		{name: "metadata service", url: "http://169.254.169.254/latest/meta-data/"},
+		{name: "private", url: "https://10.0.0.5/hook"},
+		{name: "mapped private", url: "https://[::ffff:192.168.1.1]/hook"},
+		{name: "unspecified", url: "http://0.0.0.0/hook"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			uow := mocks.NewMockUnitOfWork(ctrl)
+			accountRepo := mocks.NewMockAccountRepository(ctrl)
+			webhookRepo := mocks.NewMockWebhookRepository(ctrl)
+			uc := webhook.NewUseCase(uow)
+
+			accountID := uuid.New()
+			uow.EXPECT().Accounts().Return(accountRepo)
+			accountRepo.EXPECT().FindByID(gomock.Any(), accountID).
+				Return(entity.NewAccount(accountID, entity.ReconstructMoney(0, "RUB")), nil)
+			uow.EXPECT().Webhooks().Return(webhookRepo)
+			webhookRepo.EXPECT().FindEndpointByAccount(gomock.Any(), accountID).Return(nil, repository.ErrWebhookNotFound)
+
+			_, err := uc.Register(context.Background(), accountID, tt.url)
+
+			require.ErrorIs(t, err, entity.ErrInvalidWebhookURL)
+		})
+	}
+}
+
+func TestUseCase_Publish_QueuesCompletedPayment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	webhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	uc := webhook.NewUseCase(uow)
+
+	event, payee := paymentEvent(t, entity.StatusSuccess)
+	endpoint, err := entity.NewWebhookEndpoint(payee, "https://merchant.example.com/hook")
+	require.NoError(t, err)
+
+	var queued *entity.WebhookDelivery
+	uow.EXPECT().Webhooks().Return(webhookRepo).Times(2)
+	webhookRepo.EXPECT().FindEndpointByAccount(gomock.Any(), payee).Return(endpoint, nil)
+	webhookRepo.EXPECT().AddDelivery(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, d *entity.WebhookDelivery) error {
+			queued = d
+			return nil
+		})
+
+	require.NoError(t, uc.Publish(context.Background(), event))
+
+	require.NotNil(t, queued)
+	assert.Equal(t, endpoint.ID(), queued.EndpointID())
+	assert.Equal(t, payee, queued.AccountID())
+	assert.Equal(t, event.ID(), queued.EventID())
+	assert.Equal(t, entity.WebhookPending, queued.Status())
+
+	var body struct {
+		ID   string              `json:"id"`
+		Type string              `json:"type"`
+		Data entity.PaymentEvent `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(queued.Body(), &body))
+	assert.Equal(t, event.ID().String(), body.ID)
+	assert.Equal(t, string(entity.EventPaymentCompleted), body.Type)
+	assert.Equal(t, payee.String(), body.Data.ToAccountID)
+}
+
+func TestUseCase_Publish_SkipsFailedPaymentsAndAccountsWithoutEndpoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	webhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	uc := webhook.NewUseCase(uow)
+
+	failed, _ := paymentEvent(t, entity.StatusFailed)
+	require.NoError(t, uc.Publish(context.Background(), failed))
+
+	completed, payee := paymentEvent(t, entity.StatusSuccess)
+	uow.EXPECT().Webhooks().Return(webhookRepo)
+	webhookRepo.EXPECT().FindEndpointByAccount(gomock.Any(), payee).Return(nil, repository.ErrWebhookNotFound)
+
+	require.NoError(t, uc.Publish(context.Background(), completed))
+}
+
+func TestUseCase_Resend_RequeuesDeadDelivery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uow := mocks.NewMockUnitOfWork(ctrl)
+	txUow := mocks.NewMockUnitOfWork(ctrl)
+	webhookRepo := mocks.NewMockWebhookRepository(ctrl)
+	uc := webhook.NewUseCase(uow)
+
+	_, dead := newDelivery(t, "https://merchant.example.com/hook", 7)
+	dead.Fail(http.StatusInternalServerError, "500 Internal Server Error", time.Now(), 8)
+	require.Equal(t, entity.WebhookDead, dead.Status())
+
+	uow.EXPECT().Begin(gomock.Any()).Return(txUow, nil)
+	txUow.EXPECT().Rollback(gomock.Any()).Return(nil)
+	txUow.EXPECT().Webhooks().Return(webhookRepo).Times(2)
+	webhookRepo.EXPECT().FindDeliveryForUpdate(gomock.Any(), dead.ID()).Return(dead, nil)
+	webhookRepo.EXPECT().UpdateDelivery(gomock.Any(), dead).Return(nil)
+	txUow.EXPECT().Commit(gomock.Any()).Return(nil)
+
+	resent, err := uc.Resend(context.Background(), dead.ID())
+
+	require.NoError(t, err)
+	assert.Equal(t, entity.WebhookPending, resent.Status())
+	assert.Zero(t, resent.Attempts())
+	assert.WithinDuration(t, time.Now(), resent.NextAttemptAt(), time.Second)
+}
+
+// paymentEvent returns the event of a payment in the given status, and its
+// payee.
+func paymentEvent(t *testing.T, status entity.TransactionStatus) (*entity.Event, uuid.UUID) {
+	t.Helper()
+	payee := uuid.New()
+	txn := entity.NewTransaction(uuid.New(), payee, entity.ReconstructMoney(1000, "RUB"), status)
+	e, err := entity.NewPaymentEvent(txn)
+	require.NoError(t, err)
+	return e, payee
+}
+
+// newDelivery returns an endpoint at url and a pending delivery to it that
+// has already failed attempts times.
+func newDelivery(t *testing.T, url string, attempts int) (*entity.WebhookEndpoint, *entity.WebhookDelivery) {
+	t.Helper()
+	event, payee := paymentEvent(t, entity.StatusSuccess)
+	// Test servers listen on loopback, which registration would reject.
+	endpoint := entity.ReconstructWebhookEndpoint(uuid.New(), payee, url, "whsec_test", time.Now())
+	d, err := entity.NewWebhookDelivery(endpoint, event)
+	require.NoError(t, err)
+	return endpoint, entity.ReconstructWebhookDelivery(
+		d.ID(), d.EndpointID(), d.AccountID(), d.EventID(), d.EventType(), d.Body(),
+		entity.WebhookPending, attempts, d.NextAttemptAt(), 0, "", d.CreatedAt(), time.Time{},
+	)
+}
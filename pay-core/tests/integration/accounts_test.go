@@ -0,0 +1,65 @@
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Xausdorf/qr-pay-hub/gen/pb"
+)
+
+func TestAccountManagement(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pool, err := pgxpool.New(ctx, dbURL)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	conn, connErr := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, connErr)
+	defer conn.Close()
+
+	client := pb.NewPaymentProcessorClient(conn)
+
+	created, err := client.CreateAccount(ctx, &pb.CreateAccountRequest{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		pool.Exec(context.Background(), `DELETE FROM accounts WHERE id = $1`, created.GetId())
+	})
+
+	require.Equal(t, int64(0), created.GetBalance())
+
+	fetched, err := client.GetAccount(ctx, &pb.GetAccountRequest{AccountId: created.GetId()})
+	require.NoError(t, err)
+	require.Equal(t, created.GetId(), fetched.GetId())
+	require.Equal(t, int64(0), fetched.GetBalance())
+
+	_, err = client.GetAccount(ctx, &pb.GetAccountRequest{AccountId: uuid.NewString()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	found := false
+	pageToken := ""
+	for !found {
+		page, listErr := client.ListAccounts(ctx, &pb.ListAccountsRequest{PageSize: 100, PageToken: pageToken})
+		require.NoError(t, listErr)
+		for _, acc := range page.GetAccounts() {
+			if acc.GetId() == created.GetId() {
+				found = true
+			}
+		}
+		if page.GetNextPageToken() == "" {
+			break
+		}
+		pageToken = page.GetNextPageToken()
+	}
+	require.True(t, found, "created account must be listed")
+}
@@ -0,0 +1,109 @@
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Xausdorf/qr-pay-hub/gen/pb"
+)
+
+func TestAuthorizeCaptureVoid(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pool, err := pgxpool.New(ctx, dbURL)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	conn, connErr := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, connErr)
+	defer conn.Close()
+
+	client := pb.NewPaymentProcessorClient(conn)
+
+	payer := uuid.New()
+	payee := uuid.New()
+
+	_, err = pool.Exec(ctx, `INSERT INTO accounts (id, balance) VALUES ($1, 1000), ($2, 0)`, payer, payee)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		pool.Exec(context.Background(), `DELETE FROM authorizations WHERE from_account = $1`, payer)
+		pool.Exec(context.Background(), `DELETE FROM ledger_entries WHERE account_id IN ($1, $2)`, payer, payee)
+		pool.Exec(context.Background(), `DELETE FROM transactions WHERE from_account = $1`, payer)
+		pool.Exec(
+			context.Background(),
+			`DELETE FROM idempotency_keys WHERE key LIKE $1`,
+			fmt.Sprintf("hold-%s-%%", payer),
+		)
+		pool.Exec(context.Background(), `DELETE FROM accounts WHERE id IN ($1, $2)`, payer, payee)
+	})
+
+	auth, err := client.AuthorizePayment(ctx, &pb.AuthorizeRequest{
+		IdempotencyKey: fmt.Sprintf("hold-%s-auth", payer),
+		FromAccountId:  payer.String(),
+		ToAccountId:    payee.String(),
+		Amount:         700,
+	})
+	require.NoError(t, err)
+	require.Equal(t, pb.AuthorizationStatus_AUTHORIZATION_STATUS_ACTIVE, auth.GetStatus())
+
+	acc, err := client.GetAccount(ctx, &pb.GetAccountRequest{AccountId: payer.String()})
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), acc.GetBalance(), "a hold must not move money")
+	require.Equal(t, int64(700), acc.GetHeld())
+	require.Equal(t, int64(300), acc.GetAvailable())
+
+	// Only the available balance can be spent while the hold is active.
+	payment, err := client.ProcessPayment(ctx, &pb.PaymentRequest{
+		IdempotencyKey: fmt.Sprintf("hold-%s-pay", payer),
+		FromAccountId:  payer.String(),
+		ToAccountId:    payee.String(),
+		Amount:         400,
+	})
+	require.NoError(t, err)
+	require.Equal(t, pb.TransactionStatus_TRANSACTION_STATUS_FAILED, payment.GetStatus())
+
+	captured, err := client.CapturePayment(ctx, &pb.CaptureRequest{
+		IdempotencyKey:  fmt.Sprintf("hold-%s-capture", payer),
+		AuthorizationId: auth.GetId(),
+		Amount:          500,
+	})
+	require.NoError(t, err)
+	require.Equal(t, pb.TransactionStatus_TRANSACTION_STATUS_SUCCESS, captured.GetStatus())
+
+	acc, err = client.GetAccount(ctx, &pb.GetAccountRequest{AccountId: payer.String()})
+	require.NoError(t, err)
+	require.Equal(t, int64(500), acc.GetBalance())
+	require.Equal(t, int64(0), acc.GetHeld(), "the uncaptured remainder must be released")
+
+	_, err = client.VoidAuthorization(ctx, &pb.VoidAuthorizationRequest{AuthorizationId: auth.GetId()})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	second, err := client.AuthorizePayment(ctx, &pb.AuthorizeRequest{
+		IdempotencyKey: fmt.Sprintf("hold-%s-auth-2", payer),
+		FromAccountId:  payer.String(),
+		ToAccountId:    payee.String(),
+		Amount:         200,
+	})
+	require.NoError(t, err)
+
+	voided, err := client.VoidAuthorization(ctx, &pb.VoidAuthorizationRequest{AuthorizationId: second.GetId()})
+	require.NoError(t, err)
+	require.Equal(t, pb.AuthorizationStatus_AUTHORIZATION_STATUS_VOIDED, voided.GetStatus())
+
+	acc, err = client.GetAccount(ctx, &pb.GetAccountRequest{AccountId: payer.String()})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), acc.GetHeld())
+	require.Equal(t, int64(500), acc.GetAvailable())
+}
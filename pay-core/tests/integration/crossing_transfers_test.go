@@ -0,0 +1,104 @@
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pb "github.com/Xausdorf/qr-pay-hub/gen/pb"
+)
+
+func TestCrossingTransfersDoNotDeadlock(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	pool, err := pgxpool.New(ctx, dbURL)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	conn, connErr := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, connErr)
+	defer conn.Close()
+
+	client := pb.NewPaymentProcessorClient(conn)
+
+	accountA := uuid.New()
+	accountB := uuid.New()
+
+	_, err = pool.Exec(ctx, `INSERT INTO accounts (id, balance) VALUES ($1, 10000), ($2, 10000)`, accountA, accountB)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		pool.Exec(context.Background(), `DELETE FROM ledger_entries WHERE account_id IN ($1, $2)`, accountA, accountB)
+		pool.Exec(
+			context.Background(),
+			`DELETE FROM transactions WHERE from_account IN ($1, $2)`,
+			accountA, accountB,
+		)
+		pool.Exec(
+			context.Background(),
+			`DELETE FROM idempotency_keys WHERE key LIKE $1`,
+			fmt.Sprintf("crossing-%s-%%", accountA),
+		)
+		pool.Exec(context.Background(), `DELETE FROM accounts WHERE id IN ($1, $2)`, accountA, accountB)
+	})
+
+	const (
+		workers   = 20
+		perWorker = 25
+		amount    = 10
+	)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := range workers {
+		go func(worker int) {
+			defer wg.Done()
+
+			from, to := accountA, accountB
+			if worker%2 == 1 {
+				from, to = accountB, accountA
+			}
+
+			for i := range perWorker {
+				resp, rpcErr := client.ProcessPayment(ctx, &pb.PaymentRequest{
+					IdempotencyKey: fmt.Sprintf("crossing-%s-%d-%d", accountA, worker, i),
+					FromAccountId:  from.String(),
+					ToAccountId:    to.String(),
+					Amount:         amount,
+				})
+				if !assert.NoError(t, rpcErr, "worker %d, transfer %d", worker, i) {
+					return
+				}
+				assert.Equal(t, pb.TransactionStatus_TRANSACTION_STATUS_SUCCESS, resp.GetStatus())
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	var balanceA, balanceB int64
+	err = pool.QueryRow(ctx, `SELECT balance FROM accounts WHERE id = $1`, accountA).Scan(&balanceA)
+	require.NoError(t, err)
+	err = pool.QueryRow(ctx, `SELECT balance FROM accounts WHERE id = $1`, accountB).Scan(&balanceB)
+	require.NoError(t, err)
+
+	require.Equal(t, int64(10000), balanceA, "equal number of transfers in both directions must cancel out")
+	require.Equal(t, int64(10000), balanceB, "equal number of transfers in both directions must cancel out")
+
+	var txCount int
+	err = pool.QueryRow(ctx,
+		`SELECT COUNT(*) FROM transactions WHERE from_account IN ($1, $2) AND status = 'success'`,
+		accountA, accountB,
+	).Scan(&txCount)
+	require.NoError(t, err)
+	require.Equal(t, workers*perWorker, txCount)
+}
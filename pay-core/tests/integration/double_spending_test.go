@@ -43,6 +43,7 @@ func TestDoubleSpendingAttack(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Cleanup(func() {
+		pool.Exec(context.Background(), `DELETE FROM ledger_entries WHERE account_id IN ($1, $2)`, senderID, receiverID)
 		pool.Exec(context.Background(), `DELETE FROM transactions WHERE from_account = $1`, senderID)
 		pool.Exec(
 			context.Background(),
@@ -98,5 +99,18 @@ func TestDoubleSpendingAttack(t *testing.T) {
 	require.Equal(t, int64(0), senderBalance, "sender balance must be 0")
 	require.Equal(t, int64(1000), receiverBalance, "receiver balance must be 1000")
 
+	rows, err := pool.Query(ctx,
+		`SELECT balance, ledger_balance FROM ledger_reconciliation WHERE account_id IN ($1, $2)`,
+		senderID, receiverID,
+	)
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var balance, ledgerBalance int64
+		require.NoError(t, rows.Scan(&balance, &ledgerBalance))
+		require.Equal(t, balance, ledgerBalance, "balance must match the sum of ledger postings")
+	}
+	require.NoError(t, rows.Err())
+
 	t.Logf("Final balances: sender=%d, receiver=%d", senderBalance, receiverBalance)
 }
@@ -11,7 +11,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	pb "github.com/Xausdorf/qr-pay-hub/gen/pb"
 )
@@ -38,6 +40,7 @@ func TestIdempotencyNetworkRetryStorm(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Cleanup(func() {
+		pool.Exec(context.Background(), `DELETE FROM ledger_entries WHERE account_id IN ($1, $2)`, senderID, receiverID)
 		pool.Exec(context.Background(), `DELETE FROM transactions WHERE from_account = $1`, senderID)
 		pool.Exec(context.Background(), `DELETE FROM idempotency_keys WHERE key = $1`, idempotencyKey)
 		pool.Exec(context.Background(), `DELETE FROM accounts WHERE id IN ($1, $2)`, senderID, receiverID)
@@ -150,6 +153,7 @@ func TestIdempotencyPreservesFailure(t *testing.T) {
 	require.NoError(t, err)
 
 	t.Cleanup(func() {
+		pool.Exec(context.Background(), `DELETE FROM ledger_entries WHERE account_id IN ($1, $2)`, senderID, receiverID)
 		pool.Exec(context.Background(), `DELETE FROM transactions WHERE from_account = $1`, senderID)
 		pool.Exec(context.Background(), `DELETE FROM idempotency_keys WHERE key = $1`, idempotencyKey)
 		pool.Exec(context.Background(), `DELETE FROM accounts WHERE id IN ($1, $2)`, senderID, receiverID)
@@ -177,8 +181,19 @@ func TestIdempotencyPreservesFailure(t *testing.T) {
 			i,
 		)
 		require.Contains(t, resp.GetErrorMessage(), "insufficient funds")
+		require.Equal(t, "insufficient_funds", resp.GetFailureReason())
+		require.Equal(t, responses[0].GetTransactionId(), resp.GetTransactionId(), "retry %d has different tx_id", i)
 	}
 
+	var txStatus, failureReason string
+	err = pool.QueryRow(ctx,
+		`SELECT status, failure_reason FROM transactions WHERE id = $1`,
+		responses[0].GetTransactionId(),
+	).Scan(&txStatus, &failureReason)
+	require.NoError(t, err, "declined payment must be recorded")
+	require.Equal(t, "failed", txStatus)
+	require.Equal(t, "insufficient_funds", failureReason)
+
 	var balance int64
 	err = pool.QueryRow(ctx, `SELECT balance FROM accounts WHERE id = $1`, senderID).Scan(&balance)
 	require.NoError(t, err)
@@ -186,3 +201,54 @@ func TestIdempotencyPreservesFailure(t *testing.T) {
 
 	t.Logf("Idempotency correctly preserves FAILED status across retries")
 }
+
+func TestIdempotencyKeyReusedWithDifferentRequest(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pool, err := pgxpool.New(ctx, dbURL)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	conn, connErr := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, connErr)
+	defer conn.Close()
+
+	client := pb.NewPaymentProcessorClient(conn)
+
+	senderID := uuid.New()
+	receiverID := uuid.New()
+	idempotencyKey := uuid.New().String()
+
+	_, err = pool.Exec(ctx, `INSERT INTO accounts (id, balance) VALUES ($1, 5000), ($2, 0)`, senderID, receiverID)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		pool.Exec(context.Background(), `DELETE FROM ledger_entries WHERE account_id IN ($1, $2)`, senderID, receiverID)
+		pool.Exec(context.Background(), `DELETE FROM transactions WHERE from_account = $1`, senderID)
+		pool.Exec(context.Background(), `DELETE FROM idempotency_keys WHERE key = $1`, idempotencyKey)
+		pool.Exec(context.Background(), `DELETE FROM accounts WHERE id IN ($1, $2)`, senderID, receiverID)
+	})
+
+	first, err := client.ProcessPayment(ctx, &pb.PaymentRequest{
+		IdempotencyKey: idempotencyKey,
+		FromAccountId:  senderID.String(),
+		ToAccountId:    receiverID.String(),
+		Amount:         1000,
+	})
+	require.NoError(t, err)
+	require.Equal(t, pb.TransactionStatus_TRANSACTION_STATUS_SUCCESS, first.GetStatus())
+
+	_, err = client.ProcessPayment(ctx, &pb.PaymentRequest{
+		IdempotencyKey: idempotencyKey,
+		FromAccountId:  senderID.String(),
+		ToAccountId:    receiverID.String(),
+		Amount:         2000,
+	})
+	require.Equal(t, codes.AlreadyExists, status.Code(err), "reused key with a different amount must be rejected")
+
+	var senderBalance int64
+	err = pool.QueryRow(ctx, `SELECT balance FROM accounts WHERE id = $1`, senderID).Scan(&senderBalance)
+	require.NoError(t, err)
+	require.Equal(t, int64(4000), senderBalance, "only the first payment may be applied")
+}
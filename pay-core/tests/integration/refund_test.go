@@ -0,0 +1,129 @@
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Xausdorf/qr-pay-hub/gen/pb"
+)
+
+func TestConcurrentRefundsNeverExceedOriginal(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pool, err := pgxpool.New(ctx, dbURL)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	conn, connErr := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, connErr)
+	defer conn.Close()
+
+	client := pb.NewPaymentProcessorClient(conn)
+
+	customer := uuid.New()
+	merchant := uuid.New()
+
+	_, err = pool.Exec(ctx, `INSERT INTO accounts (id, balance) VALUES ($1, 1000), ($2, 0)`, customer, merchant)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		pool.Exec(context.Background(), `DELETE FROM ledger_entries WHERE account_id IN ($1, $2)`, customer, merchant)
+		pool.Exec(context.Background(), `DELETE FROM transactions WHERE from_account = $1`, merchant)
+		pool.Exec(context.Background(), `DELETE FROM transactions WHERE from_account = $1`, customer)
+		pool.Exec(
+			context.Background(),
+			`DELETE FROM idempotency_keys WHERE key LIKE $1`,
+			fmt.Sprintf("refund-%s-%%", customer),
+		)
+		pool.Exec(context.Background(), `DELETE FROM accounts WHERE id IN ($1, $2)`, customer, merchant)
+	})
+
+	payment, err := client.ProcessPayment(ctx, &pb.PaymentRequest{
+		IdempotencyKey: fmt.Sprintf("refund-%s-payment", customer),
+		FromAccountId:  customer.String(),
+		ToAccountId:    merchant.String(),
+		Amount:         1000,
+	})
+	require.NoError(t, err)
+	require.Equal(t, pb.TransactionStatus_TRANSACTION_STATUS_SUCCESS, payment.GetStatus())
+
+	// Ten concurrent refunds of 300 against a payment of 1000: exactly three fit.
+	const attempts = 10
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+		rejected  int
+	)
+	wg.Add(attempts)
+	for i := range attempts {
+		go func(i int) {
+			defer wg.Done()
+			resp, rpcErr := client.RefundPayment(ctx, &pb.RefundRequest{
+				IdempotencyKey: fmt.Sprintf("refund-%s-%d", customer, i),
+				TransactionId:  payment.GetTransactionId(),
+				Amount:         300,
+			})
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case rpcErr == nil && resp.GetStatus() == pb.TransactionStatus_TRANSACTION_STATUS_SUCCESS:
+				succeeded++
+			case status.Code(rpcErr) == codes.FailedPrecondition:
+				rejected++
+			default:
+				t.Errorf("unexpected refund outcome: %v, %v", resp, rpcErr)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	require.Equal(t, 3, succeeded)
+	require.Equal(t, attempts-3, rejected)
+
+	var customerBalance, merchantBalance int64
+	err = pool.QueryRow(ctx, `SELECT balance FROM accounts WHERE id = $1`, customer).Scan(&customerBalance)
+	require.NoError(t, err)
+	err = pool.QueryRow(ctx, `SELECT balance FROM accounts WHERE id = $1`, merchant).Scan(&merchantBalance)
+	require.NoError(t, err)
+	require.Equal(t, int64(900), customerBalance)
+	require.Equal(t, int64(100), merchantBalance)
+
+	refunds, err := client.ListTransactions(ctx, &pb.ListTransactionsRequest{
+		AccountId: merchant.String(),
+		Direction: pb.TransactionDirection_TRANSACTION_DIRECTION_OUTGOING,
+	})
+	require.NoError(t, err)
+	require.Len(t, refunds.GetTransactions(), 3)
+	for _, r := range refunds.GetTransactions() {
+		require.Equal(t, payment.GetTransactionId(), r.GetOriginalTransactionId())
+	}
+
+	// The remaining 100 can still be refunded, nothing beyond that.
+	_, err = client.RefundPayment(ctx, &pb.RefundRequest{
+		IdempotencyKey: fmt.Sprintf("refund-%s-rest", customer),
+		TransactionId:  payment.GetTransactionId(),
+		Amount:         100,
+	})
+	require.NoError(t, err)
+
+	_, err = client.RefundPayment(ctx, &pb.RefundRequest{
+		IdempotencyKey: fmt.Sprintf("refund-%s-over", customer),
+		TransactionId:  payment.GetTransactionId(),
+		Amount:         1,
+	})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
@@ -0,0 +1,112 @@
+package integration_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/Xausdorf/qr-pay-hub/gen/pb"
+)
+
+func TestTransactionHistory(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pool, err := pgxpool.New(ctx, dbURL)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	conn, connErr := grpc.NewClient(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, connErr)
+	defer conn.Close()
+
+	client := pb.NewPaymentProcessorClient(conn)
+
+	sender := uuid.New()
+	receiver := uuid.New()
+
+	_, err = pool.Exec(ctx, `INSERT INTO accounts (id, balance) VALUES ($1, 250), ($2, 0)`, sender, receiver)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		pool.Exec(context.Background(), `DELETE FROM ledger_entries WHERE account_id IN ($1, $2)`, sender, receiver)
+		pool.Exec(context.Background(), `DELETE FROM transactions WHERE from_account IN ($1, $2)`, sender, receiver)
+		pool.Exec(
+			context.Background(),
+			`DELETE FROM idempotency_keys WHERE key LIKE $1`,
+			fmt.Sprintf("history-%s-%%", sender),
+		)
+		pool.Exec(context.Background(), `DELETE FROM accounts WHERE id IN ($1, $2)`, sender, receiver)
+	})
+
+	// Three transfers of 100 from a balance of 250: two succeed, one is declined.
+	var lastID string
+	for i := range 3 {
+		resp, payErr := client.ProcessPayment(ctx, &pb.PaymentRequest{
+			IdempotencyKey: fmt.Sprintf("history-%s-%d", sender, i),
+			FromAccountId:  sender.String(),
+			ToAccountId:    receiver.String(),
+			Amount:         100,
+		})
+		require.NoError(t, payErr)
+		lastID = resp.GetTransactionId()
+	}
+
+	txn, err := client.GetTransaction(ctx, &pb.GetTransactionRequest{TransactionId: lastID})
+	require.NoError(t, err)
+	require.Equal(t, pb.TransactionStatus_TRANSACTION_STATUS_FAILED, txn.GetStatus())
+	require.Equal(t, "insufficient_funds", txn.GetFailureReason())
+
+	_, err = client.GetTransaction(ctx, &pb.GetTransactionRequest{TransactionId: uuid.NewString()})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	var seen []string
+	pageToken := ""
+	for {
+		page, listErr := client.ListTransactions(ctx, &pb.ListTransactionsRequest{
+			AccountId: receiver.String(),
+			Direction: pb.TransactionDirection_TRANSACTION_DIRECTION_INCOMING,
+			PageSize:  1,
+			PageToken: pageToken,
+		})
+		require.NoError(t, listErr)
+		for _, tx := range page.GetTransactions() {
+			seen = append(seen, tx.GetId())
+		}
+		if page.GetNextPageToken() == "" {
+			break
+		}
+		pageToken = page.GetNextPageToken()
+	}
+	require.Len(t, seen, 3)
+	require.Equal(t, lastID, seen[0], "history must be ordered newest first")
+
+	succeeded, err := client.ListTransactions(ctx, &pb.ListTransactionsRequest{
+		AccountId: sender.String(),
+		Direction: pb.TransactionDirection_TRANSACTION_DIRECTION_OUTGOING,
+		Status:    pb.TransactionStatus_TRANSACTION_STATUS_SUCCESS,
+	})
+	require.NoError(t, err)
+	require.Len(t, succeeded.GetTransactions(), 2)
+
+	outgoing, err := client.ListTransactions(ctx, &pb.ListTransactionsRequest{
+		AccountId: receiver.String(),
+		Direction: pb.TransactionDirection_TRANSACTION_DIRECTION_OUTGOING,
+	})
+	require.NoError(t, err)
+	require.Empty(t, outgoing.GetTransactions())
+
+	_, err = client.ListTransactions(ctx, &pb.ListTransactionsRequest{
+		Direction: pb.TransactionDirection_TRANSACTION_DIRECTION_INCOMING,
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
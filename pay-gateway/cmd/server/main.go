@@ -11,11 +11,17 @@ import (
 	"time"
 
 	httpdelivery "github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/delivery/http"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/qrcode"
 	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/infrastructure/config"
 	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/infrastructure/grpcclient"
 	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/infrastructure/qrgenerator"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/infrastructure/qrscanner"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/infrastructure/qrsign"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/usecase/account"
 	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/usecase/generateqr"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/usecase/history"
 	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/usecase/pay"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/usecase/scanqr"
 )
 
 const (
@@ -40,12 +46,34 @@ func main() {
 	}
 	defer paymentClient.Close()
 
-	qrGen := qrgenerator.NewGenerator(qrCodeSize)
+	var signer qrcode.Signer
+	if cfg.QRKeystoreFile != "" {
+		keystore, ksErr := qrsign.NewKeystore(cfg.QRKeystoreFile)
+		if ksErr != nil {
+			logger.Error("qr keystore load failed", "error", ksErr, "path", cfg.QRKeystoreFile)
+			return
+		}
+		logger.Info("qr keystore loaded", "active_key_id", keystore.ActiveKeyID(), "path", cfg.QRKeystoreFile)
+		if cfg.QRKeystoreRefreshInterval > 0 {
+			go keystore.Run(ctx, cfg.QRKeystoreRefreshInterval, logger)
+		}
+		signer = keystore
+	}
+	qrGen := qrgenerator.NewGenerator(qrCodeSize, signer, cfg.QRSignatureTTL)
+
+	payUC := pay.NewUseCase(paymentClient, qrGen)
+	generateQRUC := generateqr.NewUseCase(qrGen, paymentClient, qrcode.Merchant{
+		Name:         cfg.QRMerchantName,
+		City:         cfg.QRMerchantCity,
+		CountryCode:  cfg.QRMerchantCountry,
+		CategoryCode: cfg.QRMerchantCategory,
+	})
+	scanQRUC := scanqr.NewUseCase(qrscanner.NewScanner(), qrGen, qrGen, paymentClient)
 
-	payUC := pay.NewUseCase(paymentClient)
-	generateQRUC := generateqr.NewUseCase(qrGen)
+	accountUC := account.NewUseCase(paymentClient)
 
-	handler := httpdelivery.NewHandler(payUC, generateQRUC)
+	historyUC := history.NewUseCase(paymentClient)
+	handler := httpdelivery.NewHandler(payUC, generateQRUC, scanQRUC, accountUC, historyUC)
 	router := httpdelivery.NewRouter(handler)
 
 	srv := &http.Server{
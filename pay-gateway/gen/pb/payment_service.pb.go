@@ -9,6 +9,7 @@ package pb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -21,6 +22,57 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type TransferType int32
+
+const (
+	// Treated as P2P.
+	TransferType_TRANSFER_TYPE_UNSPECIFIED TransferType = 0
+	TransferType_TRANSFER_TYPE_P2P         TransferType = 1
+	// Payment to a merchant by scanning its QR code.
+	TransferType_TRANSFER_TYPE_QR_MERCHANT TransferType = 2
+)
+
+// Enum value maps for TransferType.
+var (
+	TransferType_name = map[int32]string{
+		0: "TRANSFER_TYPE_UNSPECIFIED",
+		1: "TRANSFER_TYPE_P2P",
+		2: "TRANSFER_TYPE_QR_MERCHANT",
+	}
+	TransferType_value = map[string]int32{
+		"TRANSFER_TYPE_UNSPECIFIED": 0,
+		"TRANSFER_TYPE_P2P":         1,
+		"TRANSFER_TYPE_QR_MERCHANT": 2,
+	}
+)
+
+func (x TransferType) Enum() *TransferType {
+	p := new(TransferType)
+	*p = x
+	return p
+}
+
+func (x TransferType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransferType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_payment_service_proto_enumTypes[0].Descriptor()
+}
+
+func (TransferType) Type() protoreflect.EnumType {
+	return &file_proto_payment_service_proto_enumTypes[0]
+}
+
+func (x TransferType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransferType.Descriptor instead.
+func (TransferType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{0}
+}
+
 type TransactionStatus int32
 
 const (
@@ -38,66 +90,4197 @@ var (
 		2: "TRANSACTION_STATUS_SUCCESS",
 		3: "TRANSACTION_STATUS_FAILED",
 	}
-	TransactionStatus_value = map[string]int32{
-		"TRANSACTION_STATUS_UNSPECIFIED": 0,
-		"TRANSACTION_STATUS_PENDING":     1,
-		"TRANSACTION_STATUS_SUCCESS":     2,
-		"TRANSACTION_STATUS_FAILED":      3,
+	TransactionStatus_value = map[string]int32{
+		"TRANSACTION_STATUS_UNSPECIFIED": 0,
+		"TRANSACTION_STATUS_PENDING":     1,
+		"TRANSACTION_STATUS_SUCCESS":     2,
+		"TRANSACTION_STATUS_FAILED":      3,
+	}
+)
+
+func (x TransactionStatus) Enum() *TransactionStatus {
+	p := new(TransactionStatus)
+	*p = x
+	return p
+}
+
+func (x TransactionStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransactionStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_payment_service_proto_enumTypes[1].Descriptor()
+}
+
+func (TransactionStatus) Type() protoreflect.EnumType {
+	return &file_proto_payment_service_proto_enumTypes[1]
+}
+
+func (x TransactionStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransactionStatus.Descriptor instead.
+func (TransactionStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{1}
+}
+
+type AccountStatus int32
+
+const (
+	AccountStatus_ACCOUNT_STATUS_UNSPECIFIED AccountStatus = 0
+	AccountStatus_ACCOUNT_STATUS_ACTIVE      AccountStatus = 1
+	// Can receive money but not send it.
+	AccountStatus_ACCOUNT_STATUS_FROZEN AccountStatus = 2
+	// Can neither send nor receive; its balance is zero.
+	AccountStatus_ACCOUNT_STATUS_CLOSED AccountStatus = 3
+)
+
+// Enum value maps for AccountStatus.
+var (
+	AccountStatus_name = map[int32]string{
+		0: "ACCOUNT_STATUS_UNSPECIFIED",
+		1: "ACCOUNT_STATUS_ACTIVE",
+		2: "ACCOUNT_STATUS_FROZEN",
+		3: "ACCOUNT_STATUS_CLOSED",
+	}
+	AccountStatus_value = map[string]int32{
+		"ACCOUNT_STATUS_UNSPECIFIED": 0,
+		"ACCOUNT_STATUS_ACTIVE":      1,
+		"ACCOUNT_STATUS_FROZEN":      2,
+		"ACCOUNT_STATUS_CLOSED":      3,
+	}
+)
+
+func (x AccountStatus) Enum() *AccountStatus {
+	p := new(AccountStatus)
+	*p = x
+	return p
+}
+
+func (x AccountStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AccountStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_payment_service_proto_enumTypes[2].Descriptor()
+}
+
+func (AccountStatus) Type() protoreflect.EnumType {
+	return &file_proto_payment_service_proto_enumTypes[2]
+}
+
+func (x AccountStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AccountStatus.Descriptor instead.
+func (AccountStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{2}
+}
+
+type AccountKind int32
+
+const (
+	AccountKind_ACCOUNT_KIND_UNSPECIFIED AccountKind = 0
+	AccountKind_ACCOUNT_KIND_CUSTOMER    AccountKind = 1
+	// House account holding its FX position in the currency; may be negative.
+	AccountKind_ACCOUNT_KIND_FX_LIQUIDITY AccountKind = 2
+	// House account collecting the FX spread earned in the currency.
+	AccountKind_ACCOUNT_KIND_FX_REVENUE AccountKind = 3
+	// House account collecting transfer fees in the currency.
+	AccountKind_ACCOUNT_KIND_FEE_REVENUE AccountKind = 4
+)
+
+// Enum value maps for AccountKind.
+var (
+	AccountKind_name = map[int32]string{
+		0: "ACCOUNT_KIND_UNSPECIFIED",
+		1: "ACCOUNT_KIND_CUSTOMER",
+		2: "ACCOUNT_KIND_FX_LIQUIDITY",
+		3: "ACCOUNT_KIND_FX_REVENUE",
+		4: "ACCOUNT_KIND_FEE_REVENUE",
+	}
+	AccountKind_value = map[string]int32{
+		"ACCOUNT_KIND_UNSPECIFIED":  0,
+		"ACCOUNT_KIND_CUSTOMER":     1,
+		"ACCOUNT_KIND_FX_LIQUIDITY": 2,
+		"ACCOUNT_KIND_FX_REVENUE":   3,
+		"ACCOUNT_KIND_FEE_REVENUE":  4,
+	}
+)
+
+func (x AccountKind) Enum() *AccountKind {
+	p := new(AccountKind)
+	*p = x
+	return p
+}
+
+func (x AccountKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AccountKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_payment_service_proto_enumTypes[3].Descriptor()
+}
+
+func (AccountKind) Type() protoreflect.EnumType {
+	return &file_proto_payment_service_proto_enumTypes[3]
+}
+
+func (x AccountKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AccountKind.Descriptor instead.
+func (AccountKind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{3}
+}
+
+type AuthorizationStatus int32
+
+const (
+	AuthorizationStatus_AUTHORIZATION_STATUS_UNSPECIFIED AuthorizationStatus = 0
+	AuthorizationStatus_AUTHORIZATION_STATUS_ACTIVE      AuthorizationStatus = 1
+	AuthorizationStatus_AUTHORIZATION_STATUS_CAPTURED    AuthorizationStatus = 2
+	AuthorizationStatus_AUTHORIZATION_STATUS_VOIDED      AuthorizationStatus = 3
+	AuthorizationStatus_AUTHORIZATION_STATUS_EXPIRED     AuthorizationStatus = 4
+)
+
+// Enum value maps for AuthorizationStatus.
+var (
+	AuthorizationStatus_name = map[int32]string{
+		0: "AUTHORIZATION_STATUS_UNSPECIFIED",
+		1: "AUTHORIZATION_STATUS_ACTIVE",
+		2: "AUTHORIZATION_STATUS_CAPTURED",
+		3: "AUTHORIZATION_STATUS_VOIDED",
+		4: "AUTHORIZATION_STATUS_EXPIRED",
+	}
+	AuthorizationStatus_value = map[string]int32{
+		"AUTHORIZATION_STATUS_UNSPECIFIED": 0,
+		"AUTHORIZATION_STATUS_ACTIVE":      1,
+		"AUTHORIZATION_STATUS_CAPTURED":    2,
+		"AUTHORIZATION_STATUS_VOIDED":      3,
+		"AUTHORIZATION_STATUS_EXPIRED":     4,
+	}
+)
+
+func (x AuthorizationStatus) Enum() *AuthorizationStatus {
+	p := new(AuthorizationStatus)
+	*p = x
+	return p
+}
+
+func (x AuthorizationStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AuthorizationStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_payment_service_proto_enumTypes[4].Descriptor()
+}
+
+func (AuthorizationStatus) Type() protoreflect.EnumType {
+	return &file_proto_payment_service_proto_enumTypes[4]
+}
+
+func (x AuthorizationStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AuthorizationStatus.Descriptor instead.
+func (AuthorizationStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{4}
+}
+
+type TransactionDirection int32
+
+const (
+	TransactionDirection_TRANSACTION_DIRECTION_UNSPECIFIED TransactionDirection = 0
+	TransactionDirection_TRANSACTION_DIRECTION_INCOMING    TransactionDirection = 1
+	TransactionDirection_TRANSACTION_DIRECTION_OUTGOING    TransactionDirection = 2
+)
+
+// Enum value maps for TransactionDirection.
+var (
+	TransactionDirection_name = map[int32]string{
+		0: "TRANSACTION_DIRECTION_UNSPECIFIED",
+		1: "TRANSACTION_DIRECTION_INCOMING",
+		2: "TRANSACTION_DIRECTION_OUTGOING",
+	}
+	TransactionDirection_value = map[string]int32{
+		"TRANSACTION_DIRECTION_UNSPECIFIED": 0,
+		"TRANSACTION_DIRECTION_INCOMING":    1,
+		"TRANSACTION_DIRECTION_OUTGOING":    2,
+	}
+)
+
+func (x TransactionDirection) Enum() *TransactionDirection {
+	p := new(TransactionDirection)
+	*p = x
+	return p
+}
+
+func (x TransactionDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TransactionDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_payment_service_proto_enumTypes[5].Descriptor()
+}
+
+func (TransactionDirection) Type() protoreflect.EnumType {
+	return &file_proto_payment_service_proto_enumTypes[5]
+}
+
+func (x TransactionDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TransactionDirection.Descriptor instead.
+func (TransactionDirection) EnumDescriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{5}
+}
+
+type WebhookDeliveryStatus int32
+
+const (
+	WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED WebhookDeliveryStatus = 0
+	WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_PENDING     WebhookDeliveryStatus = 1
+	WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DELIVERED   WebhookDeliveryStatus = 2
+	// Out of attempts; kept in the dead-letter queue until resent.
+	WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_DEAD WebhookDeliveryStatus = 3
+)
+
+// Enum value maps for WebhookDeliveryStatus.
+var (
+	WebhookDeliveryStatus_name = map[int32]string{
+		0: "WEBHOOK_DELIVERY_STATUS_UNSPECIFIED",
+		1: "WEBHOOK_DELIVERY_STATUS_PENDING",
+		2: "WEBHOOK_DELIVERY_STATUS_DELIVERED",
+		3: "WEBHOOK_DELIVERY_STATUS_DEAD",
+	}
+	WebhookDeliveryStatus_value = map[string]int32{
+		"WEBHOOK_DELIVERY_STATUS_UNSPECIFIED": 0,
+		"WEBHOOK_DELIVERY_STATUS_PENDING":     1,
+		"WEBHOOK_DELIVERY_STATUS_DELIVERED":   2,
+		"WEBHOOK_DELIVERY_STATUS_DEAD":        3,
+	}
+)
+
+func (x WebhookDeliveryStatus) Enum() *WebhookDeliveryStatus {
+	p := new(WebhookDeliveryStatus)
+	*p = x
+	return p
+}
+
+func (x WebhookDeliveryStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WebhookDeliveryStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_payment_service_proto_enumTypes[6].Descriptor()
+}
+
+func (WebhookDeliveryStatus) Type() protoreflect.EnumType {
+	return &file_proto_payment_service_proto_enumTypes[6]
+}
+
+func (x WebhookDeliveryStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WebhookDeliveryStatus.Descriptor instead.
+func (WebhookDeliveryStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{6}
+}
+
+type BatchMode int32
+
+const (
+	BatchMode_BATCH_MODE_UNSPECIFIED BatchMode = 0
+	// Every transfer is paid in one database transaction, or none is.
+	BatchMode_BATCH_MODE_ATOMIC BatchMode = 1
+	// Each transfer is paid on its own; declined ones do not stop the others.
+	BatchMode_BATCH_MODE_BEST_EFFORT BatchMode = 2
+)
+
+// Enum value maps for BatchMode.
+var (
+	BatchMode_name = map[int32]string{
+		0: "BATCH_MODE_UNSPECIFIED",
+		1: "BATCH_MODE_ATOMIC",
+		2: "BATCH_MODE_BEST_EFFORT",
+	}
+	BatchMode_value = map[string]int32{
+		"BATCH_MODE_UNSPECIFIED": 0,
+		"BATCH_MODE_ATOMIC":      1,
+		"BATCH_MODE_BEST_EFFORT": 2,
+	}
+)
+
+func (x BatchMode) Enum() *BatchMode {
+	p := new(BatchMode)
+	*p = x
+	return p
+}
+
+func (x BatchMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BatchMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_payment_service_proto_enumTypes[7].Descriptor()
+}
+
+func (BatchMode) Type() protoreflect.EnumType {
+	return &file_proto_payment_service_proto_enumTypes[7]
+}
+
+func (x BatchMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BatchMode.Descriptor instead.
+func (BatchMode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{7}
+}
+
+type BatchStatus int32
+
+const (
+	BatchStatus_BATCH_STATUS_UNSPECIFIED BatchStatus = 0
+	// A best-effort batch whose transfers are still being paid.
+	BatchStatus_BATCH_STATUS_PROCESSING          BatchStatus = 1
+	BatchStatus_BATCH_STATUS_COMPLETED           BatchStatus = 2
+	BatchStatus_BATCH_STATUS_PARTIALLY_COMPLETED BatchStatus = 3
+	BatchStatus_BATCH_STATUS_FAILED              BatchStatus = 4
+)
+
+// Enum value maps for BatchStatus.
+var (
+	BatchStatus_name = map[int32]string{
+		0: "BATCH_STATUS_UNSPECIFIED",
+		1: "BATCH_STATUS_PROCESSING",
+		2: "BATCH_STATUS_COMPLETED",
+		3: "BATCH_STATUS_PARTIALLY_COMPLETED",
+		4: "BATCH_STATUS_FAILED",
+	}
+	BatchStatus_value = map[string]int32{
+		"BATCH_STATUS_UNSPECIFIED":         0,
+		"BATCH_STATUS_PROCESSING":          1,
+		"BATCH_STATUS_COMPLETED":           2,
+		"BATCH_STATUS_PARTIALLY_COMPLETED": 3,
+		"BATCH_STATUS_FAILED":              4,
+	}
+)
+
+func (x BatchStatus) Enum() *BatchStatus {
+	p := new(BatchStatus)
+	*p = x
+	return p
+}
+
+func (x BatchStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BatchStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_payment_service_proto_enumTypes[8].Descriptor()
+}
+
+func (BatchStatus) Type() protoreflect.EnumType {
+	return &file_proto_payment_service_proto_enumTypes[8]
+}
+
+func (x BatchStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BatchStatus.Descriptor instead.
+func (BatchStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{8}
+}
+
+type BatchItemStatus int32
+
+const (
+	BatchItemStatus_BATCH_ITEM_STATUS_UNSPECIFIED BatchItemStatus = 0
+	BatchItemStatus_BATCH_ITEM_STATUS_PENDING     BatchItemStatus = 1
+	BatchItemStatus_BATCH_ITEM_STATUS_SUCCESS     BatchItemStatus = 2
+	BatchItemStatus_BATCH_ITEM_STATUS_FAILED      BatchItemStatus = 3
+	// Rolled back because another transfer of the atomic batch failed.
+	BatchItemStatus_BATCH_ITEM_STATUS_SKIPPED BatchItemStatus = 4
+)
+
+// Enum value maps for BatchItemStatus.
+var (
+	BatchItemStatus_name = map[int32]string{
+		0: "BATCH_ITEM_STATUS_UNSPECIFIED",
+		1: "BATCH_ITEM_STATUS_PENDING",
+		2: "BATCH_ITEM_STATUS_SUCCESS",
+		3: "BATCH_ITEM_STATUS_FAILED",
+		4: "BATCH_ITEM_STATUS_SKIPPED",
+	}
+	BatchItemStatus_value = map[string]int32{
+		"BATCH_ITEM_STATUS_UNSPECIFIED": 0,
+		"BATCH_ITEM_STATUS_PENDING":     1,
+		"BATCH_ITEM_STATUS_SUCCESS":     2,
+		"BATCH_ITEM_STATUS_FAILED":      3,
+		"BATCH_ITEM_STATUS_SKIPPED":     4,
+	}
+)
+
+func (x BatchItemStatus) Enum() *BatchItemStatus {
+	p := new(BatchItemStatus)
+	*p = x
+	return p
+}
+
+func (x BatchItemStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BatchItemStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_payment_service_proto_enumTypes[9].Descriptor()
+}
+
+func (BatchItemStatus) Type() protoreflect.EnumType {
+	return &file_proto_payment_service_proto_enumTypes[9]
+}
+
+func (x BatchItemStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BatchItemStatus.Descriptor instead.
+func (BatchItemStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{9}
+}
+
+type PaymentIntentStatus int32
+
+const (
+	PaymentIntentStatus_PAYMENT_INTENT_STATUS_UNSPECIFIED PaymentIntentStatus = 0
+	PaymentIntentStatus_PAYMENT_INTENT_STATUS_CREATED     PaymentIntentStatus = 1
+	PaymentIntentStatus_PAYMENT_INTENT_STATUS_PAID        PaymentIntentStatus = 2
+	PaymentIntentStatus_PAYMENT_INTENT_STATUS_EXPIRED     PaymentIntentStatus = 3
+	PaymentIntentStatus_PAYMENT_INTENT_STATUS_CANCELLED   PaymentIntentStatus = 4
+)
+
+// Enum value maps for PaymentIntentStatus.
+var (
+	PaymentIntentStatus_name = map[int32]string{
+		0: "PAYMENT_INTENT_STATUS_UNSPECIFIED",
+		1: "PAYMENT_INTENT_STATUS_CREATED",
+		2: "PAYMENT_INTENT_STATUS_PAID",
+		3: "PAYMENT_INTENT_STATUS_EXPIRED",
+		4: "PAYMENT_INTENT_STATUS_CANCELLED",
+	}
+	PaymentIntentStatus_value = map[string]int32{
+		"PAYMENT_INTENT_STATUS_UNSPECIFIED": 0,
+		"PAYMENT_INTENT_STATUS_CREATED":     1,
+		"PAYMENT_INTENT_STATUS_PAID":        2,
+		"PAYMENT_INTENT_STATUS_EXPIRED":     3,
+		"PAYMENT_INTENT_STATUS_CANCELLED":   4,
+	}
+)
+
+func (x PaymentIntentStatus) Enum() *PaymentIntentStatus {
+	p := new(PaymentIntentStatus)
+	*p = x
+	return p
+}
+
+func (x PaymentIntentStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PaymentIntentStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_payment_service_proto_enumTypes[10].Descriptor()
+}
+
+func (PaymentIntentStatus) Type() protoreflect.EnumType {
+	return &file_proto_payment_service_proto_enumTypes[10]
+}
+
+func (x PaymentIntentStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PaymentIntentStatus.Descriptor instead.
+func (PaymentIntentStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{10}
+}
+
+type PaymentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	IdempotencyKey string                 `protobuf:"bytes,1,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	FromAccountId  string                 `protobuf:"bytes,2,opt,name=from_account_id,json=fromAccountId,proto3" json:"from_account_id,omitempty"`
+	ToAccountId    string                 `protobuf:"bytes,3,opt,name=to_account_id,json=toAccountId,proto3" json:"to_account_id,omitempty"`
+	// In minor units of currency.
+	Amount int64 `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	// ISO 4217 code; both accounts must hold it. Defaults to RUB.
+	Currency string `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Settles the payment as a conversion at a quote from GetQuote. amount and
+	// currency must then equal the quote's source amount and currency, and the
+	// payee is credited its target amount in the target currency.
+	QuoteId string `protobuf:"bytes,6,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	// Selects the fee schedule. Conversions are not charged transfer fees.
+	TransferType  TransferType `protobuf:"varint,7,opt,name=transfer_type,json=transferType,proto3,enum=qrpay.v1.TransferType" json:"transfer_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentRequest) Reset() {
+	*x = PaymentRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentRequest) ProtoMessage() {}
+
+func (x *PaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentRequest.ProtoReflect.Descriptor instead.
+func (*PaymentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PaymentRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *PaymentRequest) GetFromAccountId() string {
+	if x != nil {
+		return x.FromAccountId
+	}
+	return ""
+}
+
+func (x *PaymentRequest) GetToAccountId() string {
+	if x != nil {
+		return x.ToAccountId
+	}
+	return ""
+}
+
+func (x *PaymentRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PaymentRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PaymentRequest) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+func (x *PaymentRequest) GetTransferType() TransferType {
+	if x != nil {
+		return x.TransferType
+	}
+	return TransferType_TRANSFER_TYPE_UNSPECIFIED
+}
+
+type RefundRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	IdempotencyKey string                 `protobuf:"bytes,1,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	// The payment to give money back for.
+	TransactionId string `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Amount        int64  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundRequest) Reset() {
+	*x = RefundRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundRequest) ProtoMessage() {}
+
+func (x *RefundRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundRequest.ProtoReflect.Descriptor instead.
+func (*RefundRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RefundRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *RefundRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *RefundRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type PaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Status        TransactionStatus      `protobuf:"varint,2,opt,name=status,proto3,enum=qrpay.v1.TransactionStatus" json:"status,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	FailureReason string                 `protobuf:"bytes,4,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	// Fee applied to the payment, in minor units of its currency.
+	FeeAmount int64 `protobuf:"varint,5,opt,name=fee_amount,json=feeAmount,proto3" json:"fee_amount,omitempty"`
+	// "payer" if the fee was debited on top of the amount, "payee" if it was
+	// netted from what the payee was credited; empty if there was no fee.
+	FeeBearer string `protobuf:"bytes,6,opt,name=fee_bearer,json=feeBearer,proto3" json:"fee_bearer,omitempty"`
+	// For a payment declined with failure_reason "limit_exceeded", the limit
+	// it would have breached: "single", "daily", "monthly" or "hourly_count".
+	ExceededLimit string `protobuf:"bytes,7,opt,name=exceeded_limit,json=exceededLimit,proto3" json:"exceeded_limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentResponse) Reset() {
+	*x = PaymentResponse{}
+	mi := &file_proto_payment_service_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentResponse) ProtoMessage() {}
+
+func (x *PaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentResponse.ProtoReflect.Descriptor instead.
+func (*PaymentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PaymentResponse) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *PaymentResponse) GetStatus() TransactionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TransactionStatus_TRANSACTION_STATUS_UNSPECIFIED
+}
+
+func (x *PaymentResponse) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *PaymentResponse) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *PaymentResponse) GetFeeAmount() int64 {
+	if x != nil {
+		return x.FeeAmount
+	}
+	return 0
+}
+
+func (x *PaymentResponse) GetFeeBearer() string {
+	if x != nil {
+		return x.FeeBearer
+	}
+	return ""
+}
+
+func (x *PaymentResponse) GetExceededLimit() string {
+	if x != nil {
+		return x.ExceededLimit
+	}
+	return ""
+}
+
+type Account struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Balance   int64                  `protobuf:"varint,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Part of the balance reserved by active authorizations.
+	Held int64 `protobuf:"varint,4,opt,name=held,proto3" json:"held,omitempty"`
+	// balance - held: what can be spent or held right now.
+	Available int64 `protobuf:"varint,5,opt,name=available,proto3" json:"available,omitempty"`
+	// ISO 4217 code; all amounts of the account are in its minor units.
+	Currency string      `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	Kind     AccountKind `protobuf:"varint,7,opt,name=kind,proto3,enum=qrpay.v1.AccountKind" json:"kind,omitempty"`
+	// Pricing tier fee schedules are selected by.
+	Tier   string        `protobuf:"bytes,8,opt,name=tier,proto3" json:"tier,omitempty"`
+	Status AccountStatus `protobuf:"varint,9,opt,name=status,proto3,enum=qrpay.v1.AccountStatus" json:"status,omitempty"`
+	// Why and when the status was last changed.
+	StatusReason    string                 `protobuf:"bytes,10,opt,name=status_reason,json=statusReason,proto3" json:"status_reason,omitempty"`
+	StatusChangedAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=status_changed_at,json=statusChangedAt,proto3" json:"status_changed_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Account) Reset() {
+	*x = Account{}
+	mi := &file_proto_payment_service_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Account) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Account) ProtoMessage() {}
+
+func (x *Account) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Account.ProtoReflect.Descriptor instead.
+func (*Account) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Account) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Account) GetBalance() int64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *Account) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Account) GetHeld() int64 {
+	if x != nil {
+		return x.Held
+	}
+	return 0
+}
+
+func (x *Account) GetAvailable() int64 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *Account) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Account) GetKind() AccountKind {
+	if x != nil {
+		return x.Kind
+	}
+	return AccountKind_ACCOUNT_KIND_UNSPECIFIED
+}
+
+func (x *Account) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+func (x *Account) GetStatus() AccountStatus {
+	if x != nil {
+		return x.Status
+	}
+	return AccountStatus_ACCOUNT_STATUS_UNSPECIFIED
+}
+
+func (x *Account) GetStatusReason() string {
+	if x != nil {
+		return x.StatusReason
+	}
+	return ""
+}
+
+func (x *Account) GetStatusChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StatusChangedAt
+	}
+	return nil
+}
+
+// Bank details of the payee's settlement account at a Russian bank.
+type AccountRequisites struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Payee as the bank knows them, up to 160 characters.
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// 20-digit settlement account.
+	PersonalAcc string `protobuf:"bytes,3,opt,name=personal_acc,json=personalAcc,proto3" json:"personal_acc,omitempty"`
+	// Up to 45 characters.
+	BankName string `protobuf:"bytes,4,opt,name=bank_name,json=bankName,proto3" json:"bank_name,omitempty"`
+	// 9-digit bank identification code.
+	Bic string `protobuf:"bytes,5,opt,name=bic,proto3" json:"bic,omitempty"`
+	// Optional: the bank's 20-digit correspondent account.
+	CorrespAcc string `protobuf:"bytes,6,opt,name=corresp_acc,json=correspAcc,proto3" json:"corresp_acc,omitempty"`
+	// Optional: 10 or 12 digits.
+	PayeeInn string `protobuf:"bytes,7,opt,name=payee_inn,json=payeeInn,proto3" json:"payee_inn,omitempty"`
+	// Optional: 9 digits.
+	Kpp           string                 `protobuf:"bytes,8,opt,name=kpp,proto3" json:"kpp,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountRequisites) Reset() {
+	*x = AccountRequisites{}
+	mi := &file_proto_payment_service_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountRequisites) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountRequisites) ProtoMessage() {}
+
+func (x *AccountRequisites) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountRequisites.ProtoReflect.Descriptor instead.
+func (*AccountRequisites) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AccountRequisites) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *AccountRequisites) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AccountRequisites) GetPersonalAcc() string {
+	if x != nil {
+		return x.PersonalAcc
+	}
+	return ""
+}
+
+func (x *AccountRequisites) GetBankName() string {
+	if x != nil {
+		return x.BankName
+	}
+	return ""
+}
+
+func (x *AccountRequisites) GetBic() string {
+	if x != nil {
+		return x.Bic
+	}
+	return ""
+}
+
+func (x *AccountRequisites) GetCorrespAcc() string {
+	if x != nil {
+		return x.CorrespAcc
+	}
+	return ""
+}
+
+func (x *AccountRequisites) GetPayeeInn() string {
+	if x != nil {
+		return x.PayeeInn
+	}
+	return ""
+}
+
+func (x *AccountRequisites) GetKpp() string {
+	if x != nil {
+		return x.Kpp
+	}
+	return ""
+}
+
+func (x *AccountRequisites) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetAccountRequisitesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountRequisitesRequest) Reset() {
+	*x = GetAccountRequisitesRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountRequisitesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountRequisitesRequest) ProtoMessage() {}
+
+func (x *GetAccountRequisitesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountRequisitesRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountRequisitesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetAccountRequisitesRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type AuthorizeRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	IdempotencyKey string                 `protobuf:"bytes,1,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	FromAccountId  string                 `protobuf:"bytes,2,opt,name=from_account_id,json=fromAccountId,proto3" json:"from_account_id,omitempty"`
+	ToAccountId    string                 `protobuf:"bytes,3,opt,name=to_account_id,json=toAccountId,proto3" json:"to_account_id,omitempty"`
+	Amount         int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	// ISO 4217 code; both accounts must hold it. Defaults to RUB.
+	Currency      string `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuthorizeRequest) Reset() {
+	*x = AuthorizeRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthorizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorizeRequest) ProtoMessage() {}
+
+func (x *AuthorizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorizeRequest.ProtoReflect.Descriptor instead.
+func (*AuthorizeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AuthorizeRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *AuthorizeRequest) GetFromAccountId() string {
+	if x != nil {
+		return x.FromAccountId
+	}
+	return ""
+}
+
+func (x *AuthorizeRequest) GetToAccountId() string {
+	if x != nil {
+		return x.ToAccountId
+	}
+	return ""
+}
+
+func (x *AuthorizeRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *AuthorizeRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type CaptureRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	IdempotencyKey  string                 `protobuf:"bytes,1,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	AuthorizationId string                 `protobuf:"bytes,2,opt,name=authorization_id,json=authorizationId,proto3" json:"authorization_id,omitempty"`
+	// Amount to settle, at most the authorized amount; 0 captures all of it.
+	Amount        int64 `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaptureRequest) Reset() {
+	*x = CaptureRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptureRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureRequest) ProtoMessage() {}
+
+func (x *CaptureRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureRequest.ProtoReflect.Descriptor instead.
+func (*CaptureRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CaptureRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CaptureRequest) GetAuthorizationId() string {
+	if x != nil {
+		return x.AuthorizationId
+	}
+	return ""
+}
+
+func (x *CaptureRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type VoidAuthorizationRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AuthorizationId string                 `protobuf:"bytes,1,opt,name=authorization_id,json=authorizationId,proto3" json:"authorization_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *VoidAuthorizationRequest) Reset() {
+	*x = VoidAuthorizationRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoidAuthorizationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoidAuthorizationRequest) ProtoMessage() {}
+
+func (x *VoidAuthorizationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoidAuthorizationRequest.ProtoReflect.Descriptor instead.
+func (*VoidAuthorizationRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *VoidAuthorizationRequest) GetAuthorizationId() string {
+	if x != nil {
+		return x.AuthorizationId
+	}
+	return ""
+}
+
+type Authorization struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FromAccountId  string                 `protobuf:"bytes,2,opt,name=from_account_id,json=fromAccountId,proto3" json:"from_account_id,omitempty"`
+	ToAccountId    string                 `protobuf:"bytes,3,opt,name=to_account_id,json=toAccountId,proto3" json:"to_account_id,omitempty"`
+	Amount         int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	CapturedAmount int64                  `protobuf:"varint,5,opt,name=captured_amount,json=capturedAmount,proto3" json:"captured_amount,omitempty"`
+	Status         AuthorizationStatus    `protobuf:"varint,6,opt,name=status,proto3,enum=qrpay.v1.AuthorizationStatus" json:"status,omitempty"`
+	// Set once captured: the transaction that moved the money.
+	CaptureTransactionId string                 `protobuf:"bytes,7,opt,name=capture_transaction_id,json=captureTransactionId,proto3" json:"capture_transaction_id,omitempty"`
+	ExpiresAt            *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt            *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Currency             string                 `protobuf:"bytes,10,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Authorization) Reset() {
+	*x = Authorization{}
+	mi := &file_proto_payment_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Authorization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Authorization) ProtoMessage() {}
+
+func (x *Authorization) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Authorization.ProtoReflect.Descriptor instead.
+func (*Authorization) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Authorization) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Authorization) GetFromAccountId() string {
+	if x != nil {
+		return x.FromAccountId
+	}
+	return ""
+}
+
+func (x *Authorization) GetToAccountId() string {
+	if x != nil {
+		return x.ToAccountId
+	}
+	return ""
+}
+
+func (x *Authorization) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Authorization) GetCapturedAmount() int64 {
+	if x != nil {
+		return x.CapturedAmount
+	}
+	return 0
+}
+
+func (x *Authorization) GetStatus() AuthorizationStatus {
+	if x != nil {
+		return x.Status
+	}
+	return AuthorizationStatus_AUTHORIZATION_STATUS_UNSPECIFIED
+}
+
+func (x *Authorization) GetCaptureTransactionId() string {
+	if x != nil {
+		return x.CaptureTransactionId
+	}
+	return ""
+}
+
+func (x *Authorization) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Authorization) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Authorization) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type CreateAccountRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ISO 4217 code of the new account. Defaults to RUB.
+	Currency string `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Pricing tier: lowercase letters, digits and underscores. Defaults to
+	// "standard".
+	Tier          string `protobuf:"bytes,2,opt,name=tier,proto3" json:"tier,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAccountRequest) Reset() {
+	*x = CreateAccountRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAccountRequest) ProtoMessage() {}
+
+func (x *CreateAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAccountRequest.ProtoReflect.Descriptor instead.
+func (*CreateAccountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateAccountRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreateAccountRequest) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+type GetAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountRequest) Reset() {
+	*x = GetAccountRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountRequest) ProtoMessage() {}
+
+func (x *GetAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetAccountRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type ListAccountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountsRequest) Reset() {
+	*x = ListAccountsRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountsRequest) ProtoMessage() {}
+
+func (x *ListAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountsRequest.ProtoReflect.Descriptor instead.
+func (*ListAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListAccountsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListAccountsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListAccountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accounts      []*Account             `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountsResponse) Reset() {
+	*x = ListAccountsResponse{}
+	mi := &file_proto_payment_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountsResponse) ProtoMessage() {}
+
+func (x *ListAccountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountsResponse.ProtoReflect.Descriptor instead.
+func (*ListAccountsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListAccountsResponse) GetAccounts() []*Account {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+func (x *ListAccountsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type Transaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FromAccountId string                 `protobuf:"bytes,2,opt,name=from_account_id,json=fromAccountId,proto3" json:"from_account_id,omitempty"`
+	ToAccountId   string                 `protobuf:"bytes,3,opt,name=to_account_id,json=toAccountId,proto3" json:"to_account_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Status        TransactionStatus      `protobuf:"varint,5,opt,name=status,proto3,enum=qrpay.v1.TransactionStatus" json:"status,omitempty"`
+	FailureReason string                 `protobuf:"bytes,6,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Set on refunds: the payment the refund was made against.
+	OriginalTransactionId string `protobuf:"bytes,8,opt,name=original_transaction_id,json=originalTransactionId,proto3" json:"original_transaction_id,omitempty"`
+	Currency              string `protobuf:"bytes,9,opt,name=currency,proto3" json:"currency,omitempty"`
+	// What the payee was credited; differs from amount and currency only for
+	// conversions, and from amount when the payee bore a fee.
+	CreditedAmount   int64  `protobuf:"varint,10,opt,name=credited_amount,json=creditedAmount,proto3" json:"credited_amount,omitempty"`
+	CreditedCurrency string `protobuf:"bytes,11,opt,name=credited_currency,json=creditedCurrency,proto3" json:"credited_currency,omitempty"`
+	// Set on conversions: the quote the payment settled at.
+	QuoteId string `protobuf:"bytes,12,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	// Fee credited to fee revenue, in minor units of currency.
+	FeeAmount int64 `protobuf:"varint,13,opt,name=fee_amount,json=feeAmount,proto3" json:"fee_amount,omitempty"`
+	// Set on the legs of a split payment: the split payment they belong to.
+	SplitId       string `protobuf:"bytes,14,opt,name=split_id,json=splitId,proto3" json:"split_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_proto_payment_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Transaction) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Transaction) GetFromAccountId() string {
+	if x != nil {
+		return x.FromAccountId
+	}
+	return ""
+}
+
+func (x *Transaction) GetToAccountId() string {
+	if x != nil {
+		return x.ToAccountId
+	}
+	return ""
+}
+
+func (x *Transaction) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Transaction) GetStatus() TransactionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TransactionStatus_TRANSACTION_STATUS_UNSPECIFIED
+}
+
+func (x *Transaction) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *Transaction) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Transaction) GetOriginalTransactionId() string {
+	if x != nil {
+		return x.OriginalTransactionId
+	}
+	return ""
+}
+
+func (x *Transaction) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Transaction) GetCreditedAmount() int64 {
+	if x != nil {
+		return x.CreditedAmount
+	}
+	return 0
+}
+
+func (x *Transaction) GetCreditedCurrency() string {
+	if x != nil {
+		return x.CreditedCurrency
+	}
+	return ""
+}
+
+func (x *Transaction) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+func (x *Transaction) GetFeeAmount() int64 {
+	if x != nil {
+		return x.FeeAmount
+	}
+	return 0
+}
+
+func (x *Transaction) GetSplitId() string {
+	if x != nil {
+		return x.SplitId
+	}
+	return ""
+}
+
+type GetTransactionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionRequest) Reset() {
+	*x = GetTransactionRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionRequest) ProtoMessage() {}
+
+func (x *GetTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetTransactionRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+type ListTransactionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional. Without it transactions of all accounts are listed.
+	AccountId string `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Requires account_id; UNSPECIFIED lists both directions.
+	Direction     TransactionDirection   `protobuf:"varint,2,opt,name=direction,proto3,enum=qrpay.v1.TransactionDirection" json:"direction,omitempty"`
+	Status        TransactionStatus      `protobuf:"varint,3,opt,name=status,proto3,enum=qrpay.v1.TransactionStatus" json:"status,omitempty"`
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	PageSize      int32                  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsRequest) Reset() {
+	*x = ListTransactionsRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsRequest) ProtoMessage() {}
+
+func (x *ListTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListTransactionsRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetDirection() TransactionDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return TransactionDirection_TRANSACTION_DIRECTION_UNSPECIFIED
+}
+
+func (x *ListTransactionsRequest) GetStatus() TransactionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TransactionStatus_TRANSACTION_STATUS_UNSPECIFIED
+}
+
+func (x *ListTransactionsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListTransactionsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListTransactionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTransactionsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListTransactionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsResponse) Reset() {
+	*x = ListTransactionsResponse{}
+	mi := &file_proto_payment_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsResponse) ProtoMessage() {}
+
+func (x *ListTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListTransactionsResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ListTransactionsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetQuoteRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The currency the payer pays in.
+	FromCurrency string `protobuf:"bytes,1,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	// The currency the payee is credited in.
+	ToCurrency string `protobuf:"bytes,2,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	// In minor units of from_currency.
+	Amount        int64 `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuoteRequest) Reset() {
+	*x = GetQuoteRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuoteRequest) ProtoMessage() {}
+
+func (x *GetQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuoteRequest.ProtoReflect.Descriptor instead.
+func (*GetQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetQuoteRequest) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *GetQuoteRequest) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+func (x *GetQuoteRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type Quote struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	QuoteId        string                 `protobuf:"bytes,1,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	SourceAmount   int64                  `protobuf:"varint,2,opt,name=source_amount,json=sourceAmount,proto3" json:"source_amount,omitempty"`
+	SourceCurrency string                 `protobuf:"bytes,3,opt,name=source_currency,json=sourceCurrency,proto3" json:"source_currency,omitempty"`
+	TargetAmount   int64                  `protobuf:"varint,4,opt,name=target_amount,json=targetAmount,proto3" json:"target_amount,omitempty"`
+	TargetCurrency string                 `protobuf:"bytes,5,opt,name=target_currency,json=targetCurrency,proto3" json:"target_currency,omitempty"`
+	// Target per one major unit of source, spread included, as a decimal.
+	Rate            string                 `protobuf:"bytes,6,opt,name=rate,proto3" json:"rate,omitempty"`
+	ExpiresAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	ValidForSeconds int32                  `protobuf:"varint,8,opt,name=valid_for_seconds,json=validForSeconds,proto3" json:"valid_for_seconds,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Quote) Reset() {
+	*x = Quote{}
+	mi := &file_proto_payment_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quote) ProtoMessage() {}
+
+func (x *Quote) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quote.ProtoReflect.Descriptor instead.
+func (*Quote) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Quote) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
+func (x *Quote) GetSourceAmount() int64 {
+	if x != nil {
+		return x.SourceAmount
+	}
+	return 0
+}
+
+func (x *Quote) GetSourceCurrency() string {
+	if x != nil {
+		return x.SourceCurrency
+	}
+	return ""
+}
+
+func (x *Quote) GetTargetAmount() int64 {
+	if x != nil {
+		return x.TargetAmount
+	}
+	return 0
+}
+
+func (x *Quote) GetTargetCurrency() string {
+	if x != nil {
+		return x.TargetCurrency
+	}
+	return ""
+}
+
+func (x *Quote) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *Quote) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Quote) GetValidForSeconds() int32 {
+	if x != nil {
+		return x.ValidForSeconds
+	}
+	return 0
+}
+
+type Rate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BaseCurrency  string                 `protobuf:"bytes,1,opt,name=base_currency,json=baseCurrency,proto3" json:"base_currency,omitempty"`
+	QuoteCurrency string                 `protobuf:"bytes,2,opt,name=quote_currency,json=quoteCurrency,proto3" json:"quote_currency,omitempty"`
+	// Mid-market price of one major unit of base in quote, as a decimal.
+	MidRate string `protobuf:"bytes,3,opt,name=mid_rate,json=midRate,proto3" json:"mid_rate,omitempty"`
+	// The house's spread in basis points, below 10000.
+	SpreadBps     int64 `protobuf:"varint,4,opt,name=spread_bps,json=spreadBps,proto3" json:"spread_bps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rate) Reset() {
+	*x = Rate{}
+	mi := &file_proto_payment_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rate) ProtoMessage() {}
+
+func (x *Rate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rate.ProtoReflect.Descriptor instead.
+func (*Rate) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *Rate) GetBaseCurrency() string {
+	if x != nil {
+		return x.BaseCurrency
+	}
+	return ""
+}
+
+func (x *Rate) GetQuoteCurrency() string {
+	if x != nil {
+		return x.QuoteCurrency
+	}
+	return ""
+}
+
+func (x *Rate) GetMidRate() string {
+	if x != nil {
+		return x.MidRate
+	}
+	return ""
+}
+
+func (x *Rate) GetSpreadBps() int64 {
+	if x != nil {
+		return x.SpreadBps
+	}
+	return 0
+}
+
+type SetRatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rates         []*Rate                `protobuf:"bytes,1,rep,name=rates,proto3" json:"rates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRatesRequest) Reset() {
+	*x = SetRatesRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRatesRequest) ProtoMessage() {}
+
+func (x *SetRatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRatesRequest.ProtoReflect.Descriptor instead.
+func (*SetRatesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SetRatesRequest) GetRates() []*Rate {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+type SetRatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Updated       int32                  `protobuf:"varint,1,opt,name=updated,proto3" json:"updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRatesResponse) Reset() {
+	*x = SetRatesResponse{}
+	mi := &file_proto_payment_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRatesResponse) ProtoMessage() {}
+
+func (x *SetRatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRatesResponse.ProtoReflect.Descriptor instead.
+func (*SetRatesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SetRatesResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+// Prices transfers of one type, in one currency, for accounts of one tier:
+// fixed_amount plus rate_bps of the amount, kept within [min_amount,
+// max_amount]. The tier is the payer's for P2P transfers and the merchant's
+// for QR merchant payments.
+type FeeSchedule struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	TransferType TransferType           `protobuf:"varint,1,opt,name=transfer_type,json=transferType,proto3,enum=qrpay.v1.TransferType" json:"transfer_type,omitempty"`
+	// Empty for the schedule applied to tiers without one of their own.
+	Tier        string `protobuf:"bytes,2,opt,name=tier,proto3" json:"tier,omitempty"`
+	Currency    string `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	FixedAmount int64  `protobuf:"varint,4,opt,name=fixed_amount,json=fixedAmount,proto3" json:"fixed_amount,omitempty"`
+	RateBps     int64  `protobuf:"varint,5,opt,name=rate_bps,json=rateBps,proto3" json:"rate_bps,omitempty"`
+	MinAmount   int64  `protobuf:"varint,6,opt,name=min_amount,json=minAmount,proto3" json:"min_amount,omitempty"`
+	// Zero leaves the fee uncapped.
+	MaxAmount int64 `protobuf:"varint,7,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
+	// "payer" or "payee".
+	Bearer        string                 `protobuf:"bytes,8,opt,name=bearer,proto3" json:"bearer,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeeSchedule) Reset() {
+	*x = FeeSchedule{}
+	mi := &file_proto_payment_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeeSchedule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeeSchedule) ProtoMessage() {}
+
+func (x *FeeSchedule) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeeSchedule.ProtoReflect.Descriptor instead.
+func (*FeeSchedule) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *FeeSchedule) GetTransferType() TransferType {
+	if x != nil {
+		return x.TransferType
+	}
+	return TransferType_TRANSFER_TYPE_UNSPECIFIED
+}
+
+func (x *FeeSchedule) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+func (x *FeeSchedule) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *FeeSchedule) GetFixedAmount() int64 {
+	if x != nil {
+		return x.FixedAmount
+	}
+	return 0
+}
+
+func (x *FeeSchedule) GetRateBps() int64 {
+	if x != nil {
+		return x.RateBps
+	}
+	return 0
+}
+
+func (x *FeeSchedule) GetMinAmount() int64 {
+	if x != nil {
+		return x.MinAmount
+	}
+	return 0
+}
+
+func (x *FeeSchedule) GetMaxAmount() int64 {
+	if x != nil {
+		return x.MaxAmount
+	}
+	return 0
+}
+
+func (x *FeeSchedule) GetBearer() string {
+	if x != nil {
+		return x.Bearer
+	}
+	return ""
+}
+
+func (x *FeeSchedule) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type SetFeeSchedulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedules     []*FeeSchedule         `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetFeeSchedulesRequest) Reset() {
+	*x = SetFeeSchedulesRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFeeSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFeeSchedulesRequest) ProtoMessage() {}
+
+func (x *SetFeeSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFeeSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*SetFeeSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SetFeeSchedulesRequest) GetSchedules() []*FeeSchedule {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+type SetFeeSchedulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Updated       int32                  `protobuf:"varint,1,opt,name=updated,proto3" json:"updated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetFeeSchedulesResponse) Reset() {
+	*x = SetFeeSchedulesResponse{}
+	mi := &file_proto_payment_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFeeSchedulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFeeSchedulesResponse) ProtoMessage() {}
+
+func (x *SetFeeSchedulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFeeSchedulesResponse.ProtoReflect.Descriptor instead.
+func (*SetFeeSchedulesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SetFeeSchedulesResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+type ListFeeSchedulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFeeSchedulesRequest) Reset() {
+	*x = ListFeeSchedulesRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeeSchedulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeeSchedulesRequest) ProtoMessage() {}
+
+func (x *ListFeeSchedulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeeSchedulesRequest.ProtoReflect.Descriptor instead.
+func (*ListFeeSchedulesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{26}
+}
+
+type ListFeeSchedulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schedules     []*FeeSchedule         `protobuf:"bytes,1,rep,name=schedules,proto3" json:"schedules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFeeSchedulesResponse) Reset() {
+	*x = ListFeeSchedulesResponse{}
+	mi := &file_proto_payment_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFeeSchedulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFeeSchedulesResponse) ProtoMessage() {}
+
+func (x *ListFeeSchedulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFeeSchedulesResponse.ProtoReflect.Descriptor instead.
+func (*ListFeeSchedulesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListFeeSchedulesResponse) GetSchedules() []*FeeSchedule {
+	if x != nil {
+		return x.Schedules
+	}
+	return nil
+}
+
+// Caps what accounts send: per transfer, per UTC day and month, and the
+// number of transfers in the last hour. Set either account_id, for limits of
+// one account in its currency, or tier and currency; an account's own limits
+// take precedence over its tier's. A zero limit is not enforced.
+type TransferLimits struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccountId       string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Tier            string                 `protobuf:"bytes,2,opt,name=tier,proto3" json:"tier,omitempty"`
+	Currency        string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	MaxSingleAmount int64                  `protobuf:"varint,4,opt,name=max_single_amount,json=maxSingleAmount,proto3" json:"max_single_amount,omitempty"`
+	DailyAmount     int64                  `protobuf:"varint,5,opt,name=daily_amount,json=dailyAmount,proto3" json:"daily_amount,omitempty"`
+	MonthlyAmount   int64                  `protobuf:"varint,6,opt,name=monthly_amount,json=monthlyAmount,proto3" json:"monthly_amount,omitempty"`
+	HourlyCount     int64                  `protobuf:"varint,7,opt,name=hourly_count,json=hourlyCount,proto3" json:"hourly_count,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TransferLimits) Reset() {
+	*x = TransferLimits{}
+	mi := &file_proto_payment_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferLimits) ProtoMessage() {}
+
+func (x *TransferLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferLimits.ProtoReflect.Descriptor instead.
+func (*TransferLimits) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *TransferLimits) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *TransferLimits) GetTier() string {
+	if x != nil {
+		return x.Tier
+	}
+	return ""
+}
+
+func (x *TransferLimits) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *TransferLimits) GetMaxSingleAmount() int64 {
+	if x != nil {
+		return x.MaxSingleAmount
+	}
+	return 0
+}
+
+func (x *TransferLimits) GetDailyAmount() int64 {
+	if x != nil {
+		return x.DailyAmount
+	}
+	return 0
+}
+
+func (x *TransferLimits) GetMonthlyAmount() int64 {
+	if x != nil {
+		return x.MonthlyAmount
+	}
+	return 0
+}
+
+func (x *TransferLimits) GetHourlyCount() int64 {
+	if x != nil {
+		return x.HourlyCount
+	}
+	return 0
+}
+
+func (x *TransferLimits) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListTransferLimitsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransferLimitsRequest) Reset() {
+	*x = ListTransferLimitsRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransferLimitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransferLimitsRequest) ProtoMessage() {}
+
+func (x *ListTransferLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransferLimitsRequest.ProtoReflect.Descriptor instead.
+func (*ListTransferLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{29}
+}
+
+type ListTransferLimitsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limits        []*TransferLimits      `protobuf:"bytes,1,rep,name=limits,proto3" json:"limits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransferLimitsResponse) Reset() {
+	*x = ListTransferLimitsResponse{}
+	mi := &file_proto_payment_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransferLimitsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransferLimitsResponse) ProtoMessage() {}
+
+func (x *ListTransferLimitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransferLimitsResponse.ProtoReflect.Descriptor instead.
+func (*ListTransferLimitsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListTransferLimitsResponse) GetLimits() []*TransferLimits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+type FreezeAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FreezeAccountRequest) Reset() {
+	*x = FreezeAccountRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FreezeAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FreezeAccountRequest) ProtoMessage() {}
+
+func (x *FreezeAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FreezeAccountRequest.ProtoReflect.Descriptor instead.
+func (*FreezeAccountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *FreezeAccountRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *FreezeAccountRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type UnfreezeAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnfreezeAccountRequest) Reset() {
+	*x = UnfreezeAccountRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnfreezeAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnfreezeAccountRequest) ProtoMessage() {}
+
+func (x *UnfreezeAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnfreezeAccountRequest.ProtoReflect.Descriptor instead.
+func (*UnfreezeAccountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UnfreezeAccountRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *UnfreezeAccountRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type CloseAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CloseAccountRequest) Reset() {
+	*x = CloseAccountRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CloseAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CloseAccountRequest) ProtoMessage() {}
+
+func (x *CloseAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CloseAccountRequest.ProtoReflect.Descriptor instead.
+func (*CloseAccountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CloseAccountRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *CloseAccountRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RegisterWebhookRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// Absolute http or https URL.
+	Url           string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterWebhookRequest) Reset() {
+	*x = RegisterWebhookRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterWebhookRequest) ProtoMessage() {}
+
+func (x *RegisterWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterWebhookRequest.ProtoReflect.Descriptor instead.
+func (*RegisterWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *RegisterWebhookRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *RegisterWebhookRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+// Deliveries are POSTed as JSON {id, type, created_at, data}, where id is the
+// event ID to deduplicate by and data the settled transaction. Each carries
+// X-Webhook-Timestamp (Unix seconds) and X-Webhook-Signature:
+// "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body)).
+type WebhookEndpoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountId     string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	Secret        string                 `protobuf:"bytes,4,opt,name=secret,proto3" json:"secret,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookEndpoint) Reset() {
+	*x = WebhookEndpoint{}
+	mi := &file_proto_payment_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookEndpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookEndpoint) ProtoMessage() {}
+
+func (x *WebhookEndpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookEndpoint.ProtoReflect.Descriptor instead.
+func (*WebhookEndpoint) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *WebhookEndpoint) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebhookEndpoint) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *WebhookEndpoint) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *WebhookEndpoint) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *WebhookEndpoint) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type WebhookDelivery struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountId string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	EventId   string                 `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType string                 `protobuf:"bytes,4,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Status    WebhookDeliveryStatus  `protobuf:"varint,5,opt,name=status,proto3,enum=qrpay.v1.WebhookDeliveryStatus" json:"status,omitempty"`
+	Attempts  int32                  `protobuf:"varint,6,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	// When a pending delivery is next attempted.
+	NextAttemptAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	// HTTP status of the last attempt; zero if the endpoint did not respond.
+	LastStatusCode int32                  `protobuf:"varint,8,opt,name=last_status_code,json=lastStatusCode,proto3" json:"last_status_code,omitempty"`
+	LastError      string                 `protobuf:"bytes,9,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DeliveredAt    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_proto_payment_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookDelivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookDelivery) ProtoMessage() {}
+
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *WebhookDelivery) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetStatus() WebhookDeliveryStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED
+}
+
+func (x *WebhookDelivery) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetNextAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetLastStatusCode() int32 {
+	if x != nil {
+		return x.LastStatusCode
+	}
+	return 0
+}
+
+func (x *WebhookDelivery) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *WebhookDelivery) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *WebhookDelivery) GetDeliveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return nil
+}
+
+// Lists deliveries newest first. All filters are optional.
+type ListWebhookDeliveriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Status        WebhookDeliveryStatus  `protobuf:"varint,2,opt,name=status,proto3,enum=qrpay.v1.WebhookDeliveryStatus" json:"status,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListWebhookDeliveriesRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ListWebhookDeliveriesRequest) GetStatus() WebhookDeliveryStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WebhookDeliveryStatus_WEBHOOK_DELIVERY_STATUS_UNSPECIFIED
+}
+
+func (x *ListWebhookDeliveriesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListWebhookDeliveriesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListWebhookDeliveriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deliveries    []*WebhookDelivery     `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhookDeliveriesResponse) Reset() {
+	*x = ListWebhookDeliveriesResponse{}
+	mi := &file_proto_payment_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhookDeliveriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
+
+func (x *ListWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+func (x *ListWebhookDeliveriesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ResendWebhookDeliveryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeliveryId    string                 `protobuf:"bytes,1,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResendWebhookDeliveryRequest) Reset() {
+	*x = ResendWebhookDeliveryRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResendWebhookDeliveryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResendWebhookDeliveryRequest) ProtoMessage() {}
+
+func (x *ResendWebhookDeliveryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResendWebhookDeliveryRequest.ProtoReflect.Descriptor instead.
+func (*ResendWebhookDeliveryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ResendWebhookDeliveryRequest) GetDeliveryId() string {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return ""
+}
+
+type SubscribeAccountEventsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	AccountId string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	// The sequence of the last event the client received; NOT_FOUND if there
+	// is no such event.
+	AfterSequence int64 `protobuf:"varint,2,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeAccountEventsRequest) Reset() {
+	*x = SubscribeAccountEventsRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeAccountEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeAccountEventsRequest) ProtoMessage() {}
+
+func (x *SubscribeAccountEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeAccountEventsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeAccountEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SubscribeAccountEventsRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *SubscribeAccountEventsRequest) GetAfterSequence() int64 {
+	if x != nil {
+		return x.AfterSequence
+	}
+	return 0
+}
+
+type AccountEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Identifies the event across redeliveries.
+	EventId string `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	// "payment.completed", "payment.failed" or "payment.refunded".
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// INCOMING if the account is the payee, OUTGOING if it is the payer.
+	Direction   TransactionDirection `protobuf:"varint,3,opt,name=direction,proto3,enum=qrpay.v1.TransactionDirection" json:"direction,omitempty"`
+	Transaction *Transaction         `protobuf:"bytes,4,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	// Orders events by when they were committed: every event committed before
+	// this one has a lower sequence.
+	Sequence      int64 `protobuf:"varint,5,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountEvent) Reset() {
+	*x = AccountEvent{}
+	mi := &file_proto_payment_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountEvent) ProtoMessage() {}
+
+func (x *AccountEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountEvent.ProtoReflect.Descriptor instead.
+func (*AccountEvent) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *AccountEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *AccountEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AccountEvent) GetDirection() TransactionDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return TransactionDirection_TRANSACTION_DIRECTION_UNSPECIFIED
+}
+
+func (x *AccountEvent) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+func (x *AccountEvent) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type BatchTransfer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromAccountId string                 `protobuf:"bytes,1,opt,name=from_account_id,json=fromAccountId,proto3" json:"from_account_id,omitempty"`
+	ToAccountId   string                 `protobuf:"bytes,2,opt,name=to_account_id,json=toAccountId,proto3" json:"to_account_id,omitempty"`
+	// In minor units of currency.
+	Amount int64 `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// ISO 4217 code. Defaults to RUB.
+	Currency      string       `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	TransferType  TransferType `protobuf:"varint,5,opt,name=transfer_type,json=transferType,proto3,enum=qrpay.v1.TransferType" json:"transfer_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchTransfer) Reset() {
+	*x = BatchTransfer{}
+	mi := &file_proto_payment_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchTransfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchTransfer) ProtoMessage() {}
+
+func (x *BatchTransfer) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchTransfer.ProtoReflect.Descriptor instead.
+func (*BatchTransfer) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *BatchTransfer) GetFromAccountId() string {
+	if x != nil {
+		return x.FromAccountId
+	}
+	return ""
+}
+
+func (x *BatchTransfer) GetToAccountId() string {
+	if x != nil {
+		return x.ToAccountId
+	}
+	return ""
+}
+
+func (x *BatchTransfer) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *BatchTransfer) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *BatchTransfer) GetTransferType() TransferType {
+	if x != nil {
+		return x.TransferType
+	}
+	return TransferType_TRANSFER_TYPE_UNSPECIFIED
+}
+
+type BatchRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	IdempotencyKey string                 `protobuf:"bytes,1,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	Mode           BatchMode              `protobuf:"varint,2,opt,name=mode,proto3,enum=qrpay.v1.BatchMode" json:"mode,omitempty"`
+	Transfers      []*BatchTransfer       `protobuf:"bytes,3,rep,name=transfers,proto3" json:"transfers,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *BatchRequest) Reset() {
+	*x = BatchRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRequest) ProtoMessage() {}
+
+func (x *BatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRequest.ProtoReflect.Descriptor instead.
+func (*BatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *BatchRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *BatchRequest) GetMode() BatchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return BatchMode_BATCH_MODE_UNSPECIFIED
+}
+
+func (x *BatchRequest) GetTransfers() []*BatchTransfer {
+	if x != nil {
+		return x.Transfers
+	}
+	return nil
+}
+
+type BatchItem struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Index of the transfer in the request.
+	Position int32           `protobuf:"varint,1,opt,name=position,proto3" json:"position,omitempty"`
+	Transfer *BatchTransfer  `protobuf:"bytes,2,opt,name=transfer,proto3" json:"transfer,omitempty"`
+	Status   BatchItemStatus `protobuf:"varint,3,opt,name=status,proto3,enum=qrpay.v1.BatchItemStatus" json:"status,omitempty"`
+	// The transaction that paid or declined the transfer; empty if it was
+	// rejected before one was recorded.
+	TransactionId string `protobuf:"bytes,4,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	FailureReason string `protobuf:"bytes,5,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	ErrorMessage  string `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchItem) Reset() {
+	*x = BatchItem{}
+	mi := &file_proto_payment_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchItem) ProtoMessage() {}
+
+func (x *BatchItem) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchItem.ProtoReflect.Descriptor instead.
+func (*BatchItem) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *BatchItem) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *BatchItem) GetTransfer() *BatchTransfer {
+	if x != nil {
+		return x.Transfer
+	}
+	return nil
+}
+
+func (x *BatchItem) GetStatus() BatchItemStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BatchItemStatus_BATCH_ITEM_STATUS_UNSPECIFIED
+}
+
+func (x *BatchItem) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *BatchItem) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *BatchItem) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+type Batch struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Mode           BatchMode              `protobuf:"varint,2,opt,name=mode,proto3,enum=qrpay.v1.BatchMode" json:"mode,omitempty"`
+	Status         BatchStatus            `protobuf:"varint,3,opt,name=status,proto3,enum=qrpay.v1.BatchStatus" json:"status,omitempty"`
+	Items          []*BatchItem           `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	SucceededCount int32                  `protobuf:"varint,5,opt,name=succeeded_count,json=succeededCount,proto3" json:"succeeded_count,omitempty"`
+	FailedCount    int32                  `protobuf:"varint,6,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// Unset while the batch is processing.
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Batch) Reset() {
+	*x = Batch{}
+	mi := &file_proto_payment_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Batch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Batch) ProtoMessage() {}
+
+func (x *Batch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Batch.ProtoReflect.Descriptor instead.
+func (*Batch) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *Batch) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Batch) GetMode() BatchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return BatchMode_BATCH_MODE_UNSPECIFIED
+}
+
+func (x *Batch) GetStatus() BatchStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BatchStatus_BATCH_STATUS_UNSPECIFIED
+}
+
+func (x *Batch) GetItems() []*BatchItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Batch) GetSucceededCount() int32 {
+	if x != nil {
+		return x.SucceededCount
+	}
+	return 0
+}
+
+func (x *Batch) GetFailedCount() int32 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+func (x *Batch) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Batch) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+type GetBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BatchId       string                 `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBatchRequest) Reset() {
+	*x = GetBatchRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBatchRequest) ProtoMessage() {}
+
+func (x *GetBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBatchRequest.ProtoReflect.Descriptor instead.
+func (*GetBatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetBatchRequest) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+type SplitLeg struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ToAccountId string                 `protobuf:"bytes,1,opt,name=to_account_id,json=toAccountId,proto3" json:"to_account_id,omitempty"`
+	// In minor units of the payment's currency.
+	Amount int64 `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	// The transaction that paid the leg; empty if the payment was declined.
+	TransactionId string `protobuf:"bytes,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SplitLeg) Reset() {
+	*x = SplitLeg{}
+	mi := &file_proto_payment_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitLeg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitLeg) ProtoMessage() {}
+
+func (x *SplitLeg) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitLeg.ProtoReflect.Descriptor instead.
+func (*SplitLeg) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *SplitLeg) GetToAccountId() string {
+	if x != nil {
+		return x.ToAccountId
+	}
+	return ""
+}
+
+func (x *SplitLeg) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *SplitLeg) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+type SplitPaymentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	IdempotencyKey string                 `protobuf:"bytes,1,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	FromAccountId  string                 `protobuf:"bytes,2,opt,name=from_account_id,json=fromAccountId,proto3" json:"from_account_id,omitempty"`
+	// The total of the legs, in minor units of currency.
+	Amount int64 `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	// ISO 4217 code of the payment and every leg. Defaults to RUB.
+	Currency string      `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	Legs     []*SplitLeg `protobuf:"bytes,5,rep,name=legs,proto3" json:"legs,omitempty"`
+	// Selects the fee schedule of every leg.
+	TransferType  TransferType `protobuf:"varint,6,opt,name=transfer_type,json=transferType,proto3,enum=qrpay.v1.TransferType" json:"transfer_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SplitPaymentRequest) Reset() {
+	*x = SplitPaymentRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitPaymentRequest) ProtoMessage() {}
+
+func (x *SplitPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitPaymentRequest.ProtoReflect.Descriptor instead.
+func (*SplitPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SplitPaymentRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *SplitPaymentRequest) GetFromAccountId() string {
+	if x != nil {
+		return x.FromAccountId
+	}
+	return ""
+}
+
+func (x *SplitPaymentRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *SplitPaymentRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *SplitPaymentRequest) GetLegs() []*SplitLeg {
+	if x != nil {
+		return x.Legs
+	}
+	return nil
+}
+
+func (x *SplitPaymentRequest) GetTransferType() TransferType {
+	if x != nil {
+		return x.TransferType
+	}
+	return TransferType_TRANSFER_TYPE_UNSPECIFIED
+}
+
+type SplitPayment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FromAccountId string                 `protobuf:"bytes,2,opt,name=from_account_id,json=fromAccountId,proto3" json:"from_account_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	Legs          []*SplitLeg            `protobuf:"bytes,5,rep,name=legs,proto3" json:"legs,omitempty"`
+	Status        TransactionStatus      `protobuf:"varint,6,opt,name=status,proto3,enum=qrpay.v1.TransactionStatus" json:"status,omitempty"`
+	FailureReason string                 `protobuf:"bytes,7,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	ErrorMessage  string                 `protobuf:"bytes,8,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SplitPayment) Reset() {
+	*x = SplitPayment{}
+	mi := &file_proto_payment_service_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitPayment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitPayment) ProtoMessage() {}
+
+func (x *SplitPayment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitPayment.ProtoReflect.Descriptor instead.
+func (*SplitPayment) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *SplitPayment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SplitPayment) GetFromAccountId() string {
+	if x != nil {
+		return x.FromAccountId
+	}
+	return ""
+}
+
+func (x *SplitPayment) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *SplitPayment) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *SplitPayment) GetLegs() []*SplitLeg {
+	if x != nil {
+		return x.Legs
+	}
+	return nil
+}
+
+func (x *SplitPayment) GetStatus() TransactionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TransactionStatus_TRANSACTION_STATUS_UNSPECIFIED
+}
+
+func (x *SplitPayment) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *SplitPayment) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *SplitPayment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetSplitPaymentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SplitPaymentId string                 `protobuf:"bytes,1,opt,name=split_payment_id,json=splitPaymentId,proto3" json:"split_payment_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetSplitPaymentRequest) Reset() {
+	*x = GetSplitPaymentRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSplitPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSplitPaymentRequest) ProtoMessage() {}
+
+func (x *GetSplitPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSplitPaymentRequest.ProtoReflect.Descriptor instead.
+func (*GetSplitPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetSplitPaymentRequest) GetSplitPaymentId() string {
+	if x != nil {
+		return x.SplitPaymentId
 	}
-)
+	return ""
+}
 
-func (x TransactionStatus) Enum() *TransactionStatus {
-	p := new(TransactionStatus)
-	*p = x
-	return p
+type CreatePaymentIntentRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ToAccountId string                 `protobuf:"bytes,1,opt,name=to_account_id,json=toAccountId,proto3" json:"to_account_id,omitempty"`
+	// In minor units of currency.
+	Amount int64 `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	// ISO 4217 code; defaults to RUB.
+	Currency string `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	// How long the intent stays payable, at most a day; 0 means 15 minutes.
+	TtlSeconds    int32 `protobuf:"varint,4,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x TransactionStatus) String() string {
-	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+func (x *CreatePaymentIntentRequest) Reset() {
+	*x = CreatePaymentIntentRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (TransactionStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_proto_payment_service_proto_enumTypes[0].Descriptor()
+func (x *CreatePaymentIntentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (TransactionStatus) Type() protoreflect.EnumType {
-	return &file_proto_payment_service_proto_enumTypes[0]
+func (*CreatePaymentIntentRequest) ProtoMessage() {}
+
+func (x *CreatePaymentIntentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x TransactionStatus) Number() protoreflect.EnumNumber {
-	return protoreflect.EnumNumber(x)
+// Deprecated: Use CreatePaymentIntentRequest.ProtoReflect.Descriptor instead.
+func (*CreatePaymentIntentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{51}
 }
 
-// Deprecated: Use TransactionStatus.Descriptor instead.
-func (TransactionStatus) EnumDescriptor() ([]byte, []int) {
-	return file_proto_payment_service_proto_rawDescGZIP(), []int{0}
+func (x *CreatePaymentIntentRequest) GetToAccountId() string {
+	if x != nil {
+		return x.ToAccountId
+	}
+	return ""
 }
 
-type PaymentRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	IdempotencyKey string                 `protobuf:"bytes,1,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
-	FromAccountId  string                 `protobuf:"bytes,2,opt,name=from_account_id,json=fromAccountId,proto3" json:"from_account_id,omitempty"`
-	ToAccountId    string                 `protobuf:"bytes,3,opt,name=to_account_id,json=toAccountId,proto3" json:"to_account_id,omitempty"`
-	Amount         int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+func (x *CreatePaymentIntentRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
 }
 
-func (x *PaymentRequest) Reset() {
-	*x = PaymentRequest{}
-	mi := &file_proto_payment_service_proto_msgTypes[0]
+func (x *CreatePaymentIntentRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CreatePaymentIntentRequest) GetTtlSeconds() int32 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+type PaymentIntent struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ToAccountId string                 `protobuf:"bytes,2,opt,name=to_account_id,json=toAccountId,proto3" json:"to_account_id,omitempty"`
+	Amount      int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency    string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	Status      PaymentIntentStatus    `protobuf:"varint,5,opt,name=status,proto3,enum=qrpay.v1.PaymentIntentStatus" json:"status,omitempty"`
+	// Set once paid: the payer and the transaction that paid the intent.
+	FromAccountId string                 `protobuf:"bytes,6,opt,name=from_account_id,json=fromAccountId,proto3" json:"from_account_id,omitempty"`
+	TransactionId string                 `protobuf:"bytes,7,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	PaidAt        *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=paid_at,json=paidAt,proto3" json:"paid_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentIntent) Reset() {
+	*x = PaymentIntent{}
+	mi := &file_proto_payment_service_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PaymentRequest) String() string {
+func (x *PaymentIntent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PaymentRequest) ProtoMessage() {}
+func (*PaymentIntent) ProtoMessage() {}
 
-func (x *PaymentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_service_proto_msgTypes[0]
+func (x *PaymentIntent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -108,63 +4291,103 @@ func (x *PaymentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PaymentRequest.ProtoReflect.Descriptor instead.
-func (*PaymentRequest) Descriptor() ([]byte, []int) {
-	return file_proto_payment_service_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use PaymentIntent.ProtoReflect.Descriptor instead.
+func (*PaymentIntent) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *PaymentRequest) GetIdempotencyKey() string {
+func (x *PaymentIntent) GetId() string {
 	if x != nil {
-		return x.IdempotencyKey
+		return x.Id
 	}
 	return ""
 }
 
-func (x *PaymentRequest) GetFromAccountId() string {
+func (x *PaymentIntent) GetToAccountId() string {
+	if x != nil {
+		return x.ToAccountId
+	}
+	return ""
+}
+
+func (x *PaymentIntent) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PaymentIntent) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PaymentIntent) GetStatus() PaymentIntentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PaymentIntentStatus_PAYMENT_INTENT_STATUS_UNSPECIFIED
+}
+
+func (x *PaymentIntent) GetFromAccountId() string {
 	if x != nil {
 		return x.FromAccountId
 	}
 	return ""
 }
 
-func (x *PaymentRequest) GetToAccountId() string {
+func (x *PaymentIntent) GetTransactionId() string {
 	if x != nil {
-		return x.ToAccountId
+		return x.TransactionId
 	}
 	return ""
 }
 
-func (x *PaymentRequest) GetAmount() int64 {
+func (x *PaymentIntent) GetExpiresAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Amount
+		return x.ExpiresAt
 	}
-	return 0
+	return nil
 }
 
-type PaymentResponse struct {
+func (x *PaymentIntent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PaymentIntent) GetPaidAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PaidAt
+	}
+	return nil
+}
+
+type GetPaymentIntentRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
-	Status        TransactionStatus      `protobuf:"varint,2,opt,name=status,proto3,enum=qrpay.v1.TransactionStatus" json:"status,omitempty"`
-	ErrorMessage  string                 `protobuf:"bytes,3,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	IntentId      string                 `protobuf:"bytes,1,opt,name=intent_id,json=intentId,proto3" json:"intent_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PaymentResponse) Reset() {
-	*x = PaymentResponse{}
-	mi := &file_proto_payment_service_proto_msgTypes[1]
+func (x *GetPaymentIntentRequest) Reset() {
+	*x = GetPaymentIntentRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PaymentResponse) String() string {
+func (x *GetPaymentIntentRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PaymentResponse) ProtoMessage() {}
+func (*GetPaymentIntentRequest) ProtoMessage() {}
 
-func (x *PaymentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_payment_service_proto_msgTypes[1]
+func (x *GetPaymentIntentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -175,28 +4398,118 @@ func (x *PaymentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PaymentResponse.ProtoReflect.Descriptor instead.
-func (*PaymentResponse) Descriptor() ([]byte, []int) {
-	return file_proto_payment_service_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use GetPaymentIntentRequest.ProtoReflect.Descriptor instead.
+func (*GetPaymentIntentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *PaymentResponse) GetTransactionId() string {
+func (x *GetPaymentIntentRequest) GetIntentId() string {
 	if x != nil {
-		return x.TransactionId
+		return x.IntentId
 	}
 	return ""
 }
 
-func (x *PaymentResponse) GetStatus() TransactionStatus {
+type CancelPaymentIntentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IntentId      string                 `protobuf:"bytes,1,opt,name=intent_id,json=intentId,proto3" json:"intent_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelPaymentIntentRequest) Reset() {
+	*x = CancelPaymentIntentRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelPaymentIntentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelPaymentIntentRequest) ProtoMessage() {}
+
+func (x *CancelPaymentIntentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[54]
 	if x != nil {
-		return x.Status
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return TransactionStatus_TRANSACTION_STATUS_UNSPECIFIED
+	return mi.MessageOf(x)
 }
 
-func (x *PaymentResponse) GetErrorMessage() string {
+// Deprecated: Use CancelPaymentIntentRequest.ProtoReflect.Descriptor instead.
+func (*CancelPaymentIntentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *CancelPaymentIntentRequest) GetIntentId() string {
 	if x != nil {
-		return x.ErrorMessage
+		return x.IntentId
+	}
+	return ""
+}
+
+type PayPaymentIntentRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	IdempotencyKey string                 `protobuf:"bytes,1,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	IntentId       string                 `protobuf:"bytes,2,opt,name=intent_id,json=intentId,proto3" json:"intent_id,omitempty"`
+	FromAccountId  string                 `protobuf:"bytes,3,opt,name=from_account_id,json=fromAccountId,proto3" json:"from_account_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PayPaymentIntentRequest) Reset() {
+	*x = PayPaymentIntentRequest{}
+	mi := &file_proto_payment_service_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayPaymentIntentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayPaymentIntentRequest) ProtoMessage() {}
+
+func (x *PayPaymentIntentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_payment_service_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayPaymentIntentRequest.ProtoReflect.Descriptor instead.
+func (*PayPaymentIntentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_payment_service_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *PayPaymentIntentRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *PayPaymentIntentRequest) GetIntentId() string {
+	if x != nil {
+		return x.IntentId
+	}
+	return ""
+}
+
+func (x *PayPaymentIntentRequest) GetFromAccountId() string {
+	if x != nil {
+		return x.FromAccountId
 	}
 	return ""
 }
@@ -205,23 +4518,430 @@ var File_proto_payment_service_proto protoreflect.FileDescriptor
 
 const file_proto_payment_service_proto_rawDesc = "" +
 	"\n" +
-	"\x1bproto/payment_service.proto\x12\bqrpay.v1\"\x9d\x01\n" +
+	"\x1bproto/payment_service.proto\x12\bqrpay.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x91\x02\n" +
 	"\x0ePaymentRequest\x12'\n" +
 	"\x0fidempotency_key\x18\x01 \x01(\tR\x0eidempotencyKey\x12&\n" +
 	"\x0ffrom_account_id\x18\x02 \x01(\tR\rfromAccountId\x12\"\n" +
 	"\rto_account_id\x18\x03 \x01(\tR\vtoAccountId\x12\x16\n" +
-	"\x06amount\x18\x04 \x01(\x03R\x06amount\"\x92\x01\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12\x19\n" +
+	"\bquote_id\x18\x06 \x01(\tR\aquoteId\x12;\n" +
+	"\rtransfer_type\x18\a \x01(\x0e2\x16.qrpay.v1.TransferTypeR\ftransferType\"w\n" +
+	"\rRefundRequest\x12'\n" +
+	"\x0fidempotency_key\x18\x01 \x01(\tR\x0eidempotencyKey\x12%\n" +
+	"\x0etransaction_id\x18\x02 \x01(\tR\rtransactionId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\"\x9e\x02\n" +
 	"\x0fPaymentResponse\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x123\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x1b.qrpay.v1.TransactionStatusR\x06status\x12#\n" +
-	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage*\x96\x01\n" +
+	"\rerror_message\x18\x03 \x01(\tR\ferrorMessage\x12%\n" +
+	"\x0efailure_reason\x18\x04 \x01(\tR\rfailureReason\x12\x1d\n" +
+	"\n" +
+	"fee_amount\x18\x05 \x01(\x03R\tfeeAmount\x12\x1d\n" +
+	"\n" +
+	"fee_bearer\x18\x06 \x01(\tR\tfeeBearer\x12%\n" +
+	"\x0eexceeded_limit\x18\a \x01(\tR\rexceededLimit\"\x99\x03\n" +
+	"\aAccount\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\x03R\abalance\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x12\n" +
+	"\x04held\x18\x04 \x01(\x03R\x04held\x12\x1c\n" +
+	"\tavailable\x18\x05 \x01(\x03R\tavailable\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12)\n" +
+	"\x04kind\x18\a \x01(\x0e2\x15.qrpay.v1.AccountKindR\x04kind\x12\x12\n" +
+	"\x04tier\x18\b \x01(\tR\x04tier\x12/\n" +
+	"\x06status\x18\t \x01(\x0e2\x17.qrpay.v1.AccountStatusR\x06status\x12#\n" +
+	"\rstatus_reason\x18\n" +
+	" \x01(\tR\fstatusReason\x12F\n" +
+	"\x11status_changed_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x0fstatusChangedAt\"\xa3\x02\n" +
+	"\x11AccountRequisites\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
+	"\fpersonal_acc\x18\x03 \x01(\tR\vpersonalAcc\x12\x1b\n" +
+	"\tbank_name\x18\x04 \x01(\tR\bbankName\x12\x10\n" +
+	"\x03bic\x18\x05 \x01(\tR\x03bic\x12\x1f\n" +
+	"\vcorresp_acc\x18\x06 \x01(\tR\n" +
+	"correspAcc\x12\x1b\n" +
+	"\tpayee_inn\x18\a \x01(\tR\bpayeeInn\x12\x10\n" +
+	"\x03kpp\x18\b \x01(\tR\x03kpp\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"<\n" +
+	"\x1bGetAccountRequisitesRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"\xbb\x01\n" +
+	"\x10AuthorizeRequest\x12'\n" +
+	"\x0fidempotency_key\x18\x01 \x01(\tR\x0eidempotencyKey\x12&\n" +
+	"\x0ffrom_account_id\x18\x02 \x01(\tR\rfromAccountId\x12\"\n" +
+	"\rto_account_id\x18\x03 \x01(\tR\vtoAccountId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\"|\n" +
+	"\x0eCaptureRequest\x12'\n" +
+	"\x0fidempotency_key\x18\x01 \x01(\tR\x0eidempotencyKey\x12)\n" +
+	"\x10authorization_id\x18\x02 \x01(\tR\x0fauthorizationId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\"E\n" +
+	"\x18VoidAuthorizationRequest\x12)\n" +
+	"\x10authorization_id\x18\x01 \x01(\tR\x0fauthorizationId\"\xab\x03\n" +
+	"\rAuthorization\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
+	"\x0ffrom_account_id\x18\x02 \x01(\tR\rfromAccountId\x12\"\n" +
+	"\rto_account_id\x18\x03 \x01(\tR\vtoAccountId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12'\n" +
+	"\x0fcaptured_amount\x18\x05 \x01(\x03R\x0ecapturedAmount\x125\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x1d.qrpay.v1.AuthorizationStatusR\x06status\x124\n" +
+	"\x16capture_transaction_id\x18\a \x01(\tR\x14captureTransactionId\x129\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1a\n" +
+	"\bcurrency\x18\n" +
+	" \x01(\tR\bcurrency\"F\n" +
+	"\x14CreateAccountRequest\x12\x1a\n" +
+	"\bcurrency\x18\x01 \x01(\tR\bcurrency\x12\x12\n" +
+	"\x04tier\x18\x02 \x01(\tR\x04tier\"2\n" +
+	"\x11GetAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"Q\n" +
+	"\x13ListAccountsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"m\n" +
+	"\x14ListAccountsResponse\x12-\n" +
+	"\baccounts\x18\x01 \x03(\v2\x11.qrpay.v1.AccountR\baccounts\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x97\x04\n" +
+	"\vTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
+	"\x0ffrom_account_id\x18\x02 \x01(\tR\rfromAccountId\x12\"\n" +
+	"\rto_account_id\x18\x03 \x01(\tR\vtoAccountId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x123\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1b.qrpay.v1.TransactionStatusR\x06status\x12%\n" +
+	"\x0efailure_reason\x18\x06 \x01(\tR\rfailureReason\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x126\n" +
+	"\x17original_transaction_id\x18\b \x01(\tR\x15originalTransactionId\x12\x1a\n" +
+	"\bcurrency\x18\t \x01(\tR\bcurrency\x12'\n" +
+	"\x0fcredited_amount\x18\n" +
+	" \x01(\x03R\x0ecreditedAmount\x12+\n" +
+	"\x11credited_currency\x18\v \x01(\tR\x10creditedCurrency\x12\x19\n" +
+	"\bquote_id\x18\f \x01(\tR\aquoteId\x12\x1d\n" +
+	"\n" +
+	"fee_amount\x18\r \x01(\x03R\tfeeAmount\x12\x19\n" +
+	"\bsplit_id\x18\x0e \x01(\tR\asplitId\">\n" +
+	"\x15GetTransactionRequest\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"\xeb\x02\n" +
+	"\x17ListTransactionsRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12<\n" +
+	"\tdirection\x18\x02 \x01(\x0e2\x1e.qrpay.v1.TransactionDirectionR\tdirection\x123\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1b.qrpay.v1.TransactionStatusR\x06status\x12?\n" +
+	"\rcreated_after\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageToken\"}\n" +
+	"\x18ListTransactionsResponse\x129\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x15.qrpay.v1.TransactionR\ftransactions\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"o\n" +
+	"\x0fGetQuoteRequest\x12#\n" +
+	"\rfrom_currency\x18\x01 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x02 \x01(\tR\n" +
+	"toCurrency\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\"\xb9\x02\n" +
+	"\x05Quote\x12\x19\n" +
+	"\bquote_id\x18\x01 \x01(\tR\aquoteId\x12#\n" +
+	"\rsource_amount\x18\x02 \x01(\x03R\fsourceAmount\x12'\n" +
+	"\x0fsource_currency\x18\x03 \x01(\tR\x0esourceCurrency\x12#\n" +
+	"\rtarget_amount\x18\x04 \x01(\x03R\ftargetAmount\x12'\n" +
+	"\x0ftarget_currency\x18\x05 \x01(\tR\x0etargetCurrency\x12\x12\n" +
+	"\x04rate\x18\x06 \x01(\tR\x04rate\x129\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12*\n" +
+	"\x11valid_for_seconds\x18\b \x01(\x05R\x0fvalidForSeconds\"\x8c\x01\n" +
+	"\x04Rate\x12#\n" +
+	"\rbase_currency\x18\x01 \x01(\tR\fbaseCurrency\x12%\n" +
+	"\x0equote_currency\x18\x02 \x01(\tR\rquoteCurrency\x12\x19\n" +
+	"\bmid_rate\x18\x03 \x01(\tR\amidRate\x12\x1d\n" +
+	"\n" +
+	"spread_bps\x18\x04 \x01(\x03R\tspreadBps\"7\n" +
+	"\x0fSetRatesRequest\x12$\n" +
+	"\x05rates\x18\x01 \x03(\v2\x0e.qrpay.v1.RateR\x05rates\",\n" +
+	"\x10SetRatesResponse\x12\x18\n" +
+	"\aupdated\x18\x01 \x01(\x05R\aupdated\"\xc9\x02\n" +
+	"\vFeeSchedule\x12;\n" +
+	"\rtransfer_type\x18\x01 \x01(\x0e2\x16.qrpay.v1.TransferTypeR\ftransferType\x12\x12\n" +
+	"\x04tier\x18\x02 \x01(\tR\x04tier\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12!\n" +
+	"\ffixed_amount\x18\x04 \x01(\x03R\vfixedAmount\x12\x19\n" +
+	"\brate_bps\x18\x05 \x01(\x03R\arateBps\x12\x1d\n" +
+	"\n" +
+	"min_amount\x18\x06 \x01(\x03R\tminAmount\x12\x1d\n" +
+	"\n" +
+	"max_amount\x18\a \x01(\x03R\tmaxAmount\x12\x16\n" +
+	"\x06bearer\x18\b \x01(\tR\x06bearer\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"M\n" +
+	"\x16SetFeeSchedulesRequest\x123\n" +
+	"\tschedules\x18\x01 \x03(\v2\x15.qrpay.v1.FeeScheduleR\tschedules\"3\n" +
+	"\x17SetFeeSchedulesResponse\x12\x18\n" +
+	"\aupdated\x18\x01 \x01(\x05R\aupdated\"\x19\n" +
+	"\x17ListFeeSchedulesRequest\"O\n" +
+	"\x18ListFeeSchedulesResponse\x123\n" +
+	"\tschedules\x18\x01 \x03(\v2\x15.qrpay.v1.FeeScheduleR\tschedules\"\xb3\x02\n" +
+	"\x0eTransferLimits\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x12\n" +
+	"\x04tier\x18\x02 \x01(\tR\x04tier\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12*\n" +
+	"\x11max_single_amount\x18\x04 \x01(\x03R\x0fmaxSingleAmount\x12!\n" +
+	"\fdaily_amount\x18\x05 \x01(\x03R\vdailyAmount\x12%\n" +
+	"\x0emonthly_amount\x18\x06 \x01(\x03R\rmonthlyAmount\x12!\n" +
+	"\fhourly_count\x18\a \x01(\x03R\vhourlyCount\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x1b\n" +
+	"\x19ListTransferLimitsRequest\"N\n" +
+	"\x1aListTransferLimitsResponse\x120\n" +
+	"\x06limits\x18\x01 \x03(\v2\x18.qrpay.v1.TransferLimitsR\x06limits\"M\n" +
+	"\x14FreezeAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"O\n" +
+	"\x16UnfreezeAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"L\n" +
+	"\x13CloseAccountRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"I\n" +
+	"\x16RegisterWebhookRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\"\xa5\x01\n" +
+	"\x0fWebhookEndpoint\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x12\x16\n" +
+	"\x06secret\x18\x04 \x01(\tR\x06secret\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xd6\x03\n" +
+	"\x0fWebhookDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x04 \x01(\tR\teventType\x127\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1f.qrpay.v1.WebhookDeliveryStatusR\x06status\x12\x1a\n" +
+	"\battempts\x18\x06 \x01(\x05R\battempts\x12B\n" +
+	"\x0fnext_attempt_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rnextAttemptAt\x12(\n" +
+	"\x10last_status_code\x18\b \x01(\x05R\x0elastStatusCode\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\t \x01(\tR\tlastError\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\fdelivered_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vdeliveredAt\"\xb2\x01\n" +
+	"\x1cListWebhookDeliveriesRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x127\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1f.qrpay.v1.WebhookDeliveryStatusR\x06status\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"\x82\x01\n" +
+	"\x1dListWebhookDeliveriesResponse\x129\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x19.qrpay.v1.WebhookDeliveryR\n" +
+	"deliveries\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"?\n" +
+	"\x1cResendWebhookDeliveryRequest\x12\x1f\n" +
+	"\vdelivery_id\x18\x01 \x01(\tR\n" +
+	"deliveryId\"e\n" +
+	"\x1dSubscribeAccountEventsRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12%\n" +
+	"\x0eafter_sequence\x18\x02 \x01(\x03R\rafterSequence\"\xd0\x01\n" +
+	"\fAccountEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12<\n" +
+	"\tdirection\x18\x03 \x01(\x0e2\x1e.qrpay.v1.TransactionDirectionR\tdirection\x127\n" +
+	"\vtransaction\x18\x04 \x01(\v2\x15.qrpay.v1.TransactionR\vtransaction\x12\x1a\n" +
+	"\bsequence\x18\x05 \x01(\x03R\bsequence\"\xcc\x01\n" +
+	"\rBatchTransfer\x12&\n" +
+	"\x0ffrom_account_id\x18\x01 \x01(\tR\rfromAccountId\x12\"\n" +
+	"\rto_account_id\x18\x02 \x01(\tR\vtoAccountId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12;\n" +
+	"\rtransfer_type\x18\x05 \x01(\x0e2\x16.qrpay.v1.TransferTypeR\ftransferType\"\x97\x01\n" +
+	"\fBatchRequest\x12'\n" +
+	"\x0fidempotency_key\x18\x01 \x01(\tR\x0eidempotencyKey\x12'\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x13.qrpay.v1.BatchModeR\x04mode\x125\n" +
+	"\ttransfers\x18\x03 \x03(\v2\x17.qrpay.v1.BatchTransferR\ttransfers\"\x82\x02\n" +
+	"\tBatchItem\x12\x1a\n" +
+	"\bposition\x18\x01 \x01(\x05R\bposition\x123\n" +
+	"\btransfer\x18\x02 \x01(\v2\x17.qrpay.v1.BatchTransferR\btransfer\x121\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x19.qrpay.v1.BatchItemStatusR\x06status\x12%\n" +
+	"\x0etransaction_id\x18\x04 \x01(\tR\rtransactionId\x12%\n" +
+	"\x0efailure_reason\x18\x05 \x01(\tR\rfailureReason\x12#\n" +
+	"\rerror_message\x18\x06 \x01(\tR\ferrorMessage\"\xe0\x02\n" +
+	"\x05Batch\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
+	"\x04mode\x18\x02 \x01(\x0e2\x13.qrpay.v1.BatchModeR\x04mode\x12-\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x15.qrpay.v1.BatchStatusR\x06status\x12)\n" +
+	"\x05items\x18\x04 \x03(\v2\x13.qrpay.v1.BatchItemR\x05items\x12'\n" +
+	"\x0fsucceeded_count\x18\x05 \x01(\x05R\x0esucceededCount\x12!\n" +
+	"\ffailed_count\x18\x06 \x01(\x05R\vfailedCount\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\fcompleted_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\",\n" +
+	"\x0fGetBatchRequest\x12\x19\n" +
+	"\bbatch_id\x18\x01 \x01(\tR\abatchId\"m\n" +
+	"\bSplitLeg\x12\"\n" +
+	"\rto_account_id\x18\x01 \x01(\tR\vtoAccountId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12%\n" +
+	"\x0etransaction_id\x18\x03 \x01(\tR\rtransactionId\"\xff\x01\n" +
+	"\x13SplitPaymentRequest\x12'\n" +
+	"\x0fidempotency_key\x18\x01 \x01(\tR\x0eidempotencyKey\x12&\n" +
+	"\x0ffrom_account_id\x18\x02 \x01(\tR\rfromAccountId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12&\n" +
+	"\x04legs\x18\x05 \x03(\v2\x12.qrpay.v1.SplitLegR\x04legs\x12;\n" +
+	"\rtransfer_type\x18\x06 \x01(\x0e2\x16.qrpay.v1.TransferTypeR\ftransferType\"\xde\x02\n" +
+	"\fSplitPayment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
+	"\x0ffrom_account_id\x18\x02 \x01(\tR\rfromAccountId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12&\n" +
+	"\x04legs\x18\x05 \x03(\v2\x12.qrpay.v1.SplitLegR\x04legs\x123\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x1b.qrpay.v1.TransactionStatusR\x06status\x12%\n" +
+	"\x0efailure_reason\x18\a \x01(\tR\rfailureReason\x12#\n" +
+	"\rerror_message\x18\b \x01(\tR\ferrorMessage\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"B\n" +
+	"\x16GetSplitPaymentRequest\x12(\n" +
+	"\x10split_payment_id\x18\x01 \x01(\tR\x0esplitPaymentId\"\x95\x01\n" +
+	"\x1aCreatePaymentIntentRequest\x12\"\n" +
+	"\rto_account_id\x18\x01 \x01(\tR\vtoAccountId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x1f\n" +
+	"\vttl_seconds\x18\x04 \x01(\x05R\n" +
+	"ttlSeconds\"\xa8\x03\n" +
+	"\rPaymentIntent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\"\n" +
+	"\rto_account_id\x18\x02 \x01(\tR\vtoAccountId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x125\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1d.qrpay.v1.PaymentIntentStatusR\x06status\x12&\n" +
+	"\x0ffrom_account_id\x18\x06 \x01(\tR\rfromAccountId\x12%\n" +
+	"\x0etransaction_id\x18\a \x01(\tR\rtransactionId\x129\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x123\n" +
+	"\apaid_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x06paidAt\"6\n" +
+	"\x17GetPaymentIntentRequest\x12\x1b\n" +
+	"\tintent_id\x18\x01 \x01(\tR\bintentId\"9\n" +
+	"\x1aCancelPaymentIntentRequest\x12\x1b\n" +
+	"\tintent_id\x18\x01 \x01(\tR\bintentId\"\x87\x01\n" +
+	"\x17PayPaymentIntentRequest\x12'\n" +
+	"\x0fidempotency_key\x18\x01 \x01(\tR\x0eidempotencyKey\x12\x1b\n" +
+	"\tintent_id\x18\x02 \x01(\tR\bintentId\x12&\n" +
+	"\x0ffrom_account_id\x18\x03 \x01(\tR\rfromAccountId*c\n" +
+	"\fTransferType\x12\x1d\n" +
+	"\x19TRANSFER_TYPE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11TRANSFER_TYPE_P2P\x10\x01\x12\x1d\n" +
+	"\x19TRANSFER_TYPE_QR_MERCHANT\x10\x02*\x96\x01\n" +
 	"\x11TransactionStatus\x12\"\n" +
 	"\x1eTRANSACTION_STATUS_UNSPECIFIED\x10\x00\x12\x1e\n" +
 	"\x1aTRANSACTION_STATUS_PENDING\x10\x01\x12\x1e\n" +
 	"\x1aTRANSACTION_STATUS_SUCCESS\x10\x02\x12\x1d\n" +
-	"\x19TRANSACTION_STATUS_FAILED\x10\x032Y\n" +
+	"\x19TRANSACTION_STATUS_FAILED\x10\x03*\x80\x01\n" +
+	"\rAccountStatus\x12\x1e\n" +
+	"\x1aACCOUNT_STATUS_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15ACCOUNT_STATUS_ACTIVE\x10\x01\x12\x19\n" +
+	"\x15ACCOUNT_STATUS_FROZEN\x10\x02\x12\x19\n" +
+	"\x15ACCOUNT_STATUS_CLOSED\x10\x03*\xa0\x01\n" +
+	"\vAccountKind\x12\x1c\n" +
+	"\x18ACCOUNT_KIND_UNSPECIFIED\x10\x00\x12\x19\n" +
+	"\x15ACCOUNT_KIND_CUSTOMER\x10\x01\x12\x1d\n" +
+	"\x19ACCOUNT_KIND_FX_LIQUIDITY\x10\x02\x12\x1b\n" +
+	"\x17ACCOUNT_KIND_FX_REVENUE\x10\x03\x12\x1c\n" +
+	"\x18ACCOUNT_KIND_FEE_REVENUE\x10\x04*\xc2\x01\n" +
+	"\x13AuthorizationStatus\x12$\n" +
+	" AUTHORIZATION_STATUS_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bAUTHORIZATION_STATUS_ACTIVE\x10\x01\x12!\n" +
+	"\x1dAUTHORIZATION_STATUS_CAPTURED\x10\x02\x12\x1f\n" +
+	"\x1bAUTHORIZATION_STATUS_VOIDED\x10\x03\x12 \n" +
+	"\x1cAUTHORIZATION_STATUS_EXPIRED\x10\x04*\x85\x01\n" +
+	"\x14TransactionDirection\x12%\n" +
+	"!TRANSACTION_DIRECTION_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eTRANSACTION_DIRECTION_INCOMING\x10\x01\x12\"\n" +
+	"\x1eTRANSACTION_DIRECTION_OUTGOING\x10\x02*\xae\x01\n" +
+	"\x15WebhookDeliveryStatus\x12'\n" +
+	"#WEBHOOK_DELIVERY_STATUS_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fWEBHOOK_DELIVERY_STATUS_PENDING\x10\x01\x12%\n" +
+	"!WEBHOOK_DELIVERY_STATUS_DELIVERED\x10\x02\x12 \n" +
+	"\x1cWEBHOOK_DELIVERY_STATUS_DEAD\x10\x03*Z\n" +
+	"\tBatchMode\x12\x1a\n" +
+	"\x16BATCH_MODE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11BATCH_MODE_ATOMIC\x10\x01\x12\x1a\n" +
+	"\x16BATCH_MODE_BEST_EFFORT\x10\x02*\xa3\x01\n" +
+	"\vBatchStatus\x12\x1c\n" +
+	"\x18BATCH_STATUS_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17BATCH_STATUS_PROCESSING\x10\x01\x12\x1a\n" +
+	"\x16BATCH_STATUS_COMPLETED\x10\x02\x12$\n" +
+	" BATCH_STATUS_PARTIALLY_COMPLETED\x10\x03\x12\x17\n" +
+	"\x13BATCH_STATUS_FAILED\x10\x04*\xaf\x01\n" +
+	"\x0fBatchItemStatus\x12!\n" +
+	"\x1dBATCH_ITEM_STATUS_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19BATCH_ITEM_STATUS_PENDING\x10\x01\x12\x1d\n" +
+	"\x19BATCH_ITEM_STATUS_SUCCESS\x10\x02\x12\x1c\n" +
+	"\x18BATCH_ITEM_STATUS_FAILED\x10\x03\x12\x1d\n" +
+	"\x19BATCH_ITEM_STATUS_SKIPPED\x10\x04*\xc7\x01\n" +
+	"\x13PaymentIntentStatus\x12%\n" +
+	"!PAYMENT_INTENT_STATUS_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dPAYMENT_INTENT_STATUS_CREATED\x10\x01\x12\x1e\n" +
+	"\x1aPAYMENT_INTENT_STATUS_PAID\x10\x02\x12!\n" +
+	"\x1dPAYMENT_INTENT_STATUS_EXPIRED\x10\x03\x12#\n" +
+	"\x1fPAYMENT_INTENT_STATUS_CANCELLED\x10\x042\xa9\x0f\n" +
 	"\x10PaymentProcessor\x12E\n" +
-	"\x0eProcessPayment\x12\x18.qrpay.v1.PaymentRequest\x1a\x19.qrpay.v1.PaymentResponseB*Z(github.com/Xausdorf/qr-pay-hub/gen/pb;pbb\x06proto3"
+	"\x0eProcessPayment\x12\x18.qrpay.v1.PaymentRequest\x1a\x19.qrpay.v1.PaymentResponse\x12C\n" +
+	"\rRefundPayment\x12\x17.qrpay.v1.RefundRequest\x1a\x19.qrpay.v1.PaymentResponse\x12G\n" +
+	"\x10AuthorizePayment\x12\x1a.qrpay.v1.AuthorizeRequest\x1a\x17.qrpay.v1.Authorization\x12E\n" +
+	"\x0eCapturePayment\x12\x18.qrpay.v1.CaptureRequest\x1a\x19.qrpay.v1.PaymentResponse\x12P\n" +
+	"\x11VoidAuthorization\x12\".qrpay.v1.VoidAuthorizationRequest\x1a\x17.qrpay.v1.Authorization\x127\n" +
+	"\fProcessBatch\x12\x16.qrpay.v1.BatchRequest\x1a\x0f.qrpay.v1.Batch\x126\n" +
+	"\bGetBatch\x12\x19.qrpay.v1.GetBatchRequest\x1a\x0f.qrpay.v1.Batch\x12L\n" +
+	"\x13ProcessSplitPayment\x12\x1d.qrpay.v1.SplitPaymentRequest\x1a\x16.qrpay.v1.SplitPayment\x12K\n" +
+	"\x0fGetSplitPayment\x12 .qrpay.v1.GetSplitPaymentRequest\x1a\x16.qrpay.v1.SplitPayment\x12T\n" +
+	"\x13CreatePaymentIntent\x12$.qrpay.v1.CreatePaymentIntentRequest\x1a\x17.qrpay.v1.PaymentIntent\x12N\n" +
+	"\x10GetPaymentIntent\x12!.qrpay.v1.GetPaymentIntentRequest\x1a\x17.qrpay.v1.PaymentIntent\x12T\n" +
+	"\x13CancelPaymentIntent\x12$.qrpay.v1.CancelPaymentIntentRequest\x1a\x17.qrpay.v1.PaymentIntent\x12P\n" +
+	"\x10PayPaymentIntent\x12!.qrpay.v1.PayPaymentIntentRequest\x1a\x19.qrpay.v1.PaymentResponse\x12B\n" +
+	"\rCreateAccount\x12\x1e.qrpay.v1.CreateAccountRequest\x1a\x11.qrpay.v1.Account\x12<\n" +
+	"\n" +
+	"GetAccount\x12\x1b.qrpay.v1.GetAccountRequest\x1a\x11.qrpay.v1.Account\x12M\n" +
+	"\fListAccounts\x12\x1d.qrpay.v1.ListAccountsRequest\x1a\x1e.qrpay.v1.ListAccountsResponse\x12P\n" +
+	"\x14SetAccountRequisites\x12\x1b.qrpay.v1.AccountRequisites\x1a\x1b.qrpay.v1.AccountRequisites\x12Z\n" +
+	"\x14GetAccountRequisites\x12%.qrpay.v1.GetAccountRequisitesRequest\x1a\x1b.qrpay.v1.AccountRequisites\x12H\n" +
+	"\x0eGetTransaction\x12\x1f.qrpay.v1.GetTransactionRequest\x1a\x15.qrpay.v1.Transaction\x12Y\n" +
+	"\x10ListTransactions\x12!.qrpay.v1.ListTransactionsRequest\x1a\".qrpay.v1.ListTransactionsResponse\x12[\n" +
+	"\x16SubscribeAccountEvents\x12'.qrpay.v1.SubscribeAccountEventsRequest\x1a\x16.qrpay.v1.AccountEvent0\x01\x126\n" +
+	"\bGetQuote\x12\x19.qrpay.v1.GetQuoteRequest\x1a\x0f.qrpay.v1.Quote\x12N\n" +
+	"\x0fRegisterWebhook\x12 .qrpay.v1.RegisterWebhookRequest\x1a\x19.qrpay.v1.WebhookEndpoint\x12h\n" +
+	"\x15ListWebhookDeliveries\x12&.qrpay.v1.ListWebhookDeliveriesRequest\x1a'.qrpay.v1.ListWebhookDeliveriesResponse\x12Z\n" +
+	"\x15ResendWebhookDelivery\x12&.qrpay.v1.ResendWebhookDeliveryRequest\x1a\x19.qrpay.v1.WebhookDelivery2\xfc\x04\n" +
+	"\fPaymentAdmin\x12A\n" +
+	"\bSetRates\x12\x19.qrpay.v1.SetRatesRequest\x1a\x1a.qrpay.v1.SetRatesResponse\x12V\n" +
+	"\x0fSetFeeSchedules\x12 .qrpay.v1.SetFeeSchedulesRequest\x1a!.qrpay.v1.SetFeeSchedulesResponse\x12Y\n" +
+	"\x10ListFeeSchedules\x12!.qrpay.v1.ListFeeSchedulesRequest\x1a\".qrpay.v1.ListFeeSchedulesResponse\x12G\n" +
+	"\x11SetTransferLimits\x12\x18.qrpay.v1.TransferLimits\x1a\x18.qrpay.v1.TransferLimits\x12_\n" +
+	"\x12ListTransferLimits\x12#.qrpay.v1.ListTransferLimitsRequest\x1a$.qrpay.v1.ListTransferLimitsResponse\x12B\n" +
+	"\rFreezeAccount\x12\x1e.qrpay.v1.FreezeAccountRequest\x1a\x11.qrpay.v1.Account\x12F\n" +
+	"\x0fUnfreezeAccount\x12 .qrpay.v1.UnfreezeAccountRequest\x1a\x11.qrpay.v1.Account\x12@\n" +
+	"\fCloseAccount\x12\x1d.qrpay.v1.CloseAccountRequest\x1a\x11.qrpay.v1.AccountB*Z(github.com/Xausdorf/qr-pay-hub/gen/pb;pbb\x06proto3"
 
 var (
 	file_proto_payment_service_proto_rawDescOnce sync.Once
@@ -235,22 +4955,204 @@ func file_proto_payment_service_proto_rawDescGZIP() []byte {
 	return file_proto_payment_service_proto_rawDescData
 }
 
-var file_proto_payment_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_payment_service_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_proto_payment_service_proto_enumTypes = make([]protoimpl.EnumInfo, 11)
+var file_proto_payment_service_proto_msgTypes = make([]protoimpl.MessageInfo, 56)
 var file_proto_payment_service_proto_goTypes = []any{
-	(TransactionStatus)(0),  // 0: qrpay.v1.TransactionStatus
-	(*PaymentRequest)(nil),  // 1: qrpay.v1.PaymentRequest
-	(*PaymentResponse)(nil), // 2: qrpay.v1.PaymentResponse
+	(TransferType)(0),                     // 0: qrpay.v1.TransferType
+	(TransactionStatus)(0),                // 1: qrpay.v1.TransactionStatus
+	(AccountStatus)(0),                    // 2: qrpay.v1.AccountStatus
+	(AccountKind)(0),                      // 3: qrpay.v1.AccountKind
+	(AuthorizationStatus)(0),              // 4: qrpay.v1.AuthorizationStatus
+	(TransactionDirection)(0),             // 5: qrpay.v1.TransactionDirection
+	(WebhookDeliveryStatus)(0),            // 6: qrpay.v1.WebhookDeliveryStatus
+	(BatchMode)(0),                        // 7: qrpay.v1.BatchMode
+	(BatchStatus)(0),                      // 8: qrpay.v1.BatchStatus
+	(BatchItemStatus)(0),                  // 9: qrpay.v1.BatchItemStatus
+	(PaymentIntentStatus)(0),              // 10: qrpay.v1.PaymentIntentStatus
+	(*PaymentRequest)(nil),                // 11: qrpay.v1.PaymentRequest
+	(*RefundRequest)(nil),                 // 12: qrpay.v1.RefundRequest
+	(*PaymentResponse)(nil),               // 13: qrpay.v1.PaymentResponse
+	(*Account)(nil),                       // 14: qrpay.v1.Account
+	(*AccountRequisites)(nil),             // 15: qrpay.v1.AccountRequisites
+	(*GetAccountRequisitesRequest)(nil),   // 16: qrpay.v1.GetAccountRequisitesRequest
+	(*AuthorizeRequest)(nil),              // 17: qrpay.v1.AuthorizeRequest
+	(*CaptureRequest)(nil),                // 18: qrpay.v1.CaptureRequest
+	(*VoidAuthorizationRequest)(nil),      // 19: qrpay.v1.VoidAuthorizationRequest
+	(*Authorization)(nil),                 // 20: qrpay.v1.Authorization
+	(*CreateAccountRequest)(nil),          // 21: qrpay.v1.CreateAccountRequest
+	(*GetAccountRequest)(nil),             // 22: qrpay.v1.GetAccountRequest
+	(*ListAccountsRequest)(nil),           // 23: qrpay.v1.ListAccountsRequest
+	(*ListAccountsResponse)(nil),          // 24: qrpay.v1.ListAccountsResponse
+	(*Transaction)(nil),                   // 25: qrpay.v1.Transaction
+	(*GetTransactionRequest)(nil),         // 26: qrpay.v1.GetTransactionRequest
+	(*ListTransactionsRequest)(nil),       // 27: qrpay.v1.ListTransactionsRequest
+	(*ListTransactionsResponse)(nil),      // 28: qrpay.v1.ListTransactionsResponse
+	(*GetQuoteRequest)(nil),               // 29: qrpay.v1.GetQuoteRequest
+	(*Quote)(nil),                         // 30: qrpay.v1.Quote
+	(*Rate)(nil),                          // 31: qrpay.v1.Rate
+	(*SetRatesRequest)(nil),               // 32: qrpay.v1.SetRatesRequest
+	(*SetRatesResponse)(nil),              // 33: qrpay.v1.SetRatesResponse
+	(*FeeSchedule)(nil),                   // 34: qrpay.v1.FeeSchedule
+	(*SetFeeSchedulesRequest)(nil),        // 35: qrpay.v1.SetFeeSchedulesRequest
+	(*SetFeeSchedulesResponse)(nil),       // 36: qrpay.v1.SetFeeSchedulesResponse
+	(*ListFeeSchedulesRequest)(nil),       // 37: qrpay.v1.ListFeeSchedulesRequest
+	(*ListFeeSchedulesResponse)(nil),      // 38: qrpay.v1.ListFeeSchedulesResponse
+	(*TransferLimits)(nil),                // 39: qrpay.v1.TransferLimits
+	(*ListTransferLimitsRequest)(nil),     // 40: qrpay.v1.ListTransferLimitsRequest
+	(*ListTransferLimitsResponse)(nil),    // 41: qrpay.v1.ListTransferLimitsResponse
+	(*FreezeAccountRequest)(nil),          // 42: qrpay.v1.FreezeAccountRequest
+	(*UnfreezeAccountRequest)(nil),        // 43: qrpay.v1.UnfreezeAccountRequest
+	(*CloseAccountRequest)(nil),           // 44: qrpay.v1.CloseAccountRequest
+	(*RegisterWebhookRequest)(nil),        // 45: qrpay.v1.RegisterWebhookRequest
+	(*WebhookEndpoint)(nil),               // 46: qrpay.v1.WebhookEndpoint
+	(*WebhookDelivery)(nil),               // 47: qrpay.v1.WebhookDelivery
+	(*ListWebhookDeliveriesRequest)(nil),  // 48: qrpay.v1.ListWebhookDeliveriesRequest
+	(*ListWebhookDeliveriesResponse)(nil), // 49: qrpay.v1.ListWebhookDeliveriesResponse
+	(*ResendWebhookDeliveryRequest)(nil),  // 50: qrpay.v1.ResendWebhookDeliveryRequest
+	(*SubscribeAccountEventsRequest)(nil), // 51: qrpay.v1.SubscribeAccountEventsRequest
+	(*AccountEvent)(nil),                  // 52: qrpay.v1.AccountEvent
+	(*BatchTransfer)(nil),                 // 53: qrpay.v1.BatchTransfer
+	(*BatchRequest)(nil),                  // 54: qrpay.v1.BatchRequest
+	(*BatchItem)(nil),                     // 55: qrpay.v1.BatchItem
+	(*Batch)(nil),                         // 56: qrpay.v1.Batch
+	(*GetBatchRequest)(nil),               // 57: qrpay.v1.GetBatchRequest
+	(*SplitLeg)(nil),                      // 58: qrpay.v1.SplitLeg
+	(*SplitPaymentRequest)(nil),           // 59: qrpay.v1.SplitPaymentRequest
+	(*SplitPayment)(nil),                  // 60: qrpay.v1.SplitPayment
+	(*GetSplitPaymentRequest)(nil),        // 61: qrpay.v1.GetSplitPaymentRequest
+	(*CreatePaymentIntentRequest)(nil),    // 62: qrpay.v1.CreatePaymentIntentRequest
+	(*PaymentIntent)(nil),                 // 63: qrpay.v1.PaymentIntent
+	(*GetPaymentIntentRequest)(nil),       // 64: qrpay.v1.GetPaymentIntentRequest
+	(*CancelPaymentIntentRequest)(nil),    // 65: qrpay.v1.CancelPaymentIntentRequest
+	(*PayPaymentIntentRequest)(nil),       // 66: qrpay.v1.PayPaymentIntentRequest
+	(*timestamppb.Timestamp)(nil),         // 67: google.protobuf.Timestamp
 }
 var file_proto_payment_service_proto_depIdxs = []int32{
-	0, // 0: qrpay.v1.PaymentResponse.status:type_name -> qrpay.v1.TransactionStatus
-	1, // 1: qrpay.v1.PaymentProcessor.ProcessPayment:input_type -> qrpay.v1.PaymentRequest
-	2, // 2: qrpay.v1.PaymentProcessor.ProcessPayment:output_type -> qrpay.v1.PaymentResponse
-	2, // [2:3] is the sub-list for method output_type
-	1, // [1:2] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	0,  // 0: qrpay.v1.PaymentRequest.transfer_type:type_name -> qrpay.v1.TransferType
+	1,  // 1: qrpay.v1.PaymentResponse.status:type_name -> qrpay.v1.TransactionStatus
+	67, // 2: qrpay.v1.Account.created_at:type_name -> google.protobuf.Timestamp
+	3,  // 3: qrpay.v1.Account.kind:type_name -> qrpay.v1.AccountKind
+	2,  // 4: qrpay.v1.Account.status:type_name -> qrpay.v1.AccountStatus
+	67, // 5: qrpay.v1.Account.status_changed_at:type_name -> google.protobuf.Timestamp
+	67, // 6: qrpay.v1.AccountRequisites.updated_at:type_name -> google.protobuf.Timestamp
+	4,  // 7: qrpay.v1.Authorization.status:type_name -> qrpay.v1.AuthorizationStatus
+	67, // 8: qrpay.v1.Authorization.expires_at:type_name -> google.protobuf.Timestamp
+	67, // 9: qrpay.v1.Authorization.created_at:type_name -> google.protobuf.Timestamp
+	14, // 10: qrpay.v1.ListAccountsResponse.accounts:type_name -> qrpay.v1.Account
+	1,  // 11: qrpay.v1.Transaction.status:type_name -> qrpay.v1.TransactionStatus
+	67, // 12: qrpay.v1.Transaction.created_at:type_name -> google.protobuf.Timestamp
+	5,  // 13: qrpay.v1.ListTransactionsRequest.direction:type_name -> qrpay.v1.TransactionDirection
+	1,  // 14: qrpay.v1.ListTransactionsRequest.status:type_name -> qrpay.v1.TransactionStatus
+	67, // 15: qrpay.v1.ListTransactionsRequest.created_after:type_name -> google.protobuf.Timestamp
+	67, // 16: qrpay.v1.ListTransactionsRequest.created_before:type_name -> google.protobuf.Timestamp
+	25, // 17: qrpay.v1.ListTransactionsResponse.transactions:type_name -> qrpay.v1.Transaction
+	67, // 18: qrpay.v1.Quote.expires_at:type_name -> google.protobuf.Timestamp
+	31, // 19: qrpay.v1.SetRatesRequest.rates:type_name -> qrpay.v1.Rate
+	0,  // 20: qrpay.v1.FeeSchedule.transfer_type:type_name -> qrpay.v1.TransferType
+	67, // 21: qrpay.v1.FeeSchedule.updated_at:type_name -> google.protobuf.Timestamp
+	34, // 22: qrpay.v1.SetFeeSchedulesRequest.schedules:type_name -> qrpay.v1.FeeSchedule
+	34, // 23: qrpay.v1.ListFeeSchedulesResponse.schedules:type_name -> qrpay.v1.FeeSchedule
+	67, // 24: qrpay.v1.TransferLimits.updated_at:type_name -> google.protobuf.Timestamp
+	39, // 25: qrpay.v1.ListTransferLimitsResponse.limits:type_name -> qrpay.v1.TransferLimits
+	67, // 26: qrpay.v1.WebhookEndpoint.created_at:type_name -> google.protobuf.Timestamp
+	6,  // 27: qrpay.v1.WebhookDelivery.status:type_name -> qrpay.v1.WebhookDeliveryStatus
+	67, // 28: qrpay.v1.WebhookDelivery.next_attempt_at:type_name -> google.protobuf.Timestamp
+	67, // 29: qrpay.v1.WebhookDelivery.created_at:type_name -> google.protobuf.Timestamp
+	67, // 30: qrpay.v1.WebhookDelivery.delivered_at:type_name -> google.protobuf.Timestamp
+	6,  // 31: qrpay.v1.ListWebhookDeliveriesRequest.status:type_name -> qrpay.v1.WebhookDeliveryStatus
+	47, // 32: qrpay.v1.ListWebhookDeliveriesResponse.deliveries:type_name -> qrpay.v1.WebhookDelivery
+	5,  // 33: qrpay.v1.AccountEvent.direction:type_name -> qrpay.v1.TransactionDirection
+	25, // 34: qrpay.v1.AccountEvent.transaction:type_name -> qrpay.v1.Transaction
+	0,  // 35: qrpay.v1.BatchTransfer.transfer_type:type_name -> qrpay.v1.TransferType
+	7,  // 36: qrpay.v1.BatchRequest.mode:type_name -> qrpay.v1.BatchMode
+	53, // 37: qrpay.v1.BatchRequest.transfers:type_name -> qrpay.v1.BatchTransfer
+	53, // 38: qrpay.v1.BatchItem.transfer:type_name -> qrpay.v1.BatchTransfer
+	9,  // 39: qrpay.v1.BatchItem.status:type_name -> qrpay.v1.BatchItemStatus
+	7,  // 40: qrpay.v1.Batch.mode:type_name -> qrpay.v1.BatchMode
+	8,  // 41: qrpay.v1.Batch.status:type_name -> qrpay.v1.BatchStatus
+	55, // 42: qrpay.v1.Batch.items:type_name -> qrpay.v1.BatchItem
+	67, // 43: qrpay.v1.Batch.created_at:type_name -> google.protobuf.Timestamp
+	67, // 44: qrpay.v1.Batch.completed_at:type_name -> google.protobuf.Timestamp
+	58, // 45: qrpay.v1.SplitPaymentRequest.legs:type_name -> qrpay.v1.SplitLeg
+	0,  // 46: qrpay.v1.SplitPaymentRequest.transfer_type:type_name -> qrpay.v1.TransferType
+	58, // 47: qrpay.v1.SplitPayment.legs:type_name -> qrpay.v1.SplitLeg
+	1,  // 48: qrpay.v1.SplitPayment.status:type_name -> qrpay.v1.TransactionStatus
+	67, // 49: qrpay.v1.SplitPayment.created_at:type_name -> google.protobuf.Timestamp
+	10, // 50: qrpay.v1.PaymentIntent.status:type_name -> qrpay.v1.PaymentIntentStatus
+	67, // 51: qrpay.v1.PaymentIntent.expires_at:type_name -> google.protobuf.Timestamp
+	67, // 52: qrpay.v1.PaymentIntent.created_at:type_name -> google.protobuf.Timestamp
+	67, // 53: qrpay.v1.PaymentIntent.paid_at:type_name -> google.protobuf.Timestamp
+	11, // 54: qrpay.v1.PaymentProcessor.ProcessPayment:input_type -> qrpay.v1.PaymentRequest
+	12, // 55: qrpay.v1.PaymentProcessor.RefundPayment:input_type -> qrpay.v1.RefundRequest
+	17, // 56: qrpay.v1.PaymentProcessor.AuthorizePayment:input_type -> qrpay.v1.AuthorizeRequest
+	18, // 57: qrpay.v1.PaymentProcessor.CapturePayment:input_type -> qrpay.v1.CaptureRequest
+	19, // 58: qrpay.v1.PaymentProcessor.VoidAuthorization:input_type -> qrpay.v1.VoidAuthorizationRequest
+	54, // 59: qrpay.v1.PaymentProcessor.ProcessBatch:input_type -> qrpay.v1.BatchRequest
+	57, // 60: qrpay.v1.PaymentProcessor.GetBatch:input_type -> qrpay.v1.GetBatchRequest
+	59, // 61: qrpay.v1.PaymentProcessor.ProcessSplitPayment:input_type -> qrpay.v1.SplitPaymentRequest
+	61, // 62: qrpay.v1.PaymentProcessor.GetSplitPayment:input_type -> qrpay.v1.GetSplitPaymentRequest
+	62, // 63: qrpay.v1.PaymentProcessor.CreatePaymentIntent:input_type -> qrpay.v1.CreatePaymentIntentRequest
+	64, // 64: qrpay.v1.PaymentProcessor.GetPaymentIntent:input_type -> qrpay.v1.GetPaymentIntentRequest
+	65, // 65: qrpay.v1.PaymentProcessor.CancelPaymentIntent:input_type -> qrpay.v1.CancelPaymentIntentRequest
+	66, // 66: qrpay.v1.PaymentProcessor.PayPaymentIntent:input_type -> qrpay.v1.PayPaymentIntentRequest
+	21, // 67: qrpay.v1.PaymentProcessor.CreateAccount:input_type -> qrpay.v1.CreateAccountRequest
+	22, // 68: qrpay.v1.PaymentProcessor.GetAccount:input_type -> qrpay.v1.GetAccountRequest
+	23, // 69: qrpay.v1.PaymentProcessor.ListAccounts:input_type -> qrpay.v1.ListAccountsRequest
+	15, // 70: qrpay.v1.PaymentProcessor.SetAccountRequisites:input_type -> qrpay.v1.AccountRequisites
+	16, // 71: qrpay.v1.PaymentProcessor.GetAccountRequisites:input_type -> qrpay.v1.GetAccountRequisitesRequest
+	26, // 72: qrpay.v1.PaymentProcessor.GetTransaction:input_type -> qrpay.v1.GetTransactionRequest
+	27, // 73: qrpay.v1.PaymentProcessor.ListTransactions:input_type -> qrpay.v1.ListTransactionsRequest
+	51, // 74: qrpay.v1.PaymentProcessor.SubscribeAccountEvents:input_type -> qrpay.v1.SubscribeAccountEventsRequest
+	29, // 75: qrpay.v1.PaymentProcessor.GetQuote:input_type -> qrpay.v1.GetQuoteRequest
+	45, // 76: qrpay.v1.PaymentProcessor.RegisterWebhook:input_type -> qrpay.v1.RegisterWebhookRequest
+	48, // 77: qrpay.v1.PaymentProcessor.ListWebhookDeliveries:input_type -> qrpay.v1.ListWebhookDeliveriesRequest
+	50, // 78: qrpay.v1.PaymentProcessor.ResendWebhookDelivery:input_type -> qrpay.v1.ResendWebhookDeliveryRequest
+	32, // 79: qrpay.v1.PaymentAdmin.SetRates:input_type -> qrpay.v1.SetRatesRequest
+	35, // 80: qrpay.v1.PaymentAdmin.SetFeeSchedules:input_type -> qrpay.v1.SetFeeSchedulesRequest
+	37, // 81: qrpay.v1.PaymentAdmin.ListFeeSchedules:input_type -> qrpay.v1.ListFeeSchedulesRequest
+	39, // 82: qrpay.v1.PaymentAdmin.SetTransferLimits:input_type -> qrpay.v1.TransferLimits
+	40, // 83: qrpay.v1.PaymentAdmin.ListTransferLimits:input_type -> qrpay.v1.ListTransferLimitsRequest
+	42, // 84: qrpay.v1.PaymentAdmin.FreezeAccount:input_type -> qrpay.v1.FreezeAccountRequest
+	43, // 85: qrpay.v1.PaymentAdmin.UnfreezeAccount:input_type -> qrpay.v1.UnfreezeAccountRequest
+	44, // 86: qrpay.v1.PaymentAdmin.CloseAccount:input_type -> qrpay.v1.CloseAccountRequest
+	13, // 87: qrpay.v1.PaymentProcessor.ProcessPayment:output_type -> qrpay.v1.PaymentResponse
+	13, // 88: qrpay.v1.PaymentProcessor.RefundPayment:output_type -> qrpay.v1.PaymentResponse
+	20, // 89: qrpay.v1.PaymentProcessor.AuthorizePayment:output_type -> qrpay.v1.Authorization
+	13, // 90: qrpay.v1.PaymentProcessor.CapturePayment:output_type -> qrpay.v1.PaymentResponse
+	20, // 91: qrpay.v1.PaymentProcessor.VoidAuthorization:output_type -> qrpay.v1.Authorization
+	56, // 92: qrpay.v1.PaymentProcessor.ProcessBatch:output_type -> qrpay.v1.Batch
+	56, // 93: qrpay.v1.PaymentProcessor.GetBatch:output_type -> qrpay.v1.Batch
+	60, // 94: qrpay.v1.PaymentProcessor.ProcessSplitPayment:output_type -> qrpay.v1.SplitPayment
+	60, // 95: qrpay.v1.PaymentProcessor.GetSplitPayment:output_type -> qrpay.v1.SplitPayment
+	63, // 96: qrpay.v1.PaymentProcessor.CreatePaymentIntent:output_type -> qrpay.v1.PaymentIntent
+	63, // 97: qrpay.v1.PaymentProcessor.GetPaymentIntent:output_type -> qrpay.v1.PaymentIntent
+	63, // 98: qrpay.v1.PaymentProcessor.CancelPaymentIntent:output_type -> qrpay.v1.PaymentIntent
+	13, // 99: qrpay.v1.PaymentProcessor.PayPaymentIntent:output_type -> qrpay.v1.PaymentResponse
+	14, // 100: qrpay.v1.PaymentProcessor.CreateAccount:output_type -> qrpay.v1.Account
+	14, // 101: qrpay.v1.PaymentProcessor.GetAccount:output_type -> qrpay.v1.Account
+	24, // 102: qrpay.v1.PaymentProcessor.ListAccounts:output_type -> qrpay.v1.ListAccountsResponse
+	15, // 103: qrpay.v1.PaymentProcessor.SetAccountRequisites:output_type -> qrpay.v1.AccountRequisites
+	15, // 104: qrpay.v1.PaymentProcessor.GetAccountRequisites:output_type -> qrpay.v1.AccountRequisites
+	25, // 105: qrpay.v1.PaymentProcessor.GetTransaction:output_type -> qrpay.v1.Transaction
+	28, // 106: qrpay.v1.PaymentProcessor.ListTransactions:output_type -> qrpay.v1.ListTransactionsResponse
+	52, // 107: qrpay.v1.PaymentProcessor.SubscribeAccountEvents:output_type -> qrpay.v1.AccountEvent
+	30, // 108: qrpay.v1.PaymentProcessor.GetQuote:output_type -> qrpay.v1.Quote
+	46, // 109: qrpay.v1.PaymentProcessor.RegisterWebhook:output_type -> qrpay.v1.WebhookEndpoint
+	49, // 110: qrpay.v1.PaymentProcessor.ListWebhookDeliveries:output_type -> qrpay.v1.ListWebhookDeliveriesResponse
+	47, // 111: qrpay.v1.PaymentProcessor.ResendWebhookDelivery:output_type -> qrpay.v1.WebhookDelivery
+	33, // 112: qrpay.v1.PaymentAdmin.SetRates:output_type -> qrpay.v1.SetRatesResponse
+	36, // 113: qrpay.v1.PaymentAdmin.SetFeeSchedules:output_type -> qrpay.v1.SetFeeSchedulesResponse
+	38, // 114: qrpay.v1.PaymentAdmin.ListFeeSchedules:output_type -> qrpay.v1.ListFeeSchedulesResponse
+	39, // 115: qrpay.v1.PaymentAdmin.SetTransferLimits:output_type -> qrpay.v1.TransferLimits
+	41, // 116: qrpay.v1.PaymentAdmin.ListTransferLimits:output_type -> qrpay.v1.ListTransferLimitsResponse
+	14, // 117: qrpay.v1.PaymentAdmin.FreezeAccount:output_type -> qrpay.v1.Account
+	14, // 118: qrpay.v1.PaymentAdmin.UnfreezeAccount:output_type -> qrpay.v1.Account
+	14, // 119: qrpay.v1.PaymentAdmin.CloseAccount:output_type -> qrpay.v1.Account
+	87, // [87:120] is the sub-list for method output_type
+	54, // [54:87] is the sub-list for method input_type
+	54, // [54:54] is the sub-list for extension type_name
+	54, // [54:54] is the sub-list for extension extendee
+	0,  // [0:54] is the sub-list for field type_name
 }
 
 func init() { file_proto_payment_service_proto_init() }
@@ -263,10 +5165,10 @@ func file_proto_payment_service_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_payment_service_proto_rawDesc), len(file_proto_payment_service_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   2,
+			NumEnums:      11,
+			NumMessages:   56,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_proto_payment_service_proto_goTypes,
 		DependencyIndexes: file_proto_payment_service_proto_depIdxs,
@@ -0,0 +1,179 @@
+package http //nolint:revive // directory-based package name, imported with alias
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	domainaccount "github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/account"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/usecase/account"
+)
+
+type CreateAccountRequest struct {
+	Currency string `json:"currency"`
+	Tier     string `json:"tier"`
+}
+
+type AccountResponse struct {
+	ID           string    `json:"id"`
+	Currency     string    `json:"currency"`
+	Tier         string    `json:"tier"`
+	Balance      int64     `json:"balance"`
+	Held         int64     `json:"held"`
+	Available    int64     `json:"available"`
+	Status       string    `json:"status"`
+	StatusReason string    `json:"status_reason,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type ListAccountsResponse struct {
+	Accounts      []AccountResponse `json:"accounts"`
+	NextPageToken string            `json:"next_page_token,omitempty"`
+}
+
+func (h *Handler) HandleCreateAccount(w http.ResponseWriter, r *http.Request) {
+	// The body is optional: without it the account gets the default currency
+	// and tier.
+	var req CreateAccountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, `{"error":"invalid json"}`, http.StatusBadRequest)
+		return
+	}
+
+	acc, err := h.accountUC.Create(r.Context(), req.Currency, req.Tier)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toAccountResponse(*acc))
+}
+
+func (h *Handler) HandleGetAccount(w http.ResponseWriter, r *http.Request) {
+	acc, err := h.accountUC.Get(r.Context(), chi.URLParam(r, "account_id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAccountResponse(*acc))
+}
+
+type RequisitesRequest struct {
+	Name        string `json:"name"`
+	PersonalAcc string `json:"personal_acc"`
+	BankName    string `json:"bank_name"`
+	BIC         string `json:"bic"`
+	CorrespAcc  string `json:"corresp_acc,omitempty"`
+	PayeeINN    string `json:"payee_inn,omitempty"`
+	KPP         string `json:"kpp,omitempty"`
+}
+
+type RequisitesResponse struct {
+	AccountID   string    `json:"account_id"`
+	Name        string    `json:"name"`
+	PersonalAcc string    `json:"personal_acc"`
+	BankName    string    `json:"bank_name"`
+	BIC         string    `json:"bic"`
+	CorrespAcc  string    `json:"corresp_acc,omitempty"`
+	PayeeINN    string    `json:"payee_inn,omitempty"`
+	KPP         string    `json:"kpp,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (h *Handler) HandleSetRequisites(w http.ResponseWriter, r *http.Request) {
+	var req RequisitesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, `{"error":"invalid json"}`, http.StatusBadRequest)
+		return
+	}
+
+	requisites, err := h.accountUC.SetRequisites(r.Context(), chi.URLParam(r, "account_id"), domainaccount.Requisites{
+		Name:        req.Name,
+		PersonalAcc: req.PersonalAcc,
+		BankName:    req.BankName,
+		BIC:         req.BIC,
+		CorrespAcc:  req.CorrespAcc,
+		PayeeINN:    req.PayeeINN,
+		KPP:         req.KPP,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toRequisitesResponse(*requisites))
+}
+
+func (h *Handler) HandleGetRequisites(w http.ResponseWriter, r *http.Request) {
+	requisites, err := h.accountUC.GetRequisites(r.Context(), chi.URLParam(r, "account_id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toRequisitesResponse(*requisites))
+}
+
+func (h *Handler) HandleListAccounts(w http.ResponseWriter, r *http.Request) {
+	pageSize := 0
+	if v := r.URL.Query().Get("page_size"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, `{"error":"invalid page_size"}`, http.StatusBadRequest)
+			return
+		}
+		pageSize = n
+	}
+
+	page, err := h.accountUC.List(r.Context(), account.ListRequest{
+		PageSize:  pageSize,
+		PageToken: r.URL.Query().Get("page_token"),
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := ListAccountsResponse{
+		Accounts:      make([]AccountResponse, 0, len(page.Accounts)),
+		NextPageToken: page.NextPageToken,
+	}
+	for _, acc := range page.Accounts {
+		resp.Accounts = append(resp.Accounts, toAccountResponse(acc))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func toAccountResponse(a domainaccount.Account) AccountResponse {
+	return AccountResponse{
+		ID:           a.ID.String(),
+		Currency:     a.Currency,
+		Tier:         a.Tier,
+		Balance:      a.Balance,
+		Held:         a.Held,
+		Available:    a.Available,
+		Status:       a.Status,
+		StatusReason: a.StatusReason,
+		CreatedAt:    a.CreatedAt,
+	}
+}
+
+func toRequisitesResponse(r domainaccount.Requisites) RequisitesResponse {
+	return RequisitesResponse{
+		AccountID:   r.AccountID.String(),
+		Name:        r.Name,
+		PersonalAcc: r.PersonalAcc,
+		BankName:    r.BankName,
+		BIC:         r.BIC,
+		CorrespAcc:  r.CorrespAcc,
+		PayeeINN:    r.PayeeINN,
+		KPP:         r.KPP,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
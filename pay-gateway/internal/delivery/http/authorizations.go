@@ -0,0 +1,118 @@
+package http //nolint:revive // directory-based package name, imported with alias
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/payment"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/usecase/pay"
+)
+
+type CaptureRequest struct {
+	Amount int64 `json:"amount"`
+}
+
+type AuthorizationResponse struct {
+	ID                   string    `json:"id"`
+	FromID               string    `json:"from_id"`
+	ToID                 string    `json:"to_id"`
+	Amount               int64     `json:"amount"`
+	Currency             string    `json:"currency"`
+	CapturedAmount       int64     `json:"captured_amount"`
+	Status               string    `json:"status"`
+	CaptureTransactionID string    `json:"capture_transaction_id,omitempty"`
+	ExpiresAt            time.Time `json:"expires_at"`
+	CreatedAt            time.Time `json:"created_at"`
+}
+
+func (h *Handler) HandleAuthorize(w http.ResponseWriter, r *http.Request) {
+	idempotencyKey := r.Header.Get("X-Idempotency-Key")
+	if idempotencyKey == "" {
+		http.Error(w, `{"error":"X-Idempotency-Key header required"}`, http.StatusBadRequest)
+		return
+	}
+
+	var req PayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, `{"error":"invalid json"}`, http.StatusBadRequest)
+		return
+	}
+
+	auth, err := h.payUC.Authorize(r.Context(), pay.AuthorizeRequest{
+		IdempotencyKey: idempotencyKey,
+		FromID:         req.FromID,
+		ToID:           req.ToID,
+		Amount:         req.Amount,
+		Currency:       req.Currency,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toAuthorizationResponse(auth))
+}
+
+func (h *Handler) HandleCapture(w http.ResponseWriter, r *http.Request) {
+	idempotencyKey := r.Header.Get("X-Idempotency-Key")
+	if idempotencyKey == "" {
+		http.Error(w, `{"error":"X-Idempotency-Key header required"}`, http.StatusBadRequest)
+		return
+	}
+
+	// The body is optional: without it the full authorized amount is captured.
+	var req CaptureRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, `{"error":"invalid json"}`, http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.payUC.Capture(r.Context(), pay.CaptureRequest{
+		IdempotencyKey:  idempotencyKey,
+		AuthorizationID: chi.URLParam(r, "authorization_id"),
+		Amount:          req.Amount,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, PayResponse{
+		TransactionID: resp.TransactionID,
+		Status:        resp.Status,
+		Error:         resp.Error,
+		FailureReason: resp.FailureReason,
+		Fee:           resp.Fee,
+		FeeBearer:     resp.FeeBearer,
+	})
+}
+
+func (h *Handler) HandleVoid(w http.ResponseWriter, r *http.Request) {
+	auth, err := h.payUC.Void(r.Context(), chi.URLParam(r, "authorization_id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAuthorizationResponse(auth))
+}
+
+func toAuthorizationResponse(a *payment.Authorization) AuthorizationResponse {
+	return AuthorizationResponse{
+		ID:                   a.ID.String(),
+		FromID:               a.FromAccountID.String(),
+		ToID:                 a.ToAccountID.String(),
+		Amount:               a.Amount,
+		Currency:             a.Currency,
+		CapturedAmount:       a.CapturedAmount,
+		Status:               a.Status,
+		CaptureTransactionID: a.CaptureTransactionID,
+		ExpiresAt:            a.ExpiresAt,
+		CreatedAt:            a.CreatedAt,
+	}
+}
@@ -0,0 +1,91 @@
+package http //nolint:revive // directory-based package name, imported with alias
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/transaction"
+)
+
+const (
+	// eventsKeepAlive keeps idle streams from being closed by proxies.
+	eventsKeepAlive = 15 * time.Second
+	// eventsRetry is how long browsers wait before reconnecting a stream.
+	eventsRetry = 3 * time.Second
+)
+
+type AccountEventResponse struct {
+	Direction   string              `json:"direction"`
+	Transaction TransactionResponse `json:"transaction"`
+}
+
+type received struct {
+	event *transaction.Event
+	err   error
+}
+
+// HandleAccountEvents relays an account's transactions as server-sent events
+// while the client stays connected. Errors before the stream opens are
+// reported as usual; once it is open, a failure simply ends it and the client
+// reconnects with the Last-Event-ID header, from which the events it missed
+// are replayed.
+func (h *Handler) HandleAccountEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	stream, err := h.historyUC.Subscribe(ctx, chi.URLParam(r, "account_id"), r.Header.Get("Last-Event-ID"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	events := make(chan received)
+	go func() {
+		for {
+			e, recvErr := stream.Recv()
+			select {
+			case events <- received{event: e, err: recvErr}:
+			case <-ctx.Done():
+				return
+			}
+			if recvErr != nil {
+				return
+			}
+		}
+	}()
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	_, _ = fmt.Fprintf(w, "retry: %d\n\n", eventsRetry.Milliseconds())
+	if err = rc.Flush(); err != nil {
+		return
+	}
+
+	keepAlive := time.NewTicker(eventsKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepAlive.C:
+			_, _ = fmt.Fprint(w, ": keepalive\n\n")
+		case rcv := <-events:
+			if rcv.err != nil {
+				return
+			}
+			data, _ := json.Marshal(AccountEventResponse{
+				Direction:   string(rcv.event.Direction),
+				Transaction: toTransactionResponse(rcv.event.Transaction),
+			})
+			_, _ = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", rcv.event.Sequence, rcv.event.Type, data)
+		}
+		if err = rc.Flush(); err != nil {
+			return
+		}
+	}
+}
@@ -2,37 +2,65 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/qrcode"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/usecase/account"
 	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/usecase/generateqr"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/usecase/history"
 	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/usecase/pay"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/usecase/scanqr"
 )
 
 type Handler struct {
 	payUC        *pay.UseCase
 	generateQRUC *generateqr.UseCase
+	scanQRUC     *scanqr.UseCase
+	accountUC    *account.UseCase
+	historyUC    *history.UseCase
 }
 
-func NewHandler(payUC *pay.UseCase, generateQRUC *generateqr.UseCase) *Handler {
+func NewHandler(
+	payUC *pay.UseCase,
+	generateQRUC *generateqr.UseCase,
+	scanQRUC *scanqr.UseCase,
+	accountUC *account.UseCase,
+	historyUC *history.UseCase,
+) *Handler {
 	return &Handler{
 		payUC:        payUC,
 		generateQRUC: generateQRUC,
+		scanQRUC:     scanQRUC,
+		accountUC:    accountUC,
+		historyUC:    historyUC,
 	}
 }
 
 type PayRequest struct {
-	FromID string `json:"from_id"`
-	ToID   string `json:"to_id"`
-	Amount int64  `json:"amount"`
+	FromID   string `json:"from_id"`
+	ToID     string `json:"to_id"`
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency,omitempty"`
+	QuoteID  string `json:"quote_id,omitempty"`
+	// Type is "p2p" (the default) or "qr_merchant".
+	Type string `json:"type,omitempty"`
+	// QRPayload is the scanned content of a signed JSON code.
+	QRPayload string `json:"qr_payload,omitempty"`
 }
 
 type PayResponse struct {
 	TransactionID string `json:"transaction_id"`
 	Status        string `json:"status"`
 	Error         string `json:"error,omitempty"`
+	FailureReason string `json:"failure_reason,omitempty"`
+	Fee           int64  `json:"fee,omitempty"`
+	FeeBearer     string `json:"fee_bearer,omitempty"`
+	ExceededLimit string `json:"exceeded_limit,omitempty"`
 }
 
 func (h *Handler) HandlePay(w http.ResponseWriter, r *http.Request) {
@@ -53,9 +81,13 @@ func (h *Handler) HandlePay(w http.ResponseWriter, r *http.Request) {
 		FromID:         req.FromID,
 		ToID:           req.ToID,
 		Amount:         req.Amount,
+		Currency:       req.Currency,
+		QuoteID:        req.QuoteID,
+		Type:           req.Type,
+		QRPayload:      req.QRPayload,
 	})
 	if err != nil {
-		http.Error(w, `{"error":"`+err.Error()+`"}`, http.StatusInternalServerError)
+		writeError(w, err)
 		return
 	}
 
@@ -64,9 +96,54 @@ func (h *Handler) HandlePay(w http.ResponseWriter, r *http.Request) {
 		TransactionID: resp.TransactionID,
 		Status:        resp.Status,
 		Error:         resp.Error,
+		FailureReason: resp.FailureReason,
+		Fee:           resp.Fee,
+		FeeBearer:     resp.FeeBearer,
+		ExceededLimit: resp.ExceededLimit,
 	})
 }
 
+type RefundRequest struct {
+	Amount int64 `json:"amount"`
+}
+
+func (h *Handler) HandleRefund(w http.ResponseWriter, r *http.Request) {
+	idempotencyKey := r.Header.Get("X-Idempotency-Key")
+	if idempotencyKey == "" {
+		http.Error(w, `{"error":"X-Idempotency-Key header required"}`, http.StatusBadRequest)
+		return
+	}
+
+	var req RefundRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, `{"error":"invalid json"}`, http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.payUC.Refund(r.Context(), pay.RefundRequest{
+		IdempotencyKey: idempotencyKey,
+		TransactionID:  chi.URLParam(r, "transaction_id"),
+		Amount:         req.Amount,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, PayResponse{
+		TransactionID: resp.TransactionID,
+		Status:        resp.Status,
+		Error:         resp.Error,
+		FailureReason: resp.FailureReason,
+	})
+}
+
+// HandleQR renders a payment code for the account. The format query
+// parameter selects the payload: "json" (the default) for the QR-Pay-Hub app,
+// "emvco" for banking apps that read EMVCo merchant-presented codes, or
+// "st00012" for Russian banking apps, which pay the account's bank details in
+// a GOST R 56042-2014 payment string. Only the JSON payload requires an
+// amount; the others let the payer enter it.
 func (h *Handler) HandleQR(w http.ResponseWriter, r *http.Request) {
 	accountID := chi.URLParam(r, "account_id")
 	if accountID == "" {
@@ -74,23 +151,62 @@ func (h *Handler) HandleQR(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	format := qrcode.Format(r.URL.Query().Get("format"))
+	switch format {
+	case "", qrcode.FormatJSON, qrcode.FormatEMVCo, qrcode.FormatGOST:
+	default:
+		http.Error(w, `{"error":"invalid format"}`, http.StatusBadRequest)
+		return
+	}
+
+	charset := qrcode.Charset(strings.ToLower(r.URL.Query().Get("charset")))
+	switch charset {
+	case "", qrcode.CharsetUTF8, qrcode.CharsetCP1251, qrcode.CharsetKOI8R:
+	default:
+		http.Error(w, `{"error":"invalid charset"}`, http.StatusBadRequest)
+		return
+	}
+
+	var amount int64
 	amountStr := r.URL.Query().Get("amount")
-	if amountStr == "" {
+	switch {
+	case amountStr != "":
+		var err error
+		amount, err = strconv.ParseInt(amountStr, 10, 64)
+		if err != nil || amount <= 0 {
+			http.Error(w, `{"error":"invalid amount"}`, http.StatusBadRequest)
+			return
+		}
+	case format == "" || format == qrcode.FormatJSON:
 		http.Error(w, `{"error":"amount query param required"}`, http.StatusBadRequest)
 		return
 	}
 
-	amount, err := strconv.ParseInt(amountStr, 10, 64)
-	if err != nil || amount <= 0 {
-		http.Error(w, `{"error":"invalid amount"}`, http.StatusBadRequest)
+	currency := strings.ToUpper(r.URL.Query().Get("currency"))
+	if currency != "" && !isCurrencyCode(currency) {
+		http.Error(w, `{"error":"invalid currency"}`, http.StatusBadRequest)
 		return
 	}
 
-	png, err := h.generateQRUC.Execute(generateqr.Request{
-		AccountID: accountID,
-		Amount:    amount,
+	png, err := h.generateQRUC.Execute(r.Context(), generateqr.Request{
+		AccountID:    accountID,
+		Amount:       amount,
+		Currency:     currency,
+		Format:       format,
+		MerchantName: r.URL.Query().Get("merchant_name"),
+		MerchantCity: r.URL.Query().Get("merchant_city"),
+		Purpose:      r.URL.Query().Get("purpose"),
+		Charset:      charset,
 	})
+	if errors.Is(err, qrcode.ErrUnencodable) {
+		writeJSON(w, http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
 	if err != nil {
+		if httpStatus(err) != http.StatusInternalServerError {
+			writeError(w, err)
+			return
+		}
 		http.Error(w, `{"error":"qr generation failed"}`, http.StatusInternalServerError)
 		return
 	}
@@ -99,3 +215,19 @@ func (h *Handler) HandleQR(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Cache-Control", "public, max-age=3600")
 	_, _ = w.Write(png)
 }
+
+const currencyCodeLen = 3
+
+// isCurrencyCode checks the shape of an ISO 4217 code; whether the currency is
+// supported is for pay-core to decide when the code is paid.
+func isCurrencyCode(code string) bool {
+	if len(code) != currencyCodeLen {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
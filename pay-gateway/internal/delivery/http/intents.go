@@ -0,0 +1,151 @@
+package http //nolint:revive // directory-based package name, imported with alias
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/payment"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/usecase/pay"
+)
+
+type IntentRequest struct {
+	ToID     string `json:"to_id"`
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency,omitempty"`
+	// TTLSeconds is how long the intent stays payable; pay-core's default
+	// applies when it is omitted.
+	TTLSeconds int32 `json:"ttl_seconds,omitempty"`
+}
+
+type IntentResponse struct {
+	ID            string     `json:"id"`
+	ToID          string     `json:"to_id"`
+	Amount        int64      `json:"amount"`
+	Currency      string     `json:"currency"`
+	Status        string     `json:"status"`
+	FromID        string     `json:"from_id,omitempty"`
+	TransactionID string     `json:"transaction_id,omitempty"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	PaidAt        *time.Time `json:"paid_at,omitempty"`
+}
+
+type PayIntentRequest struct {
+	FromID string `json:"from_id"`
+}
+
+func (h *Handler) HandleCreateIntent(w http.ResponseWriter, r *http.Request) {
+	var req IntentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, `{"error":"invalid json"}`, http.StatusBadRequest)
+		return
+	}
+
+	intent, err := h.payUC.CreateIntent(r.Context(), pay.CreateIntentRequest{
+		ToID:     req.ToID,
+		Amount:   req.Amount,
+		Currency: req.Currency,
+		TTL:      time.Duration(req.TTLSeconds) * time.Second,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toIntentResponse(intent))
+}
+
+func (h *Handler) HandleGetIntent(w http.ResponseWriter, r *http.Request) {
+	intent, err := h.payUC.GetIntent(r.Context(), chi.URLParam(r, "intent_id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toIntentResponse(intent))
+}
+
+func (h *Handler) HandleCancelIntent(w http.ResponseWriter, r *http.Request) {
+	intent, err := h.payUC.CancelIntent(r.Context(), chi.URLParam(r, "intent_id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toIntentResponse(intent))
+}
+
+// HandleIntentQR renders the code a POS terminal shows for an open intent.
+// The code is good for one payment only, so it is never cached.
+func (h *Handler) HandleIntentQR(w http.ResponseWriter, r *http.Request) {
+	intent, err := h.payUC.OpenIntent(r.Context(), chi.URLParam(r, "intent_id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	png, err := h.generateQRUC.Intent(intent.ID.String())
+	if err != nil {
+		http.Error(w, `{"error":"qr generation failed"}`, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "no-store")
+	_, _ = w.Write(png)
+}
+
+func (h *Handler) HandlePayIntent(w http.ResponseWriter, r *http.Request) {
+	idempotencyKey := r.Header.Get("X-Idempotency-Key")
+	if idempotencyKey == "" {
+		http.Error(w, `{"error":"X-Idempotency-Key header required"}`, http.StatusBadRequest)
+		return
+	}
+
+	var req PayIntentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, `{"error":"invalid json"}`, http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.payUC.PayIntent(r.Context(), pay.PayIntentRequest{
+		IdempotencyKey: idempotencyKey,
+		IntentID:       chi.URLParam(r, "intent_id"),
+		FromID:         req.FromID,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, PayResponse{
+		TransactionID: resp.TransactionID,
+		Status:        resp.Status,
+		Error:         resp.Error,
+		FailureReason: resp.FailureReason,
+		Fee:           resp.Fee,
+		FeeBearer:     resp.FeeBearer,
+		ExceededLimit: resp.ExceededLimit,
+	})
+}
+
+func toIntentResponse(i *payment.Intent) IntentResponse {
+	resp := IntentResponse{
+		ID:            i.ID.String(),
+		ToID:          i.ToAccountID.String(),
+		Amount:        i.Amount,
+		Currency:      i.Currency,
+		Status:        i.Status,
+		FromID:        i.FromAccountID,
+		TransactionID: i.TransactionID,
+		ExpiresAt:     i.ExpiresAt,
+		CreatedAt:     i.CreatedAt,
+	}
+	if !i.PaidAt.IsZero() {
+		resp.PaidAt = &i.PaidAt
+	}
+	return resp
+}
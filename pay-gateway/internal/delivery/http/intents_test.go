@@ -0,0 +1,263 @@
+package http //nolint:revive // directory-based package name, imported with alias
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/payment"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/qrcode"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/infrastructure/qrgenerator"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/infrastructure/qrscanner"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/usecase/generateqr"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/usecase/pay"
+)
+
+const (
+	payerAccount = "9b2e7d10-4c3a-4f5e-8a1b-6c7d8e9f0a1b"
+	payeeAccount = "3f1c6a52-8a0e-4a55-9a8e-2d6b1f0c7e41"
+)
+
+// intentClient keeps payment intents the way pay-core does, settling each at
+// most once; any other call panics on the nil embedded client.
+type intentClient struct {
+	payment.Client
+	intents map[uuid.UUID]*payment.Intent
+}
+
+func (c *intentClient) CreatePaymentIntent(
+	_ context.Context,
+	req payment.CreateIntentRequest,
+) (*payment.Intent, error) {
+	now := time.Now().UTC()
+	intent := &payment.Intent{
+		ID:          uuid.New(),
+		ToAccountID: req.ToAccountID,
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		Status:      payment.IntentCreated,
+		ExpiresAt:   now.Add(req.TTL),
+		CreatedAt:   now,
+	}
+	c.intents[intent.ID] = intent
+	return intent, nil
+}
+
+func (c *intentClient) GetPaymentIntent(_ context.Context, id uuid.UUID) (*payment.Intent, error) {
+	intent, ok := c.intents[id]
+	if !ok {
+		return nil, payment.ErrIntentNotFound
+	}
+	copied := *intent
+	return &copied, nil
+}
+
+func (c *intentClient) CancelPaymentIntent(ctx context.Context, id uuid.UUID) (*payment.Intent, error) {
+	intent, err := c.openIntent(id)
+	if err != nil {
+		return nil, err
+	}
+	intent.Status = payment.IntentCancelled
+	return c.GetPaymentIntent(ctx, id)
+}
+
+func (c *intentClient) PayPaymentIntent(_ context.Context, req payment.PayIntentRequest) (*payment.Response, error) {
+	intent, err := c.openIntent(req.IntentID)
+	if err != nil {
+		return nil, err
+	}
+	intent.Status = payment.IntentPaid
+	intent.FromAccountID = req.FromAccountID.String()
+	intent.TransactionID = uuid.NewString()
+	intent.PaidAt = time.Now().UTC()
+	return &payment.Response{TransactionID: intent.TransactionID, Status: "TRANSACTION_STATUS_COMPLETED"}, nil
+}
+
+func (c *intentClient) openIntent(id uuid.UUID) (*payment.Intent, error) {
+	intent, ok := c.intents[id]
+	if !ok {
+		return nil, payment.ErrIntentNotFound
+	}
+	if intent.Status != payment.IntentCreated {
+		return nil, payment.ErrIntentNotOpen
+	}
+	return intent, nil
+}
+
+func newIntentRouter() http.Handler {
+	gen := qrgenerator.NewGenerator(256, nil, 0)
+	client := &intentClient{intents: make(map[uuid.UUID]*payment.Intent)}
+	return NewRouter(&Handler{
+		payUC:        pay.NewUseCase(client, gen),
+		generateQRUC: generateqr.NewUseCase(gen, nil, qrcode.Merchant{}),
+	})
+}
+
+// serve sends a request with a JSON body, if body is not empty, and returns
+// the recorded response.
+func serve(t *testing.T, h http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	if strings.HasSuffix(path, "/pay") {
+		r.Header.Set("X-Idempotency-Key", uuid.NewString())
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func decodeIntent(t *testing.T, w *httptest.ResponseRecorder) IntentResponse {
+	t.Helper()
+	var resp IntentResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp
+}
+
+func createIntent(t *testing.T, h http.Handler) IntentResponse {
+	t.Helper()
+	w := serve(t, h, http.MethodPost, "/api/payment-intents",
+		fmt.Sprintf(`{"to_id":%q,"amount":5000,"currency":"RUB","ttl_seconds":300}`, payeeAccount))
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	return decodeIntent(t, w)
+}
+
+func TestIntents_PaidOnce(t *testing.T) {
+	h := newIntentRouter()
+	created := createIntent(t, h)
+	assert.Equal(t, payeeAccount, created.ToID)
+	assert.Equal(t, int64(5000), created.Amount)
+	assert.Equal(t, payment.IntentCreated, created.Status)
+	path := "/api/payment-intents/" + created.ID
+
+	w := serve(t, h, http.MethodGet, path, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, created, decodeIntent(t, w))
+
+	w = serve(t, h, http.MethodGet, path+"/qr", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	payload, err := qrscanner.NewScanner().Scan(w.Body.Bytes())
+	require.NoError(t, err)
+	data, format, err := qrgenerator.Decode(payload)
+	require.NoError(t, err)
+	assert.Equal(t, qrcode.FormatIntent, format)
+	assert.Equal(t, created.ID, data.IntentID)
+
+	payBody := fmt.Sprintf(`{"from_id":%q}`, payerAccount)
+	w = serve(t, h, http.MethodPost, path+"/pay", payBody)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var paid PayResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &paid))
+	assert.NotEmpty(t, paid.TransactionID)
+
+	w = serve(t, h, http.MethodGet, path, "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	got := decodeIntent(t, w)
+	assert.Equal(t, payment.IntentPaid, got.Status)
+	assert.Equal(t, payerAccount, got.FromID)
+	assert.Equal(t, paid.TransactionID, got.TransactionID)
+	assert.NotNil(t, got.PaidAt)
+
+	w = serve(t, h, http.MethodPost, path+"/pay", payBody)
+	assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	w = serve(t, h, http.MethodGet, path+"/qr", "")
+	assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	w = serve(t, h, http.MethodPost, path+"/cancel", "")
+	assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+}
+
+func TestIntents_Cancelled(t *testing.T) {
+	h := newIntentRouter()
+	path := "/api/payment-intents/" + createIntent(t, h).ID
+
+	w := serve(t, h, http.MethodPost, path+"/cancel", "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, payment.IntentCancelled, decodeIntent(t, w).Status)
+
+	w = serve(t, h, http.MethodPost, path+"/pay", fmt.Sprintf(`{"from_id":%q}`, payerAccount))
+	assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	w = serve(t, h, http.MethodGet, path+"/qr", "")
+	assert.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+}
+
+func TestIntents_BadRequests(t *testing.T) {
+	h := newIntentRouter()
+	unknown := "/api/payment-intents/" + uuid.NewString()
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{
+			name:       "create with invalid to_id",
+			method:     http.MethodPost,
+			path:       "/api/payment-intents",
+			body:       `{"to_id":"nope","amount":5000}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "create with negative ttl",
+			method:     http.MethodPost,
+			path:       "/api/payment-intents",
+			body:       fmt.Sprintf(`{"to_id":%q,"amount":5000,"ttl_seconds":-1}`, payeeAccount),
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "get invalid id",
+			method:     http.MethodGet,
+			path:       "/api/payment-intents/nope",
+			wantStatus: http.StatusBadRequest,
+		},
+		{name: "get unknown", method: http.MethodGet, path: unknown, wantStatus: http.StatusNotFound},
+		{name: "qr of unknown", method: http.MethodGet, path: unknown + "/qr", wantStatus: http.StatusNotFound},
+		{name: "cancel unknown", method: http.MethodPost, path: unknown + "/cancel", wantStatus: http.StatusNotFound},
+		{
+			name:       "pay unknown",
+			method:     http.MethodPost,
+			path:       unknown + "/pay",
+			body:       fmt.Sprintf(`{"from_id":%q}`, payerAccount),
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "pay with invalid from_id",
+			method:     http.MethodPost,
+			path:       unknown + "/pay",
+			body:       `{"from_id":"nope"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(t, h, tt.method, tt.path, tt.body)
+			assert.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestIntents_PayRequiresIdempotencyKey(t *testing.T) {
+	h := newIntentRouter()
+	path := "/api/payment-intents/" + createIntent(t, h).ID + "/pay"
+
+	r := httptest.NewRequest(http.MethodPost, path, strings.NewReader(fmt.Sprintf(`{"from_id":%q}`, payerAccount)))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+}
@@ -0,0 +1,125 @@
+package http //nolint:revive // directory-based package name, imported with alias
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/payment"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/usecase/pay"
+)
+
+type BatchTransferRequest struct {
+	FromID   string `json:"from_id"`
+	ToID     string `json:"to_id"`
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency,omitempty"`
+	Type     string `json:"type,omitempty"`
+}
+
+type BatchRequest struct {
+	// Mode is "atomic" or "best_effort".
+	Mode      string                 `json:"mode"`
+	Transfers []BatchTransferRequest `json:"transfers"`
+}
+
+type BatchItemResponse struct {
+	Position      int                  `json:"position"`
+	Transfer      BatchTransferRequest `json:"transfer"`
+	Status        string               `json:"status"`
+	TransactionID string               `json:"transaction_id,omitempty"`
+	FailureReason string               `json:"failure_reason,omitempty"`
+	Error         string               `json:"error,omitempty"`
+}
+
+type BatchResponse struct {
+	ID             string              `json:"id"`
+	Mode           string              `json:"mode"`
+	Status         string              `json:"status"`
+	SucceededCount int                 `json:"succeeded_count"`
+	FailedCount    int                 `json:"failed_count"`
+	Items          []BatchItemResponse `json:"items"`
+	CreatedAt      time.Time           `json:"created_at"`
+	CompletedAt    *time.Time          `json:"completed_at,omitempty"`
+}
+
+func (h *Handler) HandleProcessBatch(w http.ResponseWriter, r *http.Request) {
+	idempotencyKey := r.Header.Get("X-Idempotency-Key")
+	if idempotencyKey == "" {
+		http.Error(w, `{"error":"X-Idempotency-Key header required"}`, http.StatusBadRequest)
+		return
+	}
+
+	var req BatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, `{"error":"invalid json"}`, http.StatusBadRequest)
+		return
+	}
+
+	transfers := make([]pay.BatchTransfer, 0, len(req.Transfers))
+	for _, t := range req.Transfers {
+		transfers = append(transfers, pay.BatchTransfer{
+			FromID:   t.FromID,
+			ToID:     t.ToID,
+			Amount:   t.Amount,
+			Currency: t.Currency,
+			Type:     t.Type,
+		})
+	}
+
+	batch, err := h.payUC.ProcessBatch(r.Context(), pay.BatchRequest{
+		IdempotencyKey: idempotencyKey,
+		Mode:           req.Mode,
+		Transfers:      transfers,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toBatchResponse(batch))
+}
+
+func (h *Handler) HandleGetBatch(w http.ResponseWriter, r *http.Request) {
+	batch, err := h.payUC.GetBatch(r.Context(), chi.URLParam(r, "batch_id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toBatchResponse(batch))
+}
+
+func toBatchResponse(b *payment.Batch) BatchResponse {
+	resp := BatchResponse{
+		ID:             b.ID.String(),
+		Mode:           b.Mode,
+		Status:         b.Status,
+		SucceededCount: b.SucceededCount,
+		FailedCount:    b.FailedCount,
+		Items:          make([]BatchItemResponse, 0, len(b.Items)),
+		CreatedAt:      b.CreatedAt,
+	}
+	if !b.CompletedAt.IsZero() {
+		resp.CompletedAt = &b.CompletedAt
+	}
+	for _, item := range b.Items {
+		resp.Items = append(resp.Items, BatchItemResponse{
+			Position: item.Position,
+			Transfer: BatchTransferRequest{
+				FromID:   item.Transfer.FromAccountID.String(),
+				ToID:     item.Transfer.ToAccountID.String(),
+				Amount:   item.Transfer.Amount,
+				Currency: item.Transfer.Currency,
+				Type:     item.Transfer.Type,
+			},
+			Status:        item.Status,
+			TransactionID: item.TransactionID,
+			FailureReason: item.FailureReason,
+			Error:         item.ErrorMessage,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,144 @@
+package http //nolint:revive // directory-based package name, imported with alias
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/qrcode"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/usecase/scanqr"
+)
+
+// maxImageSize is the largest upload HandleDecodeQR reads, which fits a
+// full-resolution phone camera frame.
+const maxImageSize = 10 << 20
+
+type QRMerchantResponse struct {
+	Name         string `json:"name,omitempty"`
+	City         string `json:"city,omitempty"`
+	CountryCode  string `json:"country_code,omitempty"`
+	CategoryCode string `json:"category_code,omitempty"`
+}
+
+type QRPayeeResponse struct {
+	Name        string `json:"name"`
+	PersonalAcc string `json:"personal_acc"`
+	BankName    string `json:"bank_name"`
+	BIC         string `json:"bic"`
+	CorrespAcc  string `json:"corresp_acc,omitempty"`
+	PayeeINN    string `json:"payee_inn,omitempty"`
+	KPP         string `json:"kpp,omitempty"`
+}
+
+type DecodeQRResponse struct {
+	// Payload is the content of the code. A signed JSON code is paid by
+	// passing it as qr_payload to /api/pay.
+	Payload string `json:"payload"`
+	Format  string `json:"format,omitempty"`
+	// Valid is false if the code cannot be paid as it is; Errors says why.
+	Valid    bool     `json:"valid"`
+	Errors   []string `json:"errors,omitempty"`
+	ToID     string   `json:"to_id,omitempty"`
+	Amount   int64    `json:"amount,omitempty"`
+	Currency string   `json:"currency,omitempty"`
+	// ExpiresAt is when the signature of a signed JSON code expires.
+	ExpiresAt *time.Time          `json:"expires_at,omitempty"`
+	IntentID  string              `json:"intent_id,omitempty"`
+	Intent    *IntentResponse     `json:"intent,omitempty"`
+	Merchant  *QRMerchantResponse `json:"merchant,omitempty"`
+	Payee     *QRPayeeResponse    `json:"payee,omitempty"`
+	Purpose   string              `json:"purpose,omitempty"`
+	Extra     map[string]string   `json:"extra,omitempty"`
+}
+
+// HandleDecodeQR reads the code in an uploaded PNG or JPEG image, sent either
+// as the request body or as the "image" field of a multipart form, and shows
+// what paying it would pay.
+func (h *Handler) HandleDecodeQR(w http.ResponseWriter, r *http.Request) {
+	img, err := readImage(w, r)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		http.Error(w, `{"error":"image too large"}`, http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		http.Error(w, `{"error":"image required"}`, http.StatusBadRequest)
+		return
+	}
+
+	res, err := h.scanQRUC.Scan(r.Context(), img)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toDecodeQRResponse(res))
+}
+
+func readImage(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImageSize)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		img, err := io.ReadAll(r.Body)
+		if err == nil && len(img) == 0 {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return img, err
+	}
+
+	file, _, err := r.FormFile("image")
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+func toDecodeQRResponse(res *scanqr.Result) DecodeQRResponse {
+	data := res.Data
+	resp := DecodeQRResponse{
+		Payload:  res.Payload,
+		Format:   string(res.Format),
+		Valid:    len(res.Problems) == 0,
+		ToID:     data.ToAccount,
+		Amount:   data.Amount,
+		Currency: data.Currency,
+		IntentID: data.IntentID,
+		Purpose:  data.Purpose,
+		Extra:    data.Extra,
+	}
+	for _, problem := range res.Problems {
+		resp.Errors = append(resp.Errors, problem.Error())
+	}
+	if data.ExpiresAt != 0 {
+		expiresAt := time.Unix(data.ExpiresAt, 0).UTC()
+		resp.ExpiresAt = &expiresAt
+	}
+	if res.Intent != nil {
+		intent := toIntentResponse(res.Intent)
+		resp.Intent = &intent
+	}
+	if data.Merchant != (qrcode.Merchant{}) {
+		resp.Merchant = &QRMerchantResponse{
+			Name:         data.Merchant.Name,
+			City:         data.Merchant.City,
+			CountryCode:  data.Merchant.CountryCode,
+			CategoryCode: data.Merchant.CategoryCode,
+		}
+	}
+	if data.Payee != (qrcode.Payee{}) {
+		resp.Payee = &QRPayeeResponse{
+			Name:        data.Payee.Name,
+			PersonalAcc: data.Payee.PersonalAcc,
+			BankName:    data.Payee.BankName,
+			BIC:         data.Payee.BIC,
+			CorrespAcc:  data.Payee.CorrespAcc,
+			PayeeINN:    data.Payee.PayeeINN,
+			KPP:         data.Payee.KPP,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,132 @@
+package http //nolint:revive // directory-based package name, imported with alias
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/qrcode"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/infrastructure/qrgenerator"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/usecase/scanqr"
+)
+
+// imageScanner "reads" the uploaded bytes themselves as the payload, so that
+// a test can see which bytes reached the scanner.
+type imageScanner struct{}
+
+func (imageScanner) Scan(img []byte) (string, error) {
+	return string(img), nil
+}
+
+func newDecodeHandler() *Handler {
+	gen := qrgenerator.NewGenerator(0, nil, 0)
+	return &Handler{scanQRUC: scanqr.NewUseCase(imageScanner{}, gen, gen, nil)}
+}
+
+func multipartBody(t *testing.T, field string, content []byte) (*bytes.Buffer, string) {
+	t.Helper()
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile(field, "code.png")
+	require.NoError(t, err)
+	_, err = part.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, form.Close())
+	return &body, form.FormDataContentType()
+}
+
+func TestReadImage(t *testing.T) {
+	img := []byte("\x89PNG image bytes")
+	form, formType := multipartBody(t, "image", img)
+	wrongField, wrongFieldType := multipartBody(t, "file", img)
+
+	tests := []struct {
+		name        string
+		body        *bytes.Buffer
+		contentType string
+		wantErr     bool
+	}{
+		{name: "raw body", body: bytes.NewBuffer(img), contentType: "image/png"},
+		{name: "raw body without content type", body: bytes.NewBuffer(img)},
+		{name: "multipart", body: form, contentType: formType},
+		{name: "empty body", body: &bytes.Buffer{}, contentType: "image/png", wantErr: true},
+		{name: "multipart without image", body: wrongField, contentType: wrongFieldType, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/api/qr/decode", tt.body)
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+
+			got, err := readImage(httptest.NewRecorder(), r)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, img, got)
+		})
+	}
+}
+
+func TestHandleDecodeQR(t *testing.T) {
+	payload, err := qrgenerator.Encode(
+		qrcode.QRData{ToAccount: "3f1c6a52-8a0e-4a55-9a8e-2d6b1f0c7e41", Amount: 500, Currency: "RUB"},
+		qrcode.FormatJSON,
+	)
+	require.NoError(t, err)
+	form, formType := multipartBody(t, "image", []byte(payload))
+	oversized := bytes.Repeat([]byte{0}, maxImageSize+1)
+	oversizedMultipart, oversizedMultipartType := multipartBody(t, "image", oversized)
+
+	tests := []struct {
+		name        string
+		body        *bytes.Buffer
+		contentType string
+		wantStatus  int
+	}{
+		{name: "raw body", body: bytes.NewBufferString(payload), contentType: "image/png", wantStatus: http.StatusOK},
+		{name: "multipart", body: form, contentType: formType, wantStatus: http.StatusOK},
+		{name: "empty body", body: &bytes.Buffer{}, contentType: "image/png", wantStatus: http.StatusBadRequest},
+		{
+			name:        "raw body over the limit",
+			body:        bytes.NewBuffer(oversized),
+			contentType: "image/png",
+			wantStatus:  http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:        "multipart over the limit",
+			body:        oversizedMultipart,
+			contentType: oversizedMultipartType,
+			wantStatus:  http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/api/qr/decode", tt.body)
+			r.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+
+			newDecodeHandler().HandleDecodeQR(w, r)
+
+			require.Equal(t, tt.wantStatus, w.Code, w.Body.String())
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var resp DecodeQRResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, payload, resp.Payload)
+			assert.Equal(t, string(qrcode.FormatJSON), resp.Format)
+			assert.Equal(t, int64(500), resp.Amount)
+		})
+	}
+}
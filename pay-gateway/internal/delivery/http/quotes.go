@@ -0,0 +1,55 @@
+package http //nolint:revive // directory-based package name, imported with alias
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/usecase/pay"
+)
+
+type QuoteRequest struct {
+	FromCurrency string `json:"from_currency"`
+	ToCurrency   string `json:"to_currency"`
+	Amount       int64  `json:"amount"`
+}
+
+type QuoteResponse struct {
+	QuoteID         string    `json:"quote_id"`
+	SourceAmount    int64     `json:"source_amount"`
+	SourceCurrency  string    `json:"source_currency"`
+	TargetAmount    int64     `json:"target_amount"`
+	TargetCurrency  string    `json:"target_currency"`
+	Rate            string    `json:"rate"`
+	ExpiresAt       time.Time `json:"expires_at"`
+	ValidForSeconds int64     `json:"valid_for_seconds"`
+}
+
+func (h *Handler) HandleQuote(w http.ResponseWriter, r *http.Request) {
+	var req QuoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, `{"error":"invalid json"}`, http.StatusBadRequest)
+		return
+	}
+
+	quote, err := h.payUC.Quote(r.Context(), pay.QuoteRequest{
+		FromCurrency: req.FromCurrency,
+		ToCurrency:   req.ToCurrency,
+		Amount:       req.Amount,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, QuoteResponse{
+		QuoteID:         quote.ID.String(),
+		SourceAmount:    quote.SourceAmount,
+		SourceCurrency:  quote.SourceCurrency,
+		TargetAmount:    quote.TargetAmount,
+		TargetCurrency:  quote.TargetCurrency,
+		Rate:            quote.Rate,
+		ExpiresAt:       quote.ExpiresAt,
+		ValidForSeconds: int64(quote.ValidFor.Seconds()),
+	})
+}
@@ -0,0 +1,72 @@
+package http //nolint:revive // directory-based package name, imported with alias
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/payment"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/qrcode"
+)
+
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, httpStatus(err), ErrorResponse{Error: err.Error()})
+}
+
+func httpStatus(err error) int {
+	switch {
+	case errors.Is(err, payment.ErrInvalidRequest),
+		errors.Is(err, payment.ErrUnknownCurrency),
+		errors.Is(err, qrcode.ErrUnknownFormat),
+		errors.Is(err, qrcode.ErrInvalidPayload),
+		errors.Is(err, qrcode.ErrInvalidSignature),
+		errors.Is(err, qrcode.ErrSignatureExpired):
+		return http.StatusBadRequest
+	case errors.Is(err, payment.ErrAccountNotFound),
+		errors.Is(err, payment.ErrTransactionNotFound),
+		errors.Is(err, payment.ErrAuthorizationNotFound),
+		errors.Is(err, payment.ErrRateNotFound),
+		errors.Is(err, payment.ErrQuoteNotFound),
+		errors.Is(err, payment.ErrBatchNotFound),
+		errors.Is(err, payment.ErrSplitNotFound),
+		errors.Is(err, payment.ErrIntentNotFound),
+		errors.Is(err, payment.ErrEventNotFound),
+		errors.Is(err, payment.ErrRequisitesNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, payment.ErrAccountFrozen),
+		errors.Is(err, payment.ErrAccountClosed),
+		errors.Is(err, payment.ErrAuthorizationNotActive),
+		errors.Is(err, payment.ErrAuthorizationExpired),
+		errors.Is(err, payment.ErrQuoteExpired),
+		errors.Is(err, payment.ErrQuoteUsed),
+		errors.Is(err, payment.ErrIntentNotOpen),
+		errors.Is(err, payment.ErrIntentExpired),
+		errors.Is(err, payment.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, payment.ErrCurrencyMismatch),
+		errors.Is(err, payment.ErrQuoteMismatch),
+		errors.Is(err, payment.ErrInsufficientFunds),
+		errors.Is(err, payment.ErrFeeExceedsAmount),
+		errors.Is(err, payment.ErrLimitExceeded),
+		errors.Is(err, payment.ErrNotRefundable),
+		errors.Is(err, payment.ErrRefundExceedsOriginal),
+		errors.Is(err, payment.ErrCaptureExceedsAuthorized),
+		errors.Is(err, payment.ErrIdempotencyKeyReused),
+		errors.Is(err, qrcode.ErrNoCode):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, qrcode.ErrUnsupportedImage):
+		return http.StatusUnsupportedMediaType
+	default:
+		return http.StatusInternalServerError
+	}
+}
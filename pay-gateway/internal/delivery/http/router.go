@@ -14,10 +14,47 @@ func NewRouter(h *Handler) *chi.Mux {
 
 	r.Use(middleware.Logger)
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(requestTimeout))
 
+	// Event streams stay open for as long as the client listens.
+	r.Get("/api/accounts/{account_id}/events", h.HandleAccountEvents)
+
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.Timeout(requestTimeout))
+		routes(r, h)
+	})
+
+	return r
+}
+
+func routes(r chi.Router, h *Handler) {
 	r.Post("/api/pay", h.HandlePay)
+	r.Post("/api/quotes", h.HandleQuote)
 	r.Get("/api/qr/{account_id}", h.HandleQR)
+	r.Post("/api/qr/decode", h.HandleDecodeQR)
 
-	return r
+	r.Post("/api/authorizations", h.HandleAuthorize)
+	r.Post("/api/authorizations/{authorization_id}/capture", h.HandleCapture)
+	r.Post("/api/authorizations/{authorization_id}/void", h.HandleVoid)
+
+	r.Post("/api/split-payments", h.HandleSplitPayment)
+	r.Get("/api/split-payments/{split_payment_id}", h.HandleGetSplitPayment)
+
+	r.Post("/api/payment-intents", h.HandleCreateIntent)
+	r.Get("/api/payment-intents/{intent_id}", h.HandleGetIntent)
+	r.Get("/api/payment-intents/{intent_id}/qr", h.HandleIntentQR)
+	r.Post("/api/payment-intents/{intent_id}/cancel", h.HandleCancelIntent)
+	r.Post("/api/payment-intents/{intent_id}/pay", h.HandlePayIntent)
+
+	r.Post("/api/payouts/batch", h.HandleProcessBatch)
+	r.Get("/api/payouts/batch/{batch_id}", h.HandleGetBatch)
+
+	r.Post("/api/accounts", h.HandleCreateAccount)
+	r.Get("/api/accounts", h.HandleListAccounts)
+	r.Get("/api/accounts/{account_id}", h.HandleGetAccount)
+	r.Put("/api/accounts/{account_id}/requisites", h.HandleSetRequisites)
+	r.Get("/api/accounts/{account_id}/requisites", h.HandleGetRequisites)
+	r.Get("/api/accounts/{account_id}/transactions", h.HandleListTransactions)
+
+	r.Get("/api/transactions/{transaction_id}", h.HandleGetTransaction)
+	r.Post("/api/transactions/{transaction_id}/refunds", h.HandleRefund)
 }
@@ -0,0 +1,111 @@
+package http //nolint:revive // directory-based package name, imported with alias
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/payment"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/usecase/pay"
+)
+
+type SplitLegRequest struct {
+	ToID   string `json:"to_id"`
+	Amount int64  `json:"amount"`
+}
+
+type SplitRequest struct {
+	FromID string `json:"from_id"`
+	// Amount is the total the legs must sum to.
+	Amount   int64             `json:"amount"`
+	Currency string            `json:"currency,omitempty"`
+	Legs     []SplitLegRequest `json:"legs"`
+	// Type is "p2p" (the default) or "qr_merchant".
+	Type string `json:"type,omitempty"`
+}
+
+type SplitLegResponse struct {
+	ToID          string `json:"to_id"`
+	Amount        int64  `json:"amount"`
+	TransactionID string `json:"transaction_id,omitempty"`
+}
+
+type SplitResponse struct {
+	ID            string             `json:"id"`
+	FromID        string             `json:"from_id"`
+	Amount        int64              `json:"amount"`
+	Currency      string             `json:"currency"`
+	Status        string             `json:"status"`
+	Error         string             `json:"error,omitempty"`
+	FailureReason string             `json:"failure_reason,omitempty"`
+	Legs          []SplitLegResponse `json:"legs"`
+	CreatedAt     time.Time          `json:"created_at"`
+}
+
+func (h *Handler) HandleSplitPayment(w http.ResponseWriter, r *http.Request) {
+	idempotencyKey := r.Header.Get("X-Idempotency-Key")
+	if idempotencyKey == "" {
+		http.Error(w, `{"error":"X-Idempotency-Key header required"}`, http.StatusBadRequest)
+		return
+	}
+
+	var req SplitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, `{"error":"invalid json"}`, http.StatusBadRequest)
+		return
+	}
+
+	legs := make([]pay.SplitLeg, 0, len(req.Legs))
+	for _, leg := range req.Legs {
+		legs = append(legs, pay.SplitLeg{ToID: leg.ToID, Amount: leg.Amount})
+	}
+
+	split, err := h.payUC.Split(r.Context(), pay.SplitRequest{
+		IdempotencyKey: idempotencyKey,
+		FromID:         req.FromID,
+		Amount:         req.Amount,
+		Currency:       req.Currency,
+		Legs:           legs,
+		Type:           req.Type,
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toSplitResponse(split))
+}
+
+func (h *Handler) HandleGetSplitPayment(w http.ResponseWriter, r *http.Request) {
+	split, err := h.payUC.GetSplit(r.Context(), chi.URLParam(r, "split_payment_id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toSplitResponse(split))
+}
+
+func toSplitResponse(s *payment.SplitPayment) SplitResponse {
+	resp := SplitResponse{
+		ID:            s.ID.String(),
+		FromID:        s.FromAccountID.String(),
+		Amount:        s.Amount,
+		Currency:      s.Currency,
+		Status:        s.Status,
+		Error:         s.ErrorMessage,
+		FailureReason: s.FailureReason,
+		Legs:          make([]SplitLegResponse, 0, len(s.Legs)),
+		CreatedAt:     s.CreatedAt,
+	}
+	for _, leg := range s.Legs {
+		resp.Legs = append(resp.Legs, SplitLegResponse{
+			ToID:          leg.ToAccountID.String(),
+			Amount:        leg.Amount,
+			TransactionID: leg.TransactionID,
+		})
+	}
+	return resp
+}
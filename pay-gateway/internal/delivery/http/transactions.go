@@ -0,0 +1,126 @@
+package http //nolint:revive // directory-based package name, imported with alias
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/transaction"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/usecase/history"
+)
+
+type TransactionResponse struct {
+	ID            string `json:"id"`
+	FromID        string `json:"from_id"`
+	ToID          string `json:"to_id"`
+	Amount        int64  `json:"amount"`
+	Currency      string `json:"currency"`
+	Status        string `json:"status"`
+	FailureReason string `json:"failure_reason,omitempty"`
+	OriginalID    string `json:"original_transaction_id,omitempty"`
+	SplitID       string `json:"split_id,omitempty"`
+	// Set only when the payee was credited something other than amount: on
+	// conversions, with the quote they settled at, and when the payee bore a
+	// fee.
+	CreditedAmount   int64     `json:"credited_amount,omitempty"`
+	CreditedCurrency string    `json:"credited_currency,omitempty"`
+	QuoteID          string    `json:"quote_id,omitempty"`
+	Fee              int64     `json:"fee,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type ListTransactionsResponse struct {
+	Transactions  []TransactionResponse `json:"transactions"`
+	NextPageToken string                `json:"next_page_token,omitempty"`
+}
+
+func (h *Handler) HandleGetTransaction(w http.ResponseWriter, r *http.Request) {
+	txn, err := h.historyUC.Get(r.Context(), chi.URLParam(r, "transaction_id"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toTransactionResponse(*txn))
+}
+
+func (h *Handler) HandleListTransactions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	req := history.ListRequest{
+		AccountID: chi.URLParam(r, "account_id"),
+		Direction: q.Get("direction"),
+		Status:    q.Get("status"),
+		PageToken: q.Get("page_token"),
+	}
+	if v := q.Get("page_size"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, `{"error":"invalid page_size"}`, http.StatusBadRequest)
+			return
+		}
+		req.PageSize = n
+	}
+	if v := q.Get("from"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			http.Error(w, `{"error":"invalid from, expected RFC 3339"}`, http.StatusBadRequest)
+			return
+		}
+		req.CreatedAfter = t
+	}
+	if v := q.Get("to"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			http.Error(w, `{"error":"invalid to, expected RFC 3339"}`, http.StatusBadRequest)
+			return
+		}
+		req.CreatedBefore = t
+	}
+
+	page, err := h.historyUC.List(r.Context(), req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := ListTransactionsResponse{
+		Transactions:  make([]TransactionResponse, 0, len(page.Transactions)),
+		NextPageToken: page.NextPageToken,
+	}
+	for _, txn := range page.Transactions {
+		resp.Transactions = append(resp.Transactions, toTransactionResponse(txn))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func toTransactionResponse(t transaction.Transaction) TransactionResponse {
+	resp := TransactionResponse{
+		ID:            t.ID.String(),
+		FromID:        t.FromAccountID.String(),
+		ToID:          t.ToAccountID.String(),
+		Amount:        t.Amount,
+		Currency:      t.Currency,
+		Status:        t.Status,
+		FailureReason: t.FailureReason,
+		Fee:           t.Fee,
+		CreatedAt:     t.CreatedAt,
+	}
+	if t.OriginalTransactionID != uuid.Nil {
+		resp.OriginalID = t.OriginalTransactionID.String()
+	}
+	if t.QuoteID != uuid.Nil {
+		resp.QuoteID = t.QuoteID.String()
+	}
+	if t.SplitID != uuid.Nil {
+		resp.SplitID = t.SplitID.String()
+	}
+	if t.CreditedAmount != t.Amount || t.CreditedCurrency != t.Currency {
+		resp.CreditedAmount = t.CreditedAmount
+		resp.CreditedCurrency = t.CreditedCurrency
+	}
+	return resp
+}
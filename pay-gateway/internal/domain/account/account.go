@@ -0,0 +1,49 @@
+package account
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Account struct {
+	ID        uuid.UUID
+	Currency  string
+	Tier      string
+	Balance   int64
+	Held      int64
+	Available int64
+	// Status is "active", "frozen" (can receive but not send) or "closed".
+	Status       string
+	StatusReason string
+	CreatedAt    time.Time
+}
+
+// Requisites are the bank details of the payee's settlement account at a
+// Russian bank, which GOST R 56042-2014 payment codes are filled in from.
+// CorrespAcc, PayeeINN and KPP are optional.
+type Requisites struct {
+	AccountID   uuid.UUID
+	Name        string
+	PersonalAcc string
+	BankName    string
+	BIC         string
+	CorrespAcc  string
+	PayeeINN    string
+	KPP         string
+	UpdatedAt   time.Time
+}
+
+type Page struct {
+	Accounts      []Account
+	NextPageToken string
+}
+
+type Client interface {
+	CreateAccount(ctx context.Context, currency, tier string) (*Account, error)
+	GetAccount(ctx context.Context, id uuid.UUID) (*Account, error)
+	ListAccounts(ctx context.Context, pageSize int, pageToken string) (*Page, error)
+	SetAccountRequisites(ctx context.Context, requisites Requisites) (*Requisites, error)
+	GetAccountRequisites(ctx context.Context, accountID uuid.UUID) (*Requisites, error)
+}
@@ -0,0 +1,35 @@
+package payment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuthorizeRequest struct {
+	IdempotencyKey string
+	FromAccountID  uuid.UUID
+	ToAccountID    uuid.UUID
+	Amount         int64
+	Currency       string
+}
+
+type CaptureRequest struct {
+	IdempotencyKey  string
+	AuthorizationID uuid.UUID
+	// Amount to settle; zero captures the full authorized amount.
+	Amount int64
+}
+
+type Authorization struct {
+	ID                   uuid.UUID
+	FromAccountID        uuid.UUID
+	ToAccountID          uuid.UUID
+	Amount               int64
+	Currency             string
+	CapturedAmount       int64
+	Status               string
+	CaptureTransactionID string
+	ExpiresAt            time.Time
+	CreatedAt            time.Time
+}
@@ -0,0 +1,55 @@
+package payment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Batch modes say how a payout batch treats a transfer that cannot be paid.
+const (
+	// BatchAtomic pays every transfer of the batch or none of them.
+	BatchAtomic = "atomic"
+	// BatchBestEffort pays each transfer on its own.
+	BatchBestEffort = "best_effort"
+)
+
+type BatchTransfer struct {
+	FromAccountID uuid.UUID
+	ToAccountID   uuid.UUID
+	Amount        int64
+	// ISO 4217 code; empty means pay-core's default currency.
+	Currency string
+	// Type is TransferP2P or TransferQRMerchant; empty means TransferP2P.
+	Type string
+}
+
+type BatchRequest struct {
+	IdempotencyKey string
+	// Mode is BatchAtomic or BatchBestEffort.
+	Mode      string
+	Transfers []BatchTransfer
+}
+
+type BatchItem struct {
+	Position int
+	Transfer BatchTransfer
+	Status   string
+	// TransactionID is empty if the transfer was rejected before a
+	// transaction was recorded.
+	TransactionID string
+	FailureReason string
+	ErrorMessage  string
+}
+
+type Batch struct {
+	ID             uuid.UUID
+	Mode           string
+	Status         string
+	Items          []BatchItem
+	SucceededCount int
+	FailedCount    int
+	CreatedAt      time.Time
+	// CompletedAt is zero while the batch is processing.
+	CompletedAt time.Time
+}
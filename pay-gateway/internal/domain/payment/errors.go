@@ -0,0 +1,36 @@
+package payment
+
+import "errors"
+
+var (
+	ErrInvalidRequest           = errors.New("invalid request")
+	ErrAccountNotFound          = errors.New("account not found")
+	ErrTransactionNotFound      = errors.New("transaction not found")
+	ErrAuthorizationNotFound    = errors.New("authorization not found")
+	ErrRateNotFound             = errors.New("exchange rate not found")
+	ErrQuoteNotFound            = errors.New("quote not found")
+	ErrBatchNotFound            = errors.New("payout batch not found")
+	ErrSplitNotFound            = errors.New("split payment not found")
+	ErrRequisitesNotFound       = errors.New("account requisites not found")
+	ErrIntentNotFound           = errors.New("payment intent not found")
+	ErrEventNotFound            = errors.New("event not found")
+	ErrUnknownCurrency          = errors.New("unknown currency")
+	ErrCurrencyMismatch         = errors.New("currencies do not match")
+	ErrQuoteExpired             = errors.New("quote has expired")
+	ErrQuoteUsed                = errors.New("quote has already been used")
+	ErrQuoteMismatch            = errors.New("payment does not match the quote")
+	ErrInsufficientFunds        = errors.New("insufficient funds")
+	ErrFeeExceedsAmount         = errors.New("fee exceeds the transfer amount")
+	ErrAccountFrozen            = errors.New("account is frozen")
+	ErrAccountClosed            = errors.New("account is closed")
+	ErrLimitExceeded            = errors.New("limit exceeded")
+	ErrNotRefundable            = errors.New("transaction cannot be refunded")
+	ErrRefundExceedsOriginal    = errors.New("refund exceeds the unrefunded amount of the original payment")
+	ErrAuthorizationNotActive   = errors.New("authorization is no longer active")
+	ErrAuthorizationExpired     = errors.New("authorization has expired")
+	ErrCaptureExceedsAuthorized = errors.New("capture exceeds the authorized amount")
+	ErrIntentNotOpen            = errors.New("payment intent is no longer open")
+	ErrIntentExpired            = errors.New("payment intent has expired")
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was already used with a different request")
+	ErrConflict                 = errors.New("concurrent update conflict")
+)
@@ -0,0 +1,45 @@
+package payment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Payment intent statuses as pay-core reports them.
+const (
+	IntentCreated   = "PAYMENT_INTENT_STATUS_CREATED"
+	IntentPaid      = "PAYMENT_INTENT_STATUS_PAID"
+	IntentExpired   = "PAYMENT_INTENT_STATUS_EXPIRED"
+	IntentCancelled = "PAYMENT_INTENT_STATUS_CANCELLED"
+)
+
+type CreateIntentRequest struct {
+	ToAccountID uuid.UUID
+	Amount      int64
+	// ISO 4217 code; empty means pay-core's default currency.
+	Currency string
+	// TTL is how long the intent stays payable; zero means pay-core's default.
+	TTL time.Duration
+}
+
+type PayIntentRequest struct {
+	IdempotencyKey string
+	IntentID       uuid.UUID
+	FromAccountID  uuid.UUID
+}
+
+// Intent is a single-use request for a payment of a fixed amount to a payee.
+type Intent struct {
+	ID          uuid.UUID
+	ToAccountID uuid.UUID
+	Amount      int64
+	Currency    string
+	Status      string
+	// FromAccountID and TransactionID are set once the intent is paid.
+	FromAccountID string
+	TransactionID string
+	ExpiresAt     time.Time
+	CreatedAt     time.Time
+	PaidAt        time.Time
+}
@@ -2,6 +2,7 @@ package payment
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -11,14 +12,75 @@ type Request struct {
 	FromAccountID  uuid.UUID
 	ToAccountID    uuid.UUID
 	Amount         int64
+	// ISO 4217 code; empty means pay-core's default currency.
+	Currency string
+	// QuoteID, unless uuid.Nil, settles the payment as a conversion at a
+	// quote from GetQuote.
+	QuoteID uuid.UUID
+	// Type is TransferP2P or TransferQRMerchant; empty means TransferP2P.
+	Type string
+}
+
+// Transfer types select the fee schedule a payment is priced by.
+const (
+	TransferP2P        = "p2p"
+	TransferQRMerchant = "qr_merchant"
+)
+
+type RefundRequest struct {
+	IdempotencyKey string
+	TransactionID  uuid.UUID
+	Amount         int64
 }
 
 type Response struct {
 	TransactionID string
 	Status        string
 	ErrorMessage  string
+	FailureReason string
+	// Fee is the fee applied to the payment and FeeBearer "payer" or "payee";
+	// both are zero when no fee was charged.
+	Fee       int64
+	FeeBearer string
+	// ExceededLimit names the limit a payment declined with "limit_exceeded"
+	// would have breached, e.g. "daily".
+	ExceededLimit string
 }
 
 type Client interface {
 	ProcessPayment(ctx context.Context, req Request) (*Response, error)
+	RefundPayment(ctx context.Context, req RefundRequest) (*Response, error)
+	AuthorizePayment(ctx context.Context, req AuthorizeRequest) (*Authorization, error)
+	CapturePayment(ctx context.Context, req CaptureRequest) (*Response, error)
+	VoidAuthorization(ctx context.Context, id uuid.UUID) (*Authorization, error)
+	ProcessBatch(ctx context.Context, req BatchRequest) (*Batch, error)
+	GetBatch(ctx context.Context, id uuid.UUID) (*Batch, error)
+	ProcessSplitPayment(ctx context.Context, req SplitRequest) (*SplitPayment, error)
+	GetSplitPayment(ctx context.Context, id uuid.UUID) (*SplitPayment, error)
+	CreatePaymentIntent(ctx context.Context, req CreateIntentRequest) (*Intent, error)
+	GetPaymentIntent(ctx context.Context, id uuid.UUID) (*Intent, error)
+	CancelPaymentIntent(ctx context.Context, id uuid.UUID) (*Intent, error)
+	PayPaymentIntent(ctx context.Context, req PayIntentRequest) (*Response, error)
+	GetQuote(ctx context.Context, req QuoteRequest) (*Quote, error)
+}
+
+type QuoteRequest struct {
+	FromCurrency string
+	ToCurrency   string
+	// In minor units of FromCurrency.
+	Amount int64
+}
+
+// Quote is a conversion priced by pay-core. Paying with its ID before
+// ExpiresAt debits exactly SourceAmount and credits exactly TargetAmount.
+type Quote struct {
+	ID             uuid.UUID
+	SourceAmount   int64
+	SourceCurrency string
+	TargetAmount   int64
+	TargetCurrency string
+	Rate           string
+	ExpiresAt      time.Time
+	// ValidFor is how long the quote had left when pay-core answered.
+	ValidFor time.Duration
 }
@@ -0,0 +1,40 @@
+package payment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SplitLeg struct {
+	ToAccountID uuid.UUID
+	// In minor units of the split payment's currency.
+	Amount int64
+	// TransactionID is empty if the split payment was declined.
+	TransactionID string
+}
+
+type SplitRequest struct {
+	IdempotencyKey string
+	FromAccountID  uuid.UUID
+	// Amount is the total the legs must sum to.
+	Amount int64
+	// ISO 4217 code; empty means pay-core's default currency.
+	Currency string
+	Legs     []SplitLeg
+	// Type is TransferP2P or TransferQRMerchant; empty means TransferP2P.
+	Type string
+}
+
+// SplitPayment debits one payer and credits every leg's payee together.
+type SplitPayment struct {
+	ID            uuid.UUID
+	FromAccountID uuid.UUID
+	Amount        int64
+	Currency      string
+	Legs          []SplitLeg
+	Status        string
+	FailureReason string
+	ErrorMessage  string
+	CreatedAt     time.Time
+}
@@ -1,10 +1,132 @@
 package qrcode
 
+import (
+	"errors"
+	"time"
+)
+
+// DefaultCurrency is put into codes generated without an explicit currency.
+const DefaultCurrency = "RUB"
+
+var (
+	ErrUnknownFormat = errors.New("unknown qr payload format")
+	// ErrUnencodable marks data the requested format has no room for, such as
+	// a currency without an ISO 4217 numeric code in an EMVCo payload.
+	ErrUnencodable    = errors.New("data cannot be encoded in this qr payload format")
+	ErrInvalidPayload = errors.New("invalid qr payload")
+	// ErrInvalidSignature marks a payload that is unsigned, signed by an
+	// unknown key or edited after it was signed.
+	ErrInvalidSignature = errors.New("invalid qr signature")
+	ErrSignatureExpired = errors.New("qr signature has expired")
+	// ErrNoCode marks an image in which no code could be located or read.
+	ErrNoCode           = errors.New("no readable qr code found in image")
+	ErrUnsupportedImage = errors.New("unsupported image, expected png or jpeg")
+)
+
+// Format is how QRData is laid out in the code.
+type Format string
+
+const (
+	// FormatJSON is the JSON object read by the QR-Pay-Hub app.
+	FormatJSON Format = "json"
+	// FormatEMVCo is an EMVCo merchant-presented payload, which banking apps
+	// that support the standard can read.
+	FormatEMVCo Format = "emvco"
+	// FormatGOST is a GOST R 56042-2014 payment string ("ST00012|Name=..."),
+	// which Russian banking apps read to pay the payee's bank account.
+	FormatGOST Format = "st00012"
+	// FormatIntent is a JSON object carrying nothing but the id of a payment
+	// intent ({"intent_id":"..."}); the payee and amount are looked up by it.
+	FormatIntent Format = "intent"
+)
+
+// Charset is the text encoding of payloads in formats that let it be chosen.
+type Charset string
+
+const (
+	CharsetUTF8   Charset = "utf-8"
+	CharsetCP1251 Charset = "cp1251"
+	CharsetKOI8R  Charset = "koi8-r"
+)
+
+// DefaultFormat is used for codes generated without an explicit format.
+const DefaultFormat = FormatJSON
+
+// QRData is what a payer's app needs to make the payment: the amount is in
+// minor units of the ISO 4217 currency. A zero amount leaves it to the payer,
+// which only some formats allow.
 type QRData struct {
 	ToAccount string `json:"to_account"`
 	Amount    int64  `json:"amount"`
+	Currency  string `json:"currency"`
+	// KeyID, ExpiresAt (Unix seconds) and Signature are set on signed JSON
+	// payloads. The signature covers every other field of the JSON object.
+	KeyID     string `json:"kid,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	Signature string `json:"sig,omitempty"`
+	// IntentID is all that FormatIntent payloads carry.
+	IntentID string `json:"intent_id,omitempty"`
+	// Merchant is shown to the payer by formats that carry it.
+	Merchant Merchant `json:"-"`
+	// Payee, Purpose and Extra are what FormatGOST pays by. Extra holds the
+	// optional fields of GOST R 56042-2014 that have no place of their own,
+	// keyed by their names in the standard, such as "LastName" or "DocNo".
+	Payee   Payee             `json:"-"`
+	Purpose string            `json:"-"`
+	Extra   map[string]string `json:"-"`
+	// Charset defaults to CharsetUTF8 in formats that let it be chosen.
+	Charset Charset `json:"-"`
+}
+
+// Merchant describes the payee as EMVCo payloads present it.
+type Merchant struct {
+	Name string
+	City string
+	// CountryCode is an ISO 3166-1 alpha-2 code.
+	CountryCode string
+	// CategoryCode is an ISO 18245 merchant category code.
+	CategoryCode string
+}
+
+// Payee are the bank details of the payee's settlement account at a Russian
+// bank. CorrespAcc, PayeeINN and KPP are optional.
+type Payee struct {
+	Name        string
+	PersonalAcc string
+	BankName    string
+	BIC         string
+	CorrespAcc  string
+	PayeeINN    string
+	KPP         string
 }
 
 type Generator interface {
-	Generate(data QRData) ([]byte, error)
+	Generate(data QRData, format Format) ([]byte, error)
+}
+
+// Signer makes JSON payloads tamper-evident.
+type Signer interface {
+	// Sign sets data's KeyID and Signature, covering its ExpiresAt.
+	Sign(data *QRData) error
+	// Verify checks that data was signed by a known key and, at now, has not
+	// expired.
+	Verify(data QRData, now time.Time) error
+}
+
+// Reader reads the payment a scanned payload asks for.
+type Reader interface {
+	// Read decodes payload and checks its signature.
+	Read(payload string) (QRData, error)
+}
+
+// Parser recognises the format of a payload and decodes it without checking
+// its signature, to show what a code asks for before anyone pays it.
+type Parser interface {
+	Parse(payload string) (QRData, Format, error)
+}
+
+// Scanner locates a code in a photo or screenshot and returns its payload
+// byte for byte, whatever charset the payload is in.
+type Scanner interface {
+	Scan(image []byte) (string, error)
 }
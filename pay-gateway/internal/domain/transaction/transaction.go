@@ -0,0 +1,82 @@
+package transaction
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Direction string
+
+const (
+	DirectionAny      Direction = ""
+	DirectionIncoming Direction = "incoming"
+	DirectionOutgoing Direction = "outgoing"
+)
+
+type Transaction struct {
+	ID            uuid.UUID
+	FromAccountID uuid.UUID
+	ToAccountID   uuid.UUID
+	Amount        int64
+	Currency      string
+	Status        string
+	FailureReason string
+	// OriginalTransactionID is uuid.Nil unless the transaction is a refund.
+	OriginalTransactionID uuid.UUID
+	// CreditedAmount and CreditedCurrency are what the payee received; they
+	// differ from Amount and Currency only for conversions, and from Amount
+	// when the payee bore a fee.
+	CreditedAmount   int64
+	CreditedCurrency string
+	// Fee is the transfer fee charged, in minor units of Currency.
+	Fee int64
+	// QuoteID is uuid.Nil unless the transaction is a conversion.
+	QuoteID uuid.UUID
+	// SplitID is uuid.Nil unless the transaction is a leg of a split payment.
+	SplitID   uuid.UUID
+	CreatedAt time.Time
+}
+
+// Filter narrows a history query. Zero values leave the corresponding
+// criterion unrestricted; Status uses the same names as payment responses.
+type Filter struct {
+	AccountID     uuid.UUID
+	Direction     Direction
+	Status        string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	PageSize      int
+	PageToken     string
+}
+
+type Page struct {
+	Transactions  []Transaction
+	NextPageToken string
+}
+
+// Event is a transaction an account took part in, announced as it commits.
+// Sequence orders events by when they were committed; a stream resumes after
+// the last sequence it received.
+type Event struct {
+	ID          uuid.UUID
+	Sequence    int64
+	Type        string
+	Direction   Direction
+	Transaction Transaction
+}
+
+// EventStream yields an account's events until it fails or its context is
+// done.
+type EventStream interface {
+	Recv() (*Event, error)
+}
+
+type Client interface {
+	GetTransaction(ctx context.Context, id uuid.UUID) (*Transaction, error)
+	ListTransactions(ctx context.Context, filter Filter) (*Page, error)
+	// SubscribeAccountEvents first replays the events after afterSequence,
+	// unless it is zero.
+	SubscribeAccountEvents(ctx context.Context, accountID uuid.UUID, afterSequence int64) (EventStream, error)
+}
@@ -1,16 +1,43 @@
 package config
 
-import "os"
+import (
+	"os"
+	"time"
+)
+
+const (
+	defaultQRKeystoreRefreshInterval = time.Minute
+	defaultQRSignatureTTL            = 24 * time.Hour
+)
 
 type Config struct {
 	CoreGRPCAddr string
 	HTTPAddr     string
+	// The merchant shown in EMVCo QR codes unless the request names one.
+	QRMerchantName     string
+	QRMerchantCity     string
+	QRMerchantCountry  string
+	QRMerchantCategory string
+	// QRKeystoreFile holds the keys JSON codes are signed with; without it
+	// codes are unsigned and payments by a scanned code are rejected.
+	QRKeystoreFile            string
+	QRKeystoreRefreshInterval time.Duration
+	QRSignatureTTL            time.Duration
 }
 
 func Load() *Config {
 	return &Config{
 		CoreGRPCAddr: getEnv("CORE_GRPC_ADDR", "localhost:50051"),
 		HTTPAddr:     getEnv("HTTP_ADDR", ":8080"),
+
+		QRMerchantName:     getEnv("QR_MERCHANT_NAME", "QR Pay Hub"),
+		QRMerchantCity:     getEnv("QR_MERCHANT_CITY", "Moscow"),
+		QRMerchantCountry:  getEnv("QR_MERCHANT_COUNTRY", "RU"),
+		QRMerchantCategory: getEnv("QR_MERCHANT_CATEGORY", "5999"),
+
+		QRKeystoreFile:            getEnv("QR_KEYSTORE_FILE", ""),
+		QRKeystoreRefreshInterval: getEnvDuration("QR_KEYSTORE_REFRESH_INTERVAL", defaultQRKeystoreRefreshInterval),
+		QRSignatureTTL:            getEnvDuration("QR_SIGNATURE_TTL", defaultQRSignatureTTL),
 	}
 }
 
@@ -20,3 +47,10 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if v, err := time.ParseDuration(os.Getenv(key)); err == nil {
+		return v
+	}
+	return fallback
+}
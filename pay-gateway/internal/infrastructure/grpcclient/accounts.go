@@ -0,0 +1,124 @@
+package grpcclient
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	pb "github.com/Xausdorf/qr-pay-hub/pay-gateway/gen/pb"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/account"
+)
+
+func (c *Client) CreateAccount(ctx context.Context, currency, tier string) (*account.Account, error) {
+	resp, err := c.client.CreateAccount(ctx, &pb.CreateAccountRequest{Currency: currency, Tier: tier})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return fromPBAccount(resp)
+}
+
+func (c *Client) GetAccount(ctx context.Context, id uuid.UUID) (*account.Account, error) {
+	resp, err := c.client.GetAccount(ctx, &pb.GetAccountRequest{AccountId: id.String()})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return fromPBAccount(resp)
+}
+
+func (c *Client) ListAccounts(ctx context.Context, pageSize int, pageToken string) (*account.Page, error) {
+	resp, err := c.client.ListAccounts(ctx, &pb.ListAccountsRequest{
+		PageSize:  int32(min(pageSize, maxPageSize)), //nolint:gosec // G115: bounded above
+		PageToken: pageToken,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	page := &account.Page{
+		Accounts:      make([]account.Account, 0, len(resp.GetAccounts())),
+		NextPageToken: resp.GetNextPageToken(),
+	}
+	for _, a := range resp.GetAccounts() {
+		acc, convErr := fromPBAccount(a)
+		if convErr != nil {
+			return nil, convErr
+		}
+		page.Accounts = append(page.Accounts, *acc)
+	}
+	return page, nil
+}
+
+func (c *Client) SetAccountRequisites(
+	ctx context.Context,
+	r account.Requisites,
+) (*account.Requisites, error) {
+	resp, err := c.client.SetAccountRequisites(ctx, &pb.AccountRequisites{
+		AccountId:   r.AccountID.String(),
+		Name:        r.Name,
+		PersonalAcc: r.PersonalAcc,
+		BankName:    r.BankName,
+		Bic:         r.BIC,
+		CorrespAcc:  r.CorrespAcc,
+		PayeeInn:    r.PayeeINN,
+		Kpp:         r.KPP,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return fromPBRequisites(resp)
+}
+
+func (c *Client) GetAccountRequisites(ctx context.Context, accountID uuid.UUID) (*account.Requisites, error) {
+	resp, err := c.client.GetAccountRequisites(ctx, &pb.GetAccountRequisitesRequest{AccountId: accountID.String()})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return fromPBRequisites(resp)
+}
+
+func fromPBRequisites(r *pb.AccountRequisites) (*account.Requisites, error) {
+	id, err := uuid.Parse(r.GetAccountId())
+	if err != nil {
+		return nil, err
+	}
+	return &account.Requisites{
+		AccountID:   id,
+		Name:        r.GetName(),
+		PersonalAcc: r.GetPersonalAcc(),
+		BankName:    r.GetBankName(),
+		BIC:         r.GetBic(),
+		CorrespAcc:  r.GetCorrespAcc(),
+		PayeeINN:    r.GetPayeeInn(),
+		KPP:         r.GetKpp(),
+		UpdatedAt:   r.GetUpdatedAt().AsTime(),
+	}, nil
+}
+
+func fromPBAccount(a *pb.Account) (*account.Account, error) {
+	id, err := uuid.Parse(a.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &account.Account{
+		ID:           id,
+		Currency:     a.GetCurrency(),
+		Tier:         a.GetTier(),
+		Balance:      a.GetBalance(),
+		Held:         a.GetHeld(),
+		Available:    a.GetAvailable(),
+		Status:       fromPBAccountStatus(a.GetStatus()),
+		StatusReason: a.GetStatusReason(),
+		CreatedAt:    a.GetCreatedAt().AsTime(),
+	}, nil
+}
+
+func fromPBAccountStatus(s pb.AccountStatus) string {
+	switch s {
+	case pb.AccountStatus_ACCOUNT_STATUS_FROZEN:
+		return "frozen"
+	case pb.AccountStatus_ACCOUNT_STATUS_CLOSED:
+		return "closed"
+	default:
+		return "active"
+	}
+}
@@ -0,0 +1,79 @@
+package grpcclient
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	pb "github.com/Xausdorf/qr-pay-hub/pay-gateway/gen/pb"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/payment"
+)
+
+func (c *Client) AuthorizePayment(ctx context.Context, req payment.AuthorizeRequest) (*payment.Authorization, error) {
+	resp, err := c.client.AuthorizePayment(ctx, &pb.AuthorizeRequest{
+		IdempotencyKey: req.IdempotencyKey,
+		FromAccountId:  req.FromAccountID.String(),
+		ToAccountId:    req.ToAccountID.String(),
+		Amount:         req.Amount,
+		Currency:       req.Currency,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return fromPBAuthorization(resp)
+}
+
+func (c *Client) CapturePayment(ctx context.Context, req payment.CaptureRequest) (*payment.Response, error) {
+	resp, err := c.client.CapturePayment(ctx, &pb.CaptureRequest{
+		IdempotencyKey:  req.IdempotencyKey,
+		AuthorizationId: req.AuthorizationID.String(),
+		Amount:          req.Amount,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &payment.Response{
+		TransactionID: resp.GetTransactionId(),
+		Status:        resp.GetStatus().String(),
+		ErrorMessage:  resp.GetErrorMessage(),
+		FailureReason: resp.GetFailureReason(),
+		Fee:           resp.GetFeeAmount(),
+		FeeBearer:     resp.GetFeeBearer(),
+	}, nil
+}
+
+func (c *Client) VoidAuthorization(ctx context.Context, id uuid.UUID) (*payment.Authorization, error) {
+	resp, err := c.client.VoidAuthorization(ctx, &pb.VoidAuthorizationRequest{AuthorizationId: id.String()})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return fromPBAuthorization(resp)
+}
+
+func fromPBAuthorization(a *pb.Authorization) (*payment.Authorization, error) {
+	id, err := uuid.Parse(a.GetId())
+	if err != nil {
+		return nil, err
+	}
+	from, err := uuid.Parse(a.GetFromAccountId())
+	if err != nil {
+		return nil, err
+	}
+	to, err := uuid.Parse(a.GetToAccountId())
+	if err != nil {
+		return nil, err
+	}
+	return &payment.Authorization{
+		ID:                   id,
+		FromAccountID:        from,
+		ToAccountID:          to,
+		Amount:               a.GetAmount(),
+		Currency:             a.GetCurrency(),
+		CapturedAmount:       a.GetCapturedAmount(),
+		Status:               a.GetStatus().String(),
+		CaptureTransactionID: a.GetCaptureTransactionId(),
+		ExpiresAt:            a.GetExpiresAt().AsTime(),
+		CreatedAt:            a.GetCreatedAt().AsTime(),
+	}, nil
+}
@@ -0,0 +1,116 @@
+package grpcclient
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	pb "github.com/Xausdorf/qr-pay-hub/pay-gateway/gen/pb"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/payment"
+)
+
+func (c *Client) ProcessBatch(ctx context.Context, req payment.BatchRequest) (*payment.Batch, error) {
+	pbReq := &pb.BatchRequest{
+		IdempotencyKey: req.IdempotencyKey,
+		Mode:           toPBBatchMode(req.Mode),
+		Transfers:      make([]*pb.BatchTransfer, 0, len(req.Transfers)),
+	}
+	for _, t := range req.Transfers {
+		pbReq.Transfers = append(pbReq.Transfers, &pb.BatchTransfer{
+			FromAccountId: t.FromAccountID.String(),
+			ToAccountId:   t.ToAccountID.String(),
+			Amount:        t.Amount,
+			Currency:      t.Currency,
+			TransferType:  toPBTransferType(t.Type),
+		})
+	}
+
+	resp, err := c.client.ProcessBatch(ctx, pbReq)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return fromPBBatch(resp)
+}
+
+func (c *Client) GetBatch(ctx context.Context, id uuid.UUID) (*payment.Batch, error) {
+	resp, err := c.client.GetBatch(ctx, &pb.GetBatchRequest{BatchId: id.String()})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return fromPBBatch(resp)
+}
+
+func toPBBatchMode(mode string) pb.BatchMode {
+	switch mode {
+	case payment.BatchAtomic:
+		return pb.BatchMode_BATCH_MODE_ATOMIC
+	case payment.BatchBestEffort:
+		return pb.BatchMode_BATCH_MODE_BEST_EFFORT
+	default:
+		return pb.BatchMode_BATCH_MODE_UNSPECIFIED
+	}
+}
+
+func fromPBBatchMode(mode pb.BatchMode) string {
+	switch mode { //nolint:exhaustive // pay-core never returns an unspecified mode
+	case pb.BatchMode_BATCH_MODE_ATOMIC:
+		return payment.BatchAtomic
+	case pb.BatchMode_BATCH_MODE_BEST_EFFORT:
+		return payment.BatchBestEffort
+	default:
+		return ""
+	}
+}
+
+func fromPBTransferType(t pb.TransferType) string {
+	if t == pb.TransferType_TRANSFER_TYPE_QR_MERCHANT {
+		return payment.TransferQRMerchant
+	}
+	return payment.TransferP2P
+}
+
+func fromPBBatch(b *pb.Batch) (*payment.Batch, error) {
+	id, err := uuid.Parse(b.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	batch := &payment.Batch{
+		ID:             id,
+		Mode:           fromPBBatchMode(b.GetMode()),
+		Status:         b.GetStatus().String(),
+		Items:          make([]payment.BatchItem, 0, len(b.GetItems())),
+		SucceededCount: int(b.GetSucceededCount()),
+		FailedCount:    int(b.GetFailedCount()),
+		CreatedAt:      b.GetCreatedAt().AsTime(),
+	}
+	if b.GetCompletedAt() != nil {
+		batch.CompletedAt = b.GetCompletedAt().AsTime()
+	}
+	for _, item := range b.GetItems() {
+		t := item.GetTransfer()
+		from, parseErr := uuid.Parse(t.GetFromAccountId())
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		to, parseErr := uuid.Parse(t.GetToAccountId())
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		batch.Items = append(batch.Items, payment.BatchItem{
+			Position: int(item.GetPosition()),
+			Transfer: payment.BatchTransfer{
+				FromAccountID: from,
+				ToAccountID:   to,
+				Amount:        t.GetAmount(),
+				Currency:      t.GetCurrency(),
+				Type:          fromPBTransferType(t.GetTransferType()),
+			},
+			Status:        item.GetStatus().String(),
+			TransactionID: item.GetTransactionId(),
+			FailureReason: item.GetFailureReason(),
+			ErrorMessage:  item.GetErrorMessage(),
+		})
+	}
+	return batch, nil
+}
@@ -3,6 +3,7 @@ package grpcclient
 import (
 	"context"
 
+	"github.com/google/uuid"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 
@@ -10,6 +11,8 @@ import (
 	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/payment"
 )
 
+const maxPageSize = 1000
+
 type Client struct {
 	client pb.PaymentProcessorClient
 	conn   *grpc.ClientConn
@@ -31,19 +34,59 @@ func (c *Client) Close() error {
 }
 
 func (c *Client) ProcessPayment(ctx context.Context, req payment.Request) (*payment.Response, error) {
-	resp, err := c.client.ProcessPayment(ctx, &pb.PaymentRequest{
+	pbReq := &pb.PaymentRequest{
 		IdempotencyKey: req.IdempotencyKey,
 		FromAccountId:  req.FromAccountID.String(),
 		ToAccountId:    req.ToAccountID.String(),
 		Amount:         req.Amount,
+		Currency:       req.Currency,
+		TransferType:   toPBTransferType(req.Type),
+	}
+	if req.QuoteID != uuid.Nil {
+		pbReq.QuoteId = req.QuoteID.String()
+	}
+
+	resp, err := c.client.ProcessPayment(ctx, pbReq)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &payment.Response{
+		TransactionID: resp.GetTransactionId(),
+		Status:        resp.GetStatus().String(),
+		ErrorMessage:  resp.GetErrorMessage(),
+		FailureReason: resp.GetFailureReason(),
+		Fee:           resp.GetFeeAmount(),
+		FeeBearer:     resp.GetFeeBearer(),
+		ExceededLimit: resp.GetExceededLimit(),
+	}, nil
+}
+
+func toPBTransferType(t string) pb.TransferType {
+	switch t {
+	case payment.TransferP2P:
+		return pb.TransferType_TRANSFER_TYPE_P2P
+	case payment.TransferQRMerchant:
+		return pb.TransferType_TRANSFER_TYPE_QR_MERCHANT
+	default:
+		return pb.TransferType_TRANSFER_TYPE_UNSPECIFIED
+	}
+}
+
+func (c *Client) RefundPayment(ctx context.Context, req payment.RefundRequest) (*payment.Response, error) {
+	resp, err := c.client.RefundPayment(ctx, &pb.RefundRequest{
+		IdempotencyKey: req.IdempotencyKey,
+		TransactionId:  req.TransactionID.String(),
+		Amount:         req.Amount,
 	})
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 
 	return &payment.Response{
 		TransactionID: resp.GetTransactionId(),
 		Status:        resp.GetStatus().String(),
 		ErrorMessage:  resp.GetErrorMessage(),
+		FailureReason: resp.GetFailureReason(),
 	}, nil
 }
@@ -0,0 +1,121 @@
+package grpcclient
+
+import (
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/payment"
+)
+
+// mapError translates a pay-core status into a payment domain error, using the
+// ErrorInfo reason when present and falling back to the status code.
+func mapError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	domainErr := fromReason(errorReason(st))
+	if domainErr == nil {
+		domainErr = fromCode(st.Code())
+	}
+	if domainErr == nil {
+		return err
+	}
+	return fmt.Errorf("%w: %s", domainErr, st.Message())
+}
+
+func errorReason(st *status.Status) string {
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); ok {
+			return info.GetReason()
+		}
+	}
+	return ""
+}
+
+func fromReason(reason string) error {
+	switch reason {
+	case "ACCOUNT_NOT_FOUND":
+		return payment.ErrAccountNotFound
+	case "TRANSACTION_NOT_FOUND":
+		return payment.ErrTransactionNotFound
+	case "AUTHORIZATION_NOT_FOUND":
+		return payment.ErrAuthorizationNotFound
+	case "RATE_NOT_FOUND":
+		return payment.ErrRateNotFound
+	case "QUOTE_NOT_FOUND":
+		return payment.ErrQuoteNotFound
+	case "BATCH_NOT_FOUND":
+		return payment.ErrBatchNotFound
+	case "SPLIT_PAYMENT_NOT_FOUND":
+		return payment.ErrSplitNotFound
+	case "REQUISITES_NOT_FOUND":
+		return payment.ErrRequisitesNotFound
+	case "INTENT_NOT_FOUND":
+		return payment.ErrIntentNotFound
+	case "EVENT_NOT_FOUND":
+		return payment.ErrEventNotFound
+	case "UNKNOWN_CURRENCY":
+		return payment.ErrUnknownCurrency
+	case "CURRENCY_MISMATCH":
+		return payment.ErrCurrencyMismatch
+	case "QUOTE_EXPIRED":
+		return payment.ErrQuoteExpired
+	case "QUOTE_USED":
+		return payment.ErrQuoteUsed
+	case "QUOTE_MISMATCH":
+		return payment.ErrQuoteMismatch
+	case "INSUFFICIENT_FUNDS":
+		return payment.ErrInsufficientFunds
+	case "FEE_EXCEEDS_AMOUNT":
+		return payment.ErrFeeExceedsAmount
+	case "ACCOUNT_FROZEN":
+		return payment.ErrAccountFrozen
+	case "ACCOUNT_CLOSED":
+		return payment.ErrAccountClosed
+	case "LIMIT_EXCEEDED":
+		return payment.ErrLimitExceeded
+	case "NOT_REFUNDABLE":
+		return payment.ErrNotRefundable
+	case "REFUND_EXCEEDS_ORIGINAL":
+		return payment.ErrRefundExceedsOriginal
+	case "AUTHORIZATION_NOT_ACTIVE":
+		return payment.ErrAuthorizationNotActive
+	case "AUTHORIZATION_EXPIRED":
+		return payment.ErrAuthorizationExpired
+	case "CAPTURE_EXCEEDS_AUTHORIZED":
+		return payment.ErrCaptureExceedsAuthorized
+	case "INTENT_NOT_OPEN":
+		return payment.ErrIntentNotOpen
+	case "INTENT_EXPIRED":
+		return payment.ErrIntentExpired
+	case "IDEMPOTENCY_KEY_REUSED":
+		return payment.ErrIdempotencyKeyReused
+	case "CONCURRENT_UPDATE":
+		return payment.ErrConflict
+	case "INVALID_AMOUNT", "SAME_ACCOUNT", "INVALID_PAGE_TOKEN", "INVALID_FILTER", "INVALID_TIER",
+		"INVALID_BATCH", "INVALID_SPLIT", "INVALID_REQUISITES", "INVALID_INTENT":
+		return payment.ErrInvalidRequest
+	default:
+		return nil
+	}
+}
+
+func fromCode(code codes.Code) error {
+	switch code { //nolint:exhaustive // remaining codes are not domain errors
+	case codes.InvalidArgument:
+		return payment.ErrInvalidRequest
+	case codes.NotFound:
+		return payment.ErrAccountNotFound
+	case codes.AlreadyExists:
+		return payment.ErrIdempotencyKeyReused
+	case codes.Aborted:
+		return payment.ErrConflict
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,70 @@
+package grpcclient
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+
+	pb "github.com/Xausdorf/qr-pay-hub/pay-gateway/gen/pb"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/transaction"
+)
+
+// SubscribeAccountEvents returns once pay-core has accepted the subscription,
+// so that an unknown account or event is reported here rather than by the
+// first Recv.
+func (c *Client) SubscribeAccountEvents(
+	ctx context.Context,
+	accountID uuid.UUID,
+	afterSequence int64,
+) (transaction.EventStream, error) {
+	stream, err := c.client.SubscribeAccountEvents(ctx, &pb.SubscribeAccountEventsRequest{
+		AccountId:     accountID.String(),
+		AfterSequence: afterSequence,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	md, err := stream.Header()
+	if err != nil {
+		return nil, mapError(err)
+	}
+	if md == nil {
+		// The call ended before sending headers; Recv returns its status.
+		_, err = stream.Recv()
+		return nil, mapError(err)
+	}
+	return &eventStream{stream: stream}, nil
+}
+
+type eventStream struct {
+	stream grpc.ServerStreamingClient[pb.AccountEvent]
+}
+
+func (s *eventStream) Recv() (*transaction.Event, error) {
+	e, err := s.stream.Recv()
+	if err != nil {
+		return nil, mapError(err)
+	}
+	id, err := uuid.Parse(e.GetEventId())
+	if err != nil {
+		return nil, err
+	}
+	txn, err := fromPBTransaction(e.GetTransaction())
+	if err != nil {
+		return nil, err
+	}
+
+	direction := transaction.DirectionIncoming
+	if e.GetDirection() == pb.TransactionDirection_TRANSACTION_DIRECTION_OUTGOING {
+		direction = transaction.DirectionOutgoing
+	}
+	return &transaction.Event{
+		ID:          id,
+		Sequence:    e.GetSequence(),
+		Type:        e.GetType(),
+		Direction:   direction,
+		Transaction: *txn,
+	}, nil
+}
@@ -0,0 +1,88 @@
+package grpcclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	pb "github.com/Xausdorf/qr-pay-hub/pay-gateway/gen/pb"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/payment"
+)
+
+func (c *Client) CreatePaymentIntent(ctx context.Context, req payment.CreateIntentRequest) (*payment.Intent, error) {
+	resp, err := c.client.CreatePaymentIntent(ctx, &pb.CreatePaymentIntentRequest{
+		ToAccountId: req.ToAccountID.String(),
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		TtlSeconds:  int32(req.TTL / time.Second),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return fromPBIntent(resp)
+}
+
+func (c *Client) GetPaymentIntent(ctx context.Context, id uuid.UUID) (*payment.Intent, error) {
+	resp, err := c.client.GetPaymentIntent(ctx, &pb.GetPaymentIntentRequest{IntentId: id.String()})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return fromPBIntent(resp)
+}
+
+func (c *Client) CancelPaymentIntent(ctx context.Context, id uuid.UUID) (*payment.Intent, error) {
+	resp, err := c.client.CancelPaymentIntent(ctx, &pb.CancelPaymentIntentRequest{IntentId: id.String()})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return fromPBIntent(resp)
+}
+
+func (c *Client) PayPaymentIntent(ctx context.Context, req payment.PayIntentRequest) (*payment.Response, error) {
+	resp, err := c.client.PayPaymentIntent(ctx, &pb.PayPaymentIntentRequest{
+		IdempotencyKey: req.IdempotencyKey,
+		IntentId:       req.IntentID.String(),
+		FromAccountId:  req.FromAccountID.String(),
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return &payment.Response{
+		TransactionID: resp.GetTransactionId(),
+		Status:        resp.GetStatus().String(),
+		ErrorMessage:  resp.GetErrorMessage(),
+		FailureReason: resp.GetFailureReason(),
+		Fee:           resp.GetFeeAmount(),
+		FeeBearer:     resp.GetFeeBearer(),
+		ExceededLimit: resp.GetExceededLimit(),
+	}, nil
+}
+
+func fromPBIntent(i *pb.PaymentIntent) (*payment.Intent, error) {
+	id, err := uuid.Parse(i.GetId())
+	if err != nil {
+		return nil, err
+	}
+	to, err := uuid.Parse(i.GetToAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	intent := &payment.Intent{
+		ID:            id,
+		ToAccountID:   to,
+		Amount:        i.GetAmount(),
+		Currency:      i.GetCurrency(),
+		Status:        i.GetStatus().String(),
+		FromAccountID: i.GetFromAccountId(),
+		TransactionID: i.GetTransactionId(),
+		ExpiresAt:     i.GetExpiresAt().AsTime(),
+		CreatedAt:     i.GetCreatedAt().AsTime(),
+	}
+	if i.GetPaidAt() != nil {
+		intent.PaidAt = i.GetPaidAt().AsTime()
+	}
+	return intent, nil
+}
@@ -0,0 +1,37 @@
+package grpcclient
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	pb "github.com/Xausdorf/qr-pay-hub/pay-gateway/gen/pb"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/payment"
+)
+
+func (c *Client) GetQuote(ctx context.Context, req payment.QuoteRequest) (*payment.Quote, error) {
+	resp, err := c.client.GetQuote(ctx, &pb.GetQuoteRequest{
+		FromCurrency: req.FromCurrency,
+		ToCurrency:   req.ToCurrency,
+		Amount:       req.Amount,
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	id, err := uuid.Parse(resp.GetQuoteId())
+	if err != nil {
+		return nil, err
+	}
+	return &payment.Quote{
+		ID:             id,
+		SourceAmount:   resp.GetSourceAmount(),
+		SourceCurrency: resp.GetSourceCurrency(),
+		TargetAmount:   resp.GetTargetAmount(),
+		TargetCurrency: resp.GetTargetCurrency(),
+		Rate:           resp.GetRate(),
+		ExpiresAt:      resp.GetExpiresAt().AsTime(),
+		ValidFor:       time.Duration(resp.GetValidForSeconds()) * time.Second,
+	}, nil
+}
@@ -0,0 +1,76 @@
+package grpcclient
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	pb "github.com/Xausdorf/qr-pay-hub/pay-gateway/gen/pb"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/payment"
+)
+
+func (c *Client) ProcessSplitPayment(ctx context.Context, req payment.SplitRequest) (*payment.SplitPayment, error) {
+	pbReq := &pb.SplitPaymentRequest{
+		IdempotencyKey: req.IdempotencyKey,
+		FromAccountId:  req.FromAccountID.String(),
+		Amount:         req.Amount,
+		Currency:       req.Currency,
+		Legs:           make([]*pb.SplitLeg, 0, len(req.Legs)),
+		TransferType:   toPBTransferType(req.Type),
+	}
+	for _, leg := range req.Legs {
+		pbReq.Legs = append(pbReq.Legs, &pb.SplitLeg{
+			ToAccountId: leg.ToAccountID.String(),
+			Amount:      leg.Amount,
+		})
+	}
+
+	resp, err := c.client.ProcessSplitPayment(ctx, pbReq)
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return fromPBSplitPayment(resp)
+}
+
+func (c *Client) GetSplitPayment(ctx context.Context, id uuid.UUID) (*payment.SplitPayment, error) {
+	resp, err := c.client.GetSplitPayment(ctx, &pb.GetSplitPaymentRequest{SplitPaymentId: id.String()})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return fromPBSplitPayment(resp)
+}
+
+func fromPBSplitPayment(s *pb.SplitPayment) (*payment.SplitPayment, error) {
+	id, err := uuid.Parse(s.GetId())
+	if err != nil {
+		return nil, err
+	}
+	from, err := uuid.Parse(s.GetFromAccountId())
+	if err != nil {
+		return nil, err
+	}
+
+	split := &payment.SplitPayment{
+		ID:            id,
+		FromAccountID: from,
+		Amount:        s.GetAmount(),
+		Currency:      s.GetCurrency(),
+		Legs:          make([]payment.SplitLeg, 0, len(s.GetLegs())),
+		Status:        s.GetStatus().String(),
+		FailureReason: s.GetFailureReason(),
+		ErrorMessage:  s.GetErrorMessage(),
+		CreatedAt:     s.GetCreatedAt().AsTime(),
+	}
+	for _, leg := range s.GetLegs() {
+		to, parseErr := uuid.Parse(leg.GetToAccountId())
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		split.Legs = append(split.Legs, payment.SplitLeg{
+			ToAccountID:   to,
+			Amount:        leg.GetAmount(),
+			TransactionID: leg.GetTransactionId(),
+		})
+	}
+	return split, nil
+}
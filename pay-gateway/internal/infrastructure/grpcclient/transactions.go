@@ -0,0 +1,115 @@
+package grpcclient
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/Xausdorf/qr-pay-hub/pay-gateway/gen/pb"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/transaction"
+)
+
+func (c *Client) GetTransaction(ctx context.Context, id uuid.UUID) (*transaction.Transaction, error) {
+	resp, err := c.client.GetTransaction(ctx, &pb.GetTransactionRequest{TransactionId: id.String()})
+	if err != nil {
+		return nil, mapError(err)
+	}
+	return fromPBTransaction(resp)
+}
+
+func (c *Client) ListTransactions(ctx context.Context, f transaction.Filter) (*transaction.Page, error) {
+	req := &pb.ListTransactionsRequest{
+		Direction: toPBDirection(f.Direction),
+		Status:    pb.TransactionStatus(pb.TransactionStatus_value[f.Status]),
+		PageSize:  int32(min(f.PageSize, maxPageSize)), //nolint:gosec // G115: bounded above
+		PageToken: f.PageToken,
+	}
+	if f.AccountID != uuid.Nil {
+		req.AccountId = f.AccountID.String()
+	}
+	if !f.CreatedAfter.IsZero() {
+		req.CreatedAfter = timestamppb.New(f.CreatedAfter)
+	}
+	if !f.CreatedBefore.IsZero() {
+		req.CreatedBefore = timestamppb.New(f.CreatedBefore)
+	}
+
+	resp, err := c.client.ListTransactions(ctx, req)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	page := &transaction.Page{
+		Transactions:  make([]transaction.Transaction, 0, len(resp.GetTransactions())),
+		NextPageToken: resp.GetNextPageToken(),
+	}
+	for _, t := range resp.GetTransactions() {
+		txn, convErr := fromPBTransaction(t)
+		if convErr != nil {
+			return nil, convErr
+		}
+		page.Transactions = append(page.Transactions, *txn)
+	}
+	return page, nil
+}
+
+func toPBDirection(d transaction.Direction) pb.TransactionDirection {
+	switch d {
+	case transaction.DirectionIncoming:
+		return pb.TransactionDirection_TRANSACTION_DIRECTION_INCOMING
+	case transaction.DirectionOutgoing:
+		return pb.TransactionDirection_TRANSACTION_DIRECTION_OUTGOING
+	default:
+		return pb.TransactionDirection_TRANSACTION_DIRECTION_UNSPECIFIED
+	}
+}
+
+func fromPBTransaction(t *pb.Transaction) (*transaction.Transaction, error) {
+	id, err := uuid.Parse(t.GetId())
+	if err != nil {
+		return nil, err
+	}
+	from, err := uuid.Parse(t.GetFromAccountId())
+	if err != nil {
+		return nil, err
+	}
+	to, err := uuid.Parse(t.GetToAccountId())
+	if err != nil {
+		return nil, err
+	}
+	var original uuid.UUID
+	if t.GetOriginalTransactionId() != "" {
+		if original, err = uuid.Parse(t.GetOriginalTransactionId()); err != nil {
+			return nil, err
+		}
+	}
+	var quote uuid.UUID
+	if t.GetQuoteId() != "" {
+		if quote, err = uuid.Parse(t.GetQuoteId()); err != nil {
+			return nil, err
+		}
+	}
+	var split uuid.UUID
+	if t.GetSplitId() != "" {
+		if split, err = uuid.Parse(t.GetSplitId()); err != nil {
+			return nil, err
+		}
+	}
+	return &transaction.Transaction{
+		ID:                    id,
+		FromAccountID:         from,
+		ToAccountID:           to,
+		Amount:                t.GetAmount(),
+		Currency:              t.GetCurrency(),
+		Status:                t.GetStatus().String(),
+		FailureReason:         t.GetFailureReason(),
+		OriginalTransactionID: original,
+		CreditedAmount:        t.GetCreditedAmount(),
+		CreditedCurrency:      t.GetCreditedCurrency(),
+		Fee:                   t.GetFeeAmount(),
+		QuoteID:               quote,
+		SplitID:               split,
+		CreatedAt:             t.GetCreatedAt().AsTime(),
+	}, nil
+}
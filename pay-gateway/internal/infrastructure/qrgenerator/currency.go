@@ -0,0 +1,64 @@
+package qrgenerator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/qrcode"
+)
+
+type isoCurrency struct {
+	numeric    string
+	minorUnits int
+}
+
+// isoCurrencies lists the currencies pay-core supports with their ISO 4217
+// numeric codes, which is how EMVCo payloads name them.
+func isoCurrencies() map[string]isoCurrency {
+	return map[string]isoCurrency{
+		"RUB": {"643", 2}, "KZT": {"398", 2}, "BYN": {"933", 2}, "UZS": {"860", 2},
+		"KGS": {"417", 2}, "AMD": {"051", 2}, "GEL": {"981", 2}, "AZN": {"944", 2},
+		"TJS": {"972", 2}, "USD": {"840", 2}, "EUR": {"978", 2}, "GBP": {"826", 2},
+		"CNY": {"156", 2}, "TRY": {"949", 2}, "AED": {"784", 2}, "INR": {"356", 2},
+		"JPY": {"392", 0}, "KRW": {"410", 0}, "VND": {"704", 0},
+		"KWD": {"414", 3}, "BHD": {"048", 3}, "OMR": {"512", 3},
+	}
+}
+
+func currencyByNumeric(numeric string) (string, isoCurrency, bool) {
+	for code, c := range isoCurrencies() {
+		if c.numeric == numeric {
+			return code, c, true
+		}
+	}
+	return "", isoCurrency{}, false
+}
+
+// formatMajor writes an amount in minor units as a decimal number of major
+// units, e.g. 12345 with two minor units as "123.45".
+func formatMajor(amount int64, minorUnits int) string {
+	s := strconv.FormatInt(amount, 10)
+	if minorUnits == 0 {
+		return s
+	}
+	if len(s) <= minorUnits {
+		s = strings.Repeat("0", minorUnits-len(s)+1) + s
+	}
+	return s[:len(s)-minorUnits] + "." + s[len(s)-minorUnits:]
+}
+
+// parseMajor reads a decimal number of major units back into minor units,
+// rejecting more decimal places than the currency has.
+func parseMajor(s string, minorUnits int) (int64, error) {
+	whole, frac, _ := strings.Cut(s, ".")
+	if len(frac) > minorUnits {
+		return 0, fmt.Errorf("%w: amount %q has too many decimal places", qrcode.ErrInvalidPayload, s)
+	}
+	digits := whole + frac + strings.Repeat("0", minorUnits-len(frac))
+	amount, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil || amount <= 0 {
+		return 0, fmt.Errorf("%w: invalid amount %q", qrcode.ErrInvalidPayload, s)
+	}
+	return amount, nil
+}
@@ -0,0 +1,91 @@
+package qrgenerator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/qrcode"
+)
+
+func TestFormatMajor_RoundTrips(t *testing.T) {
+	tests := []struct {
+		name       string
+		amount     int64
+		minorUnits int
+		major      string
+	}{
+		{name: "no minor units", amount: 1500, minorUnits: 0, major: "1500"},
+		{name: "two minor units", amount: 12345, minorUnits: 2, major: "123.45"},
+		{name: "two minor units below one", amount: 5, minorUnits: 2, major: "0.05"},
+		{name: "two minor units whole", amount: 100, minorUnits: 2, major: "1.00"},
+		{name: "three minor units", amount: 1234, minorUnits: 3, major: "1.234"},
+		{name: "three minor units below one", amount: 5, minorUnits: 3, major: "0.005"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.major, formatMajor(tt.amount, tt.minorUnits))
+
+			amount, err := parseMajor(tt.major, tt.minorUnits)
+			require.NoError(t, err)
+			assert.Equal(t, tt.amount, amount)
+		})
+	}
+}
+
+func TestParseMajor(t *testing.T) {
+	tests := []struct {
+		name       string
+		major      string
+		minorUnits int
+		amount     int64
+		wantErr    bool
+	}{
+		{name: "fewer decimals than minor units", major: "12.5", minorUnits: 2, amount: 1250},
+		{name: "no decimals", major: "12", minorUnits: 3, amount: 12000},
+		{name: "too many decimals", major: "10.005", minorUnits: 2, wantErr: true},
+		{name: "decimals without minor units", major: "10.5", minorUnits: 0, wantErr: true},
+		{name: "too many decimals for three minor units", major: "0.0001", minorUnits: 3, wantErr: true},
+		{name: "zero", major: "0.00", minorUnits: 2, wantErr: true},
+		{name: "negative", major: "-1.00", minorUnits: 2, wantErr: true},
+		{name: "not a number", major: "1,00", minorUnits: 2, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, err := parseMajor(tt.major, tt.minorUnits)
+			if tt.wantErr {
+				require.ErrorIs(t, err, qrcode.ErrInvalidPayload)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.amount, amount)
+		})
+	}
+}
+
+func TestCurrencyByNumeric(t *testing.T) {
+	tests := []struct {
+		numeric    string
+		code       string
+		minorUnits int
+	}{
+		{numeric: "392", code: "JPY", minorUnits: 0},
+		{numeric: "643", code: "RUB", minorUnits: 2},
+		{numeric: "414", code: "KWD", minorUnits: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			code, currency, ok := currencyByNumeric(tt.numeric)
+			require.True(t, ok)
+			assert.Equal(t, tt.code, code)
+			assert.Equal(t, tt.minorUnits, currency.minorUnits)
+		})
+	}
+
+	_, _, ok := currencyByNumeric("999")
+	assert.False(t, ok)
+}
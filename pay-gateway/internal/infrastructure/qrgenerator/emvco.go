@@ -0,0 +1,234 @@
+package qrgenerator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/qrcode"
+)
+
+// Data object IDs of an EMVCo merchant-presented payload (EMV QRCPS-MPM).
+const (
+	emvcoIDPayloadFormat     = "00"
+	emvcoIDPointOfInitiation = "01"
+	emvcoIDMerchantAccount   = "26"
+	emvcoIDMerchantAccountLo = 26
+	emvcoIDMerchantAccountHi = 51
+	emvcoIDCategoryCode      = "52"
+	emvcoIDCurrency          = "53"
+	emvcoIDAmount            = "54"
+	emvcoIDCountryCode       = "58"
+	emvcoIDMerchantName      = "59"
+	emvcoIDMerchantCity      = "60"
+	emvcoIDCRC               = "63"
+
+	// Sub-IDs of the merchant account information template.
+	emvcoSubIDGloballyUnique = "00"
+	emvcoSubIDAccount        = "01"
+)
+
+const (
+	// emvcoPayloadFormat is the payload format indicator every payload
+	// starts with.
+	emvcoPayloadFormat = emvcoIDPayloadFormat + "02" + "01"
+	// emvcoCRCHeader is the ID and length of the CRC, which is always the
+	// last data object and covers the payload up to and including them.
+	emvcoCRCHeader = emvcoIDCRC + "04"
+	// emvcoStatic codes may be paid any number of times and leave the amount
+	// to the payer; emvcoDynamic codes are for a single payment of a fixed
+	// amount.
+	emvcoStatic  = "11"
+	emvcoDynamic = "12"
+	// EMVCoGUI identifies QR-Pay-Hub accounts in the merchant account
+	// information template.
+	EMVCoGUI = "hub.qrpay"
+
+	emvcoIDLen        = 2
+	emvcoHeaderLen    = 4
+	emvcoMaxValueLen  = 99
+	emvcoMaxAmountLen = 13
+	emvcoMaxNameLen   = 25
+	emvcoMaxCityLen   = 15
+	emvcoCRCLen       = 4
+)
+
+// EncodeEMVCo builds an EMVCo merchant-presented payload paying data.ToAccount.
+// Codes with an amount are dynamic, those without one static. The merchant's
+// name and city are cut to the lengths the standard allows.
+func EncodeEMVCo(data qrcode.QRData) (string, error) {
+	currency, ok := isoCurrencies()[data.Currency]
+	if !ok {
+		return "", fmt.Errorf("%w: currency %q has no ISO 4217 numeric code", qrcode.ErrUnencodable, data.Currency)
+	}
+	if data.Amount < 0 {
+		return "", fmt.Errorf("%w: negative amount", qrcode.ErrUnencodable)
+	}
+	for _, s := range []string{data.Merchant.Name, data.Merchant.City} {
+		if !isPrintableASCII(s) {
+			return "", fmt.Errorf("%w: merchant name and city must be printable ASCII", qrcode.ErrUnencodable)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(emvcoPayloadFormat)
+	if data.Amount > 0 {
+		b.WriteString(tlv(emvcoIDPointOfInitiation, emvcoDynamic))
+	} else {
+		b.WriteString(tlv(emvcoIDPointOfInitiation, emvcoStatic))
+	}
+	account := tlv(emvcoSubIDGloballyUnique, EMVCoGUI) + tlv(emvcoSubIDAccount, data.ToAccount)
+	if len(account) > emvcoMaxValueLen {
+		return "", fmt.Errorf("%w: account %q is too long", qrcode.ErrUnencodable, data.ToAccount)
+	}
+	b.WriteString(tlv(emvcoIDMerchantAccount, account))
+	b.WriteString(tlv(emvcoIDCategoryCode, data.Merchant.CategoryCode))
+	b.WriteString(tlv(emvcoIDCurrency, currency.numeric))
+	if data.Amount > 0 {
+		amount := formatMajor(data.Amount, currency.minorUnits)
+		if len(amount) > emvcoMaxAmountLen {
+			return "", fmt.Errorf("%w: amount %s is too long", qrcode.ErrUnencodable, amount)
+		}
+		b.WriteString(tlv(emvcoIDAmount, amount))
+	}
+	b.WriteString(tlv(emvcoIDCountryCode, data.Merchant.CountryCode))
+	b.WriteString(tlv(emvcoIDMerchantName, truncate(data.Merchant.Name, emvcoMaxNameLen)))
+	b.WriteString(tlv(emvcoIDMerchantCity, truncate(data.Merchant.City, emvcoMaxCityLen)))
+
+	b.WriteString(emvcoCRCHeader)
+	fmt.Fprintf(&b, "%04X", crc16CCITT(b.String()))
+	return b.String(), nil
+}
+
+// DecodeEMVCo reads an EMVCo merchant-presented payload paying a QR-Pay-Hub
+// account, after checking its CRC. Payloads of other payment schemes, which
+// carry no account under EMVCoGUI, are rejected.
+func DecodeEMVCo(payload string) (qrcode.QRData, error) {
+	if len(payload) < len(emvcoPayloadFormat)+emvcoHeaderLen+emvcoCRCLen ||
+		!strings.HasPrefix(payload, emvcoPayloadFormat) {
+		return qrcode.QRData{}, fmt.Errorf("%w: not an EMVCo payload", qrcode.ErrInvalidPayload)
+	}
+	body, sum := payload[:len(payload)-emvcoCRCLen], payload[len(payload)-emvcoCRCLen:]
+	if !strings.HasSuffix(body, emvcoCRCHeader) {
+		return qrcode.QRData{}, fmt.Errorf("%w: CRC is not the last field", qrcode.ErrInvalidPayload)
+	}
+	if !strings.EqualFold(sum, fmt.Sprintf("%04X", crc16CCITT(body))) {
+		return qrcode.QRData{}, fmt.Errorf("%w: CRC mismatch", qrcode.ErrInvalidPayload)
+	}
+
+	fields, err := parseTLV(body[:len(body)-emvcoHeaderLen])
+	if err != nil {
+		return qrcode.QRData{}, err
+	}
+
+	account, err := emvcoAccount(fields)
+	if err != nil {
+		return qrcode.QRData{}, err
+	}
+	code, currency, ok := currencyByNumeric(fields[emvcoIDCurrency])
+	if !ok {
+		return qrcode.QRData{}, fmt.Errorf("%w: unknown currency %q", qrcode.ErrInvalidPayload, fields[emvcoIDCurrency])
+	}
+	var amount int64
+	if s, present := fields[emvcoIDAmount]; present {
+		if amount, err = parseMajor(s, currency.minorUnits); err != nil {
+			return qrcode.QRData{}, err
+		}
+	}
+
+	return qrcode.QRData{
+		ToAccount: account,
+		Amount:    amount,
+		Currency:  code,
+		Merchant: qrcode.Merchant{
+			Name:         fields[emvcoIDMerchantName],
+			City:         fields[emvcoIDMerchantCity],
+			CountryCode:  fields[emvcoIDCountryCode],
+			CategoryCode: fields[emvcoIDCategoryCode],
+		},
+	}, nil
+}
+
+// emvcoAccount finds the QR-Pay-Hub account among the merchant account
+// information templates, IDs 26 to 51, each of which names its scheme by a
+// globally unique identifier.
+func emvcoAccount(fields map[string]string) (string, error) {
+	for id := emvcoIDMerchantAccountLo; id <= emvcoIDMerchantAccountHi; id++ {
+		template, ok := fields[strconv.Itoa(id)]
+		if !ok {
+			continue
+		}
+		sub, err := parseTLV(template)
+		if err != nil {
+			return "", err
+		}
+		if sub[emvcoSubIDGloballyUnique] == EMVCoGUI && sub[emvcoSubIDAccount] != "" {
+			return sub[emvcoSubIDAccount], nil
+		}
+	}
+	return "", fmt.Errorf("%w: no %s merchant account", qrcode.ErrInvalidPayload, EMVCoGUI)
+}
+
+func tlv(id, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, len(value), value)
+}
+
+// parseTLV splits s into ID-length-value data objects, keyed by ID. Lengths
+// count characters, not bytes: templates such as the merchant's name in an
+// alternate language may hold non-ASCII text.
+func parseTLV(s string) (map[string]string, error) {
+	fields := make(map[string]string)
+	rest := []rune(s)
+	for len(rest) > 0 {
+		if len(rest) < emvcoHeaderLen {
+			return nil, fmt.Errorf("%w: truncated data object", qrcode.ErrInvalidPayload)
+		}
+		id := string(rest[:emvcoIDLen])
+		n, err := strconv.Atoi(string(rest[emvcoIDLen:emvcoHeaderLen]))
+		if err != nil || n < 0 || len(rest) < emvcoHeaderLen+n {
+			return nil, fmt.Errorf("%w: bad length of data object %s", qrcode.ErrInvalidPayload, id)
+		}
+		fields[id] = string(rest[emvcoHeaderLen : emvcoHeaderLen+n])
+		rest = rest[emvcoHeaderLen+n:]
+	}
+	return fields, nil
+}
+
+// crc16CCITT is the CRC-16/CCITT-FALSE checksum the standard requires:
+// polynomial 0x1021, initial value 0xFFFF, no reflection.
+func crc16CCITT(s string) uint16 {
+	const (
+		poly    = 0x1021
+		initial = 0xFFFF
+		topBit  = 0x8000
+		bits    = 8
+	)
+	crc := uint16(initial)
+	for i := range len(s) {
+		crc ^= uint16(s[i]) << bits
+		for range bits {
+			if crc&topBit != 0 {
+				crc = crc<<1 ^ poly
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+func isPrintableASCII(s string) bool {
+	for i := range len(s) {
+		if s[i] < ' ' || s[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
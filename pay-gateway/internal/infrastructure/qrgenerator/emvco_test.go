@@ -0,0 +1,162 @@
+package qrgenerator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/qrcode"
+)
+
+const testAccount = "3f1c6a52-8a0e-4a55-9a8e-2d6b1f0c7e41"
+
+// withCRC completes an EMVCo payload body with a valid CRC.
+func withCRC(body string) string {
+	body += emvcoCRCHeader
+	return body + fmt.Sprintf("%04X", crc16CCITT(body))
+}
+
+func TestCRC16CCITT_KnownVector(t *testing.T) {
+	assert.Equal(t, uint16(0x29B1), crc16CCITT("123456789"))
+}
+
+func TestEMVCo_RoundTrips(t *testing.T) {
+	merchant := qrcode.Merchant{Name: "Coffee Point", City: "Moscow", CountryCode: "RU", CategoryCode: "5814"}
+	tests := []struct {
+		name string
+		data qrcode.QRData
+	}{
+		{
+			name: "static",
+			data: qrcode.QRData{ToAccount: testAccount, Currency: "RUB", Merchant: merchant},
+		},
+		{
+			name: "no minor units",
+			data: qrcode.QRData{ToAccount: testAccount, Amount: 1500, Currency: "JPY", Merchant: merchant},
+		},
+		{
+			name: "two minor units",
+			data: qrcode.QRData{ToAccount: testAccount, Amount: 12345, Currency: "RUB", Merchant: merchant},
+		},
+		{
+			name: "three minor units",
+			data: qrcode.QRData{ToAccount: testAccount, Amount: 1005, Currency: "KWD", Merchant: merchant},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := EncodeEMVCo(tt.data)
+			require.NoError(t, err)
+
+			data, err := DecodeEMVCo(payload)
+			require.NoError(t, err)
+			assert.Equal(t, tt.data, data)
+
+			data, format, err := Decode(payload)
+			require.NoError(t, err)
+			assert.Equal(t, qrcode.FormatEMVCo, format)
+			assert.Equal(t, tt.data, data)
+		})
+	}
+}
+
+func TestEncodeEMVCo_PointOfInitiation(t *testing.T) {
+	static, err := EncodeEMVCo(qrcode.QRData{ToAccount: testAccount, Currency: "RUB"})
+	require.NoError(t, err)
+	assert.Contains(t, static, tlv(emvcoIDPointOfInitiation, emvcoStatic))
+	assert.NotContains(t, static, emvcoIDAmount+"0")
+
+	dynamic, err := EncodeEMVCo(qrcode.QRData{ToAccount: testAccount, Amount: 100, Currency: "RUB"})
+	require.NoError(t, err)
+	assert.Contains(t, dynamic, tlv(emvcoIDPointOfInitiation, emvcoDynamic))
+	assert.Contains(t, dynamic, tlv(emvcoIDAmount, "1.00"))
+}
+
+func TestEncodeEMVCo_Rejects(t *testing.T) {
+	tests := []struct {
+		name string
+		data qrcode.QRData
+	}{
+		{name: "unknown currency", data: qrcode.QRData{ToAccount: testAccount, Currency: "XTS"}},
+		{name: "negative amount", data: qrcode.QRData{ToAccount: testAccount, Amount: -1, Currency: "RUB"}},
+		{
+			name: "non-ASCII merchant",
+			data: qrcode.QRData{ToAccount: testAccount, Currency: "RUB", Merchant: qrcode.Merchant{Name: "Кофейня"}},
+		},
+		{
+			name: "amount too long",
+			data: qrcode.QRData{ToAccount: testAccount, Amount: 1e13, Currency: "RUB"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := EncodeEMVCo(tt.data)
+			require.ErrorIs(t, err, qrcode.ErrUnencodable)
+		})
+	}
+}
+
+func TestDecodeEMVCo_Rejects(t *testing.T) {
+	valid, err := EncodeEMVCo(qrcode.QRData{ToAccount: testAccount, Amount: 1000, Currency: "RUB"})
+	require.NoError(t, err)
+	account := tlv(emvcoIDMerchantAccount, tlv(emvcoSubIDGloballyUnique, EMVCoGUI)+tlv(emvcoSubIDAccount, testAccount))
+
+	tests := []struct {
+		name    string
+		payload string
+	}{
+		{name: "not EMVCo", payload: "hello"},
+		{name: "tampered CRC", payload: valid[:len(valid)-1] + flipHex(valid[len(valid)-1])},
+		{name: "tampered body", payload: flipBody(valid)},
+		{name: "CRC not last", payload: valid + tlv(emvcoIDMerchantCity, "Moscow")},
+		{name: "truncated data object", payload: withCRC(emvcoPayloadFormat + account + "530")},
+		{name: "length past the end", payload: withCRC(emvcoPayloadFormat + account + "5309643")},
+		{name: "no hub account", payload: withCRC(emvcoPayloadFormat + tlv(emvcoIDCurrency, "643"))},
+		{
+			name:    "unknown currency",
+			payload: withCRC(emvcoPayloadFormat + account + tlv(emvcoIDCurrency, "999")),
+		},
+		{
+			name:    "too many decimals",
+			payload: withCRC(emvcoPayloadFormat + account + tlv(emvcoIDCurrency, "643") + tlv(emvcoIDAmount, "1.005")),
+		},
+		{
+			name:    "decimals in a currency without minor units",
+			payload: withCRC(emvcoPayloadFormat + account + tlv(emvcoIDCurrency, "392") + tlv(emvcoIDAmount, "10.5")),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeEMVCo(tt.payload)
+			require.ErrorIs(t, err, qrcode.ErrInvalidPayload)
+		})
+	}
+}
+
+func TestParseTLV_CountsCharacters(t *testing.T) {
+	// The alternate language template holds 17 characters in 24 bytes.
+	fields, err := parseTLV("64170002ru0107Кофейня" + tlv(emvcoIDMerchantCity, "Moscow"))
+	require.NoError(t, err)
+	assert.Equal(t, "0002ru0107Кофейня", fields["64"])
+	assert.Equal(t, "Moscow", fields[emvcoIDMerchantCity])
+}
+
+// flipHex returns a hex digit other than c.
+func flipHex(c byte) string {
+	if c == '0' {
+		return "1"
+	}
+	return "0"
+}
+
+// flipBody changes the merchant account of a payload, leaving its CRC.
+func flipBody(payload string) string {
+	i := len(emvcoPayloadFormat) + len(tlv(emvcoIDPointOfInitiation, emvcoDynamic)) + emvcoHeaderLen +
+		len(tlv(emvcoSubIDGloballyUnique, EMVCoGUI)) + emvcoHeaderLen
+	return payload[:i] + flipHex(payload[i]) + payload[i+1:]
+}
@@ -2,24 +2,118 @@ package qrgenerator
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
 
 	qr "github.com/skip2/go-qrcode"
 
 	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/qrcode"
 )
 
+// Generator renders codes and reads the payloads of scanned ones. JSON
+// payloads are signed if it has a signer; EMVCo and GOST payloads are read by
+// banking apps, which would not check a signature, and intent payloads carry
+// only an id that pay-core resolves.
 type Generator struct {
-	size int
+	size      int
+	signer    qrcode.Signer
+	signedTTL time.Duration
 }
 
-func NewGenerator(size int) *Generator {
-	return &Generator{size: size}
+// NewGenerator creates a generator of size-pixel codes; signer may be nil to
+// leave JSON payloads unsigned, and signatures expire after signedTTL.
+func NewGenerator(size int, signer qrcode.Signer, signedTTL time.Duration) *Generator {
+	return &Generator{size: size, signer: signer, signedTTL: signedTTL}
 }
 
-func (g *Generator) Generate(data qrcode.QRData) ([]byte, error) {
-	content, err := json.Marshal(data)
+func (g *Generator) Generate(data qrcode.QRData, format qrcode.Format) ([]byte, error) {
+	if format == qrcode.FormatJSON && g.signer != nil {
+		data.ExpiresAt = time.Now().Add(g.signedTTL).Unix()
+		if err := g.signer.Sign(&data); err != nil {
+			return nil, err
+		}
+	}
+	content, err := Encode(data, format)
 	if err != nil {
 		return nil, err
 	}
-	return qr.Encode(string(content), qr.Medium, g.size)
+	return qr.Encode(content, qr.Medium, g.size)
+}
+
+// Read decodes a JSON payload and checks its signature. Without a signer no
+// payload can be trusted, so every one is rejected.
+func (g *Generator) Read(payload string) (qrcode.QRData, error) {
+	data, format, err := Decode(payload)
+	if err != nil {
+		return qrcode.QRData{}, err
+	}
+	if format != qrcode.FormatJSON {
+		return qrcode.QRData{}, fmt.Errorf("%w: %s payloads are not signed", qrcode.ErrInvalidSignature, format)
+	}
+	if g.signer == nil {
+		return qrcode.QRData{}, fmt.Errorf("%w: signing is not configured", qrcode.ErrInvalidSignature)
+	}
+	if verifyErr := g.signer.Verify(data, time.Now()); verifyErr != nil {
+		return qrcode.QRData{}, verifyErr
+	}
+	return data, nil
+}
+
+// Parse decodes a payload of any format without checking its signature.
+func (g *Generator) Parse(payload string) (qrcode.QRData, qrcode.Format, error) {
+	return Decode(payload)
+}
+
+// Encode lays data out as the payload of a code in format.
+func Encode(data qrcode.QRData, format qrcode.Format) (string, error) {
+	switch format {
+	case qrcode.FormatJSON:
+		content, err := json.Marshal(data)
+		if err != nil {
+			return "", err
+		}
+		return string(content), nil
+	case qrcode.FormatEMVCo:
+		return EncodeEMVCo(data)
+	case qrcode.FormatGOST:
+		return EncodeGOST(data)
+	case qrcode.FormatIntent:
+		if data.IntentID == "" {
+			return "", fmt.Errorf("%w: intent id is required", qrcode.ErrUnencodable)
+		}
+		content, err := json.Marshal(struct {
+			IntentID string `json:"intent_id"`
+		}{data.IntentID})
+		if err != nil {
+			return "", err
+		}
+		return string(content), nil
+	default:
+		return "", fmt.Errorf("%w: %q", qrcode.ErrUnknownFormat, format)
+	}
+}
+
+// Decode reads a payload produced by Encode, recognising its format by how it
+// starts.
+func Decode(payload string) (qrcode.QRData, qrcode.Format, error) {
+	switch {
+	case strings.HasPrefix(payload, "{"):
+		var data qrcode.QRData
+		if err := json.Unmarshal([]byte(payload), &data); err != nil {
+			return qrcode.QRData{}, "", fmt.Errorf("%w: %w", qrcode.ErrInvalidPayload, err)
+		}
+		if data.IntentID != "" {
+			return qrcode.QRData{IntentID: data.IntentID}, qrcode.FormatIntent, nil
+		}
+		return data, qrcode.FormatJSON, nil
+	case strings.HasPrefix(payload, emvcoPayloadFormat):
+		data, err := DecodeEMVCo(payload)
+		return data, qrcode.FormatEMVCo, err
+	case strings.HasPrefix(payload, gostHeader):
+		data, err := DecodeGOST(payload)
+		return data, qrcode.FormatGOST, err
+	default:
+		return qrcode.QRData{}, "", qrcode.ErrUnknownFormat
+	}
 }
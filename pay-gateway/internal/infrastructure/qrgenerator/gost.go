@@ -0,0 +1,246 @@
+package qrgenerator
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/qrcode"
+)
+
+// Field names of a GOST R 56042-2014 payment string.
+const (
+	gostName        = "Name"
+	gostPersonalAcc = "PersonalAcc"
+	gostBankName    = "BankName"
+	gostBIC         = "BIC"
+	gostCorrespAcc  = "CorrespAcc"
+	gostSum         = "Sum"
+	gostPurpose     = "Purpose"
+	gostPayeeINN    = "PayeeINN"
+	gostKPP         = "KPP"
+)
+
+const (
+	// gostHeader starts every payment string: the format ID and version,
+	// followed by the encoding flag and the separator.
+	gostHeader    = "ST0001"
+	gostHeaderLen = len(gostHeader) + 2
+	// gostNoCorrespAcc fills the mandatory CorrespAcc field of a bank that
+	// has no correspondent account.
+	gostNoCorrespAcc = "0"
+	// gostSeparators are tried in order for one that no value contains.
+	gostSeparators = "|#;^~"
+
+	gostMaxSumLen     = 18
+	gostMaxPurposeLen = 210
+)
+
+// gostOptionalFields are the optional fields of the standard that QRData
+// keeps in Extra.
+func gostOptionalFields() []string {
+	return []string{
+		"PayerINN", "DrawerStatus", "CBC", "OKTMO", "PaytReason", "TaxPeriod", "DocNo", "DocDate",
+		"TaxPaytKind", "LastName", "FirstName", "MiddleName", "PayerAddress", "PersonalAccount", "DocIdx",
+		"PensAcc", "Contract", "PersAcc", "Flat", "Phone", "PayerIdType", "PayerIdNum", "ChildFio",
+		"BirthDate", "PaymTerm", "PaymPeriod", "Category", "ServiceName", "CounterId", "CounterVal",
+		"QuittId", "QuittDate", "InstNum", "ClassNum", "SpecFio", "AddAmount", "RuleId", "ExecId",
+		"RegType", "UIN", "TechCode",
+	}
+}
+
+// EncodeGOST builds a GOST R 56042-2014 payment string paying data.Payee in
+// rubles, in data.Charset. Codes without an amount leave it to the payer.
+// Extra fields are written after the others in the order of their names.
+func EncodeGOST(data qrcode.QRData) (string, error) {
+	p := data.Payee
+	if p.Name == "" || p.PersonalAcc == "" || p.BankName == "" || p.BIC == "" {
+		return "", fmt.Errorf("%w: payee requisites are incomplete", qrcode.ErrUnencodable)
+	}
+	if data.Currency != qrcode.DefaultCurrency {
+		return "", fmt.Errorf("%w: payment strings are in RUB, not %s", qrcode.ErrUnencodable, data.Currency)
+	}
+	if data.Amount < 0 {
+		return "", fmt.Errorf("%w: negative amount", qrcode.ErrUnencodable)
+	}
+	if utf8.RuneCountInString(data.Purpose) > gostMaxPurposeLen {
+		return "", fmt.Errorf("%w: purpose is longer than %d characters", qrcode.ErrUnencodable, gostMaxPurposeLen)
+	}
+	flag, enc, err := gostCharset(data.Charset)
+	if err != nil {
+		return "", err
+	}
+
+	correspAcc := p.CorrespAcc
+	if correspAcc == "" {
+		correspAcc = gostNoCorrespAcc
+	}
+	fields := [][2]string{
+		{gostName, p.Name},
+		{gostPersonalAcc, p.PersonalAcc},
+		{gostBankName, p.BankName},
+		{gostBIC, p.BIC},
+		{gostCorrespAcc, correspAcc},
+	}
+	if data.Amount > 0 {
+		sum := strconv.FormatInt(data.Amount, 10)
+		if len(sum) > gostMaxSumLen {
+			return "", fmt.Errorf("%w: amount %s is too long", qrcode.ErrUnencodable, sum)
+		}
+		fields = append(fields, [2]string{gostSum, sum})
+	}
+	for _, f := range [][2]string{{gostPurpose, data.Purpose}, {gostPayeeINN, p.PayeeINN}, {gostKPP, p.KPP}} {
+		if f[1] != "" {
+			fields = append(fields, f)
+		}
+	}
+	optional := gostOptionalFields()
+	for _, name := range slices.Sorted(maps.Keys(data.Extra)) {
+		if !slices.Contains(optional, name) {
+			return "", fmt.Errorf("%w: unknown payment string field %q", qrcode.ErrUnencodable, name)
+		}
+		if data.Extra[name] != "" {
+			fields = append(fields, [2]string{name, data.Extra[name]})
+		}
+	}
+
+	sep, err := gostSeparator(fields)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.WriteString(gostHeader)
+	b.WriteByte(flag)
+	for _, f := range fields {
+		b.WriteByte(sep)
+		b.WriteString(f[0] + "=" + f[1])
+	}
+
+	if enc == nil {
+		return b.String(), nil
+	}
+	encoded, err := enc.NewEncoder().String(b.String())
+	if err != nil {
+		return "", fmt.Errorf("%w: text cannot be written in %s", qrcode.ErrUnencodable, data.Charset)
+	}
+	return encoded, nil
+}
+
+// DecodeGOST reads a GOST R 56042-2014 payment string in any of the encodings
+// the standard allows. The result pays a bank account rather than a
+// QR-Pay-Hub one, so its ToAccount is empty. Fields of the standard other than
+// those QRData has a place for are kept in Extra, as are fields unknown to it.
+func DecodeGOST(payload string) (qrcode.QRData, error) {
+	if len(payload) < gostHeaderLen || !strings.HasPrefix(payload, gostHeader) {
+		return qrcode.QRData{}, fmt.Errorf("%w: not a GOST R 56042-2014 payment string", qrcode.ErrInvalidPayload)
+	}
+	charset, enc, err := gostCharsetByFlag(payload[len(gostHeader)])
+	if err != nil {
+		return qrcode.QRData{}, err
+	}
+	sep := payload[gostHeaderLen-1]
+	body := payload[gostHeaderLen:]
+	if enc != nil {
+		if body, err = enc.NewDecoder().String(body); err != nil {
+			return qrcode.QRData{}, fmt.Errorf("%w: %w", qrcode.ErrInvalidPayload, err)
+		}
+	}
+
+	data := qrcode.QRData{Currency: qrcode.DefaultCurrency, Charset: charset}
+	extra := make(map[string]string)
+	for field := range strings.SplitSeq(body, string(sep)) {
+		if field == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(field, "=")
+		if !ok || name == "" {
+			return qrcode.QRData{}, fmt.Errorf("%w: malformed field %q", qrcode.ErrInvalidPayload, field)
+		}
+		switch name {
+		case gostName:
+			data.Payee.Name = value
+		case gostPersonalAcc:
+			data.Payee.PersonalAcc = value
+		case gostBankName:
+			data.Payee.BankName = value
+		case gostBIC:
+			data.Payee.BIC = value
+		case gostCorrespAcc:
+			if value != gostNoCorrespAcc {
+				data.Payee.CorrespAcc = value
+			}
+		case gostSum:
+			amount, parseErr := strconv.ParseInt(value, 10, 64)
+			if parseErr != nil || amount < 0 {
+				return qrcode.QRData{}, fmt.Errorf("%w: bad sum %q", qrcode.ErrInvalidPayload, value)
+			}
+			data.Amount = amount
+		case gostPurpose:
+			data.Purpose = value
+		case gostPayeeINN:
+			data.Payee.PayeeINN = value
+		case gostKPP:
+			data.Payee.KPP = value
+		default:
+			extra[name] = value
+		}
+	}
+
+	p := data.Payee
+	if p.Name == "" || p.PersonalAcc == "" || p.BankName == "" || p.BIC == "" {
+		return qrcode.QRData{}, fmt.Errorf("%w: mandatory payee fields are missing", qrcode.ErrInvalidPayload)
+	}
+	if len(extra) > 0 {
+		data.Extra = extra
+	}
+	return data, nil
+}
+
+// gostCharset returns the encoding flag of charset and the encoding text is
+// converted to from UTF-8, which is nil for UTF-8 itself.
+func gostCharset(charset qrcode.Charset) (byte, encoding.Encoding, error) {
+	switch charset {
+	case "", qrcode.CharsetUTF8:
+		return '2', nil, nil
+	case qrcode.CharsetCP1251:
+		return '1', charmap.Windows1251, nil
+	case qrcode.CharsetKOI8R:
+		return '3', charmap.KOI8R, nil
+	default:
+		return 0, nil, fmt.Errorf("%w: unknown charset %q", qrcode.ErrUnencodable, charset)
+	}
+}
+
+func gostCharsetByFlag(flag byte) (qrcode.Charset, encoding.Encoding, error) {
+	for _, charset := range []qrcode.Charset{qrcode.CharsetCP1251, qrcode.CharsetUTF8, qrcode.CharsetKOI8R} {
+		if f, enc, _ := gostCharset(charset); f == flag {
+			return charset, enc, nil
+		}
+	}
+	return "", nil, fmt.Errorf("%w: unknown encoding flag %q", qrcode.ErrInvalidPayload, flag)
+}
+
+// gostSeparator picks the first of gostSeparators that no field contains, as
+// the standard leaves the separator to the payload.
+func gostSeparator(fields [][2]string) (byte, error) {
+	for i := range len(gostSeparators) {
+		sep := gostSeparators[i]
+		used := false
+		for _, f := range fields {
+			if strings.IndexByte(f[1], sep) >= 0 {
+				used = true
+				break
+			}
+		}
+		if !used {
+			return sep, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: values use every separator %q", qrcode.ErrUnencodable, gostSeparators)
+}
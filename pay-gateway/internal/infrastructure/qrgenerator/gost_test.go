@@ -0,0 +1,236 @@
+package qrgenerator
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/qrcode"
+)
+
+func testPayee() qrcode.Payee {
+	return qrcode.Payee{
+		Name:        `ООО "Три кита"`,
+		PersonalAcc: "40702810138250123017",
+		BankName:    `ПАО "Сбербанк"`,
+		BIC:         "044525225",
+		CorrespAcc:  "30101810400000000225",
+		PayeeINN:    "7702123456",
+		KPP:         "770201001",
+	}
+}
+
+func TestGOST_RoundTripsPerCharset(t *testing.T) {
+	tests := []struct {
+		name    string
+		charset qrcode.Charset
+		flag    byte
+		utf8    bool
+	}{
+		{name: "default", charset: "", flag: '2', utf8: true},
+		{name: "utf-8", charset: qrcode.CharsetUTF8, flag: '2', utf8: true},
+		{name: "cp1251", charset: qrcode.CharsetCP1251, flag: '1'},
+		{name: "koi8-r", charset: qrcode.CharsetKOI8R, flag: '3'},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := qrcode.QRData{
+				Amount:   150000,
+				Currency: qrcode.DefaultCurrency,
+				Payee:    testPayee(),
+				Purpose:  "Оплата по счёту 17",
+				Charset:  tt.charset,
+			}
+
+			payload, err := EncodeGOST(data)
+			require.NoError(t, err)
+			assert.Equal(t, gostHeader+string(tt.flag)+"|", payload[:gostHeaderLen])
+			assert.Equal(t, tt.utf8, utf8.ValidString(payload))
+
+			decoded, err := DecodeGOST(payload)
+			require.NoError(t, err)
+			if tt.charset == "" {
+				data.Charset = qrcode.CharsetUTF8
+			}
+			assert.Equal(t, data, decoded)
+		})
+	}
+}
+
+func TestEncodeGOST_RejectsTextOutsideCharset(t *testing.T) {
+	_, err := EncodeGOST(qrcode.QRData{
+		Currency: qrcode.DefaultCurrency,
+		Payee:    testPayee(),
+		Purpose:  "Оплата ☕",
+		Charset:  qrcode.CharsetCP1251,
+	})
+	require.ErrorIs(t, err, qrcode.ErrUnencodable)
+}
+
+func TestEncodeGOST_PicksUnusedSeparator(t *testing.T) {
+	tests := []struct {
+		name    string
+		purpose string
+		sep     byte
+		wantErr bool
+	}{
+		{name: "pipe", purpose: "Оплата", sep: '|'},
+		{name: "purpose has a pipe", purpose: "Заказ 1|2", sep: '#'},
+		{name: "purpose has a pipe and a hash", purpose: "Заказ |#17", sep: ';'},
+		{name: "purpose has every separator", purpose: "|#;^~", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := qrcode.QRData{Currency: qrcode.DefaultCurrency, Payee: testPayee(), Purpose: tt.purpose}
+
+			payload, err := EncodeGOST(data)
+			if tt.wantErr {
+				require.ErrorIs(t, err, qrcode.ErrUnencodable)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.sep, payload[gostHeaderLen-1])
+
+			decoded, err := DecodeGOST(payload)
+			require.NoError(t, err)
+			assert.Equal(t, tt.purpose, decoded.Purpose)
+		})
+	}
+}
+
+func TestGOST_ExtraFields(t *testing.T) {
+	data := qrcode.QRData{
+		Amount:   4250,
+		Currency: qrcode.DefaultCurrency,
+		Payee:    testPayee(),
+		Extra:    map[string]string{"PersAcc": "1234567", "LastName": "Иванов", "Flat": "12"},
+		Charset:  qrcode.CharsetUTF8,
+	}
+
+	payload, err := EncodeGOST(data)
+	require.NoError(t, err)
+	// Extra fields follow the others in the order of their names.
+	assert.Contains(t, payload, "|KPP=770201001|Flat=12|LastName=Иванов|PersAcc=1234567")
+
+	decoded, err := DecodeGOST(payload)
+	require.NoError(t, err)
+	assert.Equal(t, data, decoded)
+
+	data.Extra = map[string]string{"Nickname": "ivan"}
+	_, err = EncodeGOST(data)
+	require.ErrorIs(t, err, qrcode.ErrUnencodable)
+}
+
+func TestDecodeGOST_KeepsUnknownFieldsInExtra(t *testing.T) {
+	decoded, err := DecodeGOST("ST00012|Name=ИП Петров|PersonalAcc=40802810400000001234|BankName=АО Банк|" +
+		"BIC=044525974|CorrespAcc=0|X-Order=A-17")
+	require.NoError(t, err)
+	assert.Empty(t, decoded.Payee.CorrespAcc)
+	assert.Zero(t, decoded.Amount)
+	assert.Equal(t, map[string]string{"X-Order": "A-17"}, decoded.Extra)
+}
+
+func TestEncodeGOST_Rejects(t *testing.T) {
+	incomplete := testPayee()
+	incomplete.BIC = ""
+
+	tests := []struct {
+		name string
+		data qrcode.QRData
+	}{
+		{name: "missing BIC", data: qrcode.QRData{Currency: qrcode.DefaultCurrency, Payee: incomplete}},
+		{name: "no payee", data: qrcode.QRData{Currency: qrcode.DefaultCurrency}},
+		{name: "not rubles", data: qrcode.QRData{Currency: "USD", Payee: testPayee()}},
+		{name: "negative amount", data: qrcode.QRData{Amount: -1, Currency: qrcode.DefaultCurrency, Payee: testPayee()}},
+		{
+			name: "unknown charset",
+			data: qrcode.QRData{Currency: qrcode.DefaultCurrency, Payee: testPayee(), Charset: "latin1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := EncodeGOST(tt.data)
+			require.ErrorIs(t, err, qrcode.ErrUnencodable)
+		})
+	}
+}
+
+func TestDecodeGOST_Rejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload string
+	}{
+		{name: "not a payment string", payload: "ST0002|Name=x"},
+		{name: "too short", payload: "ST0001"},
+		{name: "unknown encoding flag", payload: "ST00019|Name=x"},
+		{
+			name:    "missing BIC",
+			payload: "ST00012|Name=ООО Ромашка|PersonalAcc=40702810000000000001|BankName=АО Банк",
+		},
+		{
+			name:    "missing name",
+			payload: "ST00012|PersonalAcc=40702810000000000001|BankName=АО Банк|BIC=044525974",
+		},
+		{
+			name: "malformed field",
+			payload: "ST00012|Name=ООО Ромашка|PersonalAcc=40702810000000000001|BankName=АО Банк|" +
+				"BIC=044525974|Purpose",
+		},
+		{
+			name: "bad sum",
+			payload: "ST00012|Name=ООО Ромашка|PersonalAcc=40702810000000000001|BankName=АО Банк|" +
+				"BIC=044525974|Sum=15.00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := DecodeGOST(tt.payload)
+			require.ErrorIs(t, err, qrcode.ErrInvalidPayload)
+		})
+	}
+}
+
+// TestDecodeGOST_Sample reads a utility bill payment string laid out as
+// banks print them.
+func TestDecodeGOST_Sample(t *testing.T) {
+	const sample = "ST00012|Name=ООО «УК Жилкомсервис»|PersonalAcc=40702810355000012345|" +
+		"BankName=СЕВЕРО-ЗАПАДНЫЙ БАНК ПАО СБЕРБАНК|BIC=044030653|CorrespAcc=30101810500000000653|" +
+		"PayeeINN=7814123456|KPP=781401001|Sum=523417|Purpose=Оплата ЖКУ за сентябрь 2026|" +
+		"LastName=Смирнова|FirstName=Анна|MiddleName=Сергеевна|PayerAddress=Санкт-Петербург, ул. Савушкина, д. 7|" +
+		"PersAcc=0012345678|PaymPeriod=092026|Flat=41"
+
+	data, format, err := Decode(sample)
+
+	require.NoError(t, err)
+	assert.Equal(t, qrcode.FormatGOST, format)
+	assert.Equal(t, qrcode.QRData{
+		Amount:   523417,
+		Currency: qrcode.DefaultCurrency,
+		Payee: qrcode.Payee{
+			Name:        "ООО «УК Жилкомсервис»",
+			PersonalAcc: "40702810355000012345",
+			BankName:    "СЕВЕРО-ЗАПАДНЫЙ БАНК ПАО СБЕРБАНК",
+			BIC:         "044030653",
+			CorrespAcc:  "30101810500000000653",
+			PayeeINN:    "7814123456",
+			KPP:         "781401001",
+		},
+		Purpose: "Оплата ЖКУ за сентябрь 2026",
+		Extra: map[string]string{
+			"LastName":     "Смирнова",
+			"FirstName":    "Анна",
+			"MiddleName":   "Сергеевна",
+			"PayerAddress": "Санкт-Петербург, ул. Савушкина, д. 7",
+			"PersAcc":      "0012345678",
+			"PaymPeriod":   "092026",
+			"Flat":         "41",
+		},
+		Charset: qrcode.CharsetUTF8,
+	}, data)
+}
@@ -0,0 +1,77 @@
+package qrscanner
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/jpeg" // registers the JPEG decoder with image.Decode
+	_ "image/png"  // registers the PNG decoder with image.Decode
+	"strings"
+
+	"github.com/makiuchi-d/gozxing"
+	zxingqr "github.com/makiuchi-d/gozxing/qrcode"
+	"golang.org/x/text/encoding/charmap"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/qrcode"
+)
+
+// maxPixels bounds the decoded size of an image, so that a small file that
+// expands into a huge bitmap is rejected before it is decoded. It admits the
+// frames of 16-megapixel phone cameras.
+const maxPixels = 4096 * 4096
+
+// Scanner reads codes from PNG and JPEG images with gozxing.
+type Scanner struct{}
+
+func NewScanner() *Scanner {
+	return &Scanner{}
+}
+
+// Scan locates the code in the image and returns its payload. Byte-mode
+// segments are read as ISO 8859-1 and turned back into the bytes they hold,
+// so that a GOST payload in CP1251 or KOI8-R reaches its decoder intact
+// instead of in whatever charset gozxing would guess. The payload of a code
+// that declares its charset with an ECI header is returned in UTF-8.
+func (s *Scanner) Scan(img []byte) (string, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(img))
+	if err != nil || (format != "png" && format != "jpeg") {
+		return "", qrcode.ErrUnsupportedImage
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return "", fmt.Errorf("%w: %dx%d is over %d pixels", qrcode.ErrUnsupportedImage, cfg.Width, cfg.Height, maxPixels)
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(img))
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", qrcode.ErrUnsupportedImage, err)
+	}
+	bitmap, err := gozxing.NewBinaryBitmapFromImage(decoded)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", qrcode.ErrNoCode, err)
+	}
+
+	result, err := zxingqr.NewQRCodeReader().Decode(bitmap, map[gozxing.DecodeHintType]any{
+		gozxing.DecodeHintType_TRY_HARDER:    true,
+		gozxing.DecodeHintType_CHARACTER_SET: charmap.ISO8859_1,
+	})
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", qrcode.ErrNoCode, err)
+	}
+
+	text := result.GetText()
+	if declaresCharset(result) {
+		return text, nil
+	}
+	if raw, encErr := charmap.ISO8859_1.NewEncoder().String(text); encErr == nil {
+		return raw, nil
+	}
+	// Kanji segments have no bytes of their own to return.
+	return text, nil
+}
+
+// declaresCharset reports whether the code has an ECI header, which the
+// symbology identifier ("]Q1" to "]Q6") marks with an even modifier.
+func declaresCharset(result *gozxing.Result) bool {
+	id, _ := result.GetResultMetadata()[gozxing.ResultMetadataType_SYMBOLOGY_IDENTIFIER].(string)
+	return strings.HasSuffix(id, "2") || strings.HasSuffix(id, "4") || strings.HasSuffix(id, "6")
+}
@@ -0,0 +1,122 @@
+package qrscanner
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/qrcode"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/infrastructure/qrgenerator"
+)
+
+const (
+	testAccount = "3f1c6a52-8a0e-4a55-9a8e-2d6b1f0c7e41"
+	testSize    = 256
+)
+
+func testPayee() qrcode.Payee {
+	return qrcode.Payee{
+		Name:        `ООО "Три кита"`,
+		PersonalAcc: "40702810138250123017",
+		BankName:    `ПАО "Сбербанк"`,
+		BIC:         "044525225",
+		CorrespAcc:  "30101810400000000225",
+		PayeeINN:    "7702123456",
+		KPP:         "770201001",
+	}
+}
+
+// toJPEG re-encodes a PNG code the way a camera would hand it over.
+func toJPEG(t *testing.T, pngImg []byte) []byte {
+	t.Helper()
+	decoded, err := png.Decode(bytes.NewReader(pngImg))
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, decoded, &jpeg.Options{Quality: 90}))
+	return buf.Bytes()
+}
+
+func TestScanner_ReadsGeneratedCodes(t *testing.T) {
+	gen := qrgenerator.NewGenerator(testSize, nil, 0)
+	data := qrcode.QRData{ToAccount: testAccount, Amount: 12345, Currency: "RUB"}
+	want, err := qrgenerator.Encode(data, qrcode.FormatJSON)
+	require.NoError(t, err)
+
+	pngImg, err := gen.Generate(data, qrcode.FormatJSON)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		img  []byte
+	}{
+		{name: "png", img: pngImg},
+		{name: "jpeg", img: toJPEG(t, pngImg)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload, err := NewScanner().Scan(tt.img)
+			require.NoError(t, err)
+			assert.Equal(t, want, payload)
+		})
+	}
+}
+
+func TestScanner_ReturnsCP1251BytesIntact(t *testing.T) {
+	data := qrcode.QRData{
+		Amount:   150000,
+		Currency: qrcode.DefaultCurrency,
+		Payee:    testPayee(),
+		Purpose:  "Оплата по счёту 17",
+		Charset:  qrcode.CharsetCP1251,
+	}
+	want, err := qrgenerator.EncodeGOST(data)
+	require.NoError(t, err)
+	require.False(t, utf8.ValidString(want))
+
+	img, err := qrgenerator.NewGenerator(testSize, nil, 0).Generate(data, qrcode.FormatGOST)
+	require.NoError(t, err)
+
+	payload, err := NewScanner().Scan(img)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(want), []byte(payload))
+
+	decoded, err := qrgenerator.DecodeGOST(payload)
+	require.NoError(t, err)
+	assert.Equal(t, data, decoded)
+}
+
+func TestScanner_RejectsUnsupportedImages(t *testing.T) {
+	var oversized bytes.Buffer
+	require.NoError(t, png.Encode(&oversized, image.NewGray(image.Rect(0, 0, 4097, 4096))))
+
+	tests := []struct {
+		name string
+		img  []byte
+	}{
+		{name: "oversized", img: oversized.Bytes()},
+		{name: "not an image", img: []byte("GIF89a is not supported, and neither is this")},
+		{name: "empty", img: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewScanner().Scan(tt.img)
+			assert.ErrorIs(t, err, qrcode.ErrUnsupportedImage)
+		})
+	}
+}
+
+func TestScanner_FailsWithoutCode(t *testing.T) {
+	var blank bytes.Buffer
+	require.NoError(t, png.Encode(&blank, image.NewGray(image.Rect(0, 0, testSize, testSize))))
+
+	_, err := NewScanner().Scan(blank.Bytes())
+	assert.ErrorIs(t, err, qrcode.ErrNoCode)
+}
@@ -0,0 +1,267 @@
+package qrsign
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/qrcode"
+)
+
+const (
+	AlgorithmEd25519    = "ed25519"
+	AlgorithmHMACSHA256 = "hmac-sha256"
+
+	minHMACSecretLen = 32
+)
+
+// keystoreFile is the layout of the keystore, e.g.
+//
+//	{
+//	  "active_key_id": "2026-10",
+//	  "keys": [
+//	    {"id": "2026-10", "algorithm": "ed25519", "private_key": "<base64 seed>"},
+//	    {"id": "2026-04", "algorithm": "ed25519", "public_key": "<base64>"},
+//	    {"id": "legacy", "algorithm": "hmac-sha256", "secret": "<base64>"}
+//	  ]
+//	}
+//
+// The active key signs new codes; the others only verify codes signed before
+// the rotation, and an Ed25519 key kept for that needs only its public half.
+type keystoreFile struct {
+	ActiveKeyID string     `json:"active_key_id"`
+	Keys        []keyEntry `json:"keys"`
+}
+
+type keyEntry struct {
+	ID         string `json:"id"`
+	Algorithm  string `json:"algorithm"`
+	PrivateKey string `json:"private_key,omitempty"`
+	PublicKey  string `json:"public_key,omitempty"`
+	Secret     string `json:"secret,omitempty"`
+}
+
+type key struct {
+	algorithm string
+	private   ed25519.PrivateKey
+	public    ed25519.PublicKey
+	secret    []byte
+}
+
+func (k key) canSign() bool {
+	return k.private != nil || k.secret != nil
+}
+
+func (k key) sign(msg []byte) []byte {
+	if k.algorithm == AlgorithmHMACSHA256 {
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write(msg)
+		return mac.Sum(nil)
+	}
+	return ed25519.Sign(k.private, msg)
+}
+
+func (k key) verify(msg, sig []byte) bool {
+	if k.algorithm == AlgorithmHMACSHA256 {
+		return hmac.Equal(k.sign(msg), sig)
+	}
+	return ed25519.Verify(k.public, msg, sig)
+}
+
+// Keystore signs QR payloads with the active key of a local JSON file and
+// verifies them with any key in it. It is loaded at startup and then again
+// whenever the file changes, so keys are rotated by editing the file; a file
+// with any invalid key is rejected as a whole and the keys loaded before stay
+// in effect.
+type Keystore struct {
+	path string
+
+	mu      sync.RWMutex
+	active  string
+	keys    map[string]key
+	modTime time.Time
+}
+
+// NewKeystore loads the keystore at path.
+func NewKeystore(path string) (*Keystore, error) {
+	ks := &Keystore{path: path}
+	if _, err := ks.LoadOnce(); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// Run reloads the keystore every interval until ctx is done.
+func (ks *Keystore) Run(ctx context.Context, interval time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		loaded, err := ks.LoadOnce()
+		if err != nil {
+			logger.ErrorContext(ctx, "qr keystore load failed", "error", err, "path", ks.path)
+		} else if loaded {
+			logger.InfoContext(ctx, "qr keystore loaded", "active_key_id", ks.ActiveKeyID(), "path", ks.path)
+		}
+	}
+}
+
+// LoadOnce loads the file if it changed since the last successful load and
+// reports whether it did.
+func (ks *Keystore) LoadOnce() (bool, error) {
+	info, err := os.Stat(ks.path)
+	if err != nil {
+		return false, err
+	}
+	ks.mu.RLock()
+	unchanged := info.ModTime().Equal(ks.modTime)
+	ks.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	body, err := os.ReadFile(ks.path)
+	if err != nil {
+		return false, err
+	}
+	active, keys, err := parseKeystore(body)
+	if err != nil {
+		return false, err
+	}
+
+	ks.mu.Lock()
+	ks.active, ks.keys, ks.modTime = active, keys, info.ModTime()
+	ks.mu.Unlock()
+	return true, nil
+}
+
+func (ks *Keystore) ActiveKeyID() string {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+	return ks.active
+}
+
+func (ks *Keystore) Sign(data *qrcode.QRData) error {
+	ks.mu.RLock()
+	id, k := ks.active, ks.keys[ks.active]
+	ks.mu.RUnlock()
+
+	data.KeyID = id
+	msg, err := signedBytes(*data)
+	if err != nil {
+		return err
+	}
+	data.Signature = base64.RawURLEncoding.EncodeToString(k.sign(msg))
+	return nil
+}
+
+// Verify checks the signature before the expiry, so that a payload whose
+// expiry was edited is reported as tampered with rather than as expired.
+func (ks *Keystore) Verify(data qrcode.QRData, now time.Time) error {
+	if data.Signature == "" {
+		return fmt.Errorf("%w: payload is not signed", qrcode.ErrInvalidSignature)
+	}
+	ks.mu.RLock()
+	k, ok := ks.keys[data.KeyID]
+	ks.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: unknown key %q", qrcode.ErrInvalidSignature, data.KeyID)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(data.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: malformed signature", qrcode.ErrInvalidSignature)
+	}
+	msg, err := signedBytes(data)
+	if err != nil {
+		return err
+	}
+	if !k.verify(msg, sig) {
+		return fmt.Errorf("%w: signature does not match the payload", qrcode.ErrInvalidSignature)
+	}
+	if data.ExpiresAt == 0 || now.Unix() >= data.ExpiresAt {
+		return fmt.Errorf("%w: at %s", qrcode.ErrSignatureExpired, time.Unix(data.ExpiresAt, 0).UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// signedBytes is what the signature of data covers: its JSON payload without
+// the signature itself.
+func signedBytes(data qrcode.QRData) ([]byte, error) {
+	data.Signature = ""
+	return json.Marshal(data)
+}
+
+func parseKeystore(body []byte) (string, map[string]key, error) {
+	var file keystoreFile
+	if err := json.Unmarshal(body, &file); err != nil {
+		return "", nil, err
+	}
+
+	keys := make(map[string]key, len(file.Keys))
+	for i, e := range file.Keys {
+		if e.ID == "" {
+			return "", nil, fmt.Errorf("key %d: id is required", i)
+		}
+		if _, dup := keys[e.ID]; dup {
+			return "", nil, fmt.Errorf("key %q: duplicate id", e.ID)
+		}
+		k, err := parseKey(e)
+		if err != nil {
+			return "", nil, fmt.Errorf("key %q: %w", e.ID, err)
+		}
+		keys[e.ID] = k
+	}
+
+	active, ok := keys[file.ActiveKeyID]
+	if !ok {
+		return "", nil, fmt.Errorf("active key %q is not in the keystore", file.ActiveKeyID)
+	}
+	if !active.canSign() {
+		return "", nil, fmt.Errorf("active key %q has no private key", file.ActiveKeyID)
+	}
+	return file.ActiveKeyID, keys, nil
+}
+
+func parseKey(e keyEntry) (key, error) {
+	switch e.Algorithm {
+	case AlgorithmEd25519:
+		if e.PrivateKey != "" {
+			seed, err := base64.StdEncoding.DecodeString(e.PrivateKey)
+			if err != nil || len(seed) != ed25519.SeedSize {
+				return key{}, fmt.Errorf("private_key must be a base64 %d-byte seed", ed25519.SeedSize)
+			}
+			private := ed25519.NewKeyFromSeed(seed)
+			public, _ := private.Public().(ed25519.PublicKey)
+			return key{algorithm: e.Algorithm, private: private, public: public}, nil
+		}
+		public, err := base64.StdEncoding.DecodeString(e.PublicKey)
+		if err != nil || len(public) != ed25519.PublicKeySize {
+			return key{}, fmt.Errorf("public_key must be %d bytes in base64", ed25519.PublicKeySize)
+		}
+		return key{algorithm: e.Algorithm, public: public}, nil
+	case AlgorithmHMACSHA256:
+		secret, err := base64.StdEncoding.DecodeString(e.Secret)
+		if err != nil || len(secret) < minHMACSecretLen {
+			return key{}, fmt.Errorf("secret must be at least %d bytes in base64", minHMACSecretLen)
+		}
+		return key{algorithm: e.Algorithm, secret: secret}, nil
+	default:
+		return key{}, errors.New("algorithm must be ed25519 or hmac-sha256")
+	}
+}
@@ -0,0 +1,251 @@
+package qrsign_test
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/qrcode"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/infrastructure/qrsign"
+)
+
+type keyEntry struct {
+	ID         string `json:"id"`
+	Algorithm  string `json:"algorithm"`
+	PrivateKey string `json:"private_key,omitempty"`
+	PublicKey  string `json:"public_key,omitempty"`
+	Secret     string `json:"secret,omitempty"`
+}
+
+func ed25519Key(id string, seedByte byte) keyEntry {
+	seed := []byte(strings.Repeat(string(rune(seedByte)), ed25519.SeedSize))
+	return keyEntry{ID: id, Algorithm: qrsign.AlgorithmEd25519, PrivateKey: base64.StdEncoding.EncodeToString(seed)}
+}
+
+// publicHalf is the entry an Ed25519 key is kept as once it is retired.
+func publicHalf(e keyEntry) keyEntry {
+	seed, _ := base64.StdEncoding.DecodeString(e.PrivateKey)
+	public, _ := ed25519.NewKeyFromSeed(seed).Public().(ed25519.PublicKey)
+	return keyEntry{ID: e.ID, Algorithm: e.Algorithm, PublicKey: base64.StdEncoding.EncodeToString(public)}
+}
+
+func hmacKey(id string) keyEntry {
+	secret := []byte(strings.Repeat("s", 32))
+	return keyEntry{ID: id, Algorithm: qrsign.AlgorithmHMACSHA256, Secret: base64.StdEncoding.EncodeToString(secret)}
+}
+
+// writeKeystore writes the keystore with the given modification time, which
+// is what tells the keystore that the file changed.
+func writeKeystore(t *testing.T, path, active string, modTime time.Time, keys ...keyEntry) {
+	t.Helper()
+	body, err := json.Marshal(map[string]any{"active_key_id": active, "keys": keys})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, body, 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func newKeystore(t *testing.T, active string, keys ...keyEntry) (*qrsign.Keystore, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "keystore.json")
+	writeKeystore(t, path, active, time.Now().Add(-time.Hour), keys...)
+	ks, err := qrsign.NewKeystore(path)
+	require.NoError(t, err)
+	return ks, path
+}
+
+func signed(t *testing.T, ks *qrsign.Keystore) qrcode.QRData {
+	t.Helper()
+	data := qrcode.QRData{
+		ToAccount: "3f1c6a52-8a0e-4a55-9a8e-2d6b1f0c7e41",
+		Amount:    1000,
+		Currency:  "RUB",
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}
+	require.NoError(t, ks.Sign(&data))
+	return data
+}
+
+func TestKeystore_SignsAndVerifies(t *testing.T) {
+	tests := []struct {
+		name string
+		key  keyEntry
+	}{
+		{name: "ed25519", key: ed25519Key("2026-10", 'a')},
+		{name: "hmac-sha256", key: hmacKey("legacy")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks, _ := newKeystore(t, tt.key.ID, tt.key)
+
+			data := signed(t, ks)
+
+			assert.Equal(t, tt.key.ID, data.KeyID)
+			assert.NotEmpty(t, data.Signature)
+			require.NoError(t, ks.Verify(data, time.Now()))
+		})
+	}
+}
+
+func TestKeystore_Verify_Rejects(t *testing.T) {
+	ks, _ := newKeystore(t, "2026-10", ed25519Key("2026-10", 'a'), hmacKey("legacy"))
+
+	tests := []struct {
+		name   string
+		tamper func(d *qrcode.QRData)
+		want   error
+	}{
+		{
+			name:   "tampered to_account",
+			tamper: func(d *qrcode.QRData) { d.ToAccount = "5b0e3a8c-1d2f-4e6a-9b7c-8d9e0f1a2b3c" },
+			want:   qrcode.ErrInvalidSignature,
+		},
+		{name: "tampered amount", tamper: func(d *qrcode.QRData) { d.Amount = 1 }, want: qrcode.ErrInvalidSignature},
+		{name: "tampered currency", tamper: func(d *qrcode.QRData) { d.Currency = "USD" }, want: qrcode.ErrInvalidSignature},
+		{
+			name:   "tampered exp",
+			tamper: func(d *qrcode.QRData) { d.ExpiresAt += 24 * 60 * 60 },
+			want:   qrcode.ErrInvalidSignature,
+		},
+		{name: "unknown kid", tamper: func(d *qrcode.QRData) { d.KeyID = "2025-01" }, want: qrcode.ErrInvalidSignature},
+		{
+			name:   "kid of another key",
+			tamper: func(d *qrcode.QRData) { d.KeyID = "legacy" },
+			want:   qrcode.ErrInvalidSignature,
+		},
+		{name: "unsigned", tamper: func(d *qrcode.QRData) { d.Signature = "" }, want: qrcode.ErrInvalidSignature},
+		{
+			name:   "malformed signature",
+			tamper: func(d *qrcode.QRData) { d.Signature = "not base64!" },
+			want:   qrcode.ErrInvalidSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := signed(t, ks)
+			tt.tamper(&data)
+
+			require.ErrorIs(t, ks.Verify(data, time.Now()), tt.want)
+		})
+	}
+}
+
+func TestKeystore_Verify_Expiry(t *testing.T) {
+	ks, _ := newKeystore(t, "2026-10", ed25519Key("2026-10", 'a'))
+	data := signed(t, ks)
+	expiresAt := time.Unix(data.ExpiresAt, 0)
+
+	require.NoError(t, ks.Verify(data, expiresAt.Add(-time.Second)))
+	require.ErrorIs(t, ks.Verify(data, expiresAt), qrcode.ErrSignatureExpired)
+
+	data.ExpiresAt = 0
+	require.NoError(t, ks.Sign(&data))
+	require.ErrorIs(t, ks.Verify(data, time.Now()), qrcode.ErrSignatureExpired)
+}
+
+func TestKeystore_VerifiesWithRetiredPublicKey(t *testing.T) {
+	old := ed25519Key("2026-04", 'a')
+	ks, path := newKeystore(t, old.ID, old)
+	before := signed(t, ks)
+
+	writeKeystore(t, path, "2026-10", time.Now(), ed25519Key("2026-10", 'b'), publicHalf(old))
+	loaded, err := ks.LoadOnce()
+	require.NoError(t, err)
+	require.True(t, loaded)
+
+	assert.Equal(t, "2026-10", ks.ActiveKeyID())
+	require.NoError(t, ks.Verify(before, time.Now()))
+	after := signed(t, ks)
+	assert.Equal(t, "2026-10", after.KeyID)
+	require.NoError(t, ks.Verify(after, time.Now()))
+}
+
+func TestKeystore_LoadOnce_KeepsKeysOnInvalidFile(t *testing.T) {
+	tests := []struct {
+		name   string
+		active string
+		keys   []keyEntry
+	}{
+		{name: "active key missing", active: "2026-10", keys: []keyEntry{ed25519Key("2026-04", 'a')}},
+		{name: "active key public only", active: "2026-04", keys: []keyEntry{publicHalf(ed25519Key("2026-04", 'a'))}},
+		{
+			name:   "short hmac secret",
+			active: "2026-04",
+			keys: []keyEntry{
+				ed25519Key("2026-04", 'a'),
+				{ID: "legacy", Algorithm: qrsign.AlgorithmHMACSHA256, Secret: base64.StdEncoding.EncodeToString([]byte("short"))},
+			},
+		},
+		{
+			name:   "bad seed",
+			active: "2026-04",
+			keys:   []keyEntry{{ID: "2026-04", Algorithm: qrsign.AlgorithmEd25519, PrivateKey: "c2VlZA=="}},
+		},
+		{
+			name:   "unknown algorithm",
+			active: "2026-04",
+			keys:   []keyEntry{ed25519Key("2026-04", 'a'), {ID: "rsa", Algorithm: "rsa"}},
+		},
+		{
+			name:   "duplicate id",
+			active: "2026-04",
+			keys:   []keyEntry{ed25519Key("2026-04", 'a'), hmacKey("2026-04")},
+		},
+		{name: "missing id", active: "2026-04", keys: []keyEntry{ed25519Key("2026-04", 'a'), hmacKey("")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ks, path := newKeystore(t, "2026-01", ed25519Key("2026-01", 'z'))
+			before := signed(t, ks)
+
+			writeKeystore(t, path, tt.active, time.Now(), tt.keys...)
+			loaded, err := ks.LoadOnce()
+
+			require.Error(t, err)
+			assert.False(t, loaded)
+			assert.Equal(t, "2026-01", ks.ActiveKeyID())
+			require.NoError(t, ks.Verify(before, time.Now()))
+			assert.Equal(t, "2026-01", signed(t, ks).KeyID)
+		})
+	}
+}
+
+func TestKeystore_LoadOnce_KeepsKeysOnMalformedJSON(t *testing.T) {
+	ks, path := newKeystore(t, "2026-01", ed25519Key("2026-01", 'z'))
+	before := signed(t, ks)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"active_key_id": `), 0o600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now()))
+	_, err := ks.LoadOnce()
+
+	require.Error(t, err)
+	require.NoError(t, ks.Verify(before, time.Now()))
+}
+
+func TestKeystore_LoadOnce_SkipsUnchangedFile(t *testing.T) {
+	ks, _ := newKeystore(t, "2026-01", ed25519Key("2026-01", 'z'))
+
+	loaded, err := ks.LoadOnce()
+
+	require.NoError(t, err)
+	assert.False(t, loaded)
+}
+
+func TestNewKeystore_RejectsInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keystore.json")
+	writeKeystore(t, path, "2026-10", time.Now(), publicHalf(ed25519Key("2026-10", 'a')))
+
+	_, err := qrsign.NewKeystore(path)
+
+	require.Error(t, err)
+}
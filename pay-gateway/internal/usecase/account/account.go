@@ -0,0 +1,68 @@
+package account
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/account"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/payment"
+)
+
+type ListRequest struct {
+	PageSize  int
+	PageToken string
+}
+
+type UseCase struct {
+	client account.Client
+}
+
+func NewUseCase(client account.Client) *UseCase {
+	return &UseCase{client: client}
+}
+
+// Create opens an empty account; an empty currency or tier leaves the choice
+// to pay-core's default.
+func (uc *UseCase) Create(ctx context.Context, currency, tier string) (*account.Account, error) {
+	return uc.client.CreateAccount(ctx, currency, tier)
+}
+
+func (uc *UseCase) Get(ctx context.Context, accountID string) (*account.Account, error) {
+	id, err := uuid.Parse(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid account_id", payment.ErrInvalidRequest)
+	}
+	return uc.client.GetAccount(ctx, id)
+}
+
+// SetRequisites replaces the bank details of the account; pay-core checks
+// their format.
+func (uc *UseCase) SetRequisites(
+	ctx context.Context,
+	accountID string,
+	requisites account.Requisites,
+) (*account.Requisites, error) {
+	id, err := uuid.Parse(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid account_id", payment.ErrInvalidRequest)
+	}
+	requisites.AccountID = id
+	return uc.client.SetAccountRequisites(ctx, requisites)
+}
+
+func (uc *UseCase) GetRequisites(ctx context.Context, accountID string) (*account.Requisites, error) {
+	id, err := uuid.Parse(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid account_id", payment.ErrInvalidRequest)
+	}
+	return uc.client.GetAccountRequisites(ctx, id)
+}
+
+func (uc *UseCase) List(ctx context.Context, req ListRequest) (*account.Page, error) {
+	if req.PageSize < 0 {
+		return nil, fmt.Errorf("%w: page_size must not be negative", payment.ErrInvalidRequest)
+	}
+	return uc.client.ListAccounts(ctx, req.PageSize, req.PageToken)
+}
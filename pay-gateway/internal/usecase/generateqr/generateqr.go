@@ -1,25 +1,105 @@
 package generateqr
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/account"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/payment"
 	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/qrcode"
 )
 
 type Request struct {
 	AccountID string
 	Amount    int64
+	// Currency defaults to qrcode.DefaultCurrency when empty.
+	Currency string
+	// Format defaults to qrcode.DefaultFormat when empty.
+	Format qrcode.Format
+	// MerchantName and MerchantCity override the gateway's defaults in
+	// formats that show them to the payer.
+	MerchantName string
+	MerchantCity string
+	// Purpose and Charset apply to qrcode.FormatGOST.
+	Purpose string
+	Charset qrcode.Charset
 }
 
 type UseCase struct {
 	generator qrcode.Generator
+	accounts  account.Client
+	merchant  qrcode.Merchant
 }
 
-func NewUseCase(generator qrcode.Generator) *UseCase {
-	return &UseCase{generator: generator}
+// NewUseCase creates the use case; merchant describes the payee in codes
+// whose requests do not describe it themselves, and accounts supplies the
+// bank details of the payee's account that GOST payment strings carry.
+func NewUseCase(generator qrcode.Generator, accounts account.Client, merchant qrcode.Merchant) *UseCase {
+	return &UseCase{generator: generator, accounts: accounts, merchant: merchant}
 }
 
-func (uc *UseCase) Execute(req Request) ([]byte, error) {
-	return uc.generator.Generate(qrcode.QRData{
+func (uc *UseCase) Execute(ctx context.Context, req Request) ([]byte, error) {
+	currency := req.Currency
+	if currency == "" {
+		currency = qrcode.DefaultCurrency
+	}
+	format := req.Format
+	if format == "" {
+		format = qrcode.DefaultFormat
+	}
+
+	merchant := uc.merchant
+	if req.MerchantName != "" {
+		merchant.Name = req.MerchantName
+	}
+	if req.MerchantCity != "" {
+		merchant.City = req.MerchantCity
+	}
+
+	data := qrcode.QRData{
 		ToAccount: req.AccountID,
 		Amount:    req.Amount,
-	})
+		Currency:  currency,
+		Merchant:  merchant,
+		Purpose:   req.Purpose,
+		Charset:   req.Charset,
+	}
+	if format == qrcode.FormatGOST {
+		payee, err := uc.payee(ctx, req.AccountID)
+		if err != nil {
+			return nil, err
+		}
+		data.Payee = payee
+	}
+
+	return uc.generator.Generate(data, format)
+}
+
+// payee reads the bank details of the account from pay-core.
+func (uc *UseCase) payee(ctx context.Context, accountID string) (qrcode.Payee, error) {
+	id, err := uuid.Parse(accountID)
+	if err != nil {
+		return qrcode.Payee{}, fmt.Errorf("%w: invalid account_id", payment.ErrInvalidRequest)
+	}
+	r, err := uc.accounts.GetAccountRequisites(ctx, id)
+	if err != nil {
+		return qrcode.Payee{}, err
+	}
+	return qrcode.Payee{
+		Name:        r.Name,
+		PersonalAcc: r.PersonalAcc,
+		BankName:    r.BankName,
+		BIC:         r.BIC,
+		CorrespAcc:  r.CorrespAcc,
+		PayeeINN:    r.PayeeINN,
+		KPP:         r.KPP,
+	}, nil
+}
+
+// Intent renders the code of a payment intent, which carries only the
+// intent's id; the payee and amount stay on the server until it is paid.
+func (uc *UseCase) Intent(intentID string) ([]byte, error) {
+	return uc.generator.Generate(qrcode.QRData{IntentID: intentID}, qrcode.FormatIntent)
 }
@@ -0,0 +1,110 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/payment"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/transaction"
+)
+
+const statusPrefix = "TRANSACTION_STATUS_"
+
+type ListRequest struct {
+	AccountID     string
+	Direction     string
+	Status        string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	PageSize      int
+	PageToken     string
+}
+
+type UseCase struct {
+	client transaction.Client
+}
+
+func NewUseCase(client transaction.Client) *UseCase {
+	return &UseCase{client: client}
+}
+
+func (uc *UseCase) Get(ctx context.Context, transactionID string) (*transaction.Transaction, error) {
+	id, err := uuid.Parse(transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid transaction_id", payment.ErrInvalidRequest)
+	}
+	return uc.client.GetTransaction(ctx, id)
+}
+
+func (uc *UseCase) List(ctx context.Context, req ListRequest) (*transaction.Page, error) {
+	accountID, err := uuid.Parse(req.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid account_id", payment.ErrInvalidRequest)
+	}
+	if req.PageSize < 0 {
+		return nil, fmt.Errorf("%w: page_size must not be negative", payment.ErrInvalidRequest)
+	}
+
+	direction := transaction.Direction(strings.ToLower(req.Direction))
+	switch direction {
+	case transaction.DirectionAny, transaction.DirectionIncoming, transaction.DirectionOutgoing:
+	default:
+		return nil, fmt.Errorf("%w: direction must be incoming or outgoing", payment.ErrInvalidRequest)
+	}
+
+	status, err := parseStatus(req.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.client.ListTransactions(ctx, transaction.Filter{
+		AccountID:     accountID,
+		Direction:     direction,
+		Status:        status,
+		CreatedAfter:  req.CreatedAfter,
+		CreatedBefore: req.CreatedBefore,
+		PageSize:      req.PageSize,
+		PageToken:     req.PageToken,
+	})
+}
+
+// Subscribe follows the transactions of an account from now on. If
+// lastEventID, the sequence of the last event the subscriber received, is
+// set, it first replays the events missed since that one; it fails with
+// payment.ErrEventNotFound if there is no such event.
+func (uc *UseCase) Subscribe(ctx context.Context, accountID, lastEventID string) (transaction.EventStream, error) {
+	id, err := uuid.Parse(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid account_id", payment.ErrInvalidRequest)
+	}
+	var afterSeq int64
+	if lastEventID != "" {
+		if afterSeq, err = strconv.ParseInt(lastEventID, 10, 64); err != nil || afterSeq < 0 {
+			return nil, fmt.Errorf("%w: invalid Last-Event-ID", payment.ErrInvalidRequest)
+		}
+	}
+	return uc.client.SubscribeAccountEvents(ctx, id, afterSeq)
+}
+
+// parseStatus accepts both the short form ("success") and the full name
+// returned in responses ("TRANSACTION_STATUS_SUCCESS").
+func parseStatus(s string) (string, error) {
+	if s == "" {
+		return "", nil
+	}
+	name := strings.ToUpper(s)
+	if !strings.HasPrefix(name, statusPrefix) {
+		name = statusPrefix + name
+	}
+	switch strings.TrimPrefix(name, statusPrefix) {
+	case "PENDING", "SUCCESS", "FAILED":
+		return name, nil
+	default:
+		return "", fmt.Errorf("%w: unknown status %q", payment.ErrInvalidRequest, s)
+	}
+}
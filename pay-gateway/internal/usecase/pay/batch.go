@@ -0,0 +1,75 @@
+package pay
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/payment"
+)
+
+type BatchTransfer struct {
+	FromID   string
+	ToID     string
+	Amount   int64
+	Currency string
+	// Type is "p2p" or "qr_merchant"; empty means "p2p".
+	Type string
+}
+
+type BatchRequest struct {
+	IdempotencyKey string
+	// Mode is "atomic" or "best_effort".
+	Mode      string
+	Transfers []BatchTransfer
+}
+
+// ProcessBatch submits a payout batch. The number of transfers and their
+// amounts are checked by pay-core, which rejects the whole batch if any
+// transfer is malformed.
+func (uc *UseCase) ProcessBatch(ctx context.Context, req BatchRequest) (*payment.Batch, error) {
+	switch req.Mode {
+	case payment.BatchAtomic, payment.BatchBestEffort:
+	default:
+		return nil, fmt.Errorf("%w: mode must be atomic or best_effort", payment.ErrInvalidRequest)
+	}
+
+	transfers := make([]payment.BatchTransfer, 0, len(req.Transfers))
+	for pos, t := range req.Transfers {
+		fromID, err := uuid.Parse(t.FromID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: transfers[%d]: invalid from_id", payment.ErrInvalidRequest, pos)
+		}
+		toID, err := uuid.Parse(t.ToID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: transfers[%d]: invalid to_id", payment.ErrInvalidRequest, pos)
+		}
+		switch t.Type {
+		case "", payment.TransferP2P, payment.TransferQRMerchant:
+		default:
+			return nil, fmt.Errorf("%w: transfers[%d]: invalid type", payment.ErrInvalidRequest, pos)
+		}
+		transfers = append(transfers, payment.BatchTransfer{
+			FromAccountID: fromID,
+			ToAccountID:   toID,
+			Amount:        t.Amount,
+			Currency:      t.Currency,
+			Type:          t.Type,
+		})
+	}
+
+	return uc.client.ProcessBatch(ctx, payment.BatchRequest{
+		IdempotencyKey: req.IdempotencyKey,
+		Mode:           req.Mode,
+		Transfers:      transfers,
+	})
+}
+
+func (uc *UseCase) GetBatch(ctx context.Context, batchID string) (*payment.Batch, error) {
+	id, err := uuid.Parse(batchID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid batch_id", payment.ErrInvalidRequest)
+	}
+	return uc.client.GetBatch(ctx, id)
+}
@@ -0,0 +1,102 @@
+package pay
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/payment"
+)
+
+type CreateIntentRequest struct {
+	ToID     string
+	Amount   int64
+	Currency string
+	// TTL is how long the intent stays payable; zero means pay-core's default.
+	TTL time.Duration
+}
+
+type PayIntentRequest struct {
+	IdempotencyKey string
+	IntentID       string
+	FromID         string
+}
+
+// CreateIntent asks pay-core for a single-use payment intent, which POS
+// terminals show as a code carrying only its id.
+func (uc *UseCase) CreateIntent(ctx context.Context, req CreateIntentRequest) (*payment.Intent, error) {
+	toID, err := uuid.Parse(req.ToID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid to_id", payment.ErrInvalidRequest)
+	}
+	if req.TTL < 0 {
+		return nil, fmt.Errorf("%w: ttl_seconds must not be negative", payment.ErrInvalidRequest)
+	}
+
+	return uc.client.CreatePaymentIntent(ctx, payment.CreateIntentRequest{
+		ToAccountID: toID,
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		TTL:         req.TTL,
+	})
+}
+
+func (uc *UseCase) GetIntent(ctx context.Context, intentID string) (*payment.Intent, error) {
+	id, err := uuid.Parse(intentID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid intent_id", payment.ErrInvalidRequest)
+	}
+	return uc.client.GetPaymentIntent(ctx, id)
+}
+
+// OpenIntent returns the intent if it can still be paid, and otherwise the
+// error paying it would fail with.
+func (uc *UseCase) OpenIntent(ctx context.Context, intentID string) (*payment.Intent, error) {
+	intent, err := uc.GetIntent(ctx, intentID)
+	if err != nil {
+		return nil, err
+	}
+	switch intent.Status {
+	case payment.IntentCreated:
+		return intent, nil
+	case payment.IntentExpired:
+		return nil, payment.ErrIntentExpired
+	default:
+		return nil, fmt.Errorf("%w: %s", payment.ErrIntentNotOpen, intent.Status)
+	}
+}
+
+func (uc *UseCase) CancelIntent(ctx context.Context, intentID string) (*payment.Intent, error) {
+	id, err := uuid.Parse(intentID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid intent_id", payment.ErrInvalidRequest)
+	}
+	return uc.client.CancelPaymentIntent(ctx, id)
+}
+
+// PayIntent pays the intent from the payer's account. pay-core settles each
+// intent at most once, so a second payment of the same code fails with
+// payment.ErrIntentNotOpen instead of charging the payer again.
+func (uc *UseCase) PayIntent(ctx context.Context, req PayIntentRequest) (*Response, error) {
+	intentID, err := uuid.Parse(req.IntentID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid intent_id", payment.ErrInvalidRequest)
+	}
+
+	fromID, err := uuid.Parse(req.FromID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid from_id", payment.ErrInvalidRequest)
+	}
+
+	resp, err := uc.client.PayPaymentIntent(ctx, payment.PayIntentRequest{
+		IdempotencyKey: req.IdempotencyKey,
+		IntentID:       intentID,
+		FromAccountID:  fromID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toResponse(resp), nil
+}
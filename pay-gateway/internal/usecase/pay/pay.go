@@ -2,10 +2,12 @@ package pay
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/google/uuid"
 
 	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/payment"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/qrcode"
 )
 
 type Request struct {
@@ -13,31 +15,70 @@ type Request struct {
 	FromID         string
 	ToID           string
 	Amount         int64
+	Currency       string
+	QuoteID        string
+	// Type is "p2p" or "qr_merchant"; empty means "p2p".
+	Type string
+	// QRPayload is the content of a scanned code the payment is made by. Its
+	// signature is checked before anything is sent to pay-core, and the
+	// payee, amount and currency it carries fill in those left empty.
+	QRPayload string
+}
+
+type RefundRequest struct {
+	IdempotencyKey string
+	TransactionID  string
+	Amount         int64
 }
 
 type Response struct {
 	TransactionID string
 	Status        string
 	Error         string
+	FailureReason string
+	Fee           int64
+	FeeBearer     string
+	ExceededLimit string
 }
 
 type UseCase struct {
 	client payment.Client
+	qr     qrcode.Reader
 }
 
-func NewUseCase(client payment.Client) *UseCase {
-	return &UseCase{client: client}
+func NewUseCase(client payment.Client, qr qrcode.Reader) *UseCase {
+	return &UseCase{client: client, qr: qr}
 }
 
 func (uc *UseCase) Execute(ctx context.Context, req Request) (*Response, error) {
+	if req.QRPayload != "" {
+		var err error
+		if req, err = uc.applyQR(req); err != nil {
+			return nil, err
+		}
+	}
+
 	fromID, err := uuid.Parse(req.FromID)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: invalid from_id", payment.ErrInvalidRequest)
 	}
 
 	toID, err := uuid.Parse(req.ToID)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: invalid to_id", payment.ErrInvalidRequest)
+	}
+
+	var quoteID uuid.UUID
+	if req.QuoteID != "" {
+		if quoteID, err = uuid.Parse(req.QuoteID); err != nil {
+			return nil, fmt.Errorf("%w: invalid quote_id", payment.ErrInvalidRequest)
+		}
+	}
+
+	switch req.Type {
+	case "", payment.TransferP2P, payment.TransferQRMerchant:
+	default:
+		return nil, fmt.Errorf("%w: invalid type", payment.ErrInvalidRequest)
 	}
 
 	resp, err := uc.client.ProcessPayment(ctx, payment.Request{
@@ -45,14 +86,146 @@ func (uc *UseCase) Execute(ctx context.Context, req Request) (*Response, error)
 		FromAccountID:  fromID,
 		ToAccountID:    toID,
 		Amount:         req.Amount,
+		Currency:       req.Currency,
+		QuoteID:        quoteID,
+		Type:           req.Type,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toResponse(resp), nil
+}
+
+// applyQR verifies the scanned payload of req and fills in the payment it
+// asks for. Fields the request sets must agree with the payload, so that a
+// client cannot pay a signed code to someone else or for another amount.
+func (uc *UseCase) applyQR(req Request) (Request, error) {
+	data, err := uc.qr.Read(req.QRPayload)
+	if err != nil {
+		return Request{}, err
+	}
+
+	if req.ToID == "" {
+		req.ToID = data.ToAccount
+	} else if req.ToID != data.ToAccount {
+		return Request{}, fmt.Errorf("%w: to_id differs from the qr code", payment.ErrInvalidRequest)
+	}
+	if req.Amount == 0 {
+		req.Amount = data.Amount
+	} else if req.Amount != data.Amount {
+		return Request{}, fmt.Errorf("%w: amount differs from the qr code", payment.ErrInvalidRequest)
+	}
+	if req.Currency == "" {
+		req.Currency = data.Currency
+	} else if req.Currency != data.Currency {
+		return Request{}, fmt.Errorf("%w: currency differs from the qr code", payment.ErrInvalidRequest)
+	}
+	return req, nil
+}
+
+func (uc *UseCase) Refund(ctx context.Context, req RefundRequest) (*Response, error) {
+	txID, err := uuid.Parse(req.TransactionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid transaction_id", payment.ErrInvalidRequest)
+	}
+
+	resp, err := uc.client.RefundPayment(ctx, payment.RefundRequest{
+		IdempotencyKey: req.IdempotencyKey,
+		TransactionID:  txID,
+		Amount:         req.Amount,
 	})
 	if err != nil {
 		return nil, err
 	}
+	return toResponse(resp), nil
+}
 
+func toResponse(resp *payment.Response) *Response {
 	return &Response{
 		TransactionID: resp.TransactionID,
 		Status:        resp.Status,
 		Error:         resp.ErrorMessage,
-	}, nil
+		FailureReason: resp.FailureReason,
+		Fee:           resp.Fee,
+		FeeBearer:     resp.FeeBearer,
+		ExceededLimit: resp.ExceededLimit,
+	}
+}
+
+type AuthorizeRequest struct {
+	IdempotencyKey string
+	FromID         string
+	ToID           string
+	Amount         int64
+	Currency       string
+}
+
+type CaptureRequest struct {
+	IdempotencyKey  string
+	AuthorizationID string
+	Amount          int64
+}
+
+func (uc *UseCase) Authorize(ctx context.Context, req AuthorizeRequest) (*payment.Authorization, error) {
+	fromID, err := uuid.Parse(req.FromID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid from_id", payment.ErrInvalidRequest)
+	}
+
+	toID, err := uuid.Parse(req.ToID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid to_id", payment.ErrInvalidRequest)
+	}
+
+	return uc.client.AuthorizePayment(ctx, payment.AuthorizeRequest{
+		IdempotencyKey: req.IdempotencyKey,
+		FromAccountID:  fromID,
+		ToAccountID:    toID,
+		Amount:         req.Amount,
+		Currency:       req.Currency,
+	})
+}
+
+func (uc *UseCase) Capture(ctx context.Context, req CaptureRequest) (*Response, error) {
+	authID, err := uuid.Parse(req.AuthorizationID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid authorization_id", payment.ErrInvalidRequest)
+	}
+
+	resp, err := uc.client.CapturePayment(ctx, payment.CaptureRequest{
+		IdempotencyKey:  req.IdempotencyKey,
+		AuthorizationID: authID,
+		Amount:          req.Amount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toResponse(resp), nil
+}
+
+func (uc *UseCase) Void(ctx context.Context, authorizationID string) (*payment.Authorization, error) {
+	authID, err := uuid.Parse(authorizationID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid authorization_id", payment.ErrInvalidRequest)
+	}
+	return uc.client.VoidAuthorization(ctx, authID)
+}
+
+type QuoteRequest struct {
+	FromCurrency string
+	ToCurrency   string
+	Amount       int64
+}
+
+// Quote asks pay-core to price a conversion; the returned quote ID is then
+// passed with the payment as Request.QuoteID.
+func (uc *UseCase) Quote(ctx context.Context, req QuoteRequest) (*payment.Quote, error) {
+	if req.FromCurrency == "" || req.ToCurrency == "" {
+		return nil, fmt.Errorf("%w: from_currency and to_currency are required", payment.ErrInvalidRequest)
+	}
+	return uc.client.GetQuote(ctx, payment.QuoteRequest{
+		FromCurrency: req.FromCurrency,
+		ToCurrency:   req.ToCurrency,
+		Amount:       req.Amount,
+	})
 }
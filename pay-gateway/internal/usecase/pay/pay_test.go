@@ -0,0 +1,157 @@
+package pay_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/payment"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/qrcode"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/infrastructure/qrgenerator"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/infrastructure/qrsign"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/usecase/pay"
+)
+
+const (
+	payerAccount = "9b2e7d10-4c3a-4f5e-8a1b-6c7d8e9f0a1b"
+	payeeAccount = "3f1c6a52-8a0e-4a55-9a8e-2d6b1f0c7e41"
+)
+
+// fakeClient records the payments it is asked to process; any other call
+// panics on the nil embedded client.
+type fakeClient struct {
+	payment.Client
+	payments []payment.Request
+}
+
+func (c *fakeClient) ProcessPayment(_ context.Context, req payment.Request) (*payment.Response, error) {
+	c.payments = append(c.payments, req)
+	return &payment.Response{TransactionID: "tx-1", Status: "TRANSACTION_STATUS_COMPLETED"}, nil
+}
+
+func newSigner(t *testing.T) *qrsign.Keystore {
+	t.Helper()
+	body, err := json.Marshal(map[string]any{
+		"active_key_id": "k1",
+		"keys": []map[string]string{{
+			"id":        "k1",
+			"algorithm": qrsign.AlgorithmHMACSHA256,
+			"secret":    base64.StdEncoding.EncodeToString([]byte(strings.Repeat("s", 32))),
+		}},
+	})
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "keystore.json")
+	require.NoError(t, os.WriteFile(path, body, 0o600))
+	ks, err := qrsign.NewKeystore(path)
+	require.NoError(t, err)
+	return ks
+}
+
+// signedPayload is the payload of a JSON code signed to expire at expiresAt.
+func signedPayload(t *testing.T, signer qrcode.Signer, data qrcode.QRData, expiresAt time.Time) string {
+	t.Helper()
+	data.ExpiresAt = expiresAt.Unix()
+	require.NoError(t, signer.Sign(&data))
+	payload, err := qrgenerator.Encode(data, qrcode.FormatJSON)
+	require.NoError(t, err)
+	return payload
+}
+
+func TestUseCase_Execute_FillsInFromQR(t *testing.T) {
+	signer := newSigner(t)
+	data := qrcode.QRData{ToAccount: payeeAccount, Amount: 12345, Currency: "RUB"}
+	client := &fakeClient{}
+	uc := pay.NewUseCase(client, qrgenerator.NewGenerator(0, signer, time.Hour))
+
+	resp, err := uc.Execute(context.Background(), pay.Request{
+		IdempotencyKey: "key-1",
+		FromID:         payerAccount,
+		QRPayload:      signedPayload(t, signer, data, time.Now().Add(time.Hour)),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "tx-1", resp.TransactionID)
+
+	require.Len(t, client.payments, 1)
+	assert.Equal(t, payeeAccount, client.payments[0].ToAccountID.String())
+	assert.Equal(t, int64(12345), client.payments[0].Amount)
+	assert.Equal(t, "RUB", client.payments[0].Currency)
+}
+
+func TestUseCase_Execute_RejectsQRBeforePaying(t *testing.T) {
+	signer := newSigner(t)
+	data := qrcode.QRData{ToAccount: payeeAccount, Amount: 12345, Currency: "RUB"}
+	signed := signedPayload(t, signer, data, time.Now().Add(time.Hour))
+	unsigned, err := qrgenerator.Encode(data, qrcode.FormatJSON)
+	require.NoError(t, err)
+	emvco, err := qrgenerator.Encode(data, qrcode.FormatEMVCo)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		req     pay.Request
+		wantErr error
+	}{
+		{
+			name:    "tampered amount",
+			req:     pay.Request{QRPayload: strings.Replace(signed, `"amount":12345`, `"amount":1`, 1)},
+			wantErr: qrcode.ErrInvalidSignature,
+		},
+		{
+			name:    "tampered payee",
+			req:     pay.Request{QRPayload: strings.Replace(signed, payeeAccount, payerAccount, 1)},
+			wantErr: qrcode.ErrInvalidSignature,
+		},
+		{
+			name:    "expired",
+			req:     pay.Request{QRPayload: signedPayload(t, signer, data, time.Now().Add(-time.Minute))},
+			wantErr: qrcode.ErrSignatureExpired,
+		},
+		{
+			name:    "unsigned",
+			req:     pay.Request{QRPayload: unsigned},
+			wantErr: qrcode.ErrInvalidSignature,
+		},
+		{
+			name:    "emvco",
+			req:     pay.Request{QRPayload: emvco},
+			wantErr: qrcode.ErrInvalidSignature,
+		},
+		{
+			name:    "other payee",
+			req:     pay.Request{ToID: payerAccount, QRPayload: signed},
+			wantErr: payment.ErrInvalidRequest,
+		},
+		{
+			name:    "other amount",
+			req:     pay.Request{Amount: 1, QRPayload: signed},
+			wantErr: payment.ErrInvalidRequest,
+		},
+		{
+			name:    "other currency",
+			req:     pay.Request{Currency: "USD", QRPayload: signed},
+			wantErr: payment.ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeClient{}
+			uc := pay.NewUseCase(client, qrgenerator.NewGenerator(0, signer, time.Hour))
+			tt.req.IdempotencyKey = "key-1"
+			tt.req.FromID = payerAccount
+
+			resp, err := uc.Execute(context.Background(), tt.req)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, resp)
+			assert.Empty(t, client.payments, "ProcessPayment must not be called")
+		})
+	}
+}
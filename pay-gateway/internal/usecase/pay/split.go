@@ -0,0 +1,66 @@
+package pay
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/payment"
+)
+
+type SplitLeg struct {
+	ToID   string
+	Amount int64
+}
+
+type SplitRequest struct {
+	IdempotencyKey string
+	FromID         string
+	Amount         int64
+	Currency       string
+	Legs           []SplitLeg
+	// Type is "p2p" or "qr_merchant"; empty means "p2p".
+	Type string
+}
+
+// Split pays several payees from one payer at once. pay-core checks that the
+// legs sum to the amount and rejects the payment as a whole otherwise.
+func (uc *UseCase) Split(ctx context.Context, req SplitRequest) (*payment.SplitPayment, error) {
+	fromID, err := uuid.Parse(req.FromID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid from_id", payment.ErrInvalidRequest)
+	}
+
+	switch req.Type {
+	case "", payment.TransferP2P, payment.TransferQRMerchant:
+	default:
+		return nil, fmt.Errorf("%w: invalid type", payment.ErrInvalidRequest)
+	}
+
+	legs := make([]payment.SplitLeg, 0, len(req.Legs))
+	for pos, leg := range req.Legs {
+		toID, parseErr := uuid.Parse(leg.ToID)
+		if parseErr != nil {
+			return nil, fmt.Errorf("%w: legs[%d]: invalid to_id", payment.ErrInvalidRequest, pos)
+		}
+		legs = append(legs, payment.SplitLeg{ToAccountID: toID, Amount: leg.Amount})
+	}
+
+	return uc.client.ProcessSplitPayment(ctx, payment.SplitRequest{
+		IdempotencyKey: req.IdempotencyKey,
+		FromAccountID:  fromID,
+		Amount:         req.Amount,
+		Currency:       req.Currency,
+		Legs:           legs,
+		Type:           req.Type,
+	})
+}
+
+func (uc *UseCase) GetSplit(ctx context.Context, splitID string) (*payment.SplitPayment, error) {
+	id, err := uuid.Parse(splitID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid split_payment_id", payment.ErrInvalidRequest)
+	}
+	return uc.client.GetSplitPayment(ctx, id)
+}
@@ -0,0 +1,138 @@
+package scanqr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/payment"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/qrcode"
+)
+
+// ErrNotPayable marks a code that was read but cannot be paid through the
+// gateway, such as a GOST payment string, which pays a bank account.
+var ErrNotPayable = errors.New("qr code cannot be paid through the gateway")
+
+// ErrUnsigned marks a code that /api/pay does not take as qr_payload because
+// it carries no signature, such as an EMVCo payload. Its payee and amount are
+// still read, and can be paid as to_id and amount.
+var ErrUnsigned = errors.New("unsigned qr code: pay with to_id, not qr_payload")
+
+// Result is what a scanned code asks the payer to pay. Problems lists every
+// reason the code cannot be paid as it is; a code without problems can be.
+type Result struct {
+	// Payload is the content of the code, which /api/pay takes as qr_payload
+	// if it is a signed JSON code.
+	Payload string
+	// Format is empty if the payload is in none of the known formats.
+	Format qrcode.Format
+	Data   qrcode.QRData
+	// Intent is the payment intent an intent code refers to, if pay-core has it.
+	Intent   *payment.Intent
+	Problems []error
+}
+
+type UseCase struct {
+	scanner qrcode.Scanner
+	parser  qrcode.Parser
+	reader  qrcode.Reader
+	client  payment.Client
+}
+
+// NewUseCase creates the use case; reader checks the signatures of JSON codes
+// and client looks up the intents that intent codes refer to.
+func NewUseCase(scanner qrcode.Scanner, parser qrcode.Parser, reader qrcode.Reader, client payment.Client) *UseCase {
+	return &UseCase{scanner: scanner, parser: parser, reader: reader, client: client}
+}
+
+// Scan reads the code in a PNG or JPEG image. An image without a readable
+// code fails with qrcode.ErrNoCode; a code that was read is always returned,
+// with whatever is wrong with it in Result.Problems.
+func (uc *UseCase) Scan(ctx context.Context, img []byte) (*Result, error) {
+	payload, err := uc.scanner.Scan(img)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Result{Payload: payload}
+	data, format, err := uc.parser.Parse(payload)
+	if err != nil {
+		res.Problems = append(res.Problems, err)
+		return res, nil
+	}
+	res.Data = data
+	res.Format = format
+
+	switch format {
+	case qrcode.FormatJSON:
+		if _, readErr := uc.reader.Read(payload); readErr != nil {
+			res.Problems = append(res.Problems, readErr)
+		}
+		res.Problems = append(res.Problems, checkPayment(data, true)...)
+	case qrcode.FormatEMVCo:
+		res.Problems = append(res.Problems, ErrUnsigned)
+		res.Problems = append(res.Problems, checkPayment(data, false)...)
+	case qrcode.FormatGOST:
+		res.Problems = append(res.Problems, fmt.Errorf("%w: %s codes pay a bank account", ErrNotPayable, format))
+	case qrcode.FormatIntent:
+		if intentErr := uc.resolveIntent(ctx, res); intentErr != nil {
+			return nil, intentErr
+		}
+	}
+	return res, nil
+}
+
+// resolveIntent fills in the payee and amount of the intent the code refers
+// to. Only failing to reach pay-core is an error; an unknown, paid or expired
+// intent is a problem with the code.
+func (uc *UseCase) resolveIntent(ctx context.Context, res *Result) error {
+	id, err := uuid.Parse(res.Data.IntentID)
+	if err != nil {
+		res.Problems = append(res.Problems, fmt.Errorf("%w: invalid intent_id", qrcode.ErrInvalidPayload))
+		return nil
+	}
+
+	intent, err := uc.client.GetPaymentIntent(ctx, id)
+	if errors.Is(err, payment.ErrIntentNotFound) {
+		res.Problems = append(res.Problems, err)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	res.Intent = intent
+	res.Data.ToAccount = intent.ToAccountID.String()
+	res.Data.Amount = intent.Amount
+	res.Data.Currency = intent.Currency
+	switch intent.Status {
+	case payment.IntentCreated:
+	case payment.IntentExpired:
+		res.Problems = append(res.Problems, payment.ErrIntentExpired)
+	default:
+		res.Problems = append(res.Problems, fmt.Errorf("%w: %s", payment.ErrIntentNotOpen, intent.Status))
+	}
+	return nil
+}
+
+// checkPayment checks that the code names an account to pay and, if
+// amountRequired, an amount; codes that leave the amount to the payer may
+// omit it.
+func checkPayment(data qrcode.QRData, amountRequired bool) []error {
+	var problems []error
+	if _, err := uuid.Parse(data.ToAccount); err != nil {
+		problems = append(problems, fmt.Errorf("%w: invalid to_account", qrcode.ErrInvalidPayload))
+	}
+	switch {
+	case data.Amount < 0:
+		problems = append(problems, fmt.Errorf("%w: negative amount", qrcode.ErrInvalidPayload))
+	case data.Amount == 0 && amountRequired:
+		problems = append(problems, fmt.Errorf("%w: amount is required", qrcode.ErrInvalidPayload))
+	}
+	if data.Currency == "" {
+		problems = append(problems, fmt.Errorf("%w: currency is required", qrcode.ErrInvalidPayload))
+	}
+	return problems
+}
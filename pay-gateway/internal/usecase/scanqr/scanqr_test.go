@@ -0,0 +1,247 @@
+package scanqr_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/payment"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/domain/qrcode"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/infrastructure/qrgenerator"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/infrastructure/qrsign"
+	"github.com/Xausdorf/qr-pay-hub/pay-gateway/internal/usecase/scanqr"
+)
+
+const testAccount = "3f1c6a52-8a0e-4a55-9a8e-2d6b1f0c7e41"
+
+// fakeScanner "reads" the payload it was given from any image.
+type fakeScanner struct {
+	payload string
+	err     error
+}
+
+func (s fakeScanner) Scan([]byte) (string, error) {
+	return s.payload, s.err
+}
+
+// fakeClient answers GetPaymentIntent; any other call panics on the nil
+// embedded client.
+type fakeClient struct {
+	payment.Client
+	intent *payment.Intent
+	err    error
+}
+
+func (c *fakeClient) GetPaymentIntent(_ context.Context, id uuid.UUID) (*payment.Intent, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	if c.intent == nil || c.intent.ID != id {
+		return nil, payment.ErrIntentNotFound
+	}
+	return c.intent, nil
+}
+
+func newSigner(t *testing.T) *qrsign.Keystore {
+	t.Helper()
+	body, err := json.Marshal(map[string]any{
+		"active_key_id": "k1",
+		"keys": []map[string]string{{
+			"id":        "k1",
+			"algorithm": qrsign.AlgorithmHMACSHA256,
+			"secret":    base64.StdEncoding.EncodeToString([]byte(strings.Repeat("s", 32))),
+		}},
+	})
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "keystore.json")
+	require.NoError(t, os.WriteFile(path, body, 0o600))
+	ks, err := qrsign.NewKeystore(path)
+	require.NoError(t, err)
+	return ks
+}
+
+// signedPayload is the payload of a JSON code signed to expire at expiresAt.
+func signedPayload(t *testing.T, signer qrcode.Signer, data qrcode.QRData, expiresAt time.Time) string {
+	t.Helper()
+	data.ExpiresAt = expiresAt.Unix()
+	require.NoError(t, signer.Sign(&data))
+	payload, err := qrgenerator.Encode(data, qrcode.FormatJSON)
+	require.NoError(t, err)
+	return payload
+}
+
+func encode(t *testing.T, data qrcode.QRData, format qrcode.Format) string {
+	t.Helper()
+	payload, err := qrgenerator.Encode(data, format)
+	require.NoError(t, err)
+	return payload
+}
+
+func TestUseCase_Scan_Problems(t *testing.T) {
+	signer := newSigner(t)
+	gen := qrgenerator.NewGenerator(0, signer, time.Hour)
+	data := qrcode.QRData{ToAccount: testAccount, Amount: 12345, Currency: "RUB"}
+	signed := signedPayload(t, signer, data, time.Now().Add(time.Hour))
+	merchant := qrcode.Merchant{Name: "Coffee Point", City: "Moscow", CountryCode: "RU", CategoryCode: "5814"}
+	payee := qrcode.Payee{
+		Name:        "Три кита",
+		PersonalAcc: "40702810138250123017",
+		BankName:    "Сбербанк",
+		BIC:         "044525225",
+	}
+
+	open := &payment.Intent{
+		ID:          uuid.New(),
+		ToAccountID: uuid.MustParse(testAccount),
+		Amount:      5000,
+		Currency:    "RUB",
+		Status:      payment.IntentCreated,
+	}
+	expired, paid := *open, *open
+	expired.Status = payment.IntentExpired
+	paid.Status = payment.IntentPaid
+	intentPayload := encode(t, qrcode.QRData{IntentID: open.ID.String()}, qrcode.FormatIntent)
+
+	tests := []struct {
+		name     string
+		payload  string
+		intent   *payment.Intent
+		format   qrcode.Format
+		problems []error
+	}{
+		{name: "signed json", payload: signed, format: qrcode.FormatJSON},
+		{
+			name:     "tampered json",
+			payload:  strings.Replace(signed, `"amount":12345`, `"amount":99999`, 1),
+			format:   qrcode.FormatJSON,
+			problems: []error{qrcode.ErrInvalidSignature},
+		},
+		{
+			name:     "expired json",
+			payload:  signedPayload(t, signer, data, time.Now().Add(-time.Minute)),
+			format:   qrcode.FormatJSON,
+			problems: []error{qrcode.ErrSignatureExpired},
+		},
+		{
+			name:     "unsigned json without amount",
+			payload:  encode(t, qrcode.QRData{ToAccount: testAccount, Currency: "RUB"}, qrcode.FormatJSON),
+			format:   qrcode.FormatJSON,
+			problems: []error{qrcode.ErrInvalidSignature, qrcode.ErrInvalidPayload},
+		},
+		{
+			name:     "emvco",
+			payload:  encode(t, qrcode.QRData{ToAccount: testAccount, Currency: "RUB", Merchant: merchant}, qrcode.FormatEMVCo),
+			format:   qrcode.FormatEMVCo,
+			problems: []error{scanqr.ErrUnsigned},
+		},
+		{
+			name:     "gost",
+			payload:  encode(t, qrcode.QRData{Amount: 150000, Currency: "RUB", Payee: payee}, qrcode.FormatGOST),
+			format:   qrcode.FormatGOST,
+			problems: []error{scanqr.ErrNotPayable},
+		},
+		{name: "open intent", payload: intentPayload, intent: open, format: qrcode.FormatIntent},
+		{
+			name:     "expired intent",
+			payload:  intentPayload,
+			intent:   &expired,
+			format:   qrcode.FormatIntent,
+			problems: []error{payment.ErrIntentExpired},
+		},
+		{
+			name:     "paid intent",
+			payload:  intentPayload,
+			intent:   &paid,
+			format:   qrcode.FormatIntent,
+			problems: []error{payment.ErrIntentNotOpen},
+		},
+		{
+			name:     "unknown intent",
+			payload:  intentPayload,
+			format:   qrcode.FormatIntent,
+			problems: []error{payment.ErrIntentNotFound},
+		},
+		{name: "unknown format", payload: "hello", problems: []error{qrcode.ErrUnknownFormat}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := scanqr.NewUseCase(fakeScanner{payload: tt.payload}, gen, gen, &fakeClient{intent: tt.intent})
+
+			res, err := uc.Scan(context.Background(), []byte("image"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.payload, res.Payload)
+			assert.Equal(t, tt.format, res.Format)
+			require.Len(t, res.Problems, len(tt.problems), "problems: %v", res.Problems)
+			for i, want := range tt.problems {
+				assert.ErrorIs(t, res.Problems[i], want)
+			}
+		})
+	}
+}
+
+func TestUseCase_Scan_FillsInIntent(t *testing.T) {
+	gen := qrgenerator.NewGenerator(0, nil, 0)
+	intent := &payment.Intent{
+		ID:          uuid.New(),
+		ToAccountID: uuid.MustParse(testAccount),
+		Amount:      5000,
+		Currency:    "RUB",
+		Status:      payment.IntentCreated,
+	}
+	payload := encode(t, qrcode.QRData{IntentID: intent.ID.String()}, qrcode.FormatIntent)
+	uc := scanqr.NewUseCase(fakeScanner{payload: payload}, gen, gen, &fakeClient{intent: intent})
+
+	res, err := uc.Scan(context.Background(), []byte("image"))
+	require.NoError(t, err)
+	assert.Same(t, intent, res.Intent)
+	assert.Equal(t, testAccount, res.Data.ToAccount)
+	assert.Equal(t, int64(5000), res.Data.Amount)
+	assert.Equal(t, "RUB", res.Data.Currency)
+}
+
+func TestUseCase_Scan_Errors(t *testing.T) {
+	gen := qrgenerator.NewGenerator(0, nil, 0)
+	unreachable := errors.New("pay-core is unreachable")
+	intentPayload := encode(t, qrcode.QRData{IntentID: uuid.NewString()}, qrcode.FormatIntent)
+
+	tests := []struct {
+		name    string
+		scanner fakeScanner
+		client  *fakeClient
+		wantErr error
+	}{
+		{
+			name:    "no code",
+			scanner: fakeScanner{err: qrcode.ErrNoCode},
+			client:  &fakeClient{},
+			wantErr: qrcode.ErrNoCode,
+		},
+		{
+			name:    "pay-core unreachable",
+			scanner: fakeScanner{payload: intentPayload},
+			client:  &fakeClient{err: unreachable},
+			wantErr: unreachable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := scanqr.NewUseCase(tt.scanner, gen, gen, tt.client)
+
+			res, err := uc.Scan(context.Background(), []byte("image"))
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Nil(t, res)
+		})
+	}
+}